@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/otrego/clamshell/go/prop"
+)
+
+// runToJSON writes the games as JSON (see movetree.MoveTree.MarshalJSON), one
+// game per line. Games that can't be parsed are reported and skipped.
+func runToJSON(args []string) error {
+	flags := newFlagSet("to-json")
+	lenient := flags.Bool("lenient", false, "Recover from malformed SGFs where possible")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	files, err := collectFiles(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	enc := json.NewEncoder(w)
+	failed := false
+	err = eachGame(files, &prop.ParseOptions{Lenient: *lenient}, func(g *game) error {
+		if g.err != nil {
+			fmt.Fprintf(os.Stderr, "%s: error: %v\n", g.name(), g.err)
+			failed = true
+			return nil
+		}
+		if err := enc.Encode(g.tree); err != nil {
+			return fmt.Errorf("%s: %w", g.name(), err)
+		}
+		return nil
+	})
+	if ferr := w.Flush(); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		return err
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRunToJSON(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   string
+		args []string
+
+		// exp are the board sizes of the games that were written.
+		exp    []int
+		expErr error
+	}{
+		{
+			desc: "collection",
+			in:   "(;GM[1]SZ[9];B[ee])(;GM[1]SZ[13];B[aa])",
+			exp:  []int{9, 13},
+		},
+		{
+			desc:   "game that can't be parsed",
+			in:     "(;GM[1]SZ[9];B[ee]KM[6.5])(;GM[1]SZ[13];B[aa])",
+			exp:    []int{13},
+			expErr: errFailed,
+		},
+		{
+			desc: "game that can't be parsed, lenient",
+			in:   "(;GM[1]SZ[9];B[ee]KM[6.5])(;GM[1]SZ[13];B[aa])",
+			args: []string{"-lenient"},
+			exp:  []int{9, 13},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{"game.sgf": tc.in})
+			out, err := captureStdout(t, runToJSON, append(tc.args, dir)...)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+			if len(lines) != len(tc.exp) {
+				t.Fatalf("got output %q, but expected %d games", out, len(tc.exp))
+			}
+			for i, line := range lines {
+				var g struct {
+					GameInfo struct {
+						Size int `json:"size"`
+					} `json:"gameInfo"`
+				}
+				if err := json.Unmarshal([]byte(line), &g); err != nil {
+					t.Fatalf("game %d: %v", i+1, err)
+				}
+				if g.GameInfo.Size != tc.exp[i] {
+					t.Errorf("got game %d of size %d, but expected %d", i+1, g.GameInfo.Size, tc.exp[i])
+				}
+			}
+		})
+	}
+}
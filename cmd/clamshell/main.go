@@ -0,0 +1,208 @@
+// Binary clamshell works with SGF files from the command line.
+//
+// Usage:
+//
+//	clamshell <command> [flags] <files or directories>
+//
+// The commands are:
+//
+//	validate  report parse errors, illegal moves, and lint issues
+//	reformat  rewrite SGFs in a canonical format
+//	split     split collections into one file per game
+//	stats     aggregate results, openings, and player records
+//	to-json   convert games to JSON, one game per line
+//
+// Directories are searched recursively for .sgf files. Files may contain
+// collections of several games.
+//
+// For example, to check a directory of games:
+//
+//	go run ./cmd/clamshell validate ./games
+//
+// For the flags of a command, run:
+//
+//	go run ./cmd/clamshell <command> -help
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+// command is a subcommand of the binary.
+type command struct {
+	name  string
+	usage string
+
+	// run runs the command, with the arguments that follow its name.
+	run func(args []string) error
+}
+
+var commands = []*command{
+	{name: "validate", usage: "report parse errors, illegal moves, and lint issues", run: runValidate},
+	{name: "reformat", usage: "rewrite SGFs in a canonical format", run: runReformat},
+	{name: "split", usage: "split collections into one file per game", run: runSplit},
+	{name: "stats", usage: "aggregate results, openings, and player records", run: runStats},
+	{name: "to-json", usage: "convert games to JSON, one game per line", run: runToJSON},
+}
+
+// errFailed indicates that a command found problems, which it already
+// reported.
+var errFailed = errors.New("failed")
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		if err := c.run(os.Args[2:]); errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		} else if errors.Is(err, errFailed) {
+			os.Exit(1)
+		} else if err != nil {
+			fmt.Fprintf(os.Stderr, "clamshell %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if name != "help" && name != "-help" && name != "-h" {
+		fmt.Fprintf(os.Stderr, "clamshell: unknown command %q\n", name)
+	}
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: clamshell <command> [flags] <files or directories>\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s%s\n", c.name, c.usage)
+	}
+}
+
+// newFlagSet creates the flag set of a command.
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet("clamshell "+name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: clamshell %s [flags] <files or directories>\n", name)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// collectFiles returns the SGF files for the arguments, in sorted order.
+// Directories are searched recursively for .sgf files, and files given
+// directly are used whatever their extension.
+func collectFiles(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no files or directories given")
+	}
+	var out []string
+	for _, arg := range args {
+		fi, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			out = append(out, arg)
+			continue
+		}
+		var files []string
+		err = filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".sgf") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+		out = append(out, files...)
+	}
+	return out, nil
+}
+
+// game is one of the games of a file.
+type game struct {
+	// file is the file, and index is the number of the game in the file,
+	// starting at 1.
+	file  string
+	index int
+
+	// raw is the SGF of the game.
+	raw []byte
+
+	tree     *movetree.MoveTree
+	warnings []*sgf.Warning
+
+	// err is the error parsing the game, if any.
+	err error
+}
+
+// name returns the name of the game, for messages.
+func (g *game) name() string {
+	if g.index == 0 {
+		return g.file
+	}
+	return fmt.Sprintf("%s: game %d", g.file, g.index)
+}
+
+// readGames reads the games of a file. An error is only returned if the file
+// can't be read: the errors parsing each game are kept with the game, and a
+// file that can't be split into games is returned as a single game with the
+// error.
+func readGames(file string, opts *prop.ParseOptions) ([]*game, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	raws, err := sgf.SplitGames(data)
+	if err != nil {
+		return []*game{{file: file, raw: data, err: err}}, nil
+	}
+	var games []*game
+	for i, raw := range raws {
+		p := sgf.FromBytes(raw).WithOptions(opts)
+		tree, err := p.Parse()
+		g := &game{file: file, raw: raw, tree: tree, warnings: p.Warnings(), err: err}
+		if len(raws) > 1 {
+			g.index = i + 1
+		}
+		games = append(games, g)
+	}
+	return games, nil
+}
+
+// eachGame calls fn for each game of the files, stopping at the first error.
+func eachGame(files []string, opts *prop.ParseOptions, fn func(g *game) error) error {
+	for _, file := range files {
+		games, err := readGames(file, opts)
+		if err != nil {
+			return err
+		}
+		for _, g := range games {
+			if err := fn(g); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes the files, given by their slash-separated paths, to a
+// temporary directory, and returns the directory.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// relPaths returns the paths relative to the directory, with slashes.
+func relPaths(t *testing.T, dir string, paths []string) []string {
+	t.Helper()
+	var out []string
+	for _, p := range paths {
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, filepath.ToSlash(rel))
+	}
+	return out
+}
+
+// captureStdout runs the command with the arguments, and returns what it
+// wrote to stdout. What it writes to stderr is discarded.
+func captureStdout(t *testing.T, run func(args []string) error, args ...string) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer null.Close()
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, null
+	err = run(args)
+	os.Stdout, os.Stderr = stdout, stderr
+	w.Close()
+	return <-out, err
+}
+
+func TestCollectFiles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"b.sgf":       "(;GM[1])",
+		"a/c.SGF":     "(;GM[1])",
+		"a/d/e.sgf":   "(;GM[1])",
+		"a/notes.txt": "(;GM[1])",
+	})
+	testCases := []struct {
+		desc   string
+		args   []string
+		exp    []string
+		expErr bool
+	}{
+		{
+			desc: "directory",
+			args: []string{dir},
+			exp:  []string{"a/c.SGF", "a/d/e.sgf", "b.sgf"},
+		},
+		{
+			desc: "files and directories, in the order given",
+			args: []string{filepath.Join(dir, "b.sgf"), filepath.Join(dir, "a", "d")},
+			exp:  []string{"b.sgf", "a/d/e.sgf"},
+		},
+		{
+			desc: "file without the extension",
+			args: []string{filepath.Join(dir, "a", "notes.txt")},
+			exp:  []string{"a/notes.txt"},
+		},
+		{
+			desc:   "missing file",
+			args:   []string{filepath.Join(dir, "missing.sgf")},
+			expErr: true,
+		},
+		{
+			desc:   "no arguments",
+			expErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			files, err := collectFiles(tc.args)
+			if (err != nil) != tc.expErr {
+				t.Fatalf("got error %v, but expected error: %v", err, tc.expErr)
+			}
+			if got := relPaths(t, dir, files); !reflect.DeepEqual(got, tc.exp) {
+				t.Errorf("got files %q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestReadGames(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   string
+
+		// exp are the names of the games, and expErr whether each game has
+		// an error.
+		exp    []string
+		expErr []bool
+	}{
+		{
+			desc:   "single game",
+			in:     "(;GM[1]SZ[9];B[ee])",
+			exp:    []string{"game.sgf"},
+			expErr: []bool{false},
+		},
+		{
+			desc:   "collection",
+			in:     "(;GM[1]SZ[9])\n(;GM[1]SZ[13])",
+			exp:    []string{"game.sgf: game 1", "game.sgf: game 2"},
+			expErr: []bool{false, false},
+		},
+		{
+			desc:   "collection with a game that can't be parsed",
+			in:     "(;GM[1]SZ[9])(;GM[1]SZ[9];B[ee]KM[6.5])",
+			exp:    []string{"game.sgf: game 1", "game.sgf: game 2"},
+			expErr: []bool{false, true},
+		},
+		{
+			desc:   "file that can't be split",
+			in:     "(;GM[1]SZ[9];B[ee]",
+			exp:    []string{"game.sgf"},
+			expErr: []bool{true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{"game.sgf": tc.in})
+			games, err := readGames(filepath.Join(dir, "game.sgf"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			var gotErr []bool
+			for _, g := range games {
+				got = append(got, strings.TrimPrefix(g.name(), dir+string(filepath.Separator)))
+				gotErr = append(gotErr, g.err != nil)
+			}
+			if !reflect.DeepEqual(got, tc.exp) || !reflect.DeepEqual(gotErr, tc.expErr) {
+				t.Errorf("got games %q with errors %v, but expected %q with errors %v", got, gotErr, tc.exp, tc.expErr)
+			}
+		})
+	}
+
+	if _, err := readGames(filepath.Join(t.TempDir(), "missing.sgf"), nil); err == nil {
+		t.Errorf("got no error reading a missing file")
+	}
+}
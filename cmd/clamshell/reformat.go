@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+// runReformat rewrites the SGFs in a canonical format, to stdout or in place.
+// Files with games that can't be parsed are reported and left unchanged.
+func runReformat(args []string) error {
+	flags := newFlagSet("reformat")
+	write := flags.Bool("w", false, "Write the result to the files, rather than to stdout")
+	normalize := flags.Bool("normalize", false, "Also normalize the structure of the games, and write them as FF[4] in UTF-8 (see sgf.WriteNormalized)")
+	lenient := flags.Bool("lenient", false, "Recover from malformed SGFs where possible")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	files, err := collectFiles(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	failed := false
+	for _, file := range files {
+		games, err := readGames(file, &prop.ParseOptions{Lenient: *lenient})
+		if err != nil {
+			return err
+		}
+		var out bytes.Buffer
+		for i, g := range games {
+			if g.err != nil {
+				fmt.Fprintf(os.Stderr, "%s: error: %v\n", g.name(), g.err)
+				failed = true
+				out.Reset()
+				break
+			}
+			if i > 0 {
+				out.WriteString("\n")
+			}
+			if *normalize {
+				if err := sgf.WriteNormalized(&out, g.tree, nil); err != nil {
+					return fmt.Errorf("%s: %w", g.name(), err)
+				}
+			} else {
+				s, err := sgf.Serialize(g.tree)
+				if err != nil {
+					return fmt.Errorf("%s: %w", g.name(), err)
+				}
+				out.WriteString(s)
+			}
+		}
+		if out.Len() == 0 {
+			continue
+		}
+		out.WriteString("\n")
+		if !*write {
+			if _, err := os.Stdout.Write(out.Bytes()); err != nil {
+				return err
+			}
+			continue
+		}
+		old, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(old, out.Bytes()) {
+			continue
+		}
+		fi, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, out.Bytes(), fi.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
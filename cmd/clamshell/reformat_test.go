@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunReformat(t *testing.T) {
+	const (
+		in       = "(;GM[1]SZ[9]C[hi];B[ee] (;W[cc])(;W[dd]))\n(;SZ[9];B[aa])"
+		reformat = "(;FF[4]GM[1]CA[UTF-8]SZ[9]C[hi];B[ee](;W[cc])(;W[dd]))\n(;FF[4]GM[1]CA[UTF-8]SZ[9];B[aa])\n"
+	)
+	testCases := []struct {
+		desc string
+		in   string
+		args []string
+
+		// exp is the output, and expFile the file after reformatting.
+		exp     string
+		expFile string
+		expErr  error
+	}{
+		{
+			desc:    "to stdout",
+			in:      in,
+			exp:     reformat,
+			expFile: in,
+		},
+		{
+			desc:    "normalized",
+			in:      in,
+			args:    []string{"-normalize"},
+			exp:     "(;FF[4]GM[1]CA[UTF-8]AP[clamshell]SZ[9]C[hi];B[ee](;W[cc])(;W[dd]))\n(;FF[4]GM[1]CA[UTF-8]AP[clamshell]SZ[9];B[aa])\n",
+			expFile: in,
+		},
+		{
+			desc:    "in place",
+			in:      in,
+			args:    []string{"-w"},
+			expFile: reformat,
+		},
+		{
+			desc:    "game that can't be parsed",
+			in:      "(;GM[1]SZ[9])(;GM[1]SZ[9];B[ee]KM[6.5])",
+			args:    []string{"-w"},
+			expFile: "(;GM[1]SZ[9])(;GM[1]SZ[9];B[ee]KM[6.5])",
+			expErr:  errFailed,
+		},
+		{
+			desc:    "game that can't be parsed, lenient",
+			in:      "(;GM[1]SZ[9];B[ee]KM[6.5])",
+			args:    []string{"-lenient"},
+			exp:     "(;FF[4]GM[1]CA[UTF-8]SZ[9];KM[6.5]B[ee])\n",
+			expFile: "(;GM[1]SZ[9];B[ee]KM[6.5])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{"game.sgf": tc.in})
+			file := filepath.Join(dir, "game.sgf")
+			out, err := captureStdout(t, runReformat, append(tc.args, file)...)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if out != tc.exp {
+				t.Errorf("got output %q, but expected %q", out, tc.exp)
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tc.expFile {
+				t.Errorf("got file %q, but expected %q", data, tc.expFile)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+// runSplit splits the collections into one file per game. The games are
+// written as they are, without being reformatted.
+func runSplit(args []string) error {
+	flags := newFlagSet("split")
+	outDir := flags.String("output_dir", "", "Directory for the split games. By default, uses the directory of each collection")
+	names := flags.Bool("names", false, "Name the files after the date, players, and event of each game (see movetree.GameInfo.SuggestedFilename), rather than after the collection")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	files, err := collectFiles(flags.Args())
+	if err != nil {
+		return err
+	}
+	if *outDir != "" {
+		if err := os.MkdirAll(*outDir, 0755); err != nil {
+			return err
+		}
+	}
+
+	taken := make(map[string]bool)
+	written := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		raws, err := sgf.SplitGames(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		dir := filepath.Dir(file)
+		if *outDir != "" {
+			dir = *outDir
+		}
+		base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		for i, raw := range raws {
+			name := fmt.Sprintf("%s-%d.sgf", base, i+1)
+			if *names {
+				name = "unknown.sgf"
+				if g, err := sgf.ParseBytes(raw); err == nil && g.Root.GameInfo != nil {
+					name = g.Root.GameInfo.SuggestedFilename()
+				}
+			}
+			path := uniquePath(filepath.Join(dir, name), taken)
+			if err := os.WriteFile(path, raw, 0644); err != nil {
+				return err
+			}
+			written++
+		}
+	}
+	fmt.Printf("wrote %d games\n", written)
+	return nil
+}
+
+// uniquePath returns the path, or if it's already taken or exists, the path
+// with a short discriminator (see movetree.UniqueFilename).
+func uniquePath(path string, taken map[string]bool) string {
+	for {
+		p := movetree.UniqueFilename(path, taken)
+		if _, err := os.Stat(p); os.IsNotExist(err) {
+			return p
+		}
+	}
+}
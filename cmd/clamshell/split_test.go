@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRunSplit(t *testing.T) {
+	files := map[string]string{
+		"games.sgf": "(;GM[1]PB[Lee Sedol]PW[AlphaGo]DT[2016-03-09])\n" +
+			"(;GM[1]PB[Lee Sedol]PW[AlphaGo]DT[2016-03-09])\n" +
+			"(;GM[1]SZ[9])",
+		"single.sgf": "(;GM[1]SZ[13])",
+	}
+	testCases := []struct {
+		desc string
+		args []string
+
+		// exp are the files of the output directory, with their contents,
+		// except for the collections that were split.
+		exp map[string]string
+	}{
+		{
+			desc: "named after the collection",
+			exp: map[string]string{
+				"games-1.sgf":  "(;GM[1]PB[Lee Sedol]PW[AlphaGo]DT[2016-03-09])",
+				"games-2.sgf":  "(;GM[1]PB[Lee Sedol]PW[AlphaGo]DT[2016-03-09])",
+				"games-3.sgf":  "(;GM[1]SZ[9])",
+				"single-1.sgf": "(;GM[1]SZ[13])",
+			},
+		},
+		{
+			desc: "named after the games",
+			args: []string{"-names"},
+			exp: map[string]string{
+				"2016-03-09_Lee-Sedol_vs_AlphaGo.sgf":   "(;GM[1]PB[Lee Sedol]PW[AlphaGo]DT[2016-03-09])",
+				"2016-03-09_Lee-Sedol_vs_AlphaGo-2.sgf": "(;GM[1]PB[Lee Sedol]PW[AlphaGo]DT[2016-03-09])",
+				"unknown.sgf":                           "(;GM[1]SZ[9])",
+				"unknown-2.sgf":                         "(;GM[1]SZ[13])",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := writeFiles(t, files)
+			outDir := filepath.Join(t.TempDir(), "out")
+			args := append(append([]string{"-output_dir", outDir}, tc.args...), dir)
+			out, err := captureStdout(t, runSplit, args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exp := "wrote 4 games\n"; out != exp {
+				t.Errorf("got output %q, but expected %q", out, exp)
+			}
+			got := make(map[string]string)
+			entries, err := os.ReadDir(outDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range entries {
+				data, err := os.ReadFile(filepath.Join(outDir, e.Name()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[e.Name()] = string(data)
+			}
+			if !reflect.DeepEqual(got, tc.exp) {
+				t.Errorf("got files %q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestRunSplit_ExistingFiles(t *testing.T) {
+	// Without an output directory, the games are written next to the
+	// collection, without overwriting existing files.
+	dir := writeFiles(t, map[string]string{
+		"games.sgf":   "(;GM[1]SZ[9])(;GM[1]SZ[13])",
+		"games-1.sgf": "(;GM[1]SZ[19])",
+	})
+	if _, err := captureStdout(t, runSplit, filepath.Join(dir, "games.sgf")); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	sort.Strings(got)
+	if exp := []string{"games-1-2.sgf", "games-1.sgf", "games-2.sgf", "games.sgf"}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("got files %q, but expected %q", got, exp)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "games-1.sgf")); err != nil || string(data) != "(;GM[1]SZ[19])" {
+		t.Errorf("got games-1.sgf %q (error %v), but expected it to be unchanged", data, err)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+)
+
+// record is the record of a player.
+type record struct {
+	name                string
+	games, wins, losses int
+}
+
+// runStats aggregates the results, the openings, and the player records of
+// the games. Games that can't be parsed are counted, but otherwise skipped.
+func runStats(args []string) error {
+	flags := newFlagSet("stats")
+	openingMoves := flags.Int("opening_moves", 4, "The number of moves of the main line that make up an opening")
+	top := flags.Int("top", 10, "The number of openings and players to list")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	files, err := collectFiles(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	games, unparsed := 0, 0
+	results := make(map[string]int)
+	openings := make(map[string]int)
+	records := make(map[string]*record)
+	player := func(name string) *record {
+		if name == "" {
+			name = "unknown"
+		}
+		r, ok := records[name]
+		if !ok {
+			r = &record{name: name}
+			records[name] = r
+		}
+		return r
+	}
+	err = eachGame(files, &prop.ParseOptions{Lenient: true}, func(g *game) error {
+		games++
+		if g.err != nil {
+			unparsed++
+			return nil
+		}
+		gi := g.tree.Root.GameInfo
+		if gi == nil {
+			gi = &movetree.GameInfo{}
+		}
+		results[resultName(gi.Result)]++
+		if o := opening(g.tree, *openingMoves); o != "" {
+			openings[o]++
+		}
+
+		black, white := player(gi.BlackPlayer), player(gi.WhitePlayer)
+		black.games++
+		white.games++
+		if gi.Result != nil && gi.Result.Winner == color.Black {
+			black.wins++
+			white.losses++
+		} else if gi.Result != nil && gi.Result.Winner == color.White {
+			white.wins++
+			black.losses++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Games: %d (%d couldn't be parsed)\n", games, unparsed)
+	fmt.Printf("\nResults:\n")
+	for _, name := range []string{"Black wins", "White wins", "Draw", "Void", "Unknown"} {
+		fmt.Printf("  %-12s%d\n", name, results[name])
+	}
+
+	fmt.Printf("\nOpenings (first %d moves):\n", *openingMoves)
+	var ops []string
+	for o := range openings {
+		ops = append(ops, o)
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if openings[ops[i]] != openings[ops[j]] {
+			return openings[ops[i]] > openings[ops[j]]
+		}
+		return ops[i] < ops[j]
+	})
+	for i, o := range ops {
+		if i == *top {
+			break
+		}
+		fmt.Printf("  %5d  %s\n", openings[o], o)
+	}
+
+	fmt.Printf("\nPlayers (games, wins, losses):\n")
+	var recs []*record
+	for _, r := range records {
+		recs = append(recs, r)
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		if recs[i].games != recs[j].games {
+			return recs[i].games > recs[j].games
+		}
+		return recs[i].name < recs[j].name
+	})
+	for i, r := range recs {
+		if i == *top {
+			break
+		}
+		fmt.Printf("  %5d %5d %5d  %s\n", r.games, r.wins, r.losses, r.name)
+	}
+	return nil
+}
+
+// resultName returns the name of the kind of result, for the stats.
+func resultName(res *movetree.Result) string {
+	switch {
+	case res == nil:
+		return "Unknown"
+	case res.Winner == color.Black:
+		return "Black wins"
+	case res.Winner == color.White:
+		return "White wins"
+	case res.Reason == movetree.ReasonDraw:
+		return "Draw"
+	case res.Reason == movetree.ReasonVoid:
+		return "Void"
+	}
+	return "Unknown"
+}
+
+// opening returns the first n moves of the main line (ex: "B[pd] W[dp]"), or
+// the empty string if the main line has fewer moves.
+func opening(g *movetree.MoveTree, n int) string {
+	var moves []string
+	for _, node := range g.MainLine() {
+		if len(moves) == n {
+			break
+		}
+		m := node.Move
+		if m == nil || m.Color() == color.Empty {
+			continue
+		}
+		v := ""
+		if !m.IsPass() {
+			var err error
+			if v, err = m.Point().ToSGF(); err != nil {
+				return ""
+			}
+		}
+		moves = append(moves, fmt.Sprintf("%s[%s]", m.Color(), v))
+	}
+	if len(moves) < n {
+		return ""
+	}
+	return strings.Join(moves, " ")
+}
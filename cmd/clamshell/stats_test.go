@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunStats(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.sgf": "(;GM[1]PB[Shusaku]PW[Gennan]RE[B+2];B[qd];W[dc];B[pq];W[oc])\n" +
+			"(;GM[1]PB[Shusaku]PW[Shuwa]RE[W+R];B[qd];W[dc];B[pq];W[oc];B[cp])",
+		"b/c.sgf": "(;GM[1]PB[Gennan]PW[Shusaku]RE[0];B[pd];W[dp];B[pp];W[dd])\n" +
+			"(;GM[1]RE[Void];B[qd];W[dc])",
+		"b/d.sgf": "(;GM[1];B[ee]",
+	})
+	testCases := []struct {
+		desc string
+		args []string
+		exp  string
+	}{
+		{
+			desc: "defaults",
+			exp: `Games: 5 (1 couldn't be parsed)
+
+Results:
+  Black wins  1
+  White wins  1
+  Draw        1
+  Void        1
+  Unknown     0
+
+Openings (first 4 moves):
+      2  B[qd] W[dc] B[pq] W[oc]
+      1  B[pd] W[dp] B[pp] W[dd]
+
+Players (games, wins, losses):
+      3     1     1  Shusaku
+      2     0     1  Gennan
+      2     0     0  unknown
+      1     1     0  Shuwa
+`,
+		},
+		{
+			desc: "shorter openings and fewer players",
+			args: []string{"-opening_moves", "2", "-top", "1"},
+			exp: `Games: 5 (1 couldn't be parsed)
+
+Results:
+  Black wins  1
+  White wins  1
+  Draw        1
+  Void        1
+  Unknown     0
+
+Openings (first 2 moves):
+      3  B[qd] W[dc]
+
+Players (games, wins, losses):
+      3     1     1  Shusaku
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := captureStdout(t, runStats, append(tc.args, dir)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Errorf("got stats diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
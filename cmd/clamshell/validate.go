@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/rules"
+)
+
+// runValidate reports the problems with the games: parse errors, illegal
+// moves, and lint issues. It fails if any game can't be parsed, has illegal
+// moves, or has lint errors.
+func runValidate(args []string) error {
+	flags := newFlagSet("validate")
+	lenient := flags.Bool("lenient", false, "Recover from malformed SGFs where possible, reporting the recoveries as warnings")
+	ruleset := flags.String("rules", "", "The ruleset for checking the legality of moves (ex: Japanese). By default, uses the RU property of each game")
+	quiet := flags.Bool("quiet", false, "Only report errors, not warnings and hints")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	files, err := collectFiles(flags.Args())
+	if err != nil {
+		return err
+	}
+	rs := rules.Unspecified
+	if *ruleset != "" {
+		rs = rules.Parse(*ruleset)
+	}
+
+	games, failed := 0, 0
+	err = eachGame(files, &prop.ParseOptions{Lenient: *lenient}, func(g *game) error {
+		games++
+		if g.err != nil {
+			fmt.Printf("%s: error: %v\n", g.name(), g.err)
+			failed++
+			return nil
+		}
+		ok := true
+		if !*quiet {
+			for _, w := range g.warnings {
+				fmt.Printf("%s: warning: %v\n", g.name(), w)
+			}
+		}
+		for _, err := range g.tree.ValidateMoves(rs) {
+			fmt.Printf("%s: error: %v\n", g.name(), err)
+			ok = false
+		}
+		for _, issue := range g.tree.Lint() {
+			if issue.Severity == movetree.SeverityError {
+				ok = false
+			} else if *quiet {
+				continue
+			}
+			fmt.Printf("%s: %v\n", g.name(), issue)
+		}
+		if !ok {
+			failed++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d games, %d with errors\n", games, failed)
+	if failed > 0 {
+		return errFailed
+	}
+	return nil
+}
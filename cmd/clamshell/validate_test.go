@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunValidate(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   string
+		args []string
+
+		// exp are the lines of the output, without the file name.
+		exp    []string
+		expErr error
+	}{
+		{
+			desc: "valid game",
+			in:   "(;GM[1]SZ[9];B[ee];W[cc])",
+			exp:  []string{"1 games, 0 with errors"},
+		},
+		{
+			desc: "collection",
+			in:   "(;GM[1]SZ[9];B[ee])(;GM[1]SZ[9];B[cc])",
+			exp:  []string{"2 games, 0 with errors"},
+		},
+		{
+			desc:   "parse error",
+			in:     "(;GM[1]SZ[9];B[ee]KM[6.5])",
+			exp:    []string{"error: ", "1 games, 1 with errors"},
+			expErr: errFailed,
+		},
+		{
+			desc: "recovered error",
+			in:   "(;GM[1]SZ[9]HA[1];B[ee])",
+			args: []string{"-lenient"},
+			exp:  []string{"warning: ", "1 games, 0 with errors"},
+		},
+		{
+			desc: "recovered error, quiet",
+			in:   "(;GM[1]SZ[9]HA[1];B[ee])",
+			args: []string{"-lenient", "-quiet"},
+			exp:  []string{"1 games, 0 with errors"},
+		},
+		{
+			desc:   "illegal move",
+			in:     "(;GM[1]SZ[9];B[ee];W[ee])",
+			exp:    []string{"error: ", "1 games, 1 with errors"},
+			expErr: errFailed,
+		},
+		{
+			desc:   "one game with errors in a collection",
+			in:     "(;GM[1]SZ[9];B[ee])(;GM[1]SZ[9];B[ee];W[ee])",
+			exp:    []string{"game 2: error: ", "2 games, 1 with errors"},
+			expErr: errFailed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{"game.sgf": tc.in})
+			file := filepath.Join(dir, "game.sgf")
+			out, err := captureStdout(t, runValidate, append(tc.args, file)...)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			var got []string
+			if out != "" {
+				got = strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+			}
+			if len(got) != len(tc.exp) {
+				t.Fatalf("got output %q, but expected %d lines", out, len(tc.exp))
+			}
+			for i, line := range got {
+				if !strings.HasPrefix(strings.TrimPrefix(strings.TrimPrefix(line, file), ": "), tc.exp[i]) {
+					t.Errorf("got line %q, but expected it to start with %q", line, tc.exp[i])
+				}
+			}
+		})
+	}
+}
@@ -14,6 +14,23 @@ import (
 var (
 	InvalidBoardState = errors.New("invalid board state")
 	IllegalMove       = errors.New("illegal move")
+
+	// ErrOffBoard indicates a move or placement off the board. It wraps
+	// IllegalMove.
+	ErrOffBoard = fmt.Errorf("%w: off the board", IllegalMove)
+
+	// ErrSuicide indicates a move that would capture its own group. It wraps
+	// IllegalMove.
+	ErrSuicide = fmt.Errorf("%w: suicide", IllegalMove)
+
+	// ErrKo indicates a move that immediately retakes a ko. It wraps
+	// IllegalMove.
+	ErrKo = fmt.Errorf("%w: ko", IllegalMove)
+
+	// ErrSuperko indicates a move that repeats an earlier board position,
+	// which is only checked when tracking superko (see TrackSuperko). It wraps
+	// ErrKo.
+	ErrSuperko = fmt.Errorf("%w: positional superko", ErrKo)
 )
 
 // Board Contains the board, capturesStones, and ko
@@ -22,17 +39,26 @@ type Board struct {
 	// The board, arranged in rows (rather than columns).
 	board [][]color.Color
 	ko    *point.Point
+
+	// history counts the hashes of the earlier board positions, for
+	// positional superko. It's nil unless superko is tracked.
+	history map[uint64]int
 }
 
 // New creates a new size x size board.
 func New(size int) *Board {
+	return NewRect(size, size)
+}
+
+// NewRect creates a new rectangular board with the given number of columns
+// and rows (ex: 19x9 for SZ[19:9]).
+func NewRect(width, height int) *Board {
 	board := Board{
-		make([][]color.Color, size),
-		nil,
+		board: make([][]color.Color, height),
 	}
 
-	for i := 0; i < size; i++ {
-		board.board[i] = make([]color.Color, size)
+	for i := 0; i < height; i++ {
+		board.board[i] = make([]color.Color, width)
 	}
 	return &board
 }
@@ -42,7 +68,7 @@ func New(size int) *Board {
 func (b *Board) PlaceStone(m *move.Move) (move.List, error) {
 	if !b.inBounds(m.Point()) {
 		return nil, fmt.Errorf("%w: move %v out of bounds for %dx%d board",
-			IllegalMove, m.Point(), len(b.board[0]), len(b.board))
+			ErrOffBoard, m.Point(), b.Width(), b.Height())
 	}
 	if b.colorAt(m.Point()) != color.Empty {
 		return nil, fmt.Errorf("%w: move %v already occupied", IllegalMove, m.Point())
@@ -53,16 +79,13 @@ func (b *Board) PlaceStone(m *move.Move) (move.List, error) {
 	capturedStones := b.findCapturedGroups(m)
 	if len(capturedStones) == 0 && len(b.capturedStones(m.Point())) != 0 {
 		b.setColor(move.New(color.Empty, m.Point()))
-		return nil, fmt.Errorf("%w: move %v is suicidal", IllegalMove, m.Point())
+		return nil, fmt.Errorf("%w: move %v is suicidal", ErrSuicide, m.Point())
 	}
 	if len(capturedStones) == 1 {
 		if b.ko != nil && *(b.ko) == *(m.Point()) {
 			b.setColor(move.New(color.Empty, m.Point()))
-			return nil, fmt.Errorf("%w: %v is an illegal ko move", IllegalMove, m.Point())
+			return nil, fmt.Errorf("%w: %v is an illegal ko move", ErrKo, m.Point())
 		}
-		b.ko = capturedStones[0]
-	} else {
-		b.ko = nil
 	}
 
 	// convert the captured stones into Move objects for convience.
@@ -74,18 +97,76 @@ func (b *Board) PlaceStone(m *move.Move) (move.List, error) {
 	captured.Sort()
 
 	b.removeCapturedStones(capturedStones)
+	if b.repeatsPosition() {
+		for _, c := range captured {
+			b.setColor(c)
+		}
+		b.setColor(move.New(color.Empty, m.Point()))
+		return nil, fmt.Errorf("%w: %v repeats an earlier position", ErrSuperko, m.Point())
+	}
+	if len(capturedStones) == 1 {
+		b.ko = capturedStones[0]
+	} else {
+		b.ko = nil
+	}
+	b.recordPosition()
 	return captured, nil
 }
 
-// findCapturedGroups returns the groups captured by *Move m.
+// PlaceSelfCapture places a stone that captures its own group, which is
+// illegal in most rulesets (see PlaceStone), and removes the group. Returns
+// the removed stones, including the placed stone. It's an error if the move
+// isn't a self-capture.
+func (b *Board) PlaceSelfCapture(m *move.Move) (move.List, error) {
+	if !b.inBounds(m.Point()) {
+		return nil, fmt.Errorf("%w: move %v out of bounds for %dx%d board",
+			ErrOffBoard, m.Point(), b.Width(), b.Height())
+	}
+	if b.colorAt(m.Point()) != color.Empty {
+		return nil, fmt.Errorf("%w: move %v already occupied", IllegalMove, m.Point())
+	}
+
+	b.setColor(m)
+	group := b.capturedStones(m.Point())
+	if len(group) == 0 || len(b.findCapturedGroups(m)) != 0 {
+		b.setColor(move.New(color.Empty, m.Point()))
+		return nil, fmt.Errorf("%w: move %v is not a self-capture", IllegalMove, m.Point())
+	}
+
+	var removed move.List
+	for _, pt := range group {
+		removed = append(removed, move.New(m.Color(), pt))
+	}
+	removed.Sort()
+
+	b.removeCapturedStones(group)
+	if b.repeatsPosition() {
+		for _, r := range removed {
+			b.setColor(r)
+		}
+		b.setColor(move.New(color.Empty, m.Point()))
+		return nil, fmt.Errorf("%w: %v repeats an earlier position", ErrSuperko, m.Point())
+	}
+	b.ko = nil
+	b.recordPosition()
+	return removed, nil
+}
+
+// findCapturedGroups returns the groups captured by *Move m. Only the
+// opponent's groups can be captured, and each group is only returned once,
+// even if the move touches it twice.
 func (b *Board) findCapturedGroups(m *move.Move) []*point.Point {
 	pt := m.Point()
 
 	points := b.getNeighbors(pt)
 	capturedStones := make([]*point.Point, 0)
+	seen := make(map[point.Point]bool)
 	for _, point := range points {
-		if b.inBounds(point) {
-			capturedStones = append(capturedStones, b.capturedStones(point)...)
+		if b.inBounds(point) && b.colorAt(point) == m.Color().Opposite() && !seen[*point] {
+			for _, st := range b.capturedStones(point) {
+				seen[*st] = true
+				capturedStones = append(capturedStones, st)
+			}
 		}
 	}
 	return capturedStones
@@ -170,32 +251,37 @@ func (b *Board) setColor(m *move.Move) {
 	b.board[y][x] = m.Color()
 }
 
-// getNeighbors returns a list of points neighboring point pt.
-// Neighboring points could be out of bounds.
+// getNeighbors returns a list of the on-board points neighboring point pt.
 func (b *Board) getNeighbors(pt *point.Point) []*point.Point {
-	points := make([]*point.Point, 4)
-	points[0] = point.New(pt.X()+1, pt.Y())
-	points[1] = point.New(pt.X()-1, pt.Y())
-	points[2] = point.New(pt.X(), pt.Y()+1)
-	points[3] = point.New(pt.X(), pt.Y()-1)
-	return points
+	return Neighbors(pt, b.Width(), b.Height())
 }
 
 // SetPlacements force-places moves on the go-board, without performing capture
 // logic. If an illegal board position results, return an error.
 func (b *Board) SetPlacements(ml move.List) error {
 
+	if err := b.checkPlacementBounds(ml); err != nil {
+		return err
+	}
 	for _, m := range ml {
 		b.setColor(m)
 	}
+	if err := b.checkPlacedGroups(ml); err != nil {
+		return err
+	}
+	b.recordPosition()
+	return nil
+}
 
+// checkPlacedGroups checks that the groups of the placements have liberties.
+func (b *Board) checkPlacedGroups(ml move.List) error {
 	// Validate we have a valid board position -- i.e., one
 	// without captures lying on the board.
 	explored := make(map[point.Point]bool)
 	for _, m := range ml {
 		pt := m.Point()
 
-		if !explored[*pt] {
+		if !explored[*pt] && b.colorAt(pt) != color.Empty {
 			stoneGroup, captured := b.getStoneGroup(pt)
 			if captured {
 				return fmt.Errorf("%w: stones at points %v are captured", InvalidBoardState, stoneGroup)
@@ -208,17 +294,115 @@ func (b *Board) SetPlacements(ml move.List) error {
 	return nil
 }
 
+// SetPlacementsAndCapture is like SetPlacements, but the groups that the
+// placements leave without liberties are captured, as when stones are played
+// (see CaptureAfterPlacements), before the position is checked. It returns
+// the captured stones. If the placements result in an illegal board position,
+// the board is left unchanged.
+func (b *Board) SetPlacementsAndCapture(ml move.List) (move.List, error) {
+	captured, _, err := b.SetPlacementsAndCaptureWithUndo(ml)
+	return captured, err
+}
+
+// checkPlacementBounds checks that the placements are on the board.
+func (b *Board) checkPlacementBounds(ml move.List) error {
+	for _, m := range ml {
+		if !b.inBounds(m.Point()) {
+			return fmt.Errorf("%w: placement %v out of bounds for %dx%d board",
+				ErrOffBoard, m.Point(), b.Width(), b.Height())
+		}
+	}
+	return nil
+}
+
+// CaptureAfterPlacements removes the groups next to the placements that have
+// no liberties, returning the removed stones. Only the groups of the opposite
+// color of a neighboring placement are captured, as when a stone is played.
+// SetPlacements doesn't perform capture logic, but a placement can fill the
+// last liberty of a group.
+func (b *Board) CaptureAfterPlacements(ml move.List) move.List {
+	var captured move.List
+	explored := make(map[point.Point]bool)
+	for _, m := range ml {
+		if m.Color() == color.Empty {
+			continue
+		}
+		for _, nb := range b.getNeighbors(m.Point()) {
+			if !b.inBounds(nb) || explored[*nb] || b.colorAt(nb) == color.Empty {
+				continue
+			}
+			c := b.colorAt(nb)
+			if c != m.Color().Opposite() {
+				continue
+			}
+			stoneGroup, isCaptured := b.getStoneGroup(nb)
+			for _, pt := range stoneGroup {
+				explored[*pt] = true
+			}
+			if !isCaptured {
+				continue
+			}
+			for _, pt := range stoneGroup {
+				captured = append(captured, move.New(c, pt))
+			}
+			b.removeCapturedStones(stoneGroup)
+		}
+	}
+	captured.Sort()
+	return captured
+}
+
+// CheckMove returns the error that PlaceStone would return for the move, such
+// as ErrKo or ErrSuicide, without changing the board. It returns nil if the
+// move is legal.
+func (b *Board) CheckMove(m *move.Move) error {
+	_, u, err := b.PlaceStoneWithUndo(m)
+	if err != nil {
+		return err
+	}
+	b.Revert(u)
+	return nil
+}
+
 // Ko returns the ko point.
 func (b *Board) Ko() *point.Point {
 	return b.ko
 }
 
+// Size returns the size of the board, where 19 = 19x19. For rectangular
+// boards, it's the larger of Width and Height.
+func (b *Board) Size() int {
+	if w := b.Width(); w > len(b.board) {
+		return w
+	}
+	return len(b.board)
+}
+
+// Width returns the number of columns of the board.
+func (b *Board) Width() int {
+	if len(b.board) == 0 {
+		return 0
+	}
+	return len(b.board[0])
+}
+
+// Height returns the number of rows of the board.
+func (b *Board) Height() int {
+	return len(b.board)
+}
+
 // Clone makes a board copy.
 func (b *Board) Clone() *Board {
 	newb := &Board{
 		ko:    b.ko,
 		board: make([][]color.Color, len(b.board)),
 	}
+	if b.history != nil {
+		newb.history = make(map[uint64]int, len(b.history))
+		for h, count := range b.history {
+			newb.history[h] = count
+		}
+	}
 	for i, row := range b.board {
 		newRow := make([]color.Color, len(row))
 		for j, col := range row {
@@ -45,6 +45,37 @@ func TestNewBoard(t *testing.T) {
 	}
 }
 
+func TestNewRect(t *testing.T) {
+	b := NewRect(4, 2)
+	if b.Width() != 4 || b.Height() != 2 || b.Size() != 4 {
+		t.Fatalf("got a %dx%d board of size %d, but expected a 4x2 board of size 4", b.Width(), b.Height(), b.Size())
+	}
+	moves := move.List{
+		move.New(color.Black, point.New(3, 0)),
+		move.New(color.White, point.New(2, 0)),
+		move.New(color.Black, point.New(0, 1)),
+	}
+	for _, m := range moves {
+		if _, err := b.PlaceStone(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The corner stone is captured at the bottom-right corner of the board.
+	captured, err := b.PlaceStone(move.New(color.White, point.New(3, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (move.List{move.New(color.Black, point.New(3, 0))}); !reflect.DeepEqual(captured, exp) {
+		t.Errorf("got captures %v, but expected %v", captured, exp)
+	}
+	if exp := "[. . W *]\n[B . . W]"; b.String() != exp {
+		t.Errorf("got board\n%v\nbut expected\n%v", b, exp)
+	}
+	if _, err := b.PlaceStone(move.New(color.Black, point.New(0, 2))); !errors.Is(err, ErrOffBoard) {
+		t.Errorf("got error %v for a move below the board, but expected %v", err, ErrOffBoard)
+	}
+}
+
 func TestString(t *testing.T) {
 
 	testCases := []struct {
@@ -77,6 +108,7 @@ func TestString(t *testing.T) {
 				{"", "", "", "", "", "", "", "", ""},
 				{"", "", "", "", "", "", "", "", ""}},
 				nil,
+				nil,
 			},
 			exp: "[. . . . . . B W .]\n" +
 				"[B . . . . B W W .]\n" +
@@ -125,6 +157,7 @@ func TestCapturedStones(t *testing.T) {
 				{"", "", "", "", "", "", "", "", ""},
 				{"", "", "", "", "", "", "", "", ""}},
 				nil,
+				nil,
 			},
 			pt:  point.New(5, 5),
 			exp: nil,
@@ -141,6 +174,7 @@ func TestCapturedStones(t *testing.T) {
 				{"", "B", "B", "B", "B", "B", "B", "", ""},
 				{"", "", "", "", "", "", "", "", ""}},
 				nil,
+				nil,
 			},
 			pt:  point.New(4, 4),
 			exp: nil,
@@ -175,6 +209,7 @@ func TestRemoveCapturedStones(t *testing.T) {
 				{"", "", "", "B", "W", "B", "", "", ""},
 				{"", "", "", "", "B", "", "", "", ""}},
 				nil,
+				nil,
 			},
 			m: move.New(color.Black, point.New(4, 4)),
 			exp: "[. . . . B . . . .]\n" +
@@ -249,6 +284,14 @@ func TestSetPlacements(t *testing.T) {
 			},
 			expErr: InvalidBoardState,
 		},
+		{
+			desc: "placements off the board",
+			ml: move.List{
+				move.New(color.White, point.New(1, 4)),
+				move.New(color.Black, point.New(19, 4)),
+			},
+			expErr: ErrOffBoard,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -263,6 +306,35 @@ func TestSetPlacements(t *testing.T) {
 		})
 	}
 }
+
+func TestCaptureAfterPlacements(t *testing.T) {
+	b := New(5)
+	err := b.SetPlacements(move.List{
+		move.New(color.White, point.New(0, 0)),
+		move.New(color.White, point.New(1, 0)),
+		move.New(color.Black, point.New(0, 1)),
+		move.New(color.Black, point.New(1, 1)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ml := move.List{move.New(color.Black, point.New(2, 0))}
+	if err := b.SetPlacements(ml); err != nil {
+		t.Fatal(err)
+	}
+	got := b.CaptureAfterPlacements(ml)
+	exp := move.List{
+		move.New(color.White, point.New(0, 0)),
+		move.New(color.White, point.New(1, 0)),
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("CaptureAfterPlacements()=%v, but expected %v", got, exp)
+	}
+	if c := b.FullBoardState()[0][0]; c != color.Empty {
+		t.Errorf("captured stone at {0,0} is still on the board: %v", c)
+	}
+}
+
 func TestPlaceStone(t *testing.T) {
 	testCases := []struct {
 		desc        string
@@ -326,6 +398,35 @@ func TestPlaceStone(t *testing.T) {
 				"[. . . . . . . . .]",
 			expCaptures: move.List{move.New(color.White, point.New(1, 0))},
 		},
+		{
+			desc: "no liberties, but captures first",
+			b: &Board{
+				board: [][]color.Color{
+					{"", "W", "B", "", "", "", "", "", ""},
+					{"W", "B", "", "", "", "", "", "", ""},
+					{"B", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""}},
+			},
+			m: move.New(color.Black, point.New(0, 0)),
+			exp: "[B . B . . . . . .]\n" +
+				"[. B . . . . . . .]\n" +
+				"[B . . . . . . . .]\n" +
+				"[. . . . . . . . .]\n" +
+				"[. . . . . . . . .]\n" +
+				"[. . . . . . . . .]\n" +
+				"[. . . . . . . . .]\n" +
+				"[. . . . . . . . .]\n" +
+				"[. . . . . . . . .]",
+			expCaptures: move.List{
+				move.New(color.White, point.New(0, 1)),
+				move.New(color.White, point.New(1, 0)),
+			},
+		},
 		{
 			desc: "4 capture groups",
 			b: &Board{
@@ -382,7 +483,7 @@ func TestPlaceStone(t *testing.T) {
 					{"", "", "", "", "", "", "", "", ""}},
 			},
 			m:      move.New(color.White, point.New(33, 4)),
-			expErr: IllegalMove,
+			expErr: ErrOffBoard,
 		},
 		{
 			desc: "test occupied",
@@ -416,6 +517,22 @@ func TestPlaceStone(t *testing.T) {
 			m:      move.New(color.White, point.New(4, 4)),
 			expErr: IllegalMove,
 		},
+		{
+			desc: "test suicidal, joining a group",
+			b: &Board{
+				board: [][]color.Color{{"", "B", "W", "", "", "", "", "", ""},
+					{"B", "W", "", "", "", "", "", "", ""},
+					{"W", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""},
+					{"", "", "", "", "", "", "", "", ""}},
+			},
+			m:      move.New(color.Black, point.New(0, 0)),
+			expErr: IllegalMove,
+		},
 		{
 			desc: "test ko",
 			b: &Board{
@@ -431,7 +548,7 @@ func TestPlaceStone(t *testing.T) {
 				ko: point.New(4, 4),
 			},
 			m:      move.New(color.White, point.New(4, 4)),
-			expErr: IllegalMove,
+			expErr: ErrKo,
 		},
 	}
 	for _, tc := range testCases {
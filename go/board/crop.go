@@ -0,0 +1,69 @@
+package board
+
+import (
+	"fmt"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// Crop returns a sub-board containing only the intersections of the region
+// (ex: the points of a VW property), for rendering a part of the board on its
+// own. The sub-board covers the bounding rectangle of the region, with its
+// origin at the top-left of the rectangle. Stones outside the region are
+// dropped, and groups that cross the boundary of the region are kept as-is.
+func (b *Board) Crop(region []*point.Point) (*Board, error) {
+	if len(region) == 0 {
+		return nil, fmt.Errorf("%w: can't crop to an empty region", InvalidBoardState)
+	}
+	minX, minY := region[0].X(), region[0].Y()
+	maxX, maxY := minX, minY
+	for _, pt := range region {
+		if !b.inBounds(pt) {
+			return nil, fmt.Errorf("%w: can't crop to point %v, which is off the board", InvalidBoardState, pt)
+		}
+		minX, maxX = minInt(minX, pt.X()), maxInt(maxX, pt.X())
+		minY, maxY = minInt(minY, pt.Y()), maxInt(maxY, pt.Y())
+	}
+
+	out := &Board{board: make([][]color.Color, maxY-minY+1)}
+	for y := range out.board {
+		out.board[y] = make([]color.Color, maxX-minX+1)
+	}
+	for _, pt := range region {
+		out.board[pt.Y()-minY][pt.X()-minX] = b.colorAt(pt)
+	}
+	if b.ko != nil && b.ko.X() >= minX && b.ko.X() <= maxX && b.ko.Y() >= minY && b.ko.Y() <= maxY {
+		out.ko = point.New(b.ko.X()-minX, b.ko.Y()-minY)
+	}
+	return out, nil
+}
+
+// CropRect is like Crop, but the region is the rectangle from the top-left
+// point tl to the bottom-right point br, inclusive.
+func (b *Board) CropRect(tl, br *point.Point) (*Board, error) {
+	if br.X() < tl.X() || br.Y() < tl.Y() {
+		return nil, fmt.Errorf("%w: the bottom-right corner %v of the rectangle is above or left of the top-left corner %v", InvalidBoardState, br, tl)
+	}
+	var region []*point.Point
+	for y := tl.Y(); y <= br.Y(); y++ {
+		for x := tl.X(); x <= br.X(); x++ {
+			region = append(region, point.New(x, y))
+		}
+	}
+	return b.Crop(region)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
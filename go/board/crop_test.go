@@ -0,0 +1,58 @@
+package board
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestCrop(t *testing.T) {
+	b := New(19)
+	err := b.SetPlacements(move.List{
+		move.New(color.Black, point.New(2, 2)),
+		move.New(color.Black, point.New(3, 6)),
+		move.New(color.White, point.New(6, 6)),
+		// Part of the group on the boundary is outside the corner.
+		move.New(color.White, point.New(7, 6)),
+		move.New(color.Black, point.New(15, 15)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := b.CropRect(point.New(0, 0), point.New(6, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `[. . . . . . .]
+[. . . . . . .]
+[. . B . . . .]
+[. . . . . . .]
+[. . . . . . .]
+[. . . . . . .]
+[. . . B . . W]`
+	if got.String() != exp {
+		t.Errorf("got cropped board\n%v\nbut expected\n%v", got, exp)
+	}
+
+	// Crop to an L-shaped region in the bottom-right corner.
+	got, err = b.Crop([]*point.Point{point.New(15, 15), point.New(16, 15), point.New(15, 16)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp = `[B .]
+[. .]`
+	if got.String() != exp {
+		t.Errorf("got cropped board\n%v\nbut expected\n%v", got, exp)
+	}
+
+	if _, err := b.CropRect(point.New(0, 0), point.New(19, 19)); !errors.Is(err, InvalidBoardState) {
+		t.Errorf("got error %v, but expected %v", err, InvalidBoardState)
+	}
+	if _, err := b.Crop(nil); !errors.Is(err, InvalidBoardState) {
+		t.Errorf("got error %v, but expected %v", err, InvalidBoardState)
+	}
+}
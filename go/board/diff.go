@@ -0,0 +1,37 @@
+package board
+
+import (
+	"fmt"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// StoneDiff is a point with a different stone on two boards.
+type StoneDiff struct {
+	Point *point.Point
+
+	// A and B are the colors of the point on each board, which are
+	// color.Empty if it's empty. A stone was added if A is empty, and removed
+	// if B is empty.
+	A, B color.Color
+}
+
+// Diff returns the points whose stones are different on the two boards, in
+// the order of StoneState (row by row). As with Equal, the ko is ignored. The
+// boards must have the same size.
+func Diff(a, b *Board) ([]StoneDiff, error) {
+	if a.Width() != b.Width() || a.Height() != b.Height() {
+		return nil, fmt.Errorf("%w: can't compare a %dx%d board to a %dx%d board", InvalidBoardState,
+			a.Width(), a.Height(), b.Width(), b.Height())
+	}
+	var diffs []StoneDiff
+	for y, row := range a.board {
+		for x, c := range row {
+			if other := b.board[y][x]; c != other {
+				diffs = append(diffs, StoneDiff{Point: point.New(x, y), A: c, B: other})
+			}
+		}
+	}
+	return diffs, nil
+}
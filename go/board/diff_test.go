@@ -0,0 +1,52 @@
+package board
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestDiff(t *testing.T) {
+	a := New(5)
+	if err := a.SetPlacements(move.List{
+		move.New(color.Black, point.New(0, 0)),
+		move.New(color.White, point.New(1, 2)),
+		move.New(color.Black, point.New(3, 3)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	b := a.Clone()
+	if _, err := b.PlaceStone(move.New(color.White, point.New(4, 0))); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetPlacements(move.List{
+		move.New(color.Empty, point.New(0, 0)),
+		move.New(color.Black, point.New(1, 2)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []StoneDiff{
+		{Point: point.New(0, 0), A: color.Black, B: color.Empty},
+		{Point: point.New(4, 0), A: color.Empty, B: color.White},
+		{Point: point.New(1, 2), A: color.White, B: color.Black},
+	}
+	if diff := cmp.Diff(exp, got, cmp.AllowUnexported(point.Point{})); diff != "" {
+		t.Errorf("Diff()=%v, but expected %v. diff=%s", got, exp, diff)
+	}
+
+	if got, err := Diff(a, a.Clone()); err != nil || got != nil {
+		t.Errorf("Diff() of equal boards=%v, %v, but expected no differences", got, err)
+	}
+	if _, err := Diff(a, New(9)); !errors.Is(err, InvalidBoardState) {
+		t.Errorf("Diff() of different sizes got error %v, but expected %v", err, InvalidBoardState)
+	}
+}
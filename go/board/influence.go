@@ -0,0 +1,134 @@
+package board
+
+import (
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/point"
+)
+
+const (
+	// DefaultDilations is the default number of dilation steps of
+	// InfluenceMap.
+	DefaultDilations = 5
+
+	// DefaultErosions is the default number of erosion steps of InfluenceMap.
+	// With 5 dilations, 21 erosions is Bouzy's recommendation for estimating
+	// territory.
+	DefaultErosions = 21
+
+	// stoneInfluence is the initial influence of a stone.
+	stoneInfluence = 128
+)
+
+// InfluenceMap estimates the influence of each player, using Bouzy's
+// dilation/erosion algorithm with the default number of steps. The result is
+// indexed by [y][x]: positive values are black's influence and negative
+// values are white's. This is a fast approximation of the score, which
+// doesn't account for dead stones.
+func (b *Board) InfluenceMap() [][]int {
+	return b.InfluenceMapSteps(DefaultDilations, DefaultErosions)
+}
+
+// InfluenceMapSteps is like InfluenceMap, but with the given number of
+// dilation and erosion steps. More dilations spread the influence further,
+// and more erosions shrink it to the areas that are firmly controlled.
+func (b *Board) InfluenceMapSteps(dilations, erosions int) [][]int {
+	inf := make([][]int, len(b.board))
+	for y, row := range b.board {
+		inf[y] = make([]int, len(row))
+		for x, c := range row {
+			switch c {
+			case color.Black:
+				inf[y][x] = stoneInfluence
+			case color.White:
+				inf[y][x] = -stoneInfluence
+			}
+		}
+	}
+	for i := 0; i < dilations; i++ {
+		inf = b.influenceStep(inf, dilate)
+	}
+	for i := 0; i < erosions; i++ {
+		inf = b.influenceStep(inf, erode)
+	}
+	return inf
+}
+
+// EstimatedTerritory counts the empty points under the influence of each
+// player, according to the influence map (see InfluenceMap).
+func (b *Board) EstimatedTerritory(influence [][]int) (black, white int) {
+	for y, row := range b.board {
+		for x, c := range row {
+			switch {
+			case c != color.Empty:
+			case influence[y][x] > 0:
+				black++
+			case influence[y][x] < 0:
+				white++
+			}
+		}
+	}
+	return black, white
+}
+
+// influenceStep applies a dilation or erosion step to every point, returning
+// the new influence map.
+func (b *Board) influenceStep(inf [][]int, step func(v int, nbs []int) int) [][]int {
+	out := make([][]int, len(inf))
+	for y := range inf {
+		out[y] = make([]int, len(inf[y]))
+		for x, v := range inf[y] {
+			var nbs []int
+			for _, nb := range Neighbors(point.New(x, y), len(inf[y]), len(inf)) {
+				nbs = append(nbs, inf[nb.Y()][nb.X()])
+			}
+			out[y][x] = step(v, nbs)
+		}
+	}
+	return out
+}
+
+// dilate is Bouzy's dilation: a point that isn't next to the opponent's
+// influence gains one for each neighbor with the player's influence.
+func dilate(v int, nbs []int) int {
+	var pos, neg int
+	for _, nb := range nbs {
+		if nb > 0 {
+			pos++
+		} else if nb < 0 {
+			neg++
+		}
+	}
+	switch {
+	case v >= 0 && neg == 0:
+		return v + pos
+	case v <= 0 && pos == 0:
+		return v - neg
+	}
+	return v
+}
+
+// erode is Bouzy's erosion: a point loses one for each neighbor without the
+// player's influence, without changing sides.
+func erode(v int, nbs []int) int {
+	switch {
+	case v > 0:
+		for _, nb := range nbs {
+			if nb <= 0 {
+				v--
+			}
+		}
+		if v < 0 {
+			return 0
+		}
+	case v < 0:
+		for _, nb := range nbs {
+			if nb >= 0 {
+				v++
+			}
+		}
+		if v > 0 {
+			return 0
+		}
+	}
+	return v
+}
@@ -0,0 +1,61 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestInfluenceMap(t *testing.T) {
+	// Black walls off the top-left corner; white has a stone in the
+	// bottom-right.
+	//
+	// . . . B . . . . .
+	// . . . B . . . . .
+	// . . . B . . . . .
+	// B B B B . . . . .
+	// . . . . . . . . .
+	// . . . . . . . . .
+	// . . . . . . W . .
+	// . . . . . . . . .
+	// . . . . . . . . .
+	b := New(9)
+	ml := move.List{move.New(color.White, point.New(6, 6))}
+	for i := 0; i < 4; i++ {
+		ml = append(ml, move.New(color.Black, point.New(3, i)))
+		if i < 3 {
+			ml = append(ml, move.New(color.Black, point.New(i, 3)))
+		}
+	}
+	if err := b.SetPlacements(ml); err != nil {
+		t.Fatal(err)
+	}
+
+	inf := b.InfluenceMap()
+	for x := 0; x < 3; x++ {
+		for y := 0; y < 3; y++ {
+			if inf[y][x] <= 0 {
+				t.Errorf("influence at {%d,%d} was %d, but expected black's influence", x, y, inf[y][x])
+			}
+		}
+	}
+	if inf[6][6] >= 0 {
+		t.Errorf("influence at the white stone was %d, but expected white's influence", inf[6][6])
+	}
+	black, white := b.EstimatedTerritory(inf)
+	if black < 9 {
+		t.Errorf("EstimatedTerritory() got %d points for black, but expected at least the 9 corner points", black)
+	}
+	if black <= white {
+		t.Errorf("EstimatedTerritory()=%d, %d, but expected black to lead", black, white)
+	}
+
+	if got := b.InfluenceMapSteps(0, 0); got[0][0] != 0 || got[3][3] != stoneInfluence {
+		t.Errorf("InfluenceMapSteps(0, 0) got %d at the corner and %d at a stone, but expected only the stones' influence", got[0][0], got[3][3])
+	}
+	if got := New(9).InfluenceMap(); got[4][4] != 0 {
+		t.Errorf("influence on an empty board was %d, but expected 0", got[4][4])
+	}
+}
@@ -0,0 +1,42 @@
+package board
+
+import (
+	"strconv"
+
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ColumnLabels returns the human-readable labels for the columns of a board of
+// the given size, from left to right: A-T skipping I for a 19x19 board. Boards
+// larger than 25x25 continue the lettering with two letters (AA, AB, ...),
+// like spreadsheet columns.
+func ColumnLabels(size int) []string {
+	out := make([]string, size)
+	for x := range out {
+		out[x] = columnLabel(x)
+	}
+	return out
+}
+
+// columnLabel returns the label for column x, lettered as GTP columns (see
+// point.GTPColumns).
+func columnLabel(x int) string {
+	n := len(point.GTPColumns)
+	label := string(point.GTPColumns[x%n])
+	for x = x/n - 1; x >= 0; x = x/n - 1 {
+		label = string(point.GTPColumns[x%n]) + label
+	}
+	return label
+}
+
+// RowLabels returns the human-readable labels for the rows of a board of the
+// given size, from top to bottom, so that the label for row y is at index y.
+// Rows are numbered from the bottom, starting at 1, so for a 19x19 board the
+// labels are 19 to 1.
+func RowLabels(size int) []string {
+	out := make([]string, size)
+	for y := range out {
+		out[y] = strconv.Itoa(size - y)
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package board
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestColumnLabels(t *testing.T) {
+	testCases := []struct {
+		desc string
+		size int
+		exp  string
+	}{
+		{
+			desc: "9x9",
+			size: 9,
+			exp:  "A B C D E F G H J",
+		},
+		{
+			desc: "19x19",
+			size: 19,
+			exp:  "A B C D E F G H J K L M N O P Q R S T",
+		},
+		{
+			desc: "25x25",
+			size: 25,
+			exp:  "A B C D E F G H J K L M N O P Q R S T U V W X Y Z",
+		},
+		{
+			desc: "larger than 25x25",
+			size: 28,
+			exp:  "A B C D E F G H J K L M N O P Q R S T U V W X Y Z AA AB AC",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := ColumnLabels(tc.size)
+			if diff := cmp.Diff(strings.Fields(tc.exp), got); diff != "" {
+				t.Errorf("ColumnLabels(%d) got diff (-want +got):\n%s", tc.size, diff)
+			}
+		})
+	}
+}
+
+func TestColumnLabels_TwoLetters(t *testing.T) {
+	labels := ColumnLabels(2*25 + 3)
+	for _, tc := range []struct {
+		x   int
+		exp string
+	}{
+		{x: 25, exp: "AA"},
+		{x: 32, exp: "AH"},
+		{x: 33, exp: "AJ"},
+		{x: 49, exp: "AZ"},
+		{x: 50, exp: "BA"},
+	} {
+		if labels[tc.x] != tc.exp {
+			t.Errorf("ColumnLabels()[%d]=%q, but expected %q", tc.x, labels[tc.x], tc.exp)
+		}
+	}
+}
+
+func TestRowLabels(t *testing.T) {
+	testCases := []struct {
+		desc string
+		size int
+		exp  string
+	}{
+		{
+			desc: "9x9",
+			size: 9,
+			exp:  "9 8 7 6 5 4 3 2 1",
+		},
+		{
+			desc: "19x19",
+			size: 19,
+			exp:  "19 18 17 16 15 14 13 12 11 10 9 8 7 6 5 4 3 2 1",
+		},
+		{
+			desc: "25x25",
+			size: 25,
+			exp:  "25 24 23 22 21 20 19 18 17 16 15 14 13 12 11 10 9 8 7 6 5 4 3 2 1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := RowLabels(tc.size)
+			if diff := cmp.Diff(strings.Fields(tc.exp), got); diff != "" {
+				t.Errorf("RowLabels(%d) got diff (-want +got):\n%s", tc.size, diff)
+			}
+		})
+	}
+}
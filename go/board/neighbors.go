@@ -0,0 +1,24 @@
+package board
+
+import "github.com/otrego/clamshell/go/point"
+
+// Neighbors returns the points orthogonally adjacent to pt that are on a
+// board of the given width and height: four for interior points, three for
+// points on an edge, and two for corners.
+func Neighbors(pt *point.Point, width, height int) []*point.Point {
+	x, y := pt.X(), pt.Y()
+	var out []*point.Point
+	if x+1 < width {
+		out = append(out, point.New(x+1, y))
+	}
+	if x > 0 {
+		out = append(out, point.New(x-1, y))
+	}
+	if y+1 < height {
+		out = append(out, point.New(x, y+1))
+	}
+	if y > 0 {
+		out = append(out, point.New(x, y-1))
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestNeighbors(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		pt            *point.Point
+		width, height int
+		exp           []*point.Point
+	}{
+		{
+			desc:  "corner",
+			pt:    point.New(0, 0),
+			width: 19, height: 19,
+			exp: []*point.Point{point.New(1, 0), point.New(0, 1)},
+		},
+		{
+			desc:  "edge",
+			pt:    point.New(18, 5),
+			width: 19, height: 19,
+			exp: []*point.Point{point.New(17, 5), point.New(18, 6), point.New(18, 4)},
+		},
+		{
+			desc:  "interior",
+			pt:    point.New(3, 3),
+			width: 19, height: 19,
+			exp: []*point.Point{point.New(4, 3), point.New(2, 3), point.New(3, 4), point.New(3, 2)},
+		},
+		{
+			desc:  "corner, rectangular",
+			pt:    point.New(6, 2),
+			width: 7, height: 3,
+			exp: []*point.Point{point.New(5, 2), point.New(6, 1)},
+		},
+		{
+			desc:  "edge, rectangular",
+			pt:    point.New(3, 2),
+			width: 7, height: 3,
+			exp: []*point.Point{point.New(4, 2), point.New(2, 2), point.New(3, 1)},
+		},
+		{
+			desc:  "interior, rectangular",
+			pt:    point.New(5, 1),
+			width: 7, height: 3,
+			exp: []*point.Point{point.New(6, 1), point.New(4, 1), point.New(5, 2), point.New(5, 0)},
+		},
+		{
+			desc:  "single row",
+			pt:    point.New(0, 0),
+			width: 3, height: 1,
+			exp: []*point.Point{point.New(1, 0)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := Neighbors(tc.pt, tc.width, tc.height)
+			if !cmp.Equal(got, tc.exp) {
+				t.Errorf("Neighbors(%v, %d, %d)=%v, but expected %v", tc.pt, tc.width, tc.height, got, tc.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package board
+
+import (
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// Region is a connected region of empty points.
+type Region struct {
+	// Points are the points of the region, sorted as by move.PointSet.
+	Points []*point.Point
+
+	// Owner is the color of the stones bordering the region. If the region is
+	// neutral, because it borders both colors (ex: dame) or no stones at all,
+	// the owner is color.Empty.
+	Owner color.Color
+}
+
+// Regions partitions the empty points of the board into connected regions.
+// The regions are sorted by their first point, so that they're in the same
+// order for boards with the same stones.
+func (b *Board) Regions() []*Region {
+	visited := make([][]bool, b.Height())
+	for y := range visited {
+		visited[y] = make([]bool, b.Width())
+	}
+	var regions []*Region
+	// Scan in the order of move.PointSet, so that the regions are sorted.
+	for x := 0; x < b.Width(); x++ {
+		for y := 0; y < b.Height(); y++ {
+			if b.board[y][x] == color.Empty && !visited[y][x] {
+				regions = append(regions, b.fillRegion(visited, x, y))
+			}
+		}
+	}
+	return regions
+}
+
+// fillRegion flood-fills the empty region containing (x, y).
+func (b *Board) fillRegion(visited [][]bool, x, y int) *Region {
+	pts := &move.PointSet{}
+	bordersBlack, bordersWhite := false, false
+	stack := []*point.Point{point.New(x, y)}
+	visited[y][x] = true
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		pts.Add(cur)
+		for _, nb := range b.getNeighbors(cur) {
+			switch b.colorAt(nb) {
+			case color.Black:
+				bordersBlack = true
+			case color.White:
+				bordersWhite = true
+			default:
+				if !visited[nb.Y()][nb.X()] {
+					visited[nb.Y()][nb.X()] = true
+					stack = append(stack, nb)
+				}
+			}
+		}
+	}
+	r := &Region{Points: pts.Sorted()}
+	if bordersBlack && !bordersWhite {
+		r.Owner = color.Black
+	} else if bordersWhite && !bordersBlack {
+		r.Owner = color.White
+	}
+	return r
+}
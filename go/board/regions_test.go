@@ -0,0 +1,55 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestRegions(t *testing.T) {
+	// . B . W .
+	// . B . W .
+	// . B . W .
+	// . B . W .
+	// . B . W .
+	b := New(5)
+	var ml move.List
+	for y := 0; y < 5; y++ {
+		ml = append(ml, move.New(color.Black, point.New(1, y)), move.New(color.White, point.New(3, y)))
+	}
+	if err := b.SetPlacements(ml); err != nil {
+		t.Fatal(err)
+	}
+
+	type region struct {
+		Points []string
+		Owner  color.Color
+	}
+	var got []region
+	for _, r := range b.Regions() {
+		var pts []string
+		for _, pt := range r.Points {
+			s, err := pt.ToSGF()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pts = append(pts, s)
+		}
+		got = append(got, region{pts, r.Owner})
+	}
+	exp := []region{
+		{[]string{"aa", "ab", "ac", "ad", "ae"}, color.Black},
+		{[]string{"ca", "cb", "cc", "cd", "ce"}, color.Empty},
+		{[]string{"ea", "eb", "ec", "ed", "ee"}, color.White},
+	}
+	if !cmp.Equal(got, exp) {
+		t.Errorf("Regions()=%v, but expected %v. Diff=%s", got, exp, cmp.Diff(got, exp))
+	}
+
+	if got := New(3).Regions(); len(got) != 1 || len(got[0].Points) != 9 || got[0].Owner != color.Empty {
+		t.Errorf("Regions() for an empty board=%v, but expected one neutral region of 9 points", got)
+	}
+}
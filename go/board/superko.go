@@ -0,0 +1,45 @@
+package board
+
+// TrackSuperko starts tracking the board positions, so that moves that repeat
+// an earlier position are illegal (positional superko), with ErrSuperko.
+// Only simple ko is checked otherwise. The current position is the first
+// tracked position, and the positions after each move and placement are
+// tracked from then on. Reverting a change with Revert forgets its position.
+//
+// The positions are tracked by their hashes (see Hash), which costs memory for
+// every position, and Clone copies the tracked positions.
+func (b *Board) TrackSuperko() {
+	if b.history != nil {
+		return
+	}
+	b.history = make(map[uint64]int)
+	b.recordPosition()
+}
+
+// TracksSuperko indicates whether the board positions are tracked for
+// positional superko.
+func (b *Board) TracksSuperko() bool {
+	return b.history != nil
+}
+
+// repeatsPosition indicates whether the current position repeats a tracked
+// position.
+func (b *Board) repeatsPosition() bool {
+	return b.history != nil && b.history[b.Hash()] > 0
+}
+
+// recordPosition tracks the current position, if superko is tracked.
+func (b *Board) recordPosition() {
+	if b.history != nil {
+		b.history[b.Hash()]++
+	}
+}
+
+// forgetPosition stops tracking one occurence of the position with hash h.
+func (b *Board) forgetPosition(h uint64) {
+	if b.history[h] <= 1 {
+		delete(b.history, h)
+		return
+	}
+	b.history[h]--
+}
@@ -0,0 +1,113 @@
+package board
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// koBoard returns a board where black has just taken a ko at (4, 4), with
+// white to retake at (4, 5).
+func koBoard(t *testing.T) *Board {
+	t.Helper()
+	b := New(9)
+	err := b.SetPlacements(move.List{
+		move.New(color.Black, point.New(4, 3)),
+		move.New(color.Black, point.New(3, 4)),
+		move.New(color.Black, point.New(5, 4)),
+		move.New(color.White, point.New(3, 5)),
+		move.New(color.White, point.New(5, 5)),
+		move.New(color.White, point.New(4, 6)),
+		move.New(color.White, point.New(4, 4)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.PlaceStone(move.New(color.Black, point.New(4, 5))); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestTrackSuperko(t *testing.T) {
+	retake := move.New(color.White, point.New(4, 4))
+	testCases := []struct {
+		desc string
+		// repeated indicates that the position after the retake occurred
+		// earlier, as in a triple ko.
+		repeated bool
+		track    bool
+		expErr   error
+	}{
+		{
+			desc:   "simple ko",
+			expErr: ErrKo,
+		},
+		{
+			desc:   "simple ko, tracking superko",
+			track:  true,
+			expErr: ErrKo,
+		},
+		{
+			desc:     "repeated position",
+			repeated: true,
+			track:    true,
+			expErr:   ErrSuperko,
+		},
+		{
+			desc:     "repeated position, not tracking superko",
+			repeated: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			b := koBoard(t)
+			if tc.track {
+				b.TrackSuperko()
+			}
+			if tc.repeated {
+				// Clear the ko, so that only superko prevents the retake.
+				b.ko = nil
+				if tc.track {
+					after := b.Clone()
+					after.history = nil
+					if _, err := after.PlaceStone(retake); err != nil {
+						t.Fatal(err)
+					}
+					b.history[after.Hash()]++
+				}
+			}
+			before := b.String()
+			_, err := b.PlaceStone(retake)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil && b.String() != before {
+				t.Errorf("got board:\n%v\nafter an illegal move, but expected it to be unchanged:\n%v", b, before)
+			}
+		})
+	}
+}
+
+func TestTrackSuperko_Revert(t *testing.T) {
+	b := New(9)
+	b.TrackSuperko()
+	m := move.New(color.Black, point.New(2, 2))
+	if err := b.CheckMove(m); err != nil {
+		t.Fatalf("CheckMove(%v)=%v, but expected the move to be legal", m, err)
+	}
+	_, u, err := b.PlaceStoneWithUndo(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Revert(u)
+	if _, err := b.PlaceStone(m); err != nil {
+		t.Errorf("after reverting, got error %v, but expected the move to be legal again", err)
+	}
+	if err := b.CheckMove(move.New(color.White, point.New(2, 2))); !errors.Is(err, IllegalMove) {
+		t.Errorf("CheckMove on an occupied point=%v, but expected %v", err, IllegalMove)
+	}
+}
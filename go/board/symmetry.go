@@ -0,0 +1,45 @@
+package board
+
+// Symmetry is one of the symmetries of a square board: the identity, the
+// rotations, and the reflections. Symmetries are numbered from 0 (the
+// identity) to NumSymmetries-1.
+type Symmetry int
+
+// NumSymmetries is the number of symmetries of a square board: the four
+// rotations, each of which may be reflected.
+const NumSymmetries Symmetry = 8
+
+// Transform applies the symmetry to the point (x, y) of a size x size board.
+// Bit 0 of the symmetry reflects the point horizontally, and the remaining
+// bits rotate it by 90 degrees clockwise that many times.
+func (s Symmetry) Transform(x, y, size int) (int, int) {
+	if s&1 != 0 {
+		x = size - 1 - x
+	}
+	for r := Symmetry(0); r < s>>1; r++ {
+		x, y = size-1-y, x
+	}
+	return x, y
+}
+
+// Preserves indicates whether the symmetry maps a width x height board onto
+// itself. Every symmetry does for square boards, but for rectangular boards,
+// only the identity, the half turn, and their reflections do.
+func (s Symmetry) Preserves(width, height int) bool {
+	return width == height || (s>>1)%2 == 0
+}
+
+// TransformRect is like Transform, for a width x height board. The symmetry
+// must preserve the board (see Preserves).
+func (s Symmetry) TransformRect(x, y, width, height int) (int, int) {
+	if width == height {
+		return s.Transform(x, y, width)
+	}
+	if s&1 != 0 {
+		x = width - 1 - x
+	}
+	if s>>1 == 2 {
+		x, y = width-1-x, height-1-y
+	}
+	return x, y
+}
@@ -0,0 +1,81 @@
+package board
+
+import (
+	"testing"
+)
+
+func TestSymmetryTransform(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s    Symmetry
+		expX int
+		expY int
+	}{
+		{desc: "identity", s: 0, expX: 1, expY: 0},
+		{desc: "reflection", s: 1, expX: 3, expY: 0},
+		{desc: "rotation by 90 degrees", s: 2, expX: 4, expY: 1},
+		{desc: "rotation by 180 degrees", s: 4, expX: 3, expY: 4},
+		{desc: "rotation by 270 degrees", s: 6, expX: 0, expY: 3},
+		{desc: "reflected rotation by 90 degrees", s: 3, expX: 4, expY: 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			x, y := tc.s.Transform(1, 0, 5)
+			if x != tc.expX || y != tc.expY {
+				t.Errorf("Transform(1, 0, 5)=(%d, %d), but expected (%d, %d)", x, y, tc.expX, tc.expY)
+			}
+		})
+	}
+
+	seen := make(map[[2]int]bool)
+	for s := Symmetry(0); s < NumSymmetries; s++ {
+		x, y := s.Transform(1, 0, 5)
+		seen[[2]int{x, y}] = true
+	}
+	if len(seen) != int(NumSymmetries) {
+		t.Errorf("got %d distinct transformed points, but expected %d", len(seen), NumSymmetries)
+	}
+}
+
+func TestSymmetryTransformRect(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		s     Symmetry
+		expOK bool
+		expX  int
+		expY  int
+	}{
+		{desc: "identity", s: 0, expOK: true, expX: 1, expY: 0},
+		{desc: "reflection", s: 1, expOK: true, expX: 3, expY: 0},
+		{desc: "rotation by 90 degrees", s: 2},
+		{desc: "rotation by 180 degrees", s: 4, expOK: true, expX: 3, expY: 1},
+		{desc: "reflected rotation by 180 degrees", s: 5, expOK: true, expX: 1, expY: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if ok := tc.s.Preserves(5, 2); ok != tc.expOK {
+				t.Fatalf("Preserves(5, 2)=%v, but expected %v", ok, tc.expOK)
+			}
+			if !tc.expOK {
+				return
+			}
+			x, y := tc.s.TransformRect(1, 0, 5, 2)
+			if x != tc.expX || y != tc.expY {
+				t.Errorf("TransformRect(1, 0, 5, 2)=(%d, %d), but expected (%d, %d)", x, y, tc.expX, tc.expY)
+			}
+		})
+	}
+
+	a, b := NewRect(5, 2), NewRect(5, 2)
+	a.board[0][1] = "B"
+	b.board[1][3] = "B"
+	if !a.EqualUpToSymmetry(b) {
+		t.Errorf("got boards\n%v\nand\n%v\nnot equal up to symmetry, but expected equal", a, b)
+	}
+	if a.CanonicalHash() != b.CanonicalHash() {
+		t.Errorf("got different canonical hashes for boards\n%v\nand\n%v", a, b)
+	}
+	if a.EqualUpToSymmetry(New(5)) {
+		t.Errorf("got a 5x2 board equal to a 5x5 board")
+	}
+}
@@ -0,0 +1,137 @@
+package board
+
+import (
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// Undo records the board state from before a change, so that the change can
+// be reverted with Revert.
+type Undo struct {
+	// prev contains the previous contents of the changed points.
+	prev move.List
+	// ko is the previous ko point.
+	ko *point.Point
+	// tracked indicates that the position after the change was tracked for
+	// superko, with the hash.
+	tracked bool
+	hash    uint64
+}
+
+// track records the position after the change, if superko is tracked, so that
+// Revert can forget it.
+func (u *Undo) track(b *Board) {
+	if b.history != nil {
+		u.tracked, u.hash = true, b.Hash()
+	}
+}
+
+// PlaceStoneWithUndo is like PlaceStone, but additionally returns an Undo for
+// reverting the move (including the captures and the ko).
+func (b *Board) PlaceStoneWithUndo(m *move.Move) (move.List, *Undo, error) {
+	u := &Undo{ko: b.ko}
+	captured, err := b.PlaceStone(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.prev = append(u.prev, move.New(color.Empty, m.Point()))
+	u.prev = append(u.prev, captured...)
+	u.track(b)
+	return captured, u, nil
+}
+
+// PlaceSelfCaptureWithUndo is like PlaceSelfCapture, but additionally returns
+// an Undo for reverting the move (including the captures and the ko).
+func (b *Board) PlaceSelfCaptureWithUndo(m *move.Move) (move.List, *Undo, error) {
+	u := &Undo{ko: b.ko}
+	captured, err := b.PlaceSelfCapture(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The placed stone is one of the captured stones, so the point ends up
+	// empty either way.
+	u.prev = append(u.prev, captured...)
+	u.track(b)
+	return captured, u, nil
+}
+
+// SetPlacementsWithUndo is like SetPlacements, but additionally returns an
+// Undo for reverting the placements. If the placements result in an illegal
+// board position, the board is left unchanged.
+func (b *Board) SetPlacementsWithUndo(ml move.List) (*Undo, error) {
+	if err := b.checkPlacementBounds(ml); err != nil {
+		return nil, err
+	}
+	u := &Undo{ko: b.ko}
+	for _, m := range ml {
+		u.prev = append(u.prev, move.New(b.colorAt(m.Point()), m.Point()))
+	}
+	if err := b.SetPlacements(ml); err != nil {
+		b.Revert(u)
+		return nil, err
+	}
+	u.track(b)
+	return u, nil
+}
+
+// SetPlacementsAndCaptureWithUndo is like SetPlacementsAndCapture, but
+// additionally returns an Undo for reverting the placements and the captures.
+func (b *Board) SetPlacementsAndCaptureWithUndo(ml move.List) (move.List, *Undo, error) {
+	if err := b.checkPlacementBounds(ml); err != nil {
+		return nil, nil, err
+	}
+	u := &Undo{ko: b.ko}
+	for _, m := range ml {
+		u.prev = append(u.prev, move.New(b.colorAt(m.Point()), m.Point()))
+	}
+	for _, m := range ml {
+		b.setColor(m)
+	}
+	captured := b.CaptureAfterPlacements(ml)
+	// A captured stone may be one of the placements, in which case it's
+	// restored before the point's previous contents.
+	u.prev = append(u.prev, captured...)
+	if err := b.checkPlacedGroups(ml); err != nil {
+		b.Revert(u)
+		return nil, nil, err
+	}
+	b.recordPosition()
+	u.track(b)
+	return captured, u, nil
+}
+
+// Revert reverts the change recorded by an Undo. Undos must be reverted in the
+// reverse order that they were created in.
+func (b *Board) Revert(u *Undo) {
+	if u.tracked && b.history != nil {
+		b.forgetPosition(u.hash)
+	}
+	for i := len(u.prev) - 1; i >= 0; i-- {
+		b.setColor(u.prev[i])
+	}
+	b.ko = u.ko
+}
+
+// Snapshot returns a copy of the board, given an earlier board prev and the
+// undos for the changes made since prev. Only the rows with changes are
+// copied; the other rows are shared with prev. Snapshots are much cheaper
+// than clones when few stones change, but since they share memory with prev,
+// neither board may be modified afterwards. Clone a snapshot to modify it.
+func (b *Board) Snapshot(prev *Board, changes ...*Undo) *Board {
+	out := &Board{
+		ko:    b.ko,
+		board: append([][]color.Color{}, prev.board...),
+	}
+	copied := make(map[int]bool)
+	for _, u := range changes {
+		for _, m := range u.prev {
+			y := m.Point().Y()
+			if !copied[y] {
+				out.board[y] = append([]color.Color{}, b.board[y]...)
+				copied[y] = true
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package board
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestPlaceStoneWithUndo(t *testing.T) {
+	b := &Board{
+		board: [][]color.Color{
+			{"", "W", "B", ""},
+			{"", "B", "", ""},
+			{"", "", "", ""},
+			{"", "", "", ""}},
+		ko: point.New(3, 3),
+	}
+	before := b.String()
+
+	captured, u, err := b.PlaceStoneWithUndo(move.New(color.Black, point.New(0, 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(captured) != 1 {
+		t.Fatalf("got captures %v, but expected one capture", captured)
+	}
+	b.Revert(u)
+	if got := b.String(); got != before {
+		t.Errorf("after Revert, got board\n%s\nbut expected\n%s", got, before)
+	}
+}
+
+func TestSetPlacementsWithUndo(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		ml     move.List
+		expErr error
+	}{
+		{
+			desc: "overwrites stones",
+			ml: move.List{
+				move.New(color.White, point.New(2, 0)),
+				move.New(color.Empty, point.New(1, 1)),
+				move.New(color.Black, point.New(3, 3)),
+			},
+		},
+		{
+			desc: "invalid position",
+			ml: move.List{
+				move.New(color.Black, point.New(0, 0)),
+				move.New(color.White, point.New(0, 1)),
+			},
+			expErr: InvalidBoardState,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			b := &Board{
+				board: [][]color.Color{
+					{"", "W", "B", ""},
+					{"", "B", "", ""},
+					{"", "", "", ""},
+					{"", "", "", ""}},
+			}
+			before := b.String()
+
+			u, err := b.SetPlacementsWithUndo(tc.ml)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err == nil {
+				b.Revert(u)
+			}
+			if got := b.String(); got != before {
+				t.Errorf("got board\n%s\nbut expected\n%s", got, before)
+			}
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	b := &Board{
+		board: [][]color.Color{
+			{"", "W", "B", ""},
+			{"W", "B", "", ""},
+			{"", "", "", ""},
+			{"", "", "", ""}},
+	}
+	prev := b.Clone()
+
+	// Capture the black stone in the corner.
+	_, u, err := b.PlaceStoneWithUndo(move.New(color.White, point.New(3, 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := b.Snapshot(prev, u)
+	if got.String() != b.String() {
+		t.Errorf("got snapshot\n%s\nbut expected\n%s", got, b)
+	}
+	for y := 1; y < len(got.board); y++ {
+		if &got.board[y][0] != &prev.board[y][0] {
+			t.Errorf("row %d was copied, but expected it to be shared", y)
+		}
+	}
+	if &got.board[0][0] == &b.board[0][0] || &got.board[0][0] == &prev.board[0][0] {
+		t.Errorf("row 0 was shared, but expected it to be copied")
+	}
+}
@@ -0,0 +1,170 @@
+package board
+
+import (
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// Hash returns the Zobrist hash of the stones on the board. Equal boards have
+// equal hashes, but since different boards may (rarely) have equal hashes
+// too, use Equal to confirm that two boards are equal. The ko isn't part of
+// the hash.
+func (b *Board) Hash() uint64 {
+	return b.symmetricHash(0)
+}
+
+// CanonicalHash is like Hash, but boards that are rotations or reflections of
+// each other have the same canonical hash. Use Equivalent to confirm that two
+// boards are equivalent.
+func (b *Board) CanonicalHash() uint64 {
+	h := b.symmetricHash(0)
+	for s := Symmetry(1); s < NumSymmetries; s++ {
+		if !s.Preserves(b.Width(), b.Height()) {
+			continue
+		}
+		if sh := b.symmetricHash(s); sh < h {
+			h = sh
+		}
+	}
+	return h
+}
+
+// ColorInvariantHash is like Hash, but swapping the colors of all the stones
+// gives the same hash. Use it to collect color-agnostic statistics, such as
+// for an opening book where a shape is the same for black and white, and use
+// Hash when the colors matter. Since the board doesn't track whose turn it is,
+// the turn isn't part of the hash: callers that need it should hash the board
+// from the point of view of the player to move, by swapping the colors when
+// white is to play, for example.
+func (b *Board) ColorInvariantHash() uint64 {
+	h, swapped := b.symmetricHash(0), b.colorSwappedHash()
+	if swapped < h {
+		return swapped
+	}
+	return h
+}
+
+// colorSwappedHash returns the Zobrist hash of the board with the colors of
+// the stones swapped.
+func (b *Board) colorSwappedHash() uint64 {
+	width := b.Width()
+	var h uint64
+	for y, row := range b.board {
+		for x, c := range row {
+			if c != color.Empty {
+				h ^= zobristKey(y*width+x, c.Opposite())
+			}
+		}
+	}
+	return h
+}
+
+// Equal indicates whether the two boards have the same size and the same
+// stones. Only the stones are compared, so boards reached by different move
+// orders are equal. The ko is ignored, as is whose turn it is, which a board
+// doesn't track.
+func (b *Board) Equal(other *Board) bool {
+	return b.equalUnder(other, 0)
+}
+
+// Equivalent indicates whether the two boards are equal, up to a rotation or
+// reflection. Rectangular boards are only equivalent under the symmetries
+// that preserve their shape (see Symmetry.Preserves). The ko is ignored.
+func (b *Board) Equivalent(other *Board) bool {
+	return b.EqualUpToSymmetry(other)
+}
+
+// EqualUpToSymmetry is like Equal, but boards that are rotations or
+// reflections of each other are also equal. As with Equal, the ko and whose
+// turn it is are ignored.
+func (b *Board) EqualUpToSymmetry(other *Board) bool {
+	for s := Symmetry(0); s < NumSymmetries; s++ {
+		if s.Preserves(b.Width(), b.Height()) && b.equalUnder(other, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateHash returns the hash of the board after a change, given the hash h of
+// the board from before the change and the Undo for the change (see
+// PlaceStoneWithUndo). Only the changed points are hashed, so it's much
+// cheaper than Hash.
+func (b *Board) UpdateHash(h uint64, u *Undo) uint64 {
+	width := b.Width()
+	for i, m := range u.prev {
+		pt := m.Point()
+		if changedEarlier(u.prev[:i], pt) {
+			// Only the first change records the point's original color.
+			continue
+		}
+		idx := pt.Y()*width + pt.X()
+		if m.Color() != color.Empty {
+			h ^= zobristKey(idx, m.Color())
+		}
+		if c := b.colorAt(pt); c != color.Empty {
+			h ^= zobristKey(idx, c)
+		}
+	}
+	return h
+}
+
+// changedEarlier indicates whether the point is in the list of changes.
+func changedEarlier(changes move.List, pt *point.Point) bool {
+	for _, m := range changes {
+		if m.Point().X() == pt.X() && m.Point().Y() == pt.Y() {
+			return true
+		}
+	}
+	return false
+}
+
+// symmetricHash returns the Zobrist hash of the board transformed by
+// symmetry s, which must preserve the board.
+func (b *Board) symmetricHash(s Symmetry) uint64 {
+	width, height := b.Width(), b.Height()
+	var h uint64
+	for y, row := range b.board {
+		for x, c := range row {
+			if c == color.Empty {
+				continue
+			}
+			tx, ty := s.TransformRect(x, y, width, height)
+			h ^= zobristKey(ty*width+tx, c)
+		}
+	}
+	return h
+}
+
+// equalUnder indicates whether the board transformed by symmetry s, which
+// must preserve the board, equals the other board.
+func (b *Board) equalUnder(other *Board, s Symmetry) bool {
+	width, height := b.Width(), b.Height()
+	if other.Width() != width || other.Height() != height {
+		return false
+	}
+	for y, row := range b.board {
+		for x, c := range row {
+			tx, ty := s.TransformRect(x, y, width, height)
+			if other.board[ty][tx] != c {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// zobristKey returns the random key for a stone of color c on the point with
+// index i. The keys are derived from the splitmix64 generator, so that hashes
+// are stable across runs.
+func zobristKey(i int, c color.Color) uint64 {
+	z := uint64(i) << 1
+	if c == color.White {
+		z |= 1
+	}
+	z = (z + 1) * 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
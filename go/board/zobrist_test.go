@@ -0,0 +1,162 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestHash(t *testing.T) {
+	newBoard := func(moves ...*move.Move) *Board {
+		b := New(5)
+		if err := b.SetPlacements(moves); err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	b := newBoard(
+		move.New(color.Black, point.New(0, 0)),
+		move.New(color.White, point.New(1, 2)))
+
+	testCases := []struct {
+		desc          string
+		other         *Board
+		expEqual      bool
+		expEquivalent bool
+		// expSwapped indicates that the boards are equal up to swapping colors.
+		expSwapped bool
+	}{
+		{
+			desc: "same stones, placed in another order",
+			other: newBoard(
+				move.New(color.White, point.New(1, 2)),
+				move.New(color.Black, point.New(0, 0))),
+			expEqual:      true,
+			expEquivalent: true,
+			expSwapped:    true,
+		},
+		{
+			desc: "rotation",
+			other: newBoard(
+				move.New(color.Black, point.New(4, 0)),
+				move.New(color.White, point.New(2, 1))),
+			expEquivalent: true,
+		},
+		{
+			desc: "reflection",
+			other: newBoard(
+				move.New(color.Black, point.New(4, 0)),
+				move.New(color.White, point.New(3, 2))),
+			expEquivalent: true,
+		},
+		{
+			desc: "colors swapped",
+			other: newBoard(
+				move.New(color.White, point.New(0, 0)),
+				move.New(color.Black, point.New(1, 2))),
+			expSwapped: true,
+		},
+		{
+			desc:  "empty board",
+			other: New(5),
+		},
+		{
+			desc:  "different size",
+			other: New(7),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := b.Equal(tc.other); got != tc.expEqual {
+				t.Errorf("Equal() = %v, but expected %v", got, tc.expEqual)
+			}
+			if got := b.Hash() == tc.other.Hash(); got != tc.expEqual {
+				t.Errorf("equal hashes = %v, but expected %v", got, tc.expEqual)
+			}
+			if got := b.Equivalent(tc.other); got != tc.expEquivalent {
+				t.Errorf("Equivalent() = %v, but expected %v", got, tc.expEquivalent)
+			}
+			if got := b.CanonicalHash() == tc.other.CanonicalHash(); got != tc.expEquivalent {
+				t.Errorf("equal canonical hashes = %v, but expected %v", got, tc.expEquivalent)
+			}
+			if got := b.ColorInvariantHash() == tc.other.ColorInvariantHash(); got != tc.expSwapped {
+				t.Errorf("equal color-invariant hashes = %v, but expected %v", got, tc.expSwapped)
+			}
+		})
+	}
+}
+
+func TestEqual_MoveOrder(t *testing.T) {
+	play := func(moves ...*move.Move) *Board {
+		b := New(5)
+		for _, m := range moves {
+			if _, err := b.PlaceStone(m); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return b
+	}
+	// Black captures the white stone at (1,0) in both games, with the moves in
+	// another order.
+	b := play(
+		move.New(color.Black, point.New(0, 0)),
+		move.New(color.White, point.New(1, 0)),
+		move.New(color.Black, point.New(1, 1)),
+		move.New(color.White, point.New(3, 3)),
+		move.New(color.Black, point.New(2, 0)))
+	other := play(
+		move.New(color.Black, point.New(2, 0)),
+		move.New(color.White, point.New(3, 3)),
+		move.New(color.Black, point.New(1, 1)),
+		move.New(color.White, point.New(1, 0)),
+		move.New(color.Black, point.New(0, 0)))
+	if !b.Equal(other) || !b.EqualUpToSymmetry(other) {
+		t.Errorf("Equal()=%v, EqualUpToSymmetry()=%v for the same stones played in another order, but expected true, true\n%v\n%v",
+			b.Equal(other), b.EqualUpToSymmetry(other), b, other)
+	}
+
+	reflected := play(
+		move.New(color.Black, point.New(4, 0)),
+		move.New(color.White, point.New(3, 0)),
+		move.New(color.Black, point.New(3, 1)),
+		move.New(color.White, point.New(1, 3)),
+		move.New(color.Black, point.New(2, 0)))
+	if b.Equal(reflected) || !b.EqualUpToSymmetry(reflected) {
+		t.Errorf("Equal()=%v, EqualUpToSymmetry()=%v for a reflection, but expected false, true\n%v\n%v",
+			b.Equal(reflected), b.EqualUpToSymmetry(reflected), b, reflected)
+	}
+}
+
+func TestUpdateHash(t *testing.T) {
+	b := New(5)
+	if err := b.SetPlacements(move.List{
+		move.New(color.Black, point.New(0, 1)),
+		move.New(color.White, point.New(0, 0)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	h := b.Hash()
+	// Black captures the white stone in the corner.
+	captured, u, err := b.PlaceStoneWithUndo(move.New(color.Black, point.New(1, 0)))
+	if err != nil || len(captured) != 1 {
+		t.Fatalf("PlaceStoneWithUndo() captured %v with error %v, but expected one capture", captured, err)
+	}
+	if got, exp := b.UpdateHash(h, u), b.Hash(); got != exp {
+		t.Errorf("UpdateHash()=%x, but expected %x", got, exp)
+	}
+	b.Revert(u)
+	h = b.Hash()
+
+	u, err = b.SetPlacementsWithUndo(move.List{
+		move.New(color.White, point.New(3, 3)),
+		move.New(color.Black, point.New(3, 3)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := b.UpdateHash(h, u), b.Hash(); got != exp {
+		t.Errorf("UpdateHash() after placing on a point twice=%x, but expected %x", got, exp)
+	}
+}
@@ -0,0 +1,267 @@
+// Package corpus parses large collections of SGF games concurrently, such as
+// the hundreds of thousands of games of a training-data pipeline.
+//
+// ParseDir and ParseReader read the games, parse them with a pool of
+// workers, and send the results on a channel, in the order in which the games
+// were read. The channel is closed once every game has been sent or the
+// context is canceled. The caller must either receive from the channel until
+// it's closed or cancel the context, so that the workers can exit.
+package corpus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+// ErrCorpus indicates that a game or a file of a corpus couldn't be read.
+// Errors parsing a game are the errors from the sgf package instead.
+var ErrCorpus = errors.New("error reading corpus")
+
+// Options contains options for parsing a corpus. A nil *Options is valid and
+// means that the defaults are used.
+type Options struct {
+	// Workers is the number of games parsed concurrently. If zero, it's
+	// runtime.GOMAXPROCS(0).
+	Workers int
+
+	// MaxPending is the number of games that have been read but not yet
+	// received from the channel, including the games being parsed. It limits
+	// the memory used to about MaxPending times the size of a parsed game. If
+	// zero, it's twice the number of workers.
+	MaxPending int
+
+	// MaxGameBytes, if positive, is the size of the largest game that's
+	// parsed. Larger games are sent as results with an error wrapping
+	// ErrCorpus, without being parsed.
+	MaxGameBytes int
+
+	// Parse contains the options for parsing the properties of the games.
+	Parse *prop.ParseOptions
+}
+
+func (o *Options) workers() int {
+	if o == nil || o.Workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return o.Workers
+}
+
+func (o *Options) maxPending() int {
+	if o == nil || o.MaxPending <= 0 {
+		return 2 * o.workers()
+	}
+	return o.MaxPending
+}
+
+func (o *Options) maxGameBytes() int {
+	if o == nil {
+		return 0
+	}
+	return o.MaxGameBytes
+}
+
+func (o *Options) parse() *prop.ParseOptions {
+	if o == nil {
+		return nil
+	}
+	return o.Parse
+}
+
+// Result is a parsed game, or the error reading or parsing it.
+type Result struct {
+	// Source is the file that the game was read from, or empty for games read
+	// from a stream.
+	Source string
+
+	// Index is the number of the game in its source, starting at 1. It's 0
+	// for errors about a whole file (ex: a file that can't be read).
+	Index int
+
+	// Tree is the parsed game, or nil if there was an error.
+	Tree *movetree.MoveTree
+
+	// Warnings are the problems that were recovered from while parsing the
+	// game (see prop.ParseOptions.Lenient).
+	Warnings []*sgf.Warning
+
+	// Err is the error reading or parsing the game, if any.
+	Err error
+}
+
+// Name returns the name of the game, for messages (ex: "games.sgf: game 2").
+func (r *Result) Name() string {
+	switch {
+	case r.Source == "":
+		return fmt.Sprintf("game %d", r.Index)
+	case r.Index == 0:
+		return r.Source
+	}
+	return fmt.Sprintf("%s: game %d", r.Source, r.Index)
+}
+
+// job is a game to parse. Its result is sent on done, which is buffered so
+// that workers never wait for the result to be received.
+type job struct {
+	res  Result
+	raw  []byte
+	done chan Result
+}
+
+// ParseDir parses the games of the .sgf files in the directory and its
+// subdirectories, in lexical order of their paths. Each file may contain a
+// collection of games. Errors walking the directory or reading a file are
+// sent as results for the file, and the rest of the directory is still
+// parsed.
+func ParseDir(ctx context.Context, dir string, opts *Options) <-chan Result {
+	return run(ctx, opts, func(emit func(*job) bool) {
+		// The walk only fails with errStopped, since the other errors are
+		// sent as results.
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			ok := true
+			if err != nil {
+				ok = emit(&job{res: Result{Source: path, Err: fmt.Errorf("%w: %v", ErrCorpus, err)}})
+			} else if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".sgf") {
+				ok = readFile(path, emit)
+			}
+			if !ok {
+				return errStopped
+			}
+			return nil
+		})
+	})
+}
+
+// errStopped stops walking a directory once the context is canceled.
+var errStopped = errors.New("stopped")
+
+// readFile emits the games of the file, returning false if the context was
+// canceled.
+func readFile(path string, emit func(*job) bool) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return emit(&job{res: Result{Source: path, Err: fmt.Errorf("%w: %v", ErrCorpus, err)}})
+	}
+	raws, err := sgf.SplitGames(data)
+	if err != nil {
+		return emit(&job{res: Result{Source: path, Err: err}})
+	}
+	for i, raw := range raws {
+		if !emit(&job{res: Result{Source: path, Index: i + 1}, raw: raw}) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseReader parses the games of a stream of concatenated SGF games (see
+// sgf.Decoder). The stream is read until its end, or until an error reading
+// it, which is sent as the last result.
+func ParseReader(ctx context.Context, r io.Reader, opts *Options) <-chan Result {
+	return run(ctx, opts, func(emit func(*job) bool) {
+		d := sgf.NewDecoder(r)
+		for i := 1; ; i++ {
+			raw, err := d.NextBytes()
+			if errors.Is(err, io.EOF) {
+				return
+			} else if err != nil {
+				emit(&job{res: Result{Index: i, Err: err}})
+				return
+			}
+			if !emit(&job{res: Result{Index: i}, raw: raw}) {
+				return
+			}
+		}
+	})
+}
+
+// run parses the games fed by feed with a pool of workers. Feed emits the
+// games in order, and stops once emit returns false, when the context is
+// canceled. Jobs without raw bytes are errors, which are sent as they are.
+func run(ctx context.Context, opts *Options, feed func(emit func(*job) bool)) <-chan Result {
+	out := make(chan Result)
+	// pending holds the jobs in order, until their results are sent, so its
+	// capacity limits the number of games in memory.
+	pending := make(chan *job, opts.maxPending())
+	work := make(chan *job)
+
+	for i := 0; i < opts.workers(); i++ {
+		go func() {
+			for j := range work {
+				j.done <- parse(j, opts)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(work)
+		defer close(pending)
+		feed(func(j *job) bool {
+			// Checking the context first stops promptly, since select picks
+			// at random when the context is done and the send is possible.
+			if ctx.Err() != nil {
+				return false
+			}
+			j.done = make(chan Result, 1)
+			select {
+			case pending <- j:
+			case <-ctx.Done():
+				return false
+			}
+			if j.raw == nil {
+				j.done <- j.res
+				return true
+			}
+			select {
+			case work <- j:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	go func() {
+		defer close(out)
+		for j := range pending {
+			if ctx.Err() != nil {
+				return
+			}
+			var res Result
+			select {
+			case res = <-j.done:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case out <- res:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// parse parses the game of the job.
+func parse(j *job, opts *Options) Result {
+	res := j.res
+	if limit := opts.maxGameBytes(); limit > 0 && len(j.raw) > limit {
+		res.Err = fmt.Errorf("%w: game of %d bytes is larger than the limit of %d bytes", ErrCorpus, len(j.raw), limit)
+		return res
+	}
+	p := sgf.FromBytes(j.raw).WithOptions(opts.parse())
+	res.Tree, res.Err = p.Parse()
+	res.Warnings = p.Warnings()
+	return res
+}
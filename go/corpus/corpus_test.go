@@ -0,0 +1,151 @@
+package corpus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/otrego/clamshell/go/corpus"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+// summarize returns the name of each result, with its board size or error.
+func summarize(results <-chan corpus.Result) []string {
+	var out []string
+	for r := range results {
+		switch {
+		case errors.Is(r.Err, corpus.ErrCorpus):
+			out = append(out, r.Name()+": corpus error")
+		case errors.Is(r.Err, sgf.ErrParse):
+			out = append(out, r.Name()+": parse error")
+		case r.Err != nil:
+			out = append(out, fmt.Sprintf("%s: %v", r.Name(), r.Err))
+		default:
+			out = append(out, fmt.Sprintf("%s: %d", r.Name(), r.Tree.Root.GameInfo.Size))
+		}
+	}
+	return out
+}
+
+func TestParseReader(t *testing.T) {
+	var sb strings.Builder
+	for i := 1; i <= 20; i++ {
+		if i == 7 {
+			sb.WriteString("(;GM[1]SZ[9];B[zzz])\n")
+			continue
+		}
+		fmt.Fprintf(&sb, "(;GM[1]SZ[%d];B[aa])\n", i%5+5)
+	}
+	comment := "(;GM[1]SZ[9]C[" + strings.Repeat("long ", 100) + "])"
+	testCases := []struct {
+		desc string
+		in   string
+		opts *corpus.Options
+		exp  []string
+	}{
+		{
+			desc: "in order, with a parse error",
+			in:   sb.String(),
+			opts: &corpus.Options{Workers: 4, MaxPending: 3},
+			exp: func() []string {
+				var exp []string
+				for i := 1; i <= 20; i++ {
+					if i == 7 {
+						exp = append(exp, "game 7: parse error")
+						continue
+					}
+					exp = append(exp, fmt.Sprintf("game %d: %d", i, i%5+5))
+				}
+				return exp
+			}(),
+		},
+		{
+			desc: "game too large",
+			in:   "(;GM[1]SZ[9])" + comment + "(;GM[1]SZ[13])",
+			opts: &corpus.Options{MaxGameBytes: 100},
+			exp:  []string{"game 1: 9", "game 2: corpus error", "game 3: 13"},
+		},
+		{
+			desc: "truncated stream",
+			in:   "(;GM[1]SZ[9])(;GM[1];B[aa]",
+			exp:  []string{"game 1: 9", "game 2: parse error"},
+		},
+		{
+			desc: "empty stream",
+			in:   " \n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := summarize(corpus.ParseReader(context.Background(), strings.NewReader(tc.in), tc.opts))
+			if !reflect.DeepEqual(got, tc.exp) {
+				t.Errorf("got results %q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestParseDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.sgf":          "(;GM[1]SZ[9])(;GM[1]SZ[13])",
+		"b/c.SGF":        "(;GM[1]SZ[19])",
+		"b/d.sgf":        "(;GM[1]SZ[9]",
+		"b/notes.txt":    "(;GM[1]SZ[9])",
+		"e/f/g/deep.sgf": "(;GM[1]SZ[7])",
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := summarize(corpus.ParseDir(context.Background(), dir, &corpus.Options{Workers: 2}))
+	for i := range got {
+		got[i] = filepath.ToSlash(strings.TrimPrefix(got[i], dir+string(filepath.Separator)))
+	}
+	exp := []string{
+		"a.sgf: game 1: 9",
+		"a.sgf: game 2: 13",
+		"b/c.SGF: game 1: 19",
+		"b/d.sgf: parse error",
+		"e/f/g/deep.sgf: game 1: 7",
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("got results %q, but expected %q", got, exp)
+	}
+
+	missing := summarize(corpus.ParseDir(context.Background(), filepath.Join(dir, "missing"), nil))
+	if len(missing) != 1 || !strings.HasSuffix(missing[0], "missing: corpus error") {
+		t.Errorf("got results %q for a missing directory, but expected a corpus error", missing)
+	}
+}
+
+func TestParseReader_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := strings.Repeat("(;GM[1]SZ[9];B[aa];W[bb])", 1000)
+	results := corpus.ParseReader(ctx, strings.NewReader(in), &corpus.Options{Workers: 2, MaxPending: 2})
+	if r := <-results; r.Err != nil || r.Index != 1 {
+		t.Fatalf("got first result %+v, but expected game 1", r)
+	}
+	cancel()
+	// The channel is closed after at most the result that was being sent,
+	// without the rest of the games.
+	n := 0
+	for range results {
+		n++
+	}
+	if n > 1 {
+		t.Errorf("got %d results after canceling, but expected at most 1", n)
+	}
+}
@@ -0,0 +1,361 @@
+// Package edit provides editing sessions for movetrees. A session records each
+// edit (adding a node, setting a property, deleting a branch) as a reversible
+// operation, so that edits can be undone and redone, and the log of the edits
+// can be exported, such as for sending the changes of a review to the other
+// reviewers rather than the whole SGF.
+package edit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/prop"
+)
+
+// ErrEdit indicates an invalid edit, undo, or redo.
+var ErrEdit = errors.New("error editing movetree")
+
+// OpKind is the kind of an edit operation.
+type OpKind string
+
+const (
+	// AddNode adds a node (see Session.AddNode).
+	AddNode OpKind = "add-node"
+
+	// SetProperty sets or removes a property of a node (see
+	// Session.SetProperty).
+	SetProperty OpKind = "set-property"
+
+	// DeleteBranch deletes a node and its descendants (see
+	// Session.DeleteBranch).
+	DeleteBranch OpKind = "delete-branch"
+)
+
+// An Op is an edit operation, as recorded in the log of a session. Ops can be
+// applied to another session on the same movetree with Session.Apply.
+type Op struct {
+	// Kind is the kind of the operation.
+	Kind OpKind `json:"kind"`
+
+	// Path is the path from the root to the node that was edited, before the
+	// edit. For AddNode, it's the path to the node that was added.
+	Path movetree.Path `json:"path"`
+
+	// Prop is the property that was set. For AddNode, it's the color of the
+	// move of the node (B or W), or empty for a node without a move.
+	Prop string `json:"prop,omitempty"`
+
+	// Values are the values the property was set to, or empty if it was
+	// removed. For AddNode, it's the SGF point of the move (ex: pd), which is
+	// empty for a pass.
+	Values []string `json:"values,omitempty"`
+}
+
+// String returns a short description of the operation, for messages (ex:
+// "set-property C[Nice] at -0-1").
+func (o Op) String() string {
+	var sb strings.Builder
+	sb.WriteString(string(o.Kind))
+	if o.Prop != "" {
+		sb.WriteString(" " + o.Prop)
+		for _, v := range o.Values {
+			sb.WriteString("[" + v + "]")
+		}
+	}
+	sb.WriteString(" at " + o.Path.CompactString())
+	return sb.String()
+}
+
+// change is an operation that was applied, with what's needed to reverse it.
+type change struct {
+	op Op
+
+	// node is the node that was added, deleted, or edited, and parent and
+	// index are its parent and variation number, for AddNode and
+	// DeleteBranch.
+	node   *movetree.Node
+	parent *movetree.Node
+	index  int
+
+	// before and after are the properties of the edited node, for
+	// SetProperty.
+	before, after *movetree.Node
+}
+
+// Session is an editing session on a movetree. Edits must be made through the
+// session, for undo and redo to restore the movetree.
+type Session struct {
+	tree *movetree.MoveTree
+
+	// done are the changes that were applied, in order. undone are the
+	// changes that were undone, with the last one undone at the end.
+	done, undone []*change
+}
+
+// New creates an editing session on the movetree.
+func New(mt *movetree.MoveTree) *Session {
+	return &Session{tree: mt}
+}
+
+// Tree returns the movetree of the session.
+func (s *Session) Tree() *movetree.MoveTree {
+	return s.tree
+}
+
+// AddNode adds a node with move m (or without a move, if m is nil) as the last
+// variation of the node at path parent, and returns the path to it. If the
+// node already has a variation with the same move, nothing is added, and the
+// path to the existing variation is returned (see Node.AddVariation).
+func (s *Session) AddNode(parent movetree.Path, m *move.Move) (movetree.Path, error) {
+	p, err := parent.Resolve(s.tree.Root)
+	if err != nil {
+		return nil, err
+	}
+	op := Op{Kind: AddNode}
+	var n *movetree.Node
+	if m == nil {
+		n = movetree.NewNode()
+		if err := p.InsertVariation(len(p.Children), n); err != nil {
+			return nil, err
+		}
+	} else {
+		pt, err := m.ToSGF()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrEdit, err)
+		}
+		op.Prop, op.Values = string(m.Color()), []string{pt}
+		var added bool
+		if n, added = p.AddVariation(m); !added {
+			return movetree.PathTo(n), nil
+		}
+	}
+	op.Path = movetree.PathTo(n)
+	s.record(&change{op: op, node: n, parent: p, index: n.VarNum()})
+	return op.Path, nil
+}
+
+// SetProperty sets the property of the node at the path to the values,
+// replacing any previous values, or removes the property if there are no
+// values. The values are converted as when parsing SGF (ex: C sets the
+// comment), and are unescaped values (ex: "a]b" rather than "a\]b").
+// Converted properties that are written together are set separately, so that
+// setting TR only replaces the triangle marks of the node.
+func (s *Session) SetProperty(path movetree.Path, p string, values []string) error {
+	n, err := path.Resolve(s.tree.Root)
+	if err != nil {
+		return err
+	}
+	if !isPropName(p) {
+		return fmt.Errorf("%w: %q isn't a property", ErrEdit, p)
+	}
+	// The property is set on the node itself, so that it's converted at its
+	// place in the tree, and the node is restored if it can't be set.
+	before := n.CopyProperties()
+	if err := s.setProperty(n, p, values); err != nil {
+		n.SetProperties(before)
+		return err
+	}
+	s.record(&change{
+		op:     Op{Kind: SetProperty, Path: path.Clone(), Prop: p, Values: append([]string{}, values...)},
+		node:   n,
+		before: before,
+		after:  n.CopyProperties(),
+	})
+	return nil
+}
+
+// setProperty sets property p of node n to the values. A property that's
+// converted with warnings (ex: a handicap that doesn't match the stones) isn't
+// set, as are moves and stones that are off the board.
+func (s *Session) setProperty(n *movetree.Node, p string, values []string) error {
+	prop.ClearProperty(n, p)
+	if len(values) == 0 {
+		return nil
+	}
+	if err := prop.ProcessPropertyData(n, p, values); err != nil {
+		return err
+	}
+	width, height := s.dimensions()
+	var pts []*point.Point
+	switch p {
+	case "B", "W":
+		if n.Move != nil && !n.Move.IsPass() {
+			pts = append(pts, n.Move.Point())
+		}
+	case "AB", "AW", "AE":
+		for _, pl := range n.Placements {
+			pts = append(pts, pl.Point())
+		}
+	}
+	for _, pt := range pts {
+		if pt.X() >= width || pt.Y() >= height {
+			return fmt.Errorf("%w: %s%v is off the %dx%d board", ErrEdit, p, values, width, height)
+		}
+	}
+	return nil
+}
+
+// isPropName indicates whether p is a well-formed property name, which may
+// be a vendor property that isn't part of the SGF specification (ex: KTV).
+func isPropName(p string) bool {
+	if p == "" {
+		return false
+	}
+	for _, r := range p {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteBranch deletes the node at the path, with its descendants (see
+// Node.DeleteBranch).
+func (s *Session) DeleteBranch(path movetree.Path) error {
+	n, err := path.Resolve(s.tree.Root)
+	if err != nil {
+		return err
+	}
+	p, index := n.Parent, n.VarNum()
+	if err := n.DeleteBranch(); err != nil {
+		return err
+	}
+	s.record(&change{op: Op{Kind: DeleteBranch, Path: path.Clone()}, node: n, parent: p, index: index})
+	return nil
+}
+
+// Apply applies an operation, such as one from the log of another session.
+// The movetree must be in the state the operation was made in.
+func (s *Session) Apply(op Op) error {
+	switch op.Kind {
+	case AddNode:
+		if len(op.Path) == 0 {
+			return fmt.Errorf("%w: %v has no parent", ErrEdit, op)
+		}
+		var m *move.Move
+		if op.Prop != "" {
+			if len(op.Values) != 1 {
+				return fmt.Errorf("%w: %v doesn't have a single point", ErrEdit, op)
+			}
+			var err error
+			if m, err = move.FromSGF(color.Color(op.Prop), op.Values[0], s.boardSize()); err != nil {
+				return fmt.Errorf("%w: %v: %v", ErrEdit, op, err)
+			}
+		}
+		parent := op.Path[:len(op.Path)-1]
+		if n, err := parent.Resolve(s.tree.Root); err == nil && len(n.Children) != op.Path[len(op.Path)-1] {
+			return fmt.Errorf("%w: %v doesn't add the last variation", ErrEdit, op)
+		}
+		path, err := s.AddNode(parent, m)
+		if err != nil {
+			return err
+		}
+		if path.CompactString() != op.Path.CompactString() {
+			return fmt.Errorf("%w: %v: the node already exists at %v", ErrEdit, op, path.CompactString())
+		}
+		return nil
+	case SetProperty:
+		return s.SetProperty(op.Path, op.Prop, op.Values)
+	case DeleteBranch:
+		return s.DeleteBranch(op.Path)
+	}
+	return fmt.Errorf("%w: unknown operation kind %q", ErrEdit, op.Kind)
+}
+
+// dimensions returns the number of columns and rows of the board, defaulting
+// to 19x19.
+func (s *Session) dimensions() (width, height int) {
+	gi := s.tree.Root.GameInfo
+	if gi == nil || gi.Size == 0 {
+		return 19, 19
+	}
+	return gi.Dimensions()
+}
+
+// boardSize returns the larger dimension of the board, defaulting to 19.
+func (s *Session) boardSize() int {
+	width, height := s.dimensions()
+	if height > width {
+		return height
+	}
+	return width
+}
+
+// record records an applied change. Since the movetree no longer matches the
+// undone changes, they can't be redone.
+func (s *Session) record(c *change) {
+	s.done = append(s.done, c)
+	s.undone = nil
+}
+
+// CanUndo indicates whether there's an operation to undo.
+func (s *Session) CanUndo() bool {
+	return len(s.done) > 0
+}
+
+// CanRedo indicates whether there's an undone operation to redo.
+func (s *Session) CanRedo() bool {
+	return len(s.undone) > 0
+}
+
+// Undo reverses the last operation that was applied, and returns it.
+func (s *Session) Undo() (Op, error) {
+	if len(s.done) == 0 {
+		return Op{}, fmt.Errorf("%w: nothing to undo", ErrEdit)
+	}
+	c := s.done[len(s.done)-1]
+	var err error
+	switch c.op.Kind {
+	case AddNode:
+		err = c.node.DeleteBranch()
+	case SetProperty:
+		c.node.SetProperties(c.before)
+	case DeleteBranch:
+		err = c.parent.InsertVariation(c.index, c.node)
+	}
+	if err != nil {
+		return Op{}, fmt.Errorf("%w: undoing %v: %v", ErrEdit, c.op, err)
+	}
+	s.done = s.done[:len(s.done)-1]
+	s.undone = append(s.undone, c)
+	return c.op, nil
+}
+
+// Redo applies the last operation that was undone again, and returns it.
+func (s *Session) Redo() (Op, error) {
+	if len(s.undone) == 0 {
+		return Op{}, fmt.Errorf("%w: nothing to redo", ErrEdit)
+	}
+	c := s.undone[len(s.undone)-1]
+	var err error
+	switch c.op.Kind {
+	case AddNode:
+		err = c.parent.InsertVariation(c.index, c.node)
+	case SetProperty:
+		c.node.SetProperties(c.after)
+	case DeleteBranch:
+		err = c.node.DeleteBranch()
+	}
+	if err != nil {
+		return Op{}, fmt.Errorf("%w: redoing %v: %v", ErrEdit, c.op, err)
+	}
+	s.undone = s.undone[:len(s.undone)-1]
+	s.done = append(s.done, c)
+	return c.op, nil
+}
+
+// Log returns the operations that have been applied and not undone, in
+// order. Applying them to the movetree the session started with gives the
+// current movetree.
+func (s *Session) Log() []Op {
+	var ops []Op
+	for _, c := range s.done {
+		ops = append(ops, c.op)
+	}
+	return ops
+}
@@ -0,0 +1,280 @@
+package edit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/edit"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+const start = "(;GM[1]SZ[9];B[ee]C[Start]TR[cc]SQ[gg](;W[cc])(;W[gg]))"
+
+// serialize returns the movetree as an SGF string.
+func serialize(t *testing.T, mt *movetree.MoveTree) string {
+	t.Helper()
+	out, err := sgf.Serialize(mt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func mustPath(t *testing.T, s string) movetree.Path {
+	t.Helper()
+	tp, err := movetree.ParsePath(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tp
+}
+
+func TestSession(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		edit   func(s *edit.Session) error
+		exp    string
+		expErr error
+	}{
+		{
+			desc: "add a move",
+			edit: func(s *edit.Session) error {
+				_, err := s.AddNode(mustPath(t, "-0-1"), move.New(color.Black, point.New(2, 6)))
+				return err
+			},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Start]SQ[gg]TR[cc](;W[cc])(;W[gg];B[cg]))",
+		},
+		{
+			desc: "add a pass",
+			edit: func(s *edit.Session) error {
+				_, err := s.AddNode(mustPath(t, "-0"), move.NewPass(color.White))
+				return err
+			},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Start]SQ[gg]TR[cc](;W[cc])(;W[gg])(;W[]))",
+		},
+		{
+			desc: "add a node without a move",
+			edit: func(s *edit.Session) error {
+				_, err := s.AddNode(mustPath(t, "-0-0"), nil)
+				return err
+			},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Start]SQ[gg]TR[cc](;W[cc];)(;W[gg]))",
+		},
+		{
+			desc: "add to a missing node",
+			edit: func(s *edit.Session) error {
+				_, err := s.AddNode(mustPath(t, "-0-2"), nil)
+				return err
+			},
+			expErr: movetree.ErrApplyTreepath,
+		},
+		{
+			desc: "set a comment",
+			edit: func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-0"), "C", []string{"Center [!]"}) },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Center [!\\]]SQ[gg]TR[cc](;W[cc])(;W[gg]))",
+		},
+		{
+			desc: "set one of the marks",
+			edit: func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-0"), "TR", []string{"aa", "bb"}) },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Start]SQ[gg]TR[aa][bb](;W[cc])(;W[gg]))",
+		},
+		{
+			desc: "remove a property",
+			edit: func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-0"), "C", nil) },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]SQ[gg]TR[cc](;W[cc])(;W[gg]))",
+		},
+		{
+			desc: "set a game info property",
+			edit: func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-"), "PB", []string{"Shusaku"}) },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9]PB[Shusaku];B[ee]C[Start]SQ[gg]TR[cc](;W[cc])(;W[gg]))",
+		},
+		{
+			desc: "set a vendor property",
+			edit: func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-0-1"), "KTV", []string{"1"}) },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Start]SQ[gg]TR[cc](;W[cc])(;W[gg]KTV[1]))",
+		},
+		{
+			desc: "replace a move",
+			edit: func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-0"), "B", []string{"dd"}) },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[dd]C[Start]SQ[gg]TR[cc](;W[cc])(;W[gg]))",
+		},
+		{
+			desc:   "set a move off the board",
+			edit:   func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-0"), "B", []string{"zz"}) },
+			expErr: edit.ErrEdit,
+		},
+		{
+			desc:   "set a stone off the board",
+			edit:   func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-"), "AB", []string{"aa", "jj"}) },
+			expErr: edit.ErrEdit,
+		},
+		{
+			desc:   "set a second move",
+			edit:   func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-0"), "W", []string{"dd"}) },
+			expErr: prop.ErrDuplicateMove,
+		},
+		{
+			desc:   "set a root property on a move",
+			edit:   func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-0"), "KM", []string{"6.5"}) },
+			expErr: prop.ErrScope,
+		},
+		{
+			desc:   "set a malformed property",
+			edit:   func(s *edit.Session) error { return s.SetProperty(mustPath(t, "-0"), "c", []string{"Hi"}) },
+			expErr: edit.ErrEdit,
+		},
+		{
+			desc: "delete a branch",
+			edit: func(s *edit.Session) error { return s.DeleteBranch(mustPath(t, "-0-0")) },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Start]SQ[gg]TR[cc];W[gg])",
+		},
+		{
+			desc:   "delete the root",
+			edit:   func(s *edit.Session) error { return s.DeleteBranch(mustPath(t, "-")) },
+			expErr: movetree.ErrVariation,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			orig := serialize(t, g)
+			s := edit.New(g)
+			err = tc.edit(s)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				if got := serialize(t, g); got != orig || s.CanUndo() {
+					t.Errorf("got movetree %q and an operation to undo after an error, but expected it to be unchanged", got)
+				}
+				return
+			}
+			if got := serialize(t, g); got != tc.exp {
+				t.Errorf("got movetree %q, but expected %q", got, tc.exp)
+			}
+
+			if _, err := s.Undo(); err != nil {
+				t.Fatal(err)
+			}
+			if got := serialize(t, g); got != orig {
+				t.Errorf("got movetree %q after undoing, but expected %q", got, orig)
+			}
+			if _, err := s.Redo(); err != nil {
+				t.Fatal(err)
+			}
+			if got := serialize(t, g); got != tc.exp {
+				t.Errorf("got movetree %q after redoing, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestSession_Warning(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[9]AB[cc][gg])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := serialize(t, g)
+	s := edit.New(g)
+	err = s.SetProperty(mustPath(t, "-"), "HA", []string{"3"})
+	var warn *prop.Warning
+	if !errors.As(err, &warn) {
+		t.Fatalf("got error %v setting a handicap that doesn't match the stones, but expected a warning", err)
+	}
+	if got := serialize(t, g); got != orig || s.CanUndo() {
+		t.Errorf("got movetree %q and an operation to undo after a warning, but expected it to be unchanged", got)
+	}
+}
+
+func TestSession_History(t *testing.T) {
+	g, err := sgf.Parse(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := edit.New(g)
+	if _, err := s.Undo(); !errors.Is(err, edit.ErrEdit) {
+		t.Errorf("got error %v undoing a new session, but expected %v", err, edit.ErrEdit)
+	}
+
+	path, err := s.AddNode(mustPath(t, "-0-0"), move.New(color.Black, point.New(6, 6)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetProperty(path, "C", []string{"Big"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteBranch(mustPath(t, "-0-1")); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := serialize(t, g), "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Start]SQ[gg]TR[cc];W[cc];B[gg]C[Big])"; got != exp {
+		t.Fatalf("got movetree %q, but expected %q", got, exp)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.Undo(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, exp := serialize(t, g), "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Start]SQ[gg]TR[cc](;W[cc];B[gg])(;W[gg]))"; got != exp {
+		t.Errorf("got movetree %q after undoing twice, but expected %q", got, exp)
+	}
+	if !s.CanRedo() {
+		t.Errorf("got no operation to redo, but expected two")
+	}
+	// A new edit drops the undone operations.
+	if err := s.SetProperty(mustPath(t, "-0"), "N", []string{"Opening"}); err != nil {
+		t.Fatal(err)
+	}
+	if s.CanRedo() {
+		t.Errorf("got an operation to redo after a new edit, but expected none")
+	}
+	exp := "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]N[Opening]C[Start]SQ[gg]TR[cc](;W[cc];B[gg])(;W[gg]))"
+	if got := serialize(t, g); got != exp {
+		t.Errorf("got movetree %q, but expected %q", got, exp)
+	}
+
+	var ops []string
+	for _, op := range s.Log() {
+		ops = append(ops, op.String())
+	}
+	expOps := []string{"add-node B[gg] at -0x3", "set-property N[Opening] at -0"}
+	if !reflect.DeepEqual(ops, expOps) {
+		t.Errorf("got log %q, but expected %q", ops, expOps)
+	}
+
+	// The exported log replays the edits on another copy of the movetree.
+	data, err := json.Marshal(s.Log())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log []edit.Op
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	other, err := sgf.Parse(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay := edit.New(other)
+	for _, op := range log {
+		if err := replay.Apply(op); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := serialize(t, other); got != exp {
+		t.Errorf("got replayed movetree %q, but expected %q", got, exp)
+	}
+	if err := replay.Apply(log[0]); !errors.Is(err, edit.ErrEdit) {
+		t.Errorf("got error %v applying an operation twice, but expected %v", err, edit.ErrEdit)
+	}
+}
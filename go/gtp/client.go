@@ -0,0 +1,207 @@
+package gtp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// Client sends GTP commands to an engine and reads its responses. Commands
+// are sent one at a time, so a Client must not be used concurrently.
+type Client struct {
+	r *bufio.Reader
+	w io.Writer
+
+	// cmd and stdin are the engine process and its input, if the client
+	// started the engine (see Start).
+	cmd   *exec.Cmd
+	stdin io.Closer
+
+	// nextID is the id of the next command.
+	nextID int
+
+	// size is the size of the board, which is needed to convert vertices.
+	size int
+}
+
+// NewClient creates a client for an engine that reads commands from w and
+// writes its responses to r. The board is assumed to be 19x19 until BoardSize
+// is called.
+func NewClient(r io.Reader, w io.Writer) *Client {
+	return &Client{r: bufio.NewReader(r), w: w, nextID: 1, size: 19}
+}
+
+// Start starts an engine in a new process, and creates a client that talks to
+// it over its standard input and output. For example:
+//
+//	c, err := gtp.Start("gnugo", "--mode", "gtp")
+//
+// The engine should be stopped with Close.
+func Start(name string, args ...string) (*Client, error) {
+	cmd := exec.Command(name, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w: starting %s: %v", ErrGTP, name, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w: starting %s: %v", ErrGTP, name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%w: starting %s: %v", ErrGTP, name, err)
+	}
+	c := NewClient(stdout, stdin)
+	c.cmd, c.stdin = cmd, stdin
+	return c, nil
+}
+
+// Command sends a command and returns the response, without the leading = and
+// id. Multi-line responses are returned with their lines separated by \n. A
+// failure response is returned as an error wrapping ErrFailure.
+func (c *Client) Command(name string, args ...string) (string, error) {
+	id := strconv.Itoa(c.nextID)
+	c.nextID++
+	line := strings.Join(append([]string{id, name}, args...), " ")
+	if _, err := io.WriteString(c.w, line+"\n"); err != nil {
+		return "", fmt.Errorf("%w: sending %s: %v", ErrGTP, name, err)
+	}
+
+	var lines []string
+	for {
+		l, err := c.r.ReadString('\n')
+		if err != nil && (err != io.EOF || l == "") {
+			return "", fmt.Errorf("%w: reading the response to %s: %v", ErrGTP, name, err)
+		}
+		l = strings.TrimRight(l, "\r\n")
+		if l == "" {
+			if len(lines) == 0 {
+				// Skip blank lines before the response.
+				continue
+			}
+			break
+		}
+		lines = append(lines, l)
+		if err == io.EOF {
+			break
+		}
+	}
+
+	first := lines[0]
+	status, rest := first[0], first[1:]
+	if status != '=' && status != '?' {
+		return "", fmt.Errorf("%w: malformed response to %s: %q", ErrGTP, name, first)
+	}
+	if !strings.HasPrefix(rest, id) || (len(rest) > len(id) && rest[len(id)] != ' ') {
+		return "", fmt.Errorf("%w: response %q doesn't match the id of %s (%s)", ErrGTP, first, name, id)
+	}
+	lines[0] = strings.TrimSpace(rest[len(id):])
+	resp := strings.Join(lines, "\n")
+	if status == '?' {
+		return "", fmt.Errorf("%w: %s: %s", ErrFailure, name, resp)
+	}
+	return resp, nil
+}
+
+// ProtocolVersion returns the version of GTP the engine implements, which
+// should be 2.
+func (c *Client) ProtocolVersion() (int, error) {
+	resp, err := c.Command("protocol_version")
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.Atoi(resp)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid protocol version %q", ErrGTP, resp)
+	}
+	return v, nil
+}
+
+// BoardSize sets the size of the board. Engines clear the board when its size
+// changes.
+func (c *Client) BoardSize(size int) error {
+	if _, err := c.Command("boardsize", strconv.Itoa(size)); err != nil {
+		return err
+	}
+	c.size = size
+	return nil
+}
+
+// ClearBoard clears the board.
+func (c *Client) ClearBoard() error {
+	_, err := c.Command("clear_board")
+	return err
+}
+
+// Komi sets the komi.
+func (c *Client) Komi(komi float64) error {
+	_, err := c.Command("komi", strconv.FormatFloat(komi, 'f', -1, 64))
+	return err
+}
+
+// Play plays a move (or a pass) on the engine's board.
+func (c *Client) Play(m *move.Move) error {
+	vertex, err := m.ToGTP(c.size)
+	if err != nil {
+		return err
+	}
+	_, err = c.Command("play", string(m.Color()), vertex)
+	return err
+}
+
+// GenMove asks the engine to generate a move for color col, and to play it on
+// its board. The move is nil if the engine resigns.
+func (c *Client) GenMove(col color.Color) (*move.Move, error) {
+	resp, err := c.Command("genmove", string(col))
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(resp, "resign") {
+		return nil, nil
+	}
+	m, err := move.FromGTP(col, resp, c.size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid move %q: %v", ErrGTP, resp, err)
+	}
+	return m, nil
+}
+
+// Undo takes back the last move on the engine's board.
+func (c *Client) Undo() error {
+	_, err := c.Command("undo")
+	return err
+}
+
+// FinalScore asks the engine to score the game, returning the result (ex:
+// B+3.5, or 0 for a draw).
+func (c *Client) FinalScore() (*movetree.Result, error) {
+	resp, err := c.Command("final_score")
+	if err != nil {
+		return nil, err
+	}
+	res, err := movetree.ParseResult(resp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid score %q: %v", ErrGTP, resp, err)
+	}
+	return res, nil
+}
+
+// Close asks the engine to quit. If the client started the engine, Close
+// also waits for the process to exit.
+func (c *Client) Close() error {
+	_, err := c.Command("quit")
+	if c.cmd == nil {
+		return err
+	}
+	c.stdin.Close()
+	if werr := c.cmd.Wait(); werr != nil && err == nil {
+		err = fmt.Errorf("%w: waiting for the engine to exit: %v", ErrGTP, werr)
+	}
+	return err
+}
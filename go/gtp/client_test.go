@@ -0,0 +1,117 @@
+package gtp
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// serve runs the server in the background, and returns a client that talks to
+// it.
+func serve(t *testing.T, s *Server) *Client {
+	t.Helper()
+	cmdR, cmdW := io.Pipe()
+	respR, respW := io.Pipe()
+	go func() {
+		s.Serve(cmdR, respW)
+		respW.Close()
+	}()
+	return NewClient(respR, cmdW)
+}
+
+func TestClient(t *testing.T) {
+	s := NewServer("clambot", "0.1", firstEmpty)
+	s.Handle("final_score", func([]string) (string, error) { return "W+2.5", nil })
+	c := serve(t, s)
+
+	if v, err := c.ProtocolVersion(); err != nil || v != 2 {
+		t.Errorf("ProtocolVersion()=%v, %v, but expected 2", v, err)
+	}
+	if err := c.BoardSize(9); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Komi(6.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Play(move.New(color.Black, point.New(0, 0))); err != nil {
+		t.Fatal(err)
+	}
+	m, err := c.GenMove(color.White)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := move.New(color.White, point.New(0, 1)); !cmp.Equal(m, exp, cmp.AllowUnexported(move.Move{}, point.Point{})) {
+		t.Errorf("GenMove()=%v, but expected %v", m, exp)
+	}
+	if err := c.Play(move.NewPass(color.Black)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Play(move.New(color.White, point.New(0, 1))); !errors.Is(err, ErrFailure) {
+		t.Errorf("playing on an occupied point got error %v, but expected %v", err, ErrFailure)
+	}
+	resp, err := c.Command("list_commands")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(resp, "\ngenmove\n") {
+		t.Errorf("list_commands=%q, but expected a multi-line response with genmove", resp)
+	}
+	res, err := c.FinalScore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	margin := 2.5
+	if exp := (&movetree.Result{Winner: color.White, Reason: movetree.ReasonScore, Margin: &margin}); !cmp.Equal(res, exp) {
+		t.Errorf("FinalScore()=%v, but expected %v", res, exp)
+	}
+	if s.Komi() != 6.5 || s.Engine().Board().Size() != 9 {
+		t.Errorf("got komi %v on a %dx%d board, but expected 6.5 on a 9x9 board", s.Komi(), s.Engine().Board().Size(), s.Engine().Board().Size())
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_Resign(t *testing.T) {
+	resign := func(*movetree.GameEngine, color.Color) (*move.Move, error) { return nil, nil }
+	c := serve(t, NewServer("clambot", "0.1", resign))
+	m, err := c.GenMove(color.Black)
+	if err != nil || m != nil {
+		t.Errorf("GenMove()=%v, %v, but expected a resignation", m, err)
+	}
+}
+
+func TestClient_MalformedResponse(t *testing.T) {
+	testCases := []struct {
+		desc string
+		resp string
+	}{
+		{
+			desc: "no status",
+			resp: "hello\n\n",
+		},
+		{
+			desc: "wrong id",
+			resp: "=12 2\n\n",
+		},
+		{
+			desc: "no response",
+			resp: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := NewClient(strings.NewReader(tc.resp), io.Discard)
+			if _, err := c.ProtocolVersion(); !errors.Is(err, ErrGTP) {
+				t.Errorf("got error %v, but expected %v", err, ErrGTP)
+			}
+		})
+	}
+}
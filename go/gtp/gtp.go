@@ -0,0 +1,73 @@
+// Package gtp implements the Go Text Protocol (GTP), version 2, which is used
+// by go engines (ex: GNU Go, Leela Zero, KataGo) and the GUIs that drive them.
+//
+// A Client talks to an engine, and a Server lets a bot built on clamshell be
+// driven by a GUI.
+package gtp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/otrego/clamshell/go/color"
+)
+
+// ErrGTP indicates an error talking GTP, such as a malformed command or
+// response.
+var ErrGTP = errors.New("GTP error")
+
+// ErrFailure indicates that the other side answered a command with a failure
+// response (ex: "? illegal move"). It wraps ErrGTP.
+var ErrFailure = fmt.Errorf("%w: failure response", ErrGTP)
+
+// command is a GTP command, with its optional id.
+type command struct {
+	// id is the id of the command, or "" if it has none.
+	id   string
+	name string
+	args []string
+}
+
+// parseCommand parses a line into a command, after removing control
+// characters and comments, as GTP requires. It returns false for lines
+// without a command, which are ignored.
+func parseCommand(line string) (command, bool) {
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		line = line[:i]
+	}
+	line = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t':
+			return ' '
+		case r < 32 || r == 127:
+			return -1
+		}
+		return r
+	}, line)
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return command{}, false
+	}
+	var cmd command
+	if _, err := strconv.Atoi(fields[0]); err == nil {
+		cmd.id, fields = fields[0], fields[1:]
+	}
+	if len(fields) == 0 {
+		return command{}, false
+	}
+	cmd.name, cmd.args = fields[0], fields[1:]
+	return cmd, true
+}
+
+// parseColor parses a GTP color: b, black, w, or white, in any case.
+func parseColor(s string) (color.Color, error) {
+	switch strings.ToLower(s) {
+	case "b", "black":
+		return color.Black, nil
+	case "w", "white":
+		return color.White, nil
+	}
+	return color.Empty, fmt.Errorf("%w: invalid color %q", ErrGTP, s)
+}
@@ -0,0 +1,223 @@
+package gtp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// maxBoardSize is the largest board that GTP vertices can describe.
+const maxBoardSize = 25
+
+// A Handler handles a GTP command, given its arguments. It returns the
+// response, which may span several lines, or an error, whose message is sent
+// as a failure response (ex: "illegal move").
+type Handler func(args []string) (string, error)
+
+// A MoveGenerator generates a move for color c, given the game so far. It
+// returns a nil move to resign.
+type MoveGenerator func(e *movetree.GameEngine, c color.Color) (*move.Move, error)
+
+// Server answers GTP commands, so that a bot can be driven by a GUI or another
+// GTP controller. It keeps track of the game itself: the board size, the
+// komi, and the moves played (boardsize, clear_board, komi, play, undo), and
+// asks its MoveGenerator for moves (genmove). Other commands can be added with
+// Handle.
+type Server struct {
+	name, version string
+	gen           MoveGenerator
+	handlers      map[string]Handler
+
+	engine *movetree.GameEngine
+	size   int
+	komi   float64
+
+	// quit indicates that the quit command was received.
+	quit bool
+}
+
+// NewServer creates a server for a bot with the given name and version, which
+// generates moves with gen. If gen is nil, genmove isn't supported. The board
+// starts as an empty 19x19 board.
+func NewServer(name, version string, gen MoveGenerator) *Server {
+	s := &Server{
+		name:    name,
+		version: version,
+		gen:     gen,
+		engine:  movetree.NewGameEngine(19),
+		size:    19,
+	}
+	s.handlers = map[string]Handler{
+		"protocol_version": func([]string) (string, error) { return "2", nil },
+		"name":             func([]string) (string, error) { return s.name, nil },
+		"version":          func([]string) (string, error) { return s.version, nil },
+		"known_command":    s.knownCommand,
+		"list_commands":    s.listCommands,
+		"quit":             func([]string) (string, error) { s.quit = true; return "", nil },
+		"boardsize":        s.boardSize,
+		"clear_board":      s.clearBoard,
+		"komi":             s.setKomi,
+		"play":             s.play,
+		"undo":             s.undo,
+		"showboard":        func([]string) (string, error) { return "\n" + s.engine.Board().String(), nil },
+	}
+	if gen != nil {
+		s.handlers["genmove"] = s.genMove
+	}
+	return s
+}
+
+// Handle adds a handler for a command, replacing the handler of a built-in
+// command with the same name.
+func (s *Server) Handle(name string, h Handler) {
+	s.handlers[name] = h
+}
+
+// Engine returns the game being played. It's owned by the server, and is
+// replaced when the board is cleared.
+func (s *Server) Engine() *movetree.GameEngine {
+	return s.engine
+}
+
+// Komi returns the komi of the game.
+func (s *Server) Komi() float64 {
+	return s.komi
+}
+
+// Serve reads commands from r and writes the responses to w, until the quit
+// command or the end of r.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	sc := bufio.NewScanner(r)
+	for !s.quit && sc.Scan() {
+		cmd, ok := parseCommand(sc.Text())
+		if !ok {
+			continue
+		}
+		status, resp := "=", ""
+		if h, ok := s.handlers[cmd.name]; !ok {
+			status, resp = "?", "unknown command"
+		} else if out, err := h(cmd.args); err != nil {
+			status, resp = "?", err.Error()
+		} else {
+			resp = out
+		}
+		if resp != "" && !strings.HasPrefix(resp, "\n") {
+			resp = " " + resp
+		}
+		if _, err := io.WriteString(w, status+cmd.id+resp+"\n\n"); err != nil {
+			return fmt.Errorf("%w: writing the response to %s: %v", ErrGTP, cmd.name, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("%w: reading commands: %v", ErrGTP, err)
+	}
+	return nil
+}
+
+func (s *Server) knownCommand(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errors.New("syntax error")
+	}
+	_, ok := s.handlers[args[0]]
+	return strconv.FormatBool(ok), nil
+}
+
+func (s *Server) listCommands([]string) (string, error) {
+	var names []string
+	for name := range s.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "\n"), nil
+}
+
+func (s *Server) boardSize(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errors.New("syntax error")
+	}
+	size, err := strconv.Atoi(args[0])
+	if err != nil {
+		return "", errors.New("syntax error")
+	}
+	if size < 1 || size > maxBoardSize {
+		return "", errors.New("unacceptable size")
+	}
+	s.size = size
+	s.engine = movetree.NewGameEngine(size)
+	return "", nil
+}
+
+func (s *Server) clearBoard([]string) (string, error) {
+	s.engine = movetree.NewGameEngine(s.size)
+	return "", nil
+}
+
+func (s *Server) setKomi(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errors.New("syntax error")
+	}
+	komi, err := strconv.ParseFloat(args[0], 64)
+	if err != nil {
+		return "", errors.New("syntax error")
+	}
+	s.komi = komi
+	return "", nil
+}
+
+func (s *Server) play(args []string) (string, error) {
+	if len(args) != 2 {
+		return "", errors.New("syntax error")
+	}
+	c, err := parseColor(args[0])
+	if err != nil {
+		return "", errors.New("syntax error")
+	}
+	m, err := move.FromGTP(c, args[1], s.size)
+	if err != nil {
+		return "", errors.New("syntax error")
+	}
+	if _, err := s.engine.Apply(m); err != nil {
+		return "", errors.New("illegal move")
+	}
+	return "", nil
+}
+
+func (s *Server) undo([]string) (string, error) {
+	if err := s.engine.Undo(); err != nil {
+		return "", errors.New("cannot undo")
+	}
+	return "", nil
+}
+
+func (s *Server) genMove(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errors.New("syntax error")
+	}
+	c, err := parseColor(args[0])
+	if err != nil {
+		return "", errors.New("syntax error")
+	}
+	m, err := s.gen(s.engine, c)
+	if err != nil {
+		return "", err
+	}
+	if m == nil {
+		return "resign", nil
+	}
+	vertex, err := m.ToGTP(s.size)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.engine.Apply(m); err != nil {
+		return "", fmt.Errorf("generated an illegal move %s: %v", vertex, err)
+	}
+	return vertex, nil
+}
@@ -0,0 +1,113 @@
+package gtp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// firstEmpty is a move generator that plays on the first empty point, column
+// by column, and resigns when the board is full.
+func firstEmpty(e *movetree.GameEngine, c color.Color) (*move.Move, error) {
+	b := e.Board()
+	for x := 0; x < b.Size(); x++ {
+		for y := 0; y < b.Size(); y++ {
+			m := move.New(c, point.New(x, y))
+			if b.CheckMove(m) == nil {
+				return m, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func TestServer(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   string
+		exp  string
+	}{
+		{
+			desc: "admin commands",
+			in:   "1 protocol_version\nname\n3 version\nknown_command play\nknown_command zork\n",
+			exp:  "=1 2\n\n= clambot\n\n=3 0.1\n\n= true\n\n= false\n\n",
+		},
+		{
+			desc: "comments, blank lines, and tabs",
+			in:   "# a comment\n\n  \t\nboardsize\t9 # nine\n",
+			exp:  "=\n\n",
+		},
+		{
+			desc: "unknown command",
+			in:   "7 zork\n",
+			exp:  "?7 unknown command\n\n",
+		},
+		{
+			desc: "play and generate moves",
+			in:   "boardsize 3\nplay black A3\ngenmove w\nplay b pass\ngenmove white\n",
+			exp:  "=\n\n=\n\n= A2\n\n=\n\n= A1\n\n",
+		},
+		{
+			desc: "illegal moves",
+			in:   "boardsize 9\nplay b E5\nplay w E5\nplay b Z9\nplay b\n",
+			exp:  "=\n\n=\n\n? illegal move\n\n? syntax error\n\n? syntax error\n\n",
+		},
+		{
+			desc: "unacceptable size",
+			in:   "boardsize 26\n",
+			exp:  "? unacceptable size\n\n",
+		},
+		{
+			desc: "undo",
+			in:   "play b D4\nundo\nundo\nplay w D4\n",
+			exp:  "=\n\n=\n\n? cannot undo\n\n=\n\n",
+		},
+		{
+			desc: "showboard",
+			in:   "boardsize 2\nplay b A2\nshowboard\n",
+			exp:  "=\n\n=\n\n=\n[B .]\n[. .]\n\n",
+		},
+		{
+			desc: "stops at quit",
+			in:   "quit\nname\n",
+			exp:  "=\n\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := NewServer("clambot", "0.1", firstEmpty)
+			var out strings.Builder
+			if err := s.Serve(strings.NewReader(tc.in), &out); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.exp, out.String()); diff != "" {
+				t.Errorf("Serve(%q) got diff (-want +got):\n%s", tc.in, diff)
+			}
+		})
+	}
+}
+
+func TestServer_Handle(t *testing.T) {
+	s := NewServer("clambot", "0.1", nil)
+	s.Handle("final_score", func(args []string) (string, error) {
+		return "", errors.New("cannot score")
+	})
+	var out strings.Builder
+	if err := s.Serve(strings.NewReader("komi 6.5\ngenmove b\nfinal_score\nlist_commands\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	exp := "=\n\n? unknown command\n\n? cannot score\n\n" +
+		"= boardsize\nclear_board\nfinal_score\nknown_command\nkomi\nlist_commands\nname\nplay\nprotocol_version\nquit\nshowboard\nundo\nversion\n\n"
+	if diff := cmp.Diff(exp, out.String()); diff != "" {
+		t.Errorf("Serve got diff (-want +got):\n%s", diff)
+	}
+	if s.Komi() != 6.5 {
+		t.Errorf("Komi()=%v, but expected 6.5", s.Komi())
+	}
+}
@@ -0,0 +1,95 @@
+package move
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// gtpPass is the GTP vertex of a pass.
+const gtpPass = "pass"
+
+// legacySGFPass is the SGF point of a pass in FF[3], which is still commonly
+// used in FF[4] on boards up to 19x19.
+const legacySGFPass = "tt"
+
+// FromGTP converts a GTP vertex (ex: Q16) on a size x size board, or a pass
+// ("pass", in any case), to a move of color col.
+func FromGTP(col color.Color, vertex string, size int) (*Move, error) {
+	return FromGTPRect(col, vertex, size, size)
+}
+
+// FromGTPRect is like FromGTP, for a rectangular board with the given number
+// of columns and rows.
+func FromGTPRect(col color.Color, vertex string, width, height int) (*Move, error) {
+	if strings.EqualFold(vertex, gtpPass) {
+		return NewPass(col), nil
+	}
+	pt, err := point.NewFromGTPRect(vertex, width, height)
+	if err != nil {
+		return nil, err
+	}
+	return New(col, pt), nil
+}
+
+// ToGTP converts the move to a GTP vertex (ex: Q16) on a size x size board,
+// or to "pass".
+func (m *Move) ToGTP(size int) (string, error) {
+	return m.ToGTPRect(size, size)
+}
+
+// ToGTPRect is like ToGTP, for a rectangular board with the given number of
+// columns and rows.
+func (m *Move) ToGTPRect(width, height int) (string, error) {
+	if m.IsPass() {
+		return gtpPass, nil
+	}
+	return m.point.ToGTPRect(width, height)
+}
+
+// FromSGF is like FromSGFPoint, but for a size x size board: the point must be
+// on the board, and on boards up to 19x19, tt is a pass, as in FF[3].
+func FromSGF(col color.Color, sgfPt string, size int) (*Move, error) {
+	if sgfPt == "" || (sgfPt == legacySGFPass && size <= 19) {
+		return NewPass(col), nil
+	}
+	pt, err := point.NewFromSGF(sgfPt)
+	if err != nil {
+		return nil, err
+	}
+	if pt.X() >= size || pt.Y() >= size {
+		return nil, fmt.Errorf("%w: point %s is off the %dx%d board", point.SGFConversionErr, sgfPt, size, size)
+	}
+	return New(col, pt), nil
+}
+
+// ToSGF converts the move to an SGF point (ex: pd), or to the empty string
+// for a pass, as in FF[4].
+func (m *Move) ToSGF() (string, error) {
+	if m.IsPass() {
+		return "", nil
+	}
+	return m.point.ToSGF()
+}
+
+// SGFToGTP converts an SGF point (ex: pd) on a size x size board to a GTP
+// vertex (ex: Q16). Passes (see FromSGF) are converted to "pass".
+func SGFToGTP(sgfPt string, size int) (string, error) {
+	m, err := FromSGF(color.Empty, sgfPt, size)
+	if err != nil {
+		return "", err
+	}
+	return m.ToGTP(size)
+}
+
+// GTPToSGF converts a GTP vertex (ex: Q16) on a size x size board to an SGF
+// point (ex: pd). A pass is converted to the empty string.
+func GTPToSGF(vertex string, size int) (string, error) {
+	m, err := FromGTP(color.Empty, vertex, size)
+	if err != nil {
+		return "", err
+	}
+	return m.ToSGF()
+}
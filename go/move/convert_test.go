@@ -0,0 +1,126 @@
+package move
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestSGFToGTP(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		sgf    string
+		size   int
+		gtp    string
+		expErr error
+	}{
+		{
+			desc: "star point",
+			sgf:  "pd",
+			size: 19,
+			gtp:  "Q16",
+		},
+		{
+			desc: "skips I",
+			sgf:  "is",
+			size: 19,
+			gtp:  "J1",
+		},
+		{
+			desc: "top left of a 9x9 board",
+			sgf:  "aa",
+			size: 9,
+			gtp:  "A9",
+		},
+		{
+			desc: "pass",
+			sgf:  "",
+			size: 19,
+			gtp:  "pass",
+		},
+		{
+			desc: "legacy pass",
+			sgf:  "tt",
+			size: 19,
+			gtp:  "pass",
+		},
+		{
+			desc: "tt on a 21x21 board",
+			sgf:  "tt",
+			size: 21,
+			gtp:  "U2",
+		},
+		{
+			desc:   "off the board",
+			sgf:    "jj",
+			size:   9,
+			expErr: point.SGFConversionErr,
+		},
+		{
+			desc:   "malformed",
+			sgf:    "p",
+			size:   19,
+			expErr: point.SGFConversionErr,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := SGFToGTP(tc.sgf, tc.size)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("SGFToGTP(%q, %d) got error %v, but expected %v", tc.sgf, tc.size, err, tc.expErr)
+			}
+			if got != tc.gtp {
+				t.Errorf("SGFToGTP(%q, %d)=%q, but expected %q", tc.sgf, tc.size, got, tc.gtp)
+			}
+			if err != nil || tc.sgf == "tt" && tc.size <= 19 {
+				return
+			}
+			back, err := GTPToSGF(tc.gtp, tc.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if back != tc.sgf {
+				t.Errorf("GTPToSGF(%q, %d)=%q, but expected %q", tc.gtp, tc.size, back, tc.sgf)
+			}
+		})
+	}
+}
+
+func TestFromGTP(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		vertex string
+		exp    *point.Point
+		expErr error
+	}{
+		{
+			desc:   "lower case",
+			vertex: "q16",
+			exp:    point.New(15, 3),
+		},
+		{
+			desc:   "pass in upper case",
+			vertex: "PASS",
+		},
+		{
+			desc:   "I isn't a column",
+			vertex: "I5",
+			expErr: point.ErrGTPConversion,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := FromGTP("B", tc.vertex, 19)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if exp := New("B", tc.exp); !got.Equal(exp) {
+				t.Errorf("FromGTP(B, %q, 19)=%v, but expected %v", tc.vertex, got, exp)
+			}
+		})
+	}
+}
@@ -34,9 +34,46 @@ func (m *Move) Point() *point.Point {
 	return m.point
 }
 
-// String returns the string value for a Move.
+// Equal indicates whether the moves are the same: the same color, and either
+// the same point or both passes.
+func (m *Move) Equal(other *Move) bool {
+	if other == nil || m.color != other.color {
+		return false
+	}
+	if m.IsPass() || other.IsPass() {
+		return m.IsPass() && other.IsPass()
+	}
+	return m.point.Equal(other.point)
+}
+
+// String returns a human-readable string for the Move, with the point in SGF
+// form, since the board size is unknown (ex: B pd, W pass). See StringForSize
+// for human coordinates.
 func (m *Move) String() string {
-	return fmt.Sprintf("{%v, %v}", m.color, m.point)
+	if m.IsPass() {
+		return fmt.Sprintf("%v pass", m.color)
+	}
+	pt, err := m.point.ToSGF()
+	if err != nil {
+		pt = m.point.String()
+	}
+	return fmt.Sprintf("%v %s", m.color, pt)
+}
+
+// StringForSize returns a human-readable string for the Move on a size x size
+// board, with the point in human coordinates (ex: B Q16, W pass). Like GTP
+// coordinates, the columns are lettered from the left, skipping I, and the rows
+// are numbered from the bottom. If the point can't be written in human
+// coordinates, this is the same as String.
+func (m *Move) StringForSize(size int) string {
+	if m.IsPass() {
+		return m.String()
+	}
+	pt, err := m.point.ToGTP(size)
+	if err != nil {
+		return m.String()
+	}
+	return fmt.Sprintf("%v %s", m.color, pt)
 }
 
 // GoString returns the string value.
@@ -65,18 +102,22 @@ func FromSGFPoint(col color.Color, sgfPt string) (*Move, error) {
 }
 
 // ListFromSGFPoints a move list of the form "ab", "bc" to a moves of the form
-// {0,1}, {0,2}. Note that pass-moves are not allowed in move-lists.
+// {0,1}, {0,2}. Values in the compressed rectangle form (ex: "aa:cc") are
+// expanded into all the points of the rectangle (see point.NewListFromSGF).
+// Note that pass-moves are not allowed in move-lists.
 func ListFromSGFPoints(col color.Color, sgfPts []string) ([]*Move, error) {
 	var moves []*Move
 	for _, sgfPt := range sgfPts {
-		pt, err := point.NewFromSGF(sgfPt)
+		pts, err := point.NewListFromSGF(sgfPt)
 		if err != nil {
 			return nil, err
 		}
-		moves = append(moves, &Move{
-			color: col,
-			point: pt,
-		})
+		for _, pt := range pts {
+			moves = append(moves, &Move{
+				color: col,
+				point: pt,
+			})
+		}
 	}
 	return moves, nil
 }
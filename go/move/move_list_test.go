@@ -15,7 +15,7 @@ func TestMoveList_String(t *testing.T) {
 		New(color.White, point.New(3, 4)),
 	}.String()
 
-	exp := "{{B, {1,2}}, {W, {3,4}}}"
+	exp := "{B bc, W de}"
 	if mvStr != exp {
 		t.Errorf("mvlist.String()=%s, but expected %s. diff=%s", mvStr, exp, cmp.Diff(mvStr, exp))
 	}
@@ -116,6 +116,12 @@ func TestListFromSGFPoints(t *testing.T) {
 			col:       color.White,
 			exp:       []*Move{New(color.White, point.New(0, 1)), New(color.White, point.New(2, 3)), New(color.White, point.New(4, 4))},
 		},
+		{
+			desc:      "Compressed Rectangle",
+			sgfPtList: []string{"aa:ab", "cc:cc"},
+			col:       color.Black,
+			exp:       []*Move{New(color.Black, point.New(0, 0)), New(color.Black, point.New(0, 1)), New(color.Black, point.New(2, 2))},
+		},
 		{
 			desc:      "Empty Move List",
 			sgfPtList: []string{},
@@ -178,3 +184,104 @@ func TestListFromSGFPoints(t *testing.T) {
 		})
 	}
 }
+
+func TestEqual(t *testing.T) {
+	testCases := []struct {
+		desc string
+		a, b *Move
+		exp  bool
+	}{
+		{
+			desc: "same move",
+			a:    New(color.Black, point.New(15, 3)),
+			b:    New(color.Black, point.New(15, 3)),
+			exp:  true,
+		},
+		{
+			desc: "different point",
+			a:    New(color.Black, point.New(15, 3)),
+			b:    New(color.Black, point.New(3, 15)),
+		},
+		{
+			desc: "different color",
+			a:    New(color.Black, point.New(15, 3)),
+			b:    New(color.White, point.New(15, 3)),
+		},
+		{
+			desc: "passes",
+			a:    NewPass(color.White),
+			b:    NewPass(color.White),
+			exp:  true,
+		},
+		{
+			desc: "passes of different colors",
+			a:    NewPass(color.White),
+			b:    NewPass(color.Black),
+		},
+		{
+			desc: "pass and move",
+			a:    NewPass(color.Black),
+			b:    New(color.Black, point.New(0, 0)),
+		},
+		{
+			desc: "nil",
+			a:    NewPass(color.Black),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.a.Equal(tc.b); got != tc.exp {
+				t.Errorf("%v.Equal(%v)=%v, but expected %v", tc.a, tc.b, got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		m          *Move
+		size       int
+		exp        string
+		expForSize string
+	}{
+		{
+			desc:       "move",
+			m:          New(color.Black, point.New(15, 3)),
+			size:       19,
+			exp:        "B pd",
+			expForSize: "B Q16",
+		},
+		{
+			desc:       "move after I",
+			m:          New(color.White, point.New(8, 18)),
+			size:       19,
+			exp:        "W is",
+			expForSize: "W J1",
+		},
+		{
+			desc:       "pass",
+			m:          NewPass(color.White),
+			size:       19,
+			exp:        "W pass",
+			expForSize: "W pass",
+		},
+		{
+			desc:       "off the board",
+			m:          New(color.Black, point.New(15, 3)),
+			size:       9,
+			exp:        "B pd",
+			expForSize: "B pd",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.m.String(); got != tc.exp {
+				t.Errorf("String()=%q, but expected %q", got, tc.exp)
+			}
+			if got := tc.m.StringForSize(tc.size); got != tc.expForSize {
+				t.Errorf("StringForSize(%d)=%q, but expected %q", tc.size, got, tc.expForSize)
+			}
+		})
+	}
+}
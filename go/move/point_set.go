@@ -0,0 +1,94 @@
+package move
+
+import (
+	"sort"
+
+	"github.com/otrego/clamshell/go/point"
+)
+
+// PointSet is a set of points. The zero value is an empty set, ready to use.
+type PointSet struct {
+	pts map[point.Point]bool
+}
+
+// NewPointSet creates a PointSet containing the provided points.
+func NewPointSet(pts ...*point.Point) *PointSet {
+	s := &PointSet{}
+	s.Add(pts...)
+	return s
+}
+
+// Add adds points to the set. Points already in the set are ignored.
+func (s *PointSet) Add(pts ...*point.Point) {
+	for _, pt := range pts {
+		s.add(*pt)
+	}
+}
+
+// Contains indicates whether the set contains point pt.
+func (s *PointSet) Contains(pt *point.Point) bool {
+	return s.pts[*pt]
+}
+
+// Len returns the number of points in the set.
+func (s *PointSet) Len() int {
+	return len(s.pts)
+}
+
+// Union returns a new set with the points that are in either set.
+func (s *PointSet) Union(other *PointSet) *PointSet {
+	out := &PointSet{}
+	for pt := range s.pts {
+		out.add(pt)
+	}
+	for pt := range other.pts {
+		out.add(pt)
+	}
+	return out
+}
+
+// Intersect returns a new set with the points that are in both sets.
+func (s *PointSet) Intersect(other *PointSet) *PointSet {
+	out := &PointSet{}
+	for pt := range s.pts {
+		if other.pts[pt] {
+			out.add(pt)
+		}
+	}
+	return out
+}
+
+// Difference returns a new set with the points that are in this set, but not
+// in the other set.
+func (s *PointSet) Difference(other *PointSet) *PointSet {
+	out := &PointSet{}
+	for pt := range s.pts {
+		if !other.pts[pt] {
+			out.add(pt)
+		}
+	}
+	return out
+}
+
+// Sorted returns the points in the set, sorted by x and then by y. This is the
+// order of the points when sorting their SGF representations (aa, ab, ba, ...).
+func (s *PointSet) Sorted() []*point.Point {
+	out := make([]*point.Point, 0, len(s.pts))
+	for pt := range s.pts {
+		out = append(out, point.New(pt.X(), pt.Y()))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].X() != out[j].X() {
+			return out[i].X() < out[j].X()
+		}
+		return out[i].Y() < out[j].Y()
+	})
+	return out
+}
+
+func (s *PointSet) add(pt point.Point) {
+	if s.pts == nil {
+		s.pts = make(map[point.Point]bool)
+	}
+	s.pts[pt] = true
+}
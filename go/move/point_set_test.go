@@ -0,0 +1,109 @@
+package move
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// sgfPoints converts a point slice to SGF points, for easier comparison.
+func sgfPoints(t *testing.T, pts []*point.Point) []string {
+	t.Helper()
+	var out []string
+	for _, pt := range pts {
+		s, err := pt.ToSGF()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestPointSet_Operations(t *testing.T) {
+	a := NewPointSet(point.New(0, 0), point.New(1, 0), point.New(2, 2))
+	b := NewPointSet(point.New(2, 2), point.New(0, 1), point.New(1, 0))
+
+	testCases := []struct {
+		desc string
+		set  *PointSet
+		exp  []string
+	}{
+		{
+			desc: "union",
+			set:  a.Union(b),
+			exp:  []string{"aa", "ab", "ba", "cc"},
+		},
+		{
+			desc: "intersect",
+			set:  a.Intersect(b),
+			exp:  []string{"ba", "cc"},
+		},
+		{
+			desc: "difference",
+			set:  a.Difference(b),
+			exp:  []string{"aa"},
+		},
+		{
+			desc: "difference, reversed",
+			set:  b.Difference(a),
+			exp:  []string{"ab"},
+		},
+		{
+			desc: "intersect with empty",
+			set:  a.Intersect(&PointSet{}),
+			exp:  nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := sgfPoints(t, tc.set.Sorted())
+			if !cmp.Equal(got, tc.exp) {
+				t.Errorf("got %v, but expected %v", got, tc.exp)
+			}
+			if tc.set.Len() != len(tc.exp) {
+				t.Errorf("Len()=%d, but expected %d", tc.set.Len(), len(tc.exp))
+			}
+		})
+	}
+
+	// The operations don't modify the original sets.
+	if got := sgfPoints(t, a.Sorted()); !cmp.Equal(got, []string{"aa", "ba", "cc"}) {
+		t.Errorf("set a was modified to %v", got)
+	}
+}
+
+func TestPointSet_AddContains(t *testing.T) {
+	var s PointSet
+	if s.Contains(point.New(0, 0)) {
+		t.Errorf("empty set contains {0,0}")
+	}
+	s.Add(point.New(0, 0), point.New(0, 0), point.New(3, 1))
+	if s.Len() != 2 {
+		t.Errorf("after adding a duplicate, Len()=%d, but expected 2", s.Len())
+	}
+	if !s.Contains(point.New(3, 1)) {
+		t.Errorf("set doesn't contain {3,1}")
+	}
+	if s.Contains(point.New(1, 3)) {
+		t.Errorf("set contains {1,3}")
+	}
+}
+
+func TestPointSet_Sorted(t *testing.T) {
+	s := NewPointSet(
+		point.New(3, 0),
+		point.New(0, 3),
+		point.New(1, 1),
+		point.New(0, 0),
+		point.New(1, 0),
+	)
+	exp := []string{"aa", "ad", "ba", "bb", "da"}
+	// Sorting is stable across calls, regardless of the map ordering.
+	for i := 0; i < 10; i++ {
+		if got := sgfPoints(t, s.Sorted()); !cmp.Equal(got, exp) {
+			t.Fatalf("Sorted()=%v, but expected %v", got, exp)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package movetree
+
+import "sort"
+
+// NodeAnnotations bundles the annotations of a node, for a front-end to
+// render without knowing about the SGF properties. Points are given as x and
+// y coordinates from the top-left corner, starting at 0, and the annotations
+// on points are sorted by x and then by y.
+type NodeAnnotations struct {
+	Marks  []PointMark  `json:"marks,omitempty"`
+	Labels []PointLabel `json:"labels,omitempty"`
+	Arrows []Line       `json:"arrows,omitempty"`
+	Lines  []Line       `json:"lines,omitempty"`
+
+	Comment string `json:"comment,omitempty"`
+
+	// MoveAnnotation is the evaluation of the move. Nil if the move isn't
+	// annotated.
+	MoveAnnotation *MoveAnnotation `json:"moveAnnotation,omitempty"`
+
+	// PositionAnnotation is the evaluation of the position. Nil if the position
+	// isn't annotated.
+	PositionAnnotation *PositionAnnotation `json:"positionAnnotation,omitempty"`
+
+	// Value is the value of the node (V), which is positive when black is
+	// ahead. Nil if there's no value.
+	Value *float64 `json:"value,omitempty"`
+}
+
+// PointMark is a mark drawn on a point.
+type PointMark struct {
+	X    int      `json:"x"`
+	Y    int      `json:"y"`
+	Type MarkType `json:"type"`
+}
+
+// PointLabel is a text label drawn on a point.
+type PointLabel struct {
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+	Text string `json:"text"`
+}
+
+// Line is an arrow or a line drawn between two points.
+type Line struct {
+	FromX int `json:"fromX"`
+	FromY int `json:"fromY"`
+	ToX   int `json:"toX"`
+	ToY   int `json:"toY"`
+}
+
+// Annotations returns the annotations of the node.
+func (n *Node) Annotations() NodeAnnotations {
+	out := NodeAnnotations{
+		Comment:            n.Comment,
+		MoveAnnotation:     n.MoveAnnotation,
+		PositionAnnotation: n.PositionAnnotation,
+		Value:              n.Value,
+		Arrows:             sortedLines(n.Arrows),
+		Lines:              sortedLines(n.Lines),
+	}
+	for pt, typ := range n.Marks {
+		out.Marks = append(out.Marks, PointMark{X: pt.X(), Y: pt.Y(), Type: typ})
+	}
+	sort.Slice(out.Marks, func(i, j int) bool {
+		return pointLess(out.Marks[i].X, out.Marks[i].Y, out.Marks[j].X, out.Marks[j].Y)
+	})
+	for pt, text := range n.Labels {
+		out.Labels = append(out.Labels, PointLabel{X: pt.X(), Y: pt.Y(), Text: text})
+	}
+	sort.Slice(out.Labels, func(i, j int) bool {
+		return pointLess(out.Labels[i].X, out.Labels[i].Y, out.Labels[j].X, out.Labels[j].Y)
+	})
+	return out
+}
+
+// sortedLines returns a copy of the lines, sorted by their start and then by
+// their end.
+func sortedLines(lines []Line) []Line {
+	out := append([]Line(nil), lines...)
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.FromX != b.FromX || a.FromY != b.FromY {
+			return pointLess(a.FromX, a.FromY, b.FromX, b.FromY)
+		}
+		return pointLess(a.ToX, a.ToY, b.ToX, b.ToY)
+	})
+	return out
+}
+
+// pointLess orders points by x and then by y.
+func pointLess(ax, ay, bx, by int) bool {
+	if ax != bx {
+		return ax < bx
+	}
+	return ay < by
+}
@@ -0,0 +1,66 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestAnnotations(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[9];B[ee]C[Strong move]TE[2]GB[2]V[3.5]" +
+		"MA[aa]TR[ee]CR[ab]LB[cc:A][bb:1]AR[aa:cc][aa:bb]LN[dd:ef])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := 3.5
+	exp := movetree.NodeAnnotations{
+		Marks: []movetree.PointMark{
+			{X: 0, Y: 0, Type: movetree.MarkX},
+			{X: 0, Y: 1, Type: movetree.MarkCircle},
+			{X: 4, Y: 4, Type: movetree.MarkTriangle},
+		},
+		Labels: []movetree.PointLabel{
+			{X: 1, Y: 1, Text: "1"},
+			{X: 2, Y: 2, Text: "A"},
+		},
+		Arrows: []movetree.Line{
+			{FromX: 0, FromY: 0, ToX: 1, ToY: 1},
+			{FromX: 0, FromY: 0, ToX: 2, ToY: 2},
+		},
+		Lines: []movetree.Line{
+			{FromX: 3, FromY: 3, ToX: 4, ToY: 5},
+		},
+		Comment:            "Strong move",
+		MoveAnnotation:     &movetree.MoveAnnotation{Type: movetree.Tesuji, Emphasis: 2},
+		PositionAnnotation: &movetree.PositionAnnotation{Type: movetree.GoodForBlack, Emphasis: 2},
+		Value:              &value,
+	}
+	if diff := cmp.Diff(exp, g.Root.Next(0).Annotations()); diff != "" {
+		t.Errorf("Annotations() got diff (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(movetree.NodeAnnotations{}, g.Root.Annotations()); diff != "" {
+		t.Errorf("Annotations() of an unannotated node got diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestAnnotations_LegacyMarkup(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]FF[3];B[ee]M[ee][aa]L[cc])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := movetree.NodeAnnotations{
+		Marks: []movetree.PointMark{
+			{X: 0, Y: 0, Type: movetree.MarkX},
+			{X: 4, Y: 4, Type: movetree.MarkTriangle},
+		},
+		Labels: []movetree.PointLabel{
+			{X: 2, Y: 2, Text: "A"},
+		},
+	}
+	if diff := cmp.Diff(exp, g.Root.Next(0).Annotations()); diff != "" {
+		t.Errorf("Annotations() got diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,162 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/otrego/clamshell/go/rules"
+)
+
+// ErrAppend indicates that a movetree could not be appended to another.
+var ErrAppend = errors.New("error appending movetree")
+
+// Append attaches the main line of other as a continuation of the end of the
+// main line, for stitching together a game that was split across files (such
+// as an adjourned game). The nodes of other are copied, so other is left
+// unmodified, and the moves are renumbered to follow on from the seam.
+//
+// If other's root has placements, they must match the position at the seam.
+// Otherwise, other's moves are played from the position at the seam. Either
+// way, the moves must be legal. A move on other's root is kept as the first
+// move of the continuation, but the rest of the root (the placements, game
+// info and raw properties) isn't.
+//
+// The game info is reconciled: fields that are missing from this movetree are
+// taken from other, the result comes from other (since it ended the game), and
+// differing dates are combined into a list of dates.
+func (mt *MoveTree) Append(other *MoveTree) error {
+	if same, a, b := mt.sameBoard(other); !same {
+		return fmt.Errorf("%w: board sizes %s and %s differ", ErrAppend, a, b)
+	}
+	end := mt.mainLineEnd()
+	b, err := mt.BoardAt(end)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAppend, err)
+	}
+	if len(other.Root.Placements) > 0 {
+		setup := other.newBoard()
+		if err := setup.SetPlacements(other.Root.Placements); err != nil {
+			return fmt.Errorf("%w: %v", ErrAppend, err)
+		}
+		if !reflect.DeepEqual(setup.FullBoardState(), b.FullBoardState()) {
+			return fmt.Errorf("%w: the setup of the continuation doesn't match the position at move %d", ErrAppend, end.MoveNum())
+		}
+	}
+
+	var nodes []*Node
+	if other.Root.Move != nil {
+		rootMove := NewNode()
+		rootMove.Move = other.Root.Move
+		rootMove.Comment = other.Root.Comment
+		nodes = append(nodes, rootMove)
+	}
+	for n := other.Root.Next(0); n != nil; n = n.Next(0) {
+		nodes = append(nodes, n.copyNode())
+	}
+	for i, n := range nodes {
+		if _, _, err := applyNode(b, n); err != nil {
+			return fmt.Errorf("%w: at continuation node %d: %v", ErrAppend, i+1, err)
+		}
+	}
+
+	prev := end
+	for _, n := range nodes {
+		n.Parent = prev
+		prev.AddChild(n)
+		prev = n
+	}
+	mt.Root.GameInfo = mergeGameInfo(mt.Root.GameInfo, other.Root.GameInfo)
+	return nil
+}
+
+// mergeGameInfo reconciles the game info of a game with that of its
+// continuation, for Append.
+func mergeGameInfo(gi, cont *GameInfo) *GameInfo {
+	if cont == nil {
+		return gi
+	}
+	out := gi.copy()
+	if out == nil {
+		out = &GameInfo{}
+	}
+	cont = cont.copy()
+	if out.Size == 0 {
+		out.Size, out.Width, out.Height = cont.Size, cont.Width, cont.Height
+	}
+	if out.Komi == nil {
+		out.Komi = cont.Komi
+	}
+	if out.Handicap == 0 {
+		out.Handicap = cont.Handicap
+	}
+	if out.Rules == rules.Unspecified {
+		out.Rules = cont.Rules
+	}
+	if out.Application == nil {
+		out.Application = cont.Application
+	}
+	if cont.Result != nil {
+		out.Result = cont.Result
+	}
+	if out.BlackPlayer == "" {
+		out.BlackPlayer = cont.BlackPlayer
+	}
+	if out.WhitePlayer == "" {
+		out.WhitePlayer = cont.WhitePlayer
+	}
+	if out.BlackRank == "" {
+		out.BlackRank = cont.BlackRank
+	}
+	if out.WhiteRank == "" {
+		out.WhiteRank = cont.WhiteRank
+	}
+	if out.Event == "" {
+		out.Event = cont.Event
+	}
+	if out.MainTime == nil {
+		out.MainTime = cont.MainTime
+	}
+	if out.Overtime == "" {
+		out.Overtime = cont.Overtime
+	}
+	if out.BlackTeam == "" {
+		out.BlackTeam = cont.BlackTeam
+	}
+	if out.WhiteTeam == "" {
+		out.WhiteTeam = cont.WhiteTeam
+	}
+	if out.GameName == "" {
+		out.GameName = cont.GameName
+	}
+	if out.GameComment == "" {
+		out.GameComment = cont.GameComment
+	}
+	if out.Place == "" {
+		out.Place = cont.Place
+	}
+	if out.Round == "" {
+		out.Round = cont.Round
+	}
+	if out.Opening == "" {
+		out.Opening = cont.Opening
+	}
+	if out.Annotator == "" {
+		out.Annotator = cont.Annotator
+	}
+	if out.Source == "" {
+		out.Source = cont.Source
+	}
+	if out.User == "" {
+		out.User = cont.User
+	}
+	if out.Copyright == "" {
+		out.Copyright = cont.Copyright
+	}
+	if out.Date == "" {
+		out.Date = cont.Date
+	} else if cont.Date != "" && cont.Date != out.Date {
+		out.Date += "," + cont.Date
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestAppend(t *testing.T) {
+	first := "(;GM[1]SZ[9]PB[Black]DT[2020-01-01];B[ee];W[cc](;B[gg])(;B[dd]))"
+	testCases := []struct {
+		desc      string
+		cont      string
+		expMoves  int
+		expBoard  string
+		expDate   string
+		expResult string
+		expErr    error
+	}{
+		{
+			desc:      "setup matches the seam",
+			cont:      "(;GM[1]SZ[9]PW[White]DT[2020-01-02]RE[B+R]AB[ee][gg]AW[cc];W[cg];B[gc])",
+			expMoves:  5,
+			expBoard:  "(;GM[1]SZ[9];B[ee];W[cc];B[gg];W[cg];B[gc])",
+			expDate:   "2020-01-01,2020-01-02",
+			expResult: "B+R",
+		},
+		{
+			desc:     "no setup",
+			cont:     "(;GM[1]SZ[9];W[cg];B[gc])",
+			expMoves: 5,
+			expBoard: "(;GM[1]SZ[9];B[ee];W[cc];B[gg];W[cg];B[gc])",
+			expDate:  "2020-01-01",
+		},
+		{
+			desc:   "setup doesn't match",
+			cont:   "(;GM[1]SZ[9]AB[ee][gg]AW[dd];W[cg])",
+			expErr: movetree.ErrAppend,
+		},
+		{
+			desc:   "illegal continuation",
+			cont:   "(;GM[1]SZ[9];W[ee])",
+			expErr: movetree.ErrAppend,
+		},
+		{
+			desc:   "different board sizes",
+			cont:   "(;GM[1]SZ[13];W[cg])",
+			expErr: movetree.ErrAppend,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cont, err := sgf.Parse(tc.cont)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = g.Append(cont)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				if g.Root.Next(0).Next(0).Next(0).Next(0) != nil {
+					t.Errorf("movetree was modified after a failed Append")
+				}
+				return
+			}
+
+			end := g.Root
+			for end.Next(0) != nil {
+				end = end.Next(0)
+			}
+			if end.MoveNum() != tc.expMoves {
+				t.Errorf("main line ends at move %d, but expected %d", end.MoveNum(), tc.expMoves)
+			}
+			if end.Parent.Parent.Parent.Next(1) == nil {
+				t.Errorf("the variation at move 3 was lost")
+			}
+			b, err := g.BoardAt(end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exp, err := sgf.Parse(tc.expBoard)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expB, err := exp.BoardAt(exp.Root.Next(0).Next(0).Next(0).Next(0).Next(0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != expB.String() {
+				t.Errorf("got board\n%v\nbut expected\n%v", b, expB)
+			}
+
+			gi := g.Root.GameInfo
+			if gi.BlackPlayer != "Black" || gi.WhitePlayer != cont.Root.GameInfo.WhitePlayer {
+				t.Errorf("got players %q and %q, but expected %q and %q", gi.BlackPlayer, gi.WhitePlayer, "Black", cont.Root.GameInfo.WhitePlayer)
+			}
+			if gi.Date != tc.expDate {
+				t.Errorf("got date %q, but expected %q", gi.Date, tc.expDate)
+			}
+			gotResult := ""
+			if gi.Result != nil {
+				gotResult = gi.Result.String()
+			}
+			if gotResult != tc.expResult {
+				t.Errorf("got result %q, but expected %q", gotResult, tc.expResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,704 @@
+package movetree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/rules"
+)
+
+// ErrBinary indicates that a binary-encoded movetree could not be decoded.
+var ErrBinary = errors.New("error decoding binary movetree")
+
+// binaryMagic starts every binary-encoded movetree.
+const binaryMagic = "CLMT"
+
+// binaryVersion is the version of the binary format. It's incremented when
+// the format changes in a way that older decoders can't read. Adding new
+// fields doesn't require a new version, since decoders skip unknown fields.
+const binaryVersion = 1
+
+// Field tags of the binary format. Each field of a node is written as its tag,
+// the length of its payload, and the payload.
+const (
+	tagEnd byte = iota
+	tagMove
+	tagPlacements
+	tagComment
+	tagMoveAnnotation
+	tagMarks
+	tagLabels
+	tagAnalysis
+	tagGameInfo
+	tagProperties
+	tagResign
+	tagTimeLeft
+	tagOvertimeLeft
+	tagArrows
+	tagLines
+	tagName
+	tagPositionAnnotation
+	tagHotspot
+	tagValue
+)
+
+// Field tags of the game info.
+const (
+	tagGameInfoEnd byte = iota
+	tagSize
+	tagKomi
+	tagRules
+	tagPlayer
+	tagApplication
+	tagResult
+	tagBlackPlayer
+	tagWhitePlayer
+	tagDate
+	tagEvent
+	tagWidth
+	tagHeight
+	tagHandicap
+	tagBlackRank
+	tagWhiteRank
+	tagMainTime
+	tagOvertime
+	tagBlackTeam
+	tagWhiteTeam
+	tagGameName
+	tagGameComment
+	tagPlace
+	tagRound
+	tagOpening
+	tagAnnotator
+	tagSource
+	tagUser
+	tagCopyright
+)
+
+// MarshalBinary encodes the movetree in a compact binary format, which is
+// faster to decode than SGF is to parse. This can be used to cache parsed
+// games. The encoding is lossless, including the raw SGF properties, except
+// for the analysis data attached with Node.SetAnalysisData, which isn't
+// encoded.
+//
+// The format starts with a version, and older versions can still be decoded
+// by newer versions of UnmarshalBinary.
+func (mt *MoveTree) MarshalBinary() ([]byte, error) {
+	e := &encoder{}
+	e.buf.WriteString(binaryMagic)
+	e.uvarint(binaryVersion)
+	e.node(mt.Root)
+	return e.buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a movetree encoded with MarshalBinary, replacing the
+// contents of the movetree.
+func (mt *MoveTree) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, []byte(binaryMagic)) {
+		return fmt.Errorf("%w: missing header", ErrBinary)
+	}
+	d := &decoder{data: data[len(binaryMagic):]}
+	if v := d.uvarint(); d.err == nil && v != binaryVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrBinary, v)
+	}
+	root := d.node(nil)
+	if d.err == nil && len(d.data) > 0 {
+		d.fail("%d unexpected bytes after the movetree", len(d.data))
+	}
+	if d.err != nil {
+		return d.err
+	}
+	mt.Root = root
+	return nil
+}
+
+// encoder writes the binary format.
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	e.buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func (e *encoder) varint(v int) {
+	var b [binary.MaxVarintLen64]byte
+	e.buf.Write(b[:binary.PutVarint(b[:], int64(v))])
+}
+
+func (e *encoder) float(f float64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+	e.buf.Write(b[:])
+}
+
+func (e *encoder) string(s string) {
+	e.uvarint(uint64(len(s)))
+	e.buf.WriteString(s)
+}
+
+func (e *encoder) point(pt *point.Point) {
+	e.varint(pt.X())
+	e.varint(pt.Y())
+}
+
+// field writes a field with the given tag, whose payload is written by fn.
+func (e *encoder) field(tag byte, fn func(e *encoder)) {
+	payload := &encoder{}
+	fn(payload)
+	e.buf.WriteByte(tag)
+	e.uvarint(uint64(payload.buf.Len()))
+	e.buf.Write(payload.buf.Bytes())
+}
+
+// node writes the node and its descendants.
+func (e *encoder) node(n *Node) {
+	if m := n.Move; m != nil {
+		e.field(tagMove, func(e *encoder) {
+			e.string(string(m.Color()))
+			if !m.IsPass() {
+				e.point(m.Point())
+			}
+		})
+	}
+	if len(n.Placements) > 0 {
+		e.field(tagPlacements, func(e *encoder) {
+			e.uvarint(uint64(len(n.Placements)))
+			for _, m := range n.Placements {
+				e.string(string(m.Color()))
+				e.point(m.Point())
+			}
+		})
+	}
+	if n.Comment != "" {
+		e.field(tagComment, func(e *encoder) { e.string(n.Comment) })
+	}
+	if ma := n.MoveAnnotation; ma != nil {
+		e.field(tagMoveAnnotation, func(e *encoder) {
+			e.string(string(ma.Type))
+			e.varint(ma.Emphasis)
+		})
+	}
+	if n.Name != "" {
+		e.field(tagName, func(e *encoder) { e.string(n.Name) })
+	}
+	if pa := n.PositionAnnotation; pa != nil {
+		e.field(tagPositionAnnotation, func(e *encoder) {
+			e.string(string(pa.Type))
+			e.varint(pa.Emphasis)
+		})
+	}
+	if n.Hotspot != 0 {
+		e.field(tagHotspot, func(e *encoder) { e.varint(n.Hotspot) })
+	}
+	if n.Value != nil {
+		e.field(tagValue, func(e *encoder) { e.float(*n.Value) })
+	}
+	if n.Marks != nil {
+		e.field(tagMarks, func(e *encoder) {
+			pts := sortedPoints(len(n.Marks), func(add func(point.Point)) {
+				for pt := range n.Marks {
+					add(pt)
+				}
+			})
+			e.uvarint(uint64(len(pts)))
+			for _, pt := range pts {
+				e.point(&pt)
+				e.string(string(n.Marks[pt]))
+			}
+		})
+	}
+	if n.Labels != nil {
+		e.field(tagLabels, func(e *encoder) {
+			pts := sortedPoints(len(n.Labels), func(add func(point.Point)) {
+				for pt := range n.Labels {
+					add(pt)
+				}
+			})
+			e.uvarint(uint64(len(pts)))
+			for _, pt := range pts {
+				e.point(&pt)
+				e.string(n.Labels[pt])
+			}
+		})
+	}
+	if n.Arrows != nil {
+		e.field(tagArrows, func(e *encoder) { e.lines(n.Arrows) })
+	}
+	if n.Lines != nil {
+		e.field(tagLines, func(e *encoder) { e.lines(n.Lines) })
+	}
+	if an := n.Analysis; an != nil {
+		e.field(tagAnalysis, func(e *encoder) {
+			e.optionalFloat(an.WinRate)
+			e.optionalFloat(an.ScoreLead)
+		})
+	}
+	if n.TimeLeft != nil {
+		e.field(tagTimeLeft, func(e *encoder) {
+			cols := sortedColors(len(n.TimeLeft), func(add func(color.Color)) {
+				for c := range n.TimeLeft {
+					add(c)
+				}
+			})
+			e.uvarint(uint64(len(cols)))
+			for _, c := range cols {
+				e.string(string(c))
+				e.float(n.TimeLeft[c])
+			}
+		})
+	}
+	if n.OvertimeLeft != nil {
+		e.field(tagOvertimeLeft, func(e *encoder) {
+			cols := sortedColors(len(n.OvertimeLeft), func(add func(color.Color)) {
+				for c := range n.OvertimeLeft {
+					add(c)
+				}
+			})
+			e.uvarint(uint64(len(cols)))
+			for _, c := range cols {
+				e.string(string(c))
+				e.varint(n.OvertimeLeft[c])
+			}
+		})
+	}
+	if gi := n.GameInfo; gi != nil {
+		e.field(tagGameInfo, func(e *encoder) { e.gameInfo(gi) })
+	}
+	if len(n.SGFProperties) > 0 {
+		e.field(tagProperties, func(e *encoder) {
+			keys := make([]string, 0, len(n.SGFProperties))
+			for k := range n.SGFProperties {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			e.uvarint(uint64(len(keys)))
+			for _, k := range keys {
+				e.string(k)
+				e.uvarint(uint64(len(n.SGFProperties[k])))
+				for _, v := range n.SGFProperties[k] {
+					e.string(v)
+				}
+			}
+		})
+	}
+	if n.resign {
+		e.field(tagResign, func(e *encoder) {})
+	}
+	e.buf.WriteByte(tagEnd)
+
+	e.uvarint(uint64(len(n.Children)))
+	for _, c := range n.Children {
+		e.node(c)
+	}
+}
+
+// lines writes arrows or lines, in order.
+func (e *encoder) lines(lines []Line) {
+	e.uvarint(uint64(len(lines)))
+	for _, l := range lines {
+		e.varint(l.FromX)
+		e.varint(l.FromY)
+		e.varint(l.ToX)
+		e.varint(l.ToY)
+	}
+}
+
+func (e *encoder) optionalFloat(f *float64) {
+	if f == nil {
+		e.buf.WriteByte(0)
+		return
+	}
+	e.buf.WriteByte(1)
+	e.float(*f)
+}
+
+// gameInfo writes the fields of the game info.
+func (e *encoder) gameInfo(gi *GameInfo) {
+	if gi.Size != 0 {
+		e.field(tagSize, func(e *encoder) { e.varint(gi.Size) })
+	}
+	if gi.Width != 0 {
+		e.field(tagWidth, func(e *encoder) { e.varint(gi.Width) })
+	}
+	if gi.Height != 0 {
+		e.field(tagHeight, func(e *encoder) { e.varint(gi.Height) })
+	}
+	if gi.Komi != nil {
+		e.field(tagKomi, func(e *encoder) { e.float(*gi.Komi) })
+	}
+	if gi.MainTime != nil {
+		e.field(tagMainTime, func(e *encoder) { e.float(*gi.MainTime) })
+	}
+	if gi.Handicap != 0 {
+		e.field(tagHandicap, func(e *encoder) { e.varint(gi.Handicap) })
+	}
+	if gi.Rules != rules.Unspecified {
+		e.field(tagRules, func(e *encoder) { e.string(string(gi.Rules)) })
+	}
+	if gi.Player != color.Empty {
+		e.field(tagPlayer, func(e *encoder) { e.string(string(gi.Player)) })
+	}
+	if app := gi.Application; app != nil {
+		e.field(tagApplication, func(e *encoder) {
+			e.string(app.Name)
+			e.string(app.Version)
+		})
+	}
+	if res := gi.Result; res != nil {
+		e.field(tagResult, func(e *encoder) {
+			e.string(string(res.Winner))
+			e.string(string(res.Reason))
+			e.optionalFloat(res.Margin)
+		})
+	}
+	for _, f := range []struct {
+		tag byte
+		val string
+	}{
+		{tagBlackPlayer, gi.BlackPlayer},
+		{tagWhitePlayer, gi.WhitePlayer},
+		{tagBlackRank, gi.BlackRank},
+		{tagWhiteRank, gi.WhiteRank},
+		{tagDate, gi.Date},
+		{tagEvent, gi.Event},
+		{tagOvertime, gi.Overtime},
+		{tagBlackTeam, gi.BlackTeam},
+		{tagWhiteTeam, gi.WhiteTeam},
+		{tagGameName, gi.GameName},
+		{tagGameComment, gi.GameComment},
+		{tagPlace, gi.Place},
+		{tagRound, gi.Round},
+		{tagOpening, gi.Opening},
+		{tagAnnotator, gi.Annotator},
+		{tagSource, gi.Source},
+		{tagUser, gi.User},
+		{tagCopyright, gi.Copyright},
+	} {
+		if f.val != "" {
+			val := f.val
+			e.field(f.tag, func(e *encoder) { e.string(val) })
+		}
+	}
+	e.buf.WriteByte(tagGameInfoEnd)
+}
+
+// sortedPoints returns the points added by fn, sorted by x, then y, so that
+// the encoding is deterministic.
+func sortedPoints(n int, fn func(add func(point.Point))) []point.Point {
+	pts := make([]point.Point, 0, n)
+	fn(func(pt point.Point) { pts = append(pts, pt) })
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].X() != pts[j].X() {
+			return pts[i].X() < pts[j].X()
+		}
+		return pts[i].Y() < pts[j].Y()
+	})
+	return pts
+}
+
+// sortedColors returns the colors added by fn, sorted, so that the encoding is
+// deterministic.
+func sortedColors(n int, fn func(add func(color.Color))) []color.Color {
+	cols := make([]color.Color, 0, n)
+	fn(func(c color.Color) { cols = append(cols, c) })
+	sort.Slice(cols, func(i, j int) bool { return cols[i] < cols[j] })
+	return cols
+}
+
+// decoder reads the binary format. Once an error occurs, err is set and the
+// reads return zero values.
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) fail(format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: %s", ErrBinary, fmt.Sprintf(format, args...))
+	}
+	d.data = nil
+}
+
+func (d *decoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.fail("malformed integer")
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) varint() int {
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.fail("malformed integer")
+		return 0
+	}
+	d.data = d.data[n:]
+	return int(v)
+}
+
+func (d *decoder) byte() byte {
+	if len(d.data) < 1 {
+		d.fail("unexpected end of data")
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *decoder) bytes(n uint64) []byte {
+	if uint64(len(d.data)) < n {
+		d.fail("unexpected end of data")
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *decoder) float() float64 {
+	b := d.bytes(8)
+	if b == nil {
+		return 0
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b))
+}
+
+// lines reads arrows or lines.
+func (d *decoder) lines() []Line {
+	out := []Line{}
+	for i, count := 0, d.count(); i < count; i++ {
+		l := Line{FromX: d.varint()}
+		l.FromY = d.varint()
+		l.ToX = d.varint()
+		l.ToY = d.varint()
+		out = append(out, l)
+	}
+	return out
+}
+
+func (d *decoder) optionalFloat() *float64 {
+	if d.byte() == 0 {
+		return nil
+	}
+	f := d.float()
+	return &f
+}
+
+func (d *decoder) string() string {
+	return string(d.bytes(d.uvarint()))
+}
+
+func (d *decoder) point() *point.Point {
+	x := d.varint()
+	return point.New(x, d.varint())
+}
+
+// count reads the number of items in a list, each of which takes at least
+// one byte.
+func (d *decoder) count() int {
+	n := d.uvarint()
+	if n > uint64(len(d.data)) {
+		d.fail("list of %d items is longer than the data", n)
+		return 0
+	}
+	return int(n)
+}
+
+// fields reads fields until the end tag, calling fn with a decoder for the
+// payload of each field. Unknown fields are skipped by fn.
+func (d *decoder) fields(end byte, fn func(tag byte, payload *decoder)) {
+	for d.err == nil {
+		tag := d.byte()
+		if d.err != nil || tag == end {
+			return
+		}
+		payload := &decoder{data: d.bytes(d.uvarint())}
+		if d.err != nil {
+			return
+		}
+		fn(tag, payload)
+		if payload.err != nil {
+			d.err = payload.err
+		}
+	}
+}
+
+// node reads a node and its descendants, adding it to its parent (if any). The
+// node is added before its children are read, so that the move numbers of the
+// descendants aren't recomputed for each ancestor.
+func (d *decoder) node(parent *Node) *Node {
+	n := NewNode()
+	d.fields(tagEnd, func(tag byte, p *decoder) {
+		switch tag {
+		case tagMove:
+			col := color.Color(p.string())
+			if len(p.data) == 0 {
+				n.Move = move.NewPass(col)
+			} else {
+				n.Move = move.New(col, p.point())
+			}
+		case tagPlacements:
+			for i, count := 0, p.count(); i < count; i++ {
+				col := color.Color(p.string())
+				n.Placements = append(n.Placements, move.New(col, p.point()))
+			}
+		case tagComment:
+			n.Comment = p.string()
+		case tagMoveAnnotation:
+			ma := &MoveAnnotation{Type: MoveAnnotationType(p.string())}
+			ma.Emphasis = p.varint()
+			n.MoveAnnotation = ma
+		case tagName:
+			n.Name = p.string()
+		case tagPositionAnnotation:
+			pa := &PositionAnnotation{Type: PositionAnnotationType(p.string())}
+			pa.Emphasis = p.varint()
+			n.PositionAnnotation = pa
+		case tagHotspot:
+			n.Hotspot = p.varint()
+		case tagValue:
+			v := p.float()
+			n.Value = &v
+		case tagMarks:
+			n.Marks = make(map[point.Point]MarkType)
+			for i, count := 0, p.count(); i < count; i++ {
+				pt := p.point()
+				n.Marks[*pt] = MarkType(p.string())
+			}
+		case tagLabels:
+			n.Labels = make(map[point.Point]string)
+			for i, count := 0, p.count(); i < count; i++ {
+				pt := p.point()
+				n.Labels[*pt] = p.string()
+			}
+		case tagArrows:
+			n.Arrows = p.lines()
+		case tagLines:
+			n.Lines = p.lines()
+		case tagAnalysis:
+			an := &Analysis{WinRate: p.optionalFloat()}
+			an.ScoreLead = p.optionalFloat()
+			n.Analysis = an
+		case tagTimeLeft:
+			n.TimeLeft = make(map[color.Color]float64)
+			for i, count := 0, p.count(); i < count; i++ {
+				c := color.Color(p.string())
+				n.TimeLeft[c] = p.float()
+			}
+		case tagOvertimeLeft:
+			n.OvertimeLeft = make(map[color.Color]int)
+			for i, count := 0, p.count(); i < count; i++ {
+				c := color.Color(p.string())
+				n.OvertimeLeft[c] = p.varint()
+			}
+		case tagGameInfo:
+			n.GameInfo = p.gameInfo()
+		case tagProperties:
+			for i, count := 0, p.count(); i < count; i++ {
+				k := p.string()
+				values := []string{}
+				for j, nv := 0, p.count(); j < nv; j++ {
+					values = append(values, p.string())
+				}
+				n.SGFProperties[k] = values
+			}
+		case tagResign:
+			n.resign = true
+		}
+	})
+
+	if parent != nil {
+		n.Parent = parent
+		parent.AddChild(n)
+	}
+	for i, count := 0, d.count(); i < count && d.err == nil; i++ {
+		d.node(n)
+	}
+	return n
+}
+
+// gameInfo reads the fields of the game info.
+func (d *decoder) gameInfo() *GameInfo {
+	gi := &GameInfo{}
+	d.fields(tagGameInfoEnd, func(tag byte, p *decoder) {
+		switch tag {
+		case tagSize:
+			gi.Size = p.varint()
+		case tagWidth:
+			gi.Width = p.varint()
+		case tagHeight:
+			gi.Height = p.varint()
+		case tagKomi:
+			komi := p.float()
+			gi.Komi = &komi
+		case tagHandicap:
+			gi.Handicap = p.varint()
+		case tagRules:
+			gi.Rules = rules.Ruleset(p.string())
+		case tagPlayer:
+			gi.Player = color.Color(p.string())
+		case tagApplication:
+			app := &Application{Name: p.string()}
+			app.Version = p.string()
+			gi.Application = app
+		case tagResult:
+			res := &Result{Winner: color.Color(p.string())}
+			res.Reason = ResultReason(p.string())
+			res.Margin = p.optionalFloat()
+			gi.Result = res
+		case tagBlackPlayer:
+			gi.BlackPlayer = p.string()
+		case tagWhitePlayer:
+			gi.WhitePlayer = p.string()
+		case tagBlackRank:
+			gi.BlackRank = p.string()
+		case tagWhiteRank:
+			gi.WhiteRank = p.string()
+		case tagDate:
+			gi.Date = p.string()
+		case tagEvent:
+			gi.Event = p.string()
+		case tagMainTime:
+			tm := p.float()
+			gi.MainTime = &tm
+		case tagOvertime:
+			gi.Overtime = p.string()
+		case tagBlackTeam:
+			gi.BlackTeam = p.string()
+		case tagWhiteTeam:
+			gi.WhiteTeam = p.string()
+		case tagGameName:
+			gi.GameName = p.string()
+		case tagGameComment:
+			gi.GameComment = p.string()
+		case tagPlace:
+			gi.Place = p.string()
+		case tagRound:
+			gi.Round = p.string()
+		case tagOpening:
+			gi.Opening = p.string()
+		case tagAnnotator:
+			gi.Annotator = p.string()
+		case tagSource:
+			gi.Source = p.string()
+		case tagUser:
+			gi.User = p.string()
+		case tagCopyright:
+			gi.Copyright = p.string()
+		}
+	})
+	return gi
+}
@@ -0,0 +1,141 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+const binaryTestSGF = `(;GM[1]FF[4]CA[UTF-8]SZ[13]KM[6.5]RU[Japanese]PL[W]HA[2]
+AP[CGoban:3]PB[Black]PW[White]DT[2016-03-09]EV[Event]RE[W+3.5]
+AB[dd][jj]XX[custom][values]C[Game comment]
+;W[gg]BM[2]CR[aa][bb]LB[cc:A]
+(;B[ad]TR[dd]
+;W[]C[A pass]N[End]DM[1]HO[2]V[-0.5])
+(;B[ce]SQ[ab]MA[ba]TE[1];W[be]))`
+
+func TestMarshalBinary(t *testing.T) {
+	g, err := sgf.Parse(binaryTestSGF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	komi := 0.61
+	g.Root.Next(0).Analysis = &movetree.Analysis{WinRate: &komi}
+	mainLineEnd(g).SetResign(true)
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &movetree.MoveTree{}
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	expSGF, err := sgf.Serialize(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotSGF, err := sgf.Serialize(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotSGF != expSGF {
+		t.Errorf("got decoded SGF\n%s\nbut expected\n%s", gotSGF, expSGF)
+	}
+	if an := got.Root.Next(0).Analysis; an == nil || an.WinRate == nil || *an.WinRate != komi || an.ScoreLead != nil {
+		t.Errorf("got analysis %v, but expected a win rate of %v", an, komi)
+	}
+	if !mainLineEnd(got).IsResign() {
+		t.Errorf("got no resignation at the end of the main line")
+	}
+	if n := mainLineEnd(got); n.MoveNum() != 3 || n.Parent.Parent.Parent != got.Root {
+		t.Errorf("got the end of the main line at move %d, but expected the parents and move numbers to be restored", n.MoveNum())
+	}
+
+	// The encoding is deterministic.
+	again, err := got.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("got a different encoding after decoding and encoding again")
+	}
+}
+
+func TestUnmarshalBinary_Errors(t *testing.T) {
+	g, err := sgf.Parse(binaryTestSGF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		desc string
+		data []byte
+	}{
+		{desc: "empty", data: nil},
+		{desc: "not a movetree", data: []byte("(;GM[1])")},
+		{desc: "unsupported version", data: append([]byte("CLMT\x7f"), data[5:]...)},
+		{desc: "truncated", data: data[:len(data)/2]},
+		{desc: "trailing data", data: append(append([]byte{}, data...), 0)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			mt := movetree.New()
+			if err := mt.UnmarshalBinary(tc.data); !errors.Is(err, movetree.ErrBinary) {
+				t.Errorf("got error %v, but expected %v", err, movetree.ErrBinary)
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshalBinary(b *testing.B) {
+	data, err := longGame(b, 250).MarshalBinary()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mt := &movetree.MoveTree{}
+		if err := mt.UnmarshalBinary(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalBinary_Parse(b *testing.B) {
+	s, err := sgf.SerializeWithOptions(longGame(b, 250), &prop.SerializeOptions{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := sgf.Parse(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalBinary_RectangularBoard(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[19:9];B[si])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &movetree.MoveTree{}
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if w, h := got.Root.GameInfo.Dimensions(); w != 19 || h != 9 {
+		t.Errorf("got a %dx%d board, but expected 19x9", w, h)
+	}
+}
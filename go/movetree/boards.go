@@ -0,0 +1,29 @@
+package movetree
+
+import (
+	"github.com/otrego/clamshell/go/board"
+)
+
+// MainLineBoards returns the board position at each node of the main line,
+// starting with the root. The moves are replayed once, and each board is a
+// snapshot of the previous one (see board.Snapshot), so only the rows changed
+// by a move are copied. For a game of n moves, this takes O(n) time and
+// memory, where calling BoardAt for each node takes O(n²) time.
+//
+// Since the boards share memory, they must not be modified. Clone a board to
+// modify it. If only a few positions are needed, BoardAt uses less memory.
+func (mt *MoveTree) MainLineBoards() ([]*board.Board, error) {
+	p, err := mt.NewPlayback()
+	if err != nil {
+		return nil, err
+	}
+	boards := []*board.Board{p.Board().Clone()}
+	for p.Node().Next(0) != nil {
+		if err := p.Forward(); err != nil {
+			return nil, err
+		}
+		prev := boards[len(boards)-1]
+		boards = append(boards, p.Board().Snapshot(prev, p.undos[len(p.undos)-1]...))
+	}
+	return boards, nil
+}
@@ -0,0 +1,93 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// longGame returns a game with the given number of legal moves on a 19x19
+// board, including captures.
+func longGame(t testing.TB, moves int) *movetree.MoveTree {
+	g := movetree.New()
+	b := board.New(19)
+	n := g.Root
+	col := color.Black
+	for i := 0; moves > 0; i++ {
+		// Visit the points in a scattered order, so that stones get captured.
+		idx := (i * 97) % 361
+		m := move.New(col, point.New(idx%19, idx/19))
+		if _, err := b.Clone().PlaceStone(m); err != nil {
+			continue
+		}
+		if _, err := b.PlaceStone(m); err != nil {
+			t.Fatal(err)
+		}
+		c := movetree.NewNode()
+		c.Move = m
+		c.Parent = n
+		n.AddChild(c)
+		n = c
+		col = col.Opposite()
+		moves--
+	}
+	return g
+}
+
+func TestMainLineBoards(t *testing.T) {
+	g := longGame(t, 250)
+	boards, err := g.MainLineBoards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := 0
+	for n := g.Root; n != nil; n = n.Next(0) {
+		if i >= len(boards) {
+			t.Fatalf("got %d boards, but expected one per main-line node", len(boards))
+		}
+		exp, err := g.BoardAt(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !boards[i].Equal(exp) || !cmpKo(boards[i].Ko(), exp.Ko()) {
+			t.Errorf("at move %d, got board\n%v\nbut expected\n%v", n.MoveNum(), boards[i], exp)
+		}
+		i++
+	}
+	if i != len(boards) {
+		t.Errorf("got %d boards, but expected %d", len(boards), i)
+	}
+}
+
+func cmpKo(a, b *point.Point) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func BenchmarkMainLineBoards(b *testing.B) {
+	g := longGame(b, 250)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := g.MainLineBoards(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMainLineBoards_BoardAt(b *testing.B) {
+	g := longGame(b, 250)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for n := g.Root; n != nil; n = n.Next(0) {
+			if _, err := g.BoardAt(n); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
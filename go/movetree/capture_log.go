@@ -0,0 +1,58 @@
+package movetree
+
+import (
+	"fmt"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// CaptureEvent is the capture of one or more stones of the same color.
+type CaptureEvent struct {
+	// MoveNum is the move number of the node where the capture happened.
+	MoveNum int
+
+	// Move is the capturing move. Nil if the capture was caused by the node's
+	// placements.
+	Move *move.Move
+
+	// Color is the color of the captured stones.
+	Color color.Color
+
+	// Captured are the points of the captured stones, in sorted order.
+	Captured []*point.Point
+}
+
+// CaptureLog replays the main line of the movetree and returns every capture,
+// in move order. This includes captures caused by placements, which fill the
+// last liberty of a group.
+func (mt *MoveTree) CaptureLog() ([]CaptureEvent, error) {
+	var events []CaptureEvent
+	b := mt.newBoard()
+	for n := mt.Root; n != nil; n = n.Next(0) {
+		setup, captured, err := applyNode(b, n)
+		if err != nil {
+			return nil, fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+		}
+		events = appendCaptures(events, n.MoveNum(), nil, setup)
+		events = appendCaptures(events, n.MoveNum(), n.Move, captured)
+	}
+	return events, nil
+}
+
+// appendCaptures appends an event for each color of the captured stones.
+func appendCaptures(events []CaptureEvent, moveNum int, m *move.Move, captured move.List) []CaptureEvent {
+	for _, c := range []color.Color{color.Black, color.White} {
+		var pts []*point.Point
+		for _, cm := range captured {
+			if cm.Color() == c {
+				pts = append(pts, cm.Point())
+			}
+		}
+		if len(pts) > 0 {
+			events = append(events, CaptureEvent{MoveNum: moveNum, Move: m, Color: c, Captured: pts})
+		}
+	}
+	return events
+}
@@ -0,0 +1,57 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestCaptureLog(t *testing.T) {
+	// White's four-stone group on the top edge is surrounded and captured by
+	// B[ea]. Then, a black placement fills the last liberty of the white stone at
+	// ad.
+	g, err := sgf.Parse(`(;GM[1]SZ[7]
+AW[aa][ba][ca][da]AB[ab][bb][cb][db][eb]
+;B[ea]
+;AW[ff]AB[ac][bd]
+;W[ad]
+;AB[ae])`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := g.CaptureLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type event struct {
+		MoveNum  int
+		Move     string
+		Color    color.Color
+		Captured []string
+	}
+	var got []event
+	for _, ev := range events {
+		e := event{MoveNum: ev.MoveNum, Color: ev.Color}
+		if ev.Move != nil {
+			e.Move = ev.Move.String()
+		}
+		for _, pt := range ev.Captured {
+			sgfPt, err := pt.ToSGF()
+			if err != nil {
+				t.Fatal(err)
+			}
+			e.Captured = append(e.Captured, sgfPt)
+		}
+		got = append(got, e)
+	}
+	exp := []event{
+		{MoveNum: 1, Move: "B ea", Color: color.White, Captured: []string{"aa", "ba", "ca", "da"}},
+		{MoveNum: 4, Color: color.White, Captured: []string{"ad"}},
+	}
+	if !cmp.Equal(got, exp) {
+		t.Errorf("CaptureLog()=%v, but expected %v. Diff=%s", got, exp, cmp.Diff(got, exp))
+	}
+}
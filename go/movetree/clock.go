@@ -0,0 +1,162 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/otrego/clamshell/go/color"
+)
+
+// ErrClock indicates that the clock state could not be reconstructed.
+var ErrClock = errors.New("error reconstructing clock state")
+
+// overtimeRegexp finds the number of periods and the period length in the
+// overtime property OT (ex: "5x30 byo-yomi").
+var overtimeRegexp = regexp.MustCompile(`(\d+)\s*[xX]\s*(\d+(?:\.\d+)?)`)
+
+// PlayerClock is the clock of one player.
+type PlayerClock struct {
+	// MainTime is the main time left, in seconds. Zero once in byo-yomi.
+	MainTime float64
+
+	// InByoYomi indicates that the main time has run out.
+	InByoYomi bool
+
+	// Periods is the number of byo-yomi periods left (OB), or for Canadian
+	// overtime, the number of moves left in the period. Zero if unknown.
+	Periods int
+
+	// PeriodTime is the time left in the current byo-yomi period, in seconds.
+	// Before byo-yomi, it's the length of a period (from OT), if known.
+	PeriodTime float64
+}
+
+// ClockState is the clock of both players.
+type ClockState struct {
+	Black, White PlayerClock
+}
+
+// ClockState reconstructs the clocks of the players at node n, from the
+// time-left properties (BL, WL) and the overtime properties (OB, OW) on the
+// path from the root, and the main time (TM) and overtime (OT) on the root.
+// A player is in byo-yomi from the first node where their overtime property
+// is recorded.
+//
+// When the clock of a player isn't recorded at n, their main time is
+// interpolated between the last recorded value and the next one on the main
+// variation below n, in proportion to the number of moves they played. If
+// the player reached byo-yomi in between, or either value is in byo-yomi,
+// the last recorded value is used as-is.
+func (e *GameEngine) ClockState(n *Node) (*ClockState, error) {
+	var path []*Node
+	for cur := n; cur != nil; cur = cur.Parent {
+		path = append([]*Node{cur}, path...)
+	}
+	initial, err := initialClock(path[0])
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &ClockState{}
+	for _, pc := range []struct {
+		c     color.Color
+		clock *PlayerClock
+	}{{color.Black, &cs.Black}, {color.White, &cs.White}} {
+		c, clock := pc.c, pc.clock
+		*clock = initial
+		last := -1
+		for i, p := range path {
+			if recordedClock(p, c, clock) {
+				last = i
+			}
+		}
+		if last == len(path)-1 || clock.InByoYomi {
+			continue
+		}
+		played := countMoves(path[last+1:], c)
+		if played == 0 {
+			continue
+		}
+		next, toPlay := nextRecordedClock(n, c, *clock)
+		if next == nil || next.InByoYomi {
+			continue
+		}
+		used := clock.MainTime - next.MainTime
+		clock.MainTime -= used * float64(played) / float64(played+toPlay)
+	}
+	return cs, nil
+}
+
+// initialClock returns the clock at the start of the game, from the main time
+// (TM) and the overtime (OT) on the root (see TimeControl).
+func initialClock(root *Node) (PlayerClock, error) {
+	var clock PlayerClock
+	tm, ot, _ := timeControlValues(root)
+	tc, err := ParseTimeControl(tm, ot)
+	if err != nil {
+		return clock, fmt.Errorf("%w: %v", ErrClock, err)
+	}
+	clock.MainTime = tc.MainTime
+	switch tc.System {
+	case OvertimeByoYomi:
+		clock.Periods = tc.Periods
+		clock.PeriodTime = tc.PeriodTime
+	case OvertimeCanadian:
+		clock.Periods = tc.Moves
+		clock.PeriodTime = tc.PeriodTime
+	}
+	return clock, nil
+}
+
+// recordedClock updates the clock with the time left (BL, WL) and the
+// overtime left (OB, OW) of color c on node n, returning whether either was
+// recorded.
+func recordedClock(n *Node, c color.Color, clock *PlayerClock) bool {
+	t, okTime := n.TimeLeft[c]
+	periods, okOvertime := n.OvertimeLeft[c]
+	if !okTime && !okOvertime {
+		return false
+	}
+	if okOvertime {
+		clock.InByoYomi = true
+		clock.MainTime = 0
+		clock.Periods = periods
+	}
+	if okTime {
+		if clock.InByoYomi {
+			clock.PeriodTime = t
+		} else {
+			clock.MainTime = t
+		}
+	}
+	return true
+}
+
+// nextRecordedClock finds the next node on the main variation below n where
+// the clock of color c is recorded. Returns the recorded clock, given the
+// clock before it, and the number of moves of color c up to and including
+// that node. The clock is nil if there's no such node.
+func nextRecordedClock(n *Node, c color.Color, clock PlayerClock) (*PlayerClock, int) {
+	moves := 0
+	for cur := n.Next(0); cur != nil; cur = cur.Next(0) {
+		if cur.Move != nil && cur.Move.Color() == c {
+			moves++
+		}
+		if recordedClock(cur, c, &clock) {
+			return &clock, moves
+		}
+	}
+	return nil, 0
+}
+
+// countMoves returns the number of moves of color c on the nodes.
+func countMoves(nodes []*Node, c color.Color) int {
+	count := 0
+	for _, n := range nodes {
+		if n.Move != nil && n.Move.Color() == c {
+			count++
+		}
+	}
+	return count
+}
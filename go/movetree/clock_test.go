@@ -0,0 +1,98 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestGameEngine_ClockState(t *testing.T) {
+	g, err := sgf.Parse(`(;GM[1]SZ[19]TM[600]OT[3x30 byo-yomi]
+;B[aa]BL[590]
+;W[bb]WL[580]
+;B[cc]
+;W[dd]WL[570]
+;B[ee]BL[570]
+;W[ff]
+;B[gg]BL[5]
+;W[hh]WL[25]OW[3]
+;B[ii]BL[28]OB[3]
+;W[jj]WL[22]OW[2])`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := movetree.PlayerClock{MainTime: 600, Periods: 3, PeriodTime: 30}
+
+	testCases := []struct {
+		desc string
+		path string
+		exp  *movetree.ClockState
+	}{
+		{
+			desc: "root",
+			path: "-",
+			exp:  &movetree.ClockState{Black: start, White: start},
+		},
+		{
+			desc: "interpolated main time",
+			path: "0x3",
+			exp: &movetree.ClockState{
+				Black: movetree.PlayerClock{MainTime: 580, Periods: 3, PeriodTime: 30},
+				White: movetree.PlayerClock{MainTime: 580, Periods: 3, PeriodTime: 30},
+			},
+		},
+		{
+			desc: "main time isn't interpolated into byo-yomi",
+			path: "0x6",
+			exp: &movetree.ClockState{
+				Black: movetree.PlayerClock{MainTime: 570, Periods: 3, PeriodTime: 30},
+				White: movetree.PlayerClock{MainTime: 570, Periods: 3, PeriodTime: 30},
+			},
+		},
+		{
+			desc: "white enters byo-yomi",
+			path: "0x8",
+			exp: &movetree.ClockState{
+				Black: movetree.PlayerClock{MainTime: 5, Periods: 3, PeriodTime: 30},
+				White: movetree.PlayerClock{InByoYomi: true, Periods: 3, PeriodTime: 25},
+			},
+		},
+		{
+			desc: "both in byo-yomi",
+			path: "0x10",
+			exp: &movetree.ClockState{
+				Black: movetree.PlayerClock{InByoYomi: true, Periods: 3, PeriodTime: 28},
+				White: movetree.PlayerClock{InByoYomi: true, Periods: 2, PeriodTime: 22},
+			},
+		},
+	}
+	e, err := g.NewGameEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tp, err := movetree.ParsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := e.ClockState(tp.Apply(g.Root))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Errorf("ClockState diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// Malformed time left is rejected when parsing, rather than when
+	// reconstructing the clock.
+	if _, err := sgf.Parse("(;GM[1]TM[600];B[aa]BL[soon])"); !errors.Is(err, prop.ErrTime) {
+		t.Errorf("got error %v, but expected %v", err, prop.ErrTime)
+	}
+}
@@ -0,0 +1,64 @@
+package movetree
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxTagKeyLen is the maximum length of the key of a comment tag.
+const maxTagKeyLen = 32
+
+// ParseComment separates the comment into freeform text and tags, which are
+// lines of the form "key: value" (ex: "Winrate: 55%"). The comment itself isn't
+// modified. See ParseCommentWithDelimiter.
+func (n *Node) ParseComment() (text string, tags map[string]string) {
+	return n.ParseCommentWithDelimiter(":")
+}
+
+// ParseCommentWithDelimiter is like ParseComment, but tag keys are separated
+// from their values with delim (ex: "=" for "winrate=0.55").
+//
+// A tag key must start with a letter, and may only contain letters, digits,
+// spaces, '-', and '_'. Keys and values are trimmed of surrounding whitespace.
+// If a key appears more than once, the last value is used. The text contains
+// the other lines, with leading and trailing blank lines removed. Tags is nil
+// if there are no tags.
+func (n *Node) ParseCommentWithDelimiter(delim string) (text string, tags map[string]string) {
+	var lines []string
+	for _, line := range strings.Split(n.Comment, "\n") {
+		key, value, ok := splitTag(line, delim)
+		if !ok {
+			lines = append(lines, line)
+			continue
+		}
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[key] = value
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n"), tags
+}
+
+// splitTag splits a comment line into a tag key and value, if it's a tag.
+func splitTag(line, delim string) (key, value string, ok bool) {
+	if delim == "" {
+		return "", "", false
+	}
+	i := strings.Index(line, delim)
+	if i <= 0 || i > maxTagKeyLen {
+		return "", "", false
+	}
+	key = strings.TrimRight(line[:i], " ")
+	for j, r := range key {
+		if j == 0 && !unicode.IsLetter(r) {
+			return "", "", false
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' && r != '_' {
+			return "", "", false
+		}
+	}
+	if key == "" {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(line[i+len(delim):]), true
+}
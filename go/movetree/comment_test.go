@@ -0,0 +1,66 @@
+package movetree
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseComment(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		comment string
+		delim   string
+		expText string
+		expTags map[string]string
+	}{
+		{
+			desc:    "prose and tags",
+			comment: "Move 42\nWinrate: 55%\nScore lead: B+2.5\n\nBlack should tenuki here.\nA tricky position, e.g. 3:2 odds.",
+			delim:   ":",
+			expText: "Move 42\n\nBlack should tenuki here.\nA tricky position, e.g. 3:2 odds.",
+			expTags: map[string]string{"Winrate": "55%", "Score lead": "B+2.5"},
+		},
+		{
+			desc:    "only prose",
+			comment: "A good move",
+			delim:   ":",
+			expText: "A good move",
+		},
+		{
+			desc:    "tags first",
+			comment: "winrate: 0.55\nvisits: 1600\nBlack is ahead",
+			delim:   ":",
+			expText: "Black is ahead",
+			expTags: map[string]string{"winrate": "0.55", "visits": "1600"},
+		},
+		{
+			desc:    "custom delimiter",
+			comment: "winrate=0.55\nSee: the ladder",
+			delim:   "=",
+			expText: "See: the ladder",
+			expTags: map[string]string{"winrate": "0.55"},
+		},
+		{
+			desc:    "empty",
+			delim:   ":",
+			expText: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			n := NewNode()
+			n.Comment = tc.comment
+			text, tags := n.ParseCommentWithDelimiter(tc.delim)
+			if text != tc.expText {
+				t.Errorf("got text %q, but expected %q", text, tc.expText)
+			}
+			if !cmp.Equal(tags, tc.expTags) {
+				t.Errorf("got tags %v, but expected %v", tags, tc.expTags)
+			}
+			if n.Comment != tc.comment {
+				t.Errorf("comment was modified to %q", n.Comment)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrDate indicates that a date (DT) could not be parsed.
+var ErrDate = errors.New("error parsing date")
+
+// PartialDate is a date that may only be partially specified: just the year,
+// or the year and the month.
+type PartialDate struct {
+	Year int
+
+	// Month is between 1 and 12, or 0 if unspecified.
+	Month int
+
+	// Day is between 1 and 31, or 0 if unspecified. The day is only specified
+	// if the month is.
+	Day int
+}
+
+// Time returns the date as a time, at midnight UTC. ok is false if the date is
+// only partially specified.
+func (d PartialDate) Time() (t time.Time, ok bool) {
+	if d.Month == 0 || d.Day == 0 {
+		return time.Time{}, false
+	}
+	return time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, time.UTC), true
+}
+
+// Before indicates whether the date is before another date. The dates are only
+// compared to the precision that both of them have, so 1996 isn't before
+// 1996-05.
+func (d PartialDate) Before(other PartialDate) bool {
+	if d.Year != other.Year {
+		return d.Year < other.Year
+	}
+	if d.Month == 0 || other.Month == 0 || d.Month != other.Month {
+		return d.Month != 0 && other.Month != 0 && d.Month < other.Month
+	}
+	return d.Day != 0 && other.Day != 0 && d.Day < other.Day
+}
+
+// String returns the date in the SGF date format: YYYY, YYYY-MM, or
+// YYYY-MM-DD.
+func (d PartialDate) String() string {
+	s := fmt.Sprintf("%04d", d.Year)
+	if d.Month != 0 {
+		s += fmt.Sprintf("-%02d", d.Month)
+	}
+	if d.Day != 0 {
+		s += fmt.Sprintf("-%02d", d.Day)
+	}
+	return s
+}
+
+// ParseDates parses the dates of the date property (DT), which is a
+// comma-separated list of dates in the format YYYY-MM-DD, YYYY-MM, or YYYY. As
+// in the SGF specification, a date can be shortened to the parts that differ
+// from the previous date: after YYYY-MM-DD, DD and MM-DD are shorthands, and
+// after YYYY-MM, MM is a shorthand. For example, 1996-05-06,07,08 is
+// 1996-05-06, 1996-05-07, and 1996-05-08.
+//
+// Impossible dates, such as 1996-13-01 or 1997-02-29, are rejected.
+func ParseDates(dt string) ([]PartialDate, error) {
+	var dates []PartialDate
+	var prev PartialDate
+	for _, part := range strings.Split(dt, ",") {
+		part = strings.TrimSpace(part)
+		fields := strings.Split(part, "-")
+		nums := make([]int, len(fields))
+		for i, f := range fields {
+			width := 2
+			if i == 0 && len(f) == 4 {
+				width = 4
+			}
+			v, err := strconv.Atoi(f)
+			if len(f) != width || err != nil || (width == 2 && v == 0) {
+				return nil, fmt.Errorf("%w: malformed date %q in %q", ErrDate, part, dt)
+			}
+			nums[i] = v
+		}
+
+		d := prev
+		switch {
+		case len(fields[0]) == 4 && len(nums) <= 3:
+			d = PartialDate{Year: nums[0]}
+			if len(nums) > 1 {
+				d.Month = nums[1]
+			}
+			if len(nums) > 2 {
+				d.Day = nums[2]
+			}
+		case len(dates) > 0 && len(nums) == 1 && prev.Day != 0:
+			d.Day = nums[0]
+		case len(dates) > 0 && len(nums) == 1 && prev.Month != 0:
+			d.Month = nums[0]
+		case len(dates) > 0 && len(nums) == 2 && prev.Day != 0:
+			d.Month, d.Day = nums[0], nums[1]
+		default:
+			return nil, fmt.Errorf("%w: malformed date %q in %q", ErrDate, part, dt)
+		}
+		if err := d.validate(); err != nil {
+			return nil, err
+		}
+		dates = append(dates, d)
+		prev = d
+	}
+	return dates, nil
+}
+
+// validate checks that the date exists.
+func (d PartialDate) validate() error {
+	if d.Month < 0 || d.Month > 12 || (d.Month == 0 && d.Day != 0) {
+		return fmt.Errorf("%w: %s has no month %d", ErrDate, d, d.Month)
+	}
+	if d.Day == 0 {
+		return nil
+	}
+	if t, _ := d.Time(); d.Day < 1 || t.Day() != d.Day {
+		return fmt.Errorf("%w: %s is not a date; %04d-%02d has no day %d", ErrDate, d, d.Year, d.Month, d.Day)
+	}
+	return nil
+}
+
+// Dates parses the dates the game was played on (see ParseDates). Returns no
+// dates if the date is unspecified.
+func (gi *GameInfo) Dates() ([]PartialDate, error) {
+	if gi == nil || gi.Date == "" {
+		return nil, nil
+	}
+	return ParseDates(gi.Date)
+}
@@ -0,0 +1,129 @@
+package movetree
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDates(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		dt     string
+		exp    []PartialDate
+		expErr error
+	}{
+		{
+			desc: "full date",
+			dt:   "2016-03-09",
+			exp:  []PartialDate{{2016, 3, 9}},
+		},
+		{
+			desc: "year and month",
+			dt:   "1996-05",
+			exp:  []PartialDate{{1996, 5, 0}},
+		},
+		{
+			desc: "years",
+			dt:   "1996,1997",
+			exp:  []PartialDate{{1996, 0, 0}, {1997, 0, 0}},
+		},
+		{
+			desc: "shorthand days",
+			dt:   "1996-05-06,07,08",
+			exp:  []PartialDate{{1996, 5, 6}, {1996, 5, 7}, {1996, 5, 8}},
+		},
+		{
+			desc: "shorthand month and day",
+			dt:   "1996-05-06,06-10",
+			exp:  []PartialDate{{1996, 5, 6}, {1996, 6, 10}},
+		},
+		{
+			desc: "shorthand months",
+			dt:   "1996-05,06",
+			exp:  []PartialDate{{1996, 5, 0}, {1996, 6, 0}},
+		},
+		{
+			desc: "new full date after shorthand",
+			dt:   "1996-12-30,31,1997-01-02",
+			exp:  []PartialDate{{1996, 12, 30}, {1996, 12, 31}, {1997, 1, 2}},
+		},
+		{
+			desc: "leap day",
+			dt:   "1996-02-29",
+			exp:  []PartialDate{{1996, 2, 29}},
+		},
+		{
+			desc:   "feb 29 in a non-leap year",
+			dt:     "1997-02-29",
+			expErr: ErrDate,
+		},
+		{
+			desc:   "month 13",
+			dt:     "1996-13",
+			expErr: ErrDate,
+		},
+		{
+			desc:   "day 0",
+			dt:     "1996-05-00",
+			expErr: ErrDate,
+		},
+		{
+			desc:   "shorthand without a previous date",
+			dt:     "06",
+			expErr: ErrDate,
+		},
+		{
+			desc:   "shorthand day after a year",
+			dt:     "1996,06",
+			expErr: ErrDate,
+		},
+		{
+			desc:   "malformed",
+			dt:     "May 6, 1996",
+			expErr: ErrDate,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseDates(tc.dt)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("ParseDates(%q) got error %v, but expected %v", tc.dt, err, tc.expErr)
+			}
+			if !cmp.Equal(got, tc.exp) {
+				t.Errorf("ParseDates(%q)=%v, but expected %v", tc.dt, got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestPartialDate_Time(t *testing.T) {
+	got, ok := PartialDate{2016, 3, 9}.Time()
+	if exp := time.Date(2016, time.March, 9, 0, 0, 0, 0, time.UTC); !ok || !got.Equal(exp) {
+		t.Errorf("Time()=%v, %v, but expected %v, true", got, ok, exp)
+	}
+	if _, ok := (PartialDate{2016, 3, 0}).Time(); ok {
+		t.Errorf("Time() of a partial date got ok, but expected !ok")
+	}
+}
+
+func TestPartialDate_Before(t *testing.T) {
+	testCases := []struct {
+		a, b PartialDate
+		exp  bool
+	}{
+		{PartialDate{1996, 5, 6}, PartialDate{1996, 5, 7}, true},
+		{PartialDate{1996, 5, 7}, PartialDate{1996, 5, 6}, false},
+		{PartialDate{1996, 12, 31}, PartialDate{1997, 1, 1}, true},
+		{PartialDate{1996, 0, 0}, PartialDate{1996, 5, 0}, false},
+		{PartialDate{1996, 5, 0}, PartialDate{1996, 0, 0}, false},
+		{PartialDate{1996, 5, 0}, PartialDate{1996, 6, 1}, true},
+	}
+	for _, tc := range testCases {
+		if got := tc.a.Before(tc.b); got != tc.exp {
+			t.Errorf("%v.Before(%v)=%v, but expected %v", tc.a, tc.b, got, tc.exp)
+		}
+	}
+}
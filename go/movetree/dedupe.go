@@ -0,0 +1,241 @@
+package movetree
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// DedupeVariations merges sibling variations that are structurally equal,
+// returning the number of variations that were removed.
+//
+// Two variations are structurally equal when their nodes have the same moves
+// and placements, and their children are (in order) structurally equal. Such
+// variations are only merged when their annotations can be merged safely:
+//
+//   - Comments are concatenated, separated by a blank line. Identical comments
+//     are only kept once.
+//   - Move annotations (BM, TE, DO, IT), position annotations (GB, GW, DM,
+//     UC), names, hotspots, and values are kept, so long as they don't differ
+//     between the two variations.
+//   - Marks and labels are combined, so long as no point has a different mark
+//     or label in the two variations.
+//   - Raw SGF properties are combined, so long as no property has different
+//     values in the two variations.
+//
+// The first of the duplicate variations is kept, and the main line stays the
+// main line.
+func (mt *MoveTree) DedupeVariations() int {
+	return mt.Root.dedupeVariations()
+}
+
+// dedupeVariations merges the duplicate variations below n.
+func (n *Node) dedupeVariations() int {
+	removed := 0
+	var kept []*Node
+	for _, c := range n.Children {
+		merged := false
+		for _, k := range kept {
+			if sameVariation(k, c) && canMergeVariation(k, c) {
+				mergeVariation(k, c)
+				merged = true
+				removed++
+				break
+			}
+		}
+		if !merged {
+			kept = append(kept, c)
+		}
+	}
+	if len(kept) != len(n.Children) {
+		n.Children = kept
+		n.renumberChildren()
+	}
+	for _, c := range n.Children {
+		removed += c.dedupeVariations()
+	}
+	return removed
+}
+
+// sameVariation indicates whether two variations are structurally equal.
+func sameVariation(a, b *Node) bool {
+	if !sameMove(a, b) || !samePlacements(a, b) || len(a.Children) != len(b.Children) {
+		return false
+	}
+	for i := range a.Children {
+		if !sameVariation(a.Children[i], b.Children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameMove(a, b *Node) bool {
+	if a.Move == nil || b.Move == nil {
+		return a.Move == nil && b.Move == nil
+	}
+	return a.Move.Equal(b.Move)
+}
+
+func samePlacements(a, b *Node) bool {
+	if len(a.Placements) != len(b.Placements) {
+		return false
+	}
+	var as, bs []string
+	for i := range a.Placements {
+		as = append(as, a.Placements[i].String())
+		bs = append(bs, b.Placements[i].String())
+	}
+	sort.Strings(as)
+	sort.Strings(bs)
+	return reflect.DeepEqual(as, bs)
+}
+
+// canMergeVariation indicates whether the annotations of two structurally equal
+// variations can be merged.
+func canMergeVariation(a, b *Node) bool {
+	if !canMergeAnnotations(a, b) {
+		return false
+	}
+	for i := range a.Children {
+		if !canMergeVariation(a.Children[i], b.Children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// canMergeAnnotations indicates whether the annotations of node b can be merged
+// into node a, ignoring their children.
+func canMergeAnnotations(a, b *Node) bool {
+	if a.MoveAnnotation != nil && b.MoveAnnotation != nil && *a.MoveAnnotation != *b.MoveAnnotation {
+		return false
+	}
+	if a.Name != "" && b.Name != "" && a.Name != b.Name {
+		return false
+	}
+	if a.PositionAnnotation != nil && b.PositionAnnotation != nil && *a.PositionAnnotation != *b.PositionAnnotation {
+		return false
+	}
+	if a.Hotspot != 0 && b.Hotspot != 0 && a.Hotspot != b.Hotspot {
+		return false
+	}
+	if a.Value != nil && b.Value != nil && *a.Value != *b.Value {
+		return false
+	}
+	for pt, m := range b.Marks {
+		if am, ok := a.Marks[pt]; ok && am != m {
+			return false
+		}
+	}
+	for pt, l := range b.Labels {
+		if al, ok := a.Labels[pt]; ok && al != l {
+			return false
+		}
+	}
+	if !canMergeLines(a.Arrows, b.Arrows) || !canMergeLines(a.Lines, b.Lines) {
+		return false
+	}
+	for c, t := range b.TimeLeft {
+		if at, ok := a.TimeLeft[c]; ok && at != t {
+			return false
+		}
+	}
+	for c, o := range b.OvertimeLeft {
+		if ao, ok := a.OvertimeLeft[c]; ok && ao != o {
+			return false
+		}
+	}
+	for k, v := range b.SGFProperties {
+		if av, ok := a.SGFProperties[k]; ok && !reflect.DeepEqual(av, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeVariation merges the annotations of variation b into variation a.
+func mergeVariation(a, b *Node) {
+	mergeAnnotations(a, b)
+	for i := range a.Children {
+		mergeVariation(a.Children[i], b.Children[i])
+	}
+}
+
+// mergeAnnotations merges the annotations of node b into node a, ignoring their
+// children. Where the annotations conflict, those of a are kept.
+func mergeAnnotations(a, b *Node) {
+	switch {
+	case b.Comment == "" || b.Comment == a.Comment:
+	case a.Comment == "":
+		a.Comment = b.Comment
+	default:
+		a.Comment = a.Comment + "\n\n" + b.Comment
+	}
+	if a.MoveAnnotation == nil {
+		a.MoveAnnotation = b.MoveAnnotation
+	}
+	if a.Name == "" {
+		a.Name = b.Name
+	}
+	if a.PositionAnnotation == nil {
+		a.PositionAnnotation = b.PositionAnnotation
+	}
+	if a.Hotspot == 0 {
+		a.Hotspot = b.Hotspot
+	}
+	if a.Value == nil {
+		a.Value = b.Value
+	}
+	for pt, m := range b.Marks {
+		if a.Marks == nil {
+			a.Marks = make(map[point.Point]MarkType)
+		}
+		if _, ok := a.Marks[pt]; !ok {
+			a.Marks[pt] = m
+		}
+	}
+	for pt, l := range b.Labels {
+		if a.Labels == nil {
+			a.Labels = make(map[point.Point]string)
+		}
+		if _, ok := a.Labels[pt]; !ok {
+			a.Labels[pt] = l
+		}
+	}
+	if a.Arrows == nil {
+		a.Arrows = b.Arrows
+	}
+	if a.Lines == nil {
+		a.Lines = b.Lines
+	}
+	for c, t := range b.TimeLeft {
+		if a.TimeLeft == nil {
+			a.TimeLeft = make(map[color.Color]float64)
+		}
+		if _, ok := a.TimeLeft[c]; !ok {
+			a.TimeLeft[c] = t
+		}
+	}
+	for c, o := range b.OvertimeLeft {
+		if a.OvertimeLeft == nil {
+			a.OvertimeLeft = make(map[color.Color]int)
+		}
+		if _, ok := a.OvertimeLeft[c]; !ok {
+			a.OvertimeLeft[c] = o
+		}
+	}
+	for k, v := range b.SGFProperties {
+		if _, ok := a.SGFProperties[k]; !ok {
+			a.SGFProperties[k] = v
+		}
+	}
+}
+
+// canMergeLines indicates whether the arrows or lines of two nodes can be
+// merged: either node has none, or they're the same.
+func canMergeLines(a, b []Line) bool {
+	return a == nil || b == nil || reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,73 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestDedupeVariations(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		sgf        string
+		expRemoved int
+		exp        string
+	}{
+		{
+			desc:       "two identical branches",
+			sgf:        "(;GM[1](;B[aa];W[bb]C[first])(;B[aa];W[bb]C[second]))",
+			expRemoved: 1,
+			exp:        "(;GM[1];B[aa];W[bb]C[first\n\nsecond])",
+		},
+		{
+			desc:       "identical comments are kept once",
+			sgf:        "(;GM[1](;B[aa]C[same])(;B[aa]C[same])(;B[cc]))",
+			expRemoved: 1,
+			exp:        "(;GM[1](;B[aa]C[same])(;B[cc]))",
+		},
+		{
+			desc:       "different continuations",
+			sgf:        "(;GM[1](;B[aa];W[bb])(;B[aa];W[cc]))",
+			expRemoved: 0,
+			exp:        "(;GM[1](;B[aa];W[bb])(;B[aa];W[cc]))",
+		},
+		{
+			desc:       "conflicting properties aren't merged",
+			sgf:        "(;GM[1](;B[aa]N[one])(;B[aa]N[two]))",
+			expRemoved: 0,
+			exp:        "(;GM[1](;B[aa]N[one])(;B[aa]N[two]))",
+		},
+		{
+			desc:       "nested duplicates",
+			sgf:        "(;GM[1];B[aa](;W[bb]TR[cc])(;W[bb]MA[dd]))",
+			expRemoved: 1,
+			exp:        "(;GM[1];B[aa];W[bb]MA[dd]TR[cc])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := g.DedupeVariations(); got != tc.expRemoved {
+				t.Errorf("DedupeVariations()=%d, but expected %d", got, tc.expRemoved)
+			}
+			exp, err := sgf.Parse(tc.exp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := sgf.Serialize(exp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("got movetree %s, but expected %s", got, want)
+			}
+		})
+	}
+}
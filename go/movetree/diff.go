@@ -0,0 +1,231 @@
+package movetree
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// DiffKind is a kind of difference between the nodes of two movetrees.
+type DiffKind string
+
+const (
+	// DiffAdded indicates a variation that's only in the second movetree.
+	DiffAdded DiffKind = "Added"
+
+	// DiffRemoved indicates a variation that's only in the first movetree.
+	DiffRemoved DiffKind = "Removed"
+
+	// DiffChanged indicates a node whose properties are different in the two
+	// movetrees.
+	DiffChanged DiffKind = "Changed"
+
+	// DiffMoved indicates a variation that has a different variation number in
+	// the two movetrees (ex: it was promoted to the main line).
+	DiffMoved DiffKind = "Moved"
+)
+
+// NodeDiff is a difference between the nodes of two movetrees.
+type NodeDiff struct {
+	Kind DiffKind
+
+	// Path is the path to the node: in the second movetree for added
+	// variations, and in the first movetree otherwise.
+	Path Path
+
+	// A and B are the node in each movetree. A is nil for added variations,
+	// and B is nil for removed variations.
+	A, B *Node
+
+	// Props are the properties that are different, for changed nodes.
+	Props []PropDiff
+}
+
+// PropDiff is a property that's different in two nodes.
+type PropDiff struct {
+	// Prop is the SGF property (ex: C).
+	Prop string
+
+	// A and B are the values of the property in each node, as unescaped SGF
+	// values in sorted order. They're nil if the node doesn't have the
+	// property.
+	A, B []string
+}
+
+// Diff returns the differences between two movetrees, such as a game and a
+// review of it, in the pre-order of the first movetree. Only the differences
+// where a variation starts are returned: the nodes of an added or removed
+// variation aren't reported separately.
+//
+// The roots are always compared. Below them, the nodes are matched by their
+// moves and placements, as in Merge, so a variation whose moves changed is
+// reported as removed and added. The properties of matching nodes are compared
+// by their SGF values (see PropDiff), and the game info is compared as in
+// GameInfo.Diff. The analyses aren't compared.
+func Diff(a, b *MoveTree) []NodeDiff {
+	var diffs []NodeDiff
+	diffNodes(a.Root, b.Root, nil, &diffs)
+	return diffs
+}
+
+// diffNodes adds the differences between the matching nodes a and b, and
+// between their descendants. tp is the path to a.
+func diffNodes(a, b *Node, tp Path, diffs *[]NodeDiff) {
+	if a.varNum != b.varNum {
+		*diffs = append(*diffs, NodeDiff{Kind: DiffMoved, Path: tp.Clone(), A: a, B: b})
+	}
+	if props := diffProps(a, b); props != nil {
+		*diffs = append(*diffs, NodeDiff{Kind: DiffChanged, Path: tp.Clone(), A: a, B: b, Props: props})
+	}
+
+	matched := make(map[*Node]bool)
+	for _, ac := range a.Children {
+		var match *Node
+		for _, bc := range b.Children {
+			if !matched[bc] && sameMove(ac, bc) && samePlacements(ac, bc) {
+				match = bc
+				break
+			}
+		}
+		ctp := append(tp.Clone(), ac.varNum)
+		if match == nil {
+			*diffs = append(*diffs, NodeDiff{Kind: DiffRemoved, Path: ctp, A: ac})
+			continue
+		}
+		matched[match] = true
+		diffNodes(ac, match, ctp, diffs)
+	}
+	for _, bc := range b.Children {
+		if !matched[bc] {
+			*diffs = append(*diffs, NodeDiff{Kind: DiffAdded, Path: PathTo(bc), B: bc})
+		}
+	}
+}
+
+// diffProps returns the properties that are different in the two nodes, with
+// the game info first and then the other properties in alphabetical order.
+// Returns nil if the properties are the same.
+func diffProps(a, b *Node) []PropDiff {
+	var diffs []PropDiff
+	if a.GameInfo != nil || b.GameInfo != nil {
+		for _, fd := range a.GameInfo.Diff(b.GameInfo) {
+			diffs = append(diffs, PropDiff{Prop: fd.Prop, A: optionalValue(fd.A), B: optionalValue(fd.B)})
+		}
+	}
+
+	pa, pb := nodeProps(a), nodeProps(b)
+	var keys []string
+	for k := range pa {
+		keys = append(keys, k)
+	}
+	for k := range pb {
+		if _, ok := pa[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !reflect.DeepEqual(pa[k], pb[k]) {
+			diffs = append(diffs, PropDiff{Prop: k, A: pa[k], B: pb[k]})
+		}
+	}
+	return diffs
+}
+
+// optionalValue returns a game-info value as property values, which are nil
+// if the value is unspecified.
+func optionalValue(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return []string{v}
+}
+
+// nodeProps returns the SGF values of the properties of the node, other than
+// its game info, including its raw properties.
+func nodeProps(n *Node) map[string][]string {
+	props := make(map[string][]string)
+	add := func(prop string, values ...string) {
+		props[prop] = append(props[prop], values...)
+	}
+
+	if m := n.Move; m != nil && m.Color() != color.Empty {
+		v := ""
+		if !m.IsPass() {
+			v = sgfPoint(m.Point())
+		}
+		add(string(m.Color()), v)
+	}
+	placements := map[color.Color]string{color.Black: "AB", color.White: "AW", color.Empty: "AE"}
+	for _, m := range n.Placements {
+		add(placements[m.Color()], sgfPoint(m.Point()))
+	}
+	if n.Comment != "" {
+		add("C", n.Comment)
+	}
+	if n.Name != "" {
+		add("N", n.Name)
+	}
+	if ma := n.MoveAnnotation; ma != nil {
+		v := ""
+		if ma.Emphasis != 0 {
+			v = strconv.Itoa(ma.Emphasis)
+		}
+		add(string(ma.Type), v)
+	}
+	if pa := n.PositionAnnotation; pa != nil {
+		add(string(pa.Type), strconv.Itoa(pa.Emphasis))
+	}
+	if n.Hotspot != 0 {
+		add("HO", strconv.Itoa(n.Hotspot))
+	}
+	if n.Value != nil {
+		add("V", strconv.FormatFloat(*n.Value, 'f', -1, 64))
+	}
+	for pt, typ := range n.Marks {
+		add(string(typ), sgfPoint(&pt))
+	}
+	for pt, text := range n.Labels {
+		add("LB", sgfPoint(&pt)+":"+text)
+	}
+	for _, l := range n.Arrows {
+		add("AR", sgfLine(l))
+	}
+	for _, l := range n.Lines {
+		add("LN", sgfLine(l))
+	}
+	timeProps := map[color.Color][2]string{color.Black: {"BL", "OB"}, color.White: {"WL", "OW"}}
+	for c, t := range n.TimeLeft {
+		add(timeProps[c][0], strconv.FormatFloat(t, 'f', -1, 64))
+	}
+	for c, o := range n.OvertimeLeft {
+		add(timeProps[c][1], strconv.Itoa(o))
+	}
+	for k, v := range n.SGFProperties {
+		add(k, v...)
+	}
+
+	// The marks and labels are stored in maps, so the order of the values
+	// isn't meaningful.
+	for _, v := range props {
+		sort.Strings(v)
+	}
+	return props
+}
+
+// sgfPoint returns the SGF value of a point.
+func sgfPoint(pt *point.Point) string {
+	s, err := pt.ToSGF()
+	if err != nil {
+		return pt.String()
+	}
+	return s
+}
+
+// sgfLine returns the SGF value of an arrow or a line.
+func sgfLine(l Line) string {
+	return sgfPoint(point.New(l.FromX, l.FromY)) + ":" + sgfPoint(point.New(l.ToX, l.ToY))
+}
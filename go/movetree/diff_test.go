@@ -0,0 +1,88 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestDiff(t *testing.T) {
+	// diff is a NodeDiff without the nodes, to compare.
+	type diff struct {
+		Kind  movetree.DiffKind
+		Path  string
+		Props []movetree.PropDiff
+	}
+	testCases := []struct {
+		desc string
+		a, b string
+		exp  []diff
+	}{
+		{
+			desc: "same",
+			a:    "(;GM[1]PB[Lee];B[aa]C[Hi](;W[bb])(;W[cc]))",
+			b:    "(;GM[1]PB[Lee];B[aa]C[Hi](;W[bb])(;W[cc]))",
+		},
+		{
+			desc: "changed comment and marks",
+			a:    "(;GM[1];B[aa]C[Hi]TR[cc];W[bb])",
+			b:    "(;GM[1];B[aa]C[Hello]TR[cc][dd];W[bb])",
+			exp: []diff{{Kind: movetree.DiffChanged, Path: "-0", Props: []movetree.PropDiff{
+				{Prop: "C", A: []string{"Hi"}, B: []string{"Hello"}},
+				{Prop: "TR", A: []string{"cc"}, B: []string{"cc", "dd"}},
+			}}},
+		},
+		{
+			desc: "changed game info",
+			a:    "(;GM[1]PB[Lee]RE[B+R];B[aa])",
+			b:    "(;GM[1]PB[Lee]KM[6.5];B[aa])",
+			exp: []diff{{Kind: movetree.DiffChanged, Path: "-", Props: []movetree.PropDiff{
+				{Prop: "KM", B: []string{"6.5"}},
+				{Prop: "RE", A: []string{"B+R"}},
+			}}},
+		},
+		{
+			desc: "added and removed variations",
+			a:    "(;GM[1];B[aa](;W[bb];B[cc])(;W[dd]))",
+			b:    "(;GM[1];B[aa](;W[bb];B[ee])(;W[dd])(;W[ff]))",
+			exp: []diff{
+				{Kind: movetree.DiffRemoved, Path: "-0x3"},
+				{Kind: movetree.DiffAdded, Path: "-0x3"},
+				{Kind: movetree.DiffAdded, Path: "-0-2"},
+			},
+		},
+		{
+			desc: "promoted variation",
+			a:    "(;GM[1];B[aa](;W[bb])(;W[cc]N[Better]))",
+			b:    "(;GM[1];B[aa](;W[cc]N[Better])(;W[bb]))",
+			exp: []diff{
+				{Kind: movetree.DiffMoved, Path: "-0x2"},
+				{Kind: movetree.DiffMoved, Path: "-0-1"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, err := sgf.Parse(tc.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := sgf.Parse(tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []diff
+			for _, d := range movetree.Diff(a, b) {
+				if (d.Kind == movetree.DiffAdded) != (d.A == nil) || (d.Kind == movetree.DiffRemoved) != (d.B == nil) {
+					t.Errorf("got %s diff at %v with nodes %v and %v", d.Kind, d.Path, d.A, d.B)
+				}
+				got = append(got, diff{Kind: d.Kind, Path: d.Path.CompactString(), Props: d.Props})
+			}
+			if d := cmp.Diff(tc.exp, got); d != "" {
+				t.Errorf("Diff()=%v, but expected %v. diff=%s", got, tc.exp, d)
+			}
+		})
+	}
+}
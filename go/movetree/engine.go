@@ -0,0 +1,297 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ErrGameOver indicates that a move was applied after the end of the game.
+var ErrGameOver = errors.New("game is over")
+
+// ErrUndo indicates that there was no move to undo.
+var ErrUndo = errors.New("nothing to undo")
+
+// GameEngine applies moves to a board while tracking the state of the game:
+// whose turn it is and whether the game has ended.
+//
+// A pass is a legal move that doesn't change the board, and two consecutive
+// passes end the game. A resignation also ends the game.
+type GameEngine struct {
+	board  *board.Board
+	toPlay color.Color
+
+	// passes is the number of consecutive passes.
+	passes int
+	// resigned indicates that the game ended by resignation.
+	resigned bool
+
+	opts     *EngineOptions
+	warnings []*EngineWarning
+
+	// history records the state before each Apply and ApplyNode, for Undo.
+	history []*engineUndo
+}
+
+// engineUndo records the state of a GameEngine from before a move or node was
+// applied.
+type engineUndo struct {
+	// changes are the changes to the board, in the order they were made.
+	changes []*board.Undo
+
+	toPlay   color.Color
+	passes   int
+	resigned bool
+	warnings int
+}
+
+// EngineOptions are options for a GameEngine. A nil *EngineOptions uses the
+// defaults.
+type EngineOptions struct {
+	// AllowSelfCapture allows self-capturing moves, which some engines use to
+	// encode a pass or another special action. Rather than being an error, the
+	// move captures its own group and an *EngineWarning is recorded. Off by
+	// default.
+	AllowSelfCapture bool
+}
+
+// allowSelfCapture indicates whether self-capturing moves are allowed.
+func (o *EngineOptions) allowSelfCapture() bool {
+	return o != nil && o.AllowSelfCapture
+}
+
+// SelfCaptureKind classifies a self-capturing move.
+type SelfCaptureKind string
+
+const (
+	// SelfCapturePass is a single stone that captures itself. The board is left
+	// unchanged, so it's treated as a pass.
+	SelfCapturePass SelfCaptureKind = "pass"
+
+	// SelfCaptureGroup is a move that captures a group of its own stones.
+	SelfCaptureGroup SelfCaptureKind = "group"
+)
+
+// EngineWarning is a non-standard move that a GameEngine applied, rather than
+// returning an error.
+type EngineWarning struct {
+	// Move is the non-standard move.
+	Move *move.Move
+
+	Kind SelfCaptureKind
+
+	// Captured are the stones of the player that made the move, which were
+	// captured, including the stone of the move.
+	Captured move.List
+}
+
+// Error returns the warning message.
+func (w *EngineWarning) Error() string {
+	return fmt.Sprintf("self-capture (%s) by move %v, capturing %d stones", w.Kind, w.Move, len(w.Captured))
+}
+
+// NewGameEngine creates a GameEngine for an empty size x size board, with
+// black to play.
+func NewGameEngine(size int) *GameEngine {
+	return &GameEngine{
+		board:  board.New(size),
+		toPlay: color.Black,
+	}
+}
+
+// NewGameEngine creates a GameEngine for the movetree, with the root node
+// applied. The player to play is given by PlayerToMove.
+func (mt *MoveTree) NewGameEngine() (*GameEngine, error) {
+	e := &GameEngine{board: mt.newBoard(), toPlay: color.Black}
+	if err := e.ApplyNode(mt.Root); err != nil {
+		return nil, err
+	}
+	e.toPlay = mt.PlayerToMove(mt.Root)
+	// The root is the start of the game, so it can't be undone.
+	e.history = nil
+	return e, nil
+}
+
+// WithOptions sets the options for applying moves.
+func (e *GameEngine) WithOptions(opts *EngineOptions) *GameEngine {
+	e.opts = opts
+	return e
+}
+
+// Warnings returns the non-standard moves that were applied (see
+// EngineOptions).
+func (e *GameEngine) Warnings() []*EngineWarning {
+	return e.warnings
+}
+
+// Board returns the current board. The board is owned by the GameEngine and is
+// modified when moves are applied, so Clone it to keep a copy.
+func (e *GameEngine) Board() *board.Board {
+	return e.board
+}
+
+// ToPlay returns the color of the player whose turn it is.
+func (e *GameEngine) ToPlay() color.Color {
+	return e.toPlay
+}
+
+// IsOver indicates whether the game has ended, either by two consecutive
+// passes or by resignation.
+func (e *GameEngine) IsOver() bool {
+	return e.resigned || e.passes >= 2
+}
+
+// IsResigned indicates whether the game ended by resignation.
+func (e *GameEngine) IsResigned() bool {
+	return e.resigned
+}
+
+// Apply applies a move (or a pass) to the board, returning the captured
+// stones. It's an error to apply a move after the game is over.
+func (e *GameEngine) Apply(m *move.Move) (move.List, error) {
+	if e.IsOver() {
+		return nil, fmt.Errorf("%w: can't apply move %v", ErrGameOver, m)
+	}
+	u := e.saveState()
+	captured, err := e.apply(m, u)
+	if err != nil {
+		return nil, err
+	}
+	e.history = append(e.history, u)
+	return captured, nil
+}
+
+// apply applies a move, recording the changes to the board in u.
+func (e *GameEngine) apply(m *move.Move, u *engineUndo) (move.List, error) {
+	if m.IsPass() {
+		e.passes++
+		e.toPlay = m.Color().Opposite()
+		return nil, nil
+	}
+	captured, bu, err := e.board.PlaceStoneWithUndo(m)
+	if errors.Is(err, board.ErrSuicide) && e.opts.allowSelfCapture() {
+		return e.applySelfCapture(m, u)
+	} else if err != nil {
+		return nil, err
+	}
+	u.changes = append(u.changes, bu)
+	e.passes = 0
+	e.toPlay = m.Color().Opposite()
+	return captured, nil
+}
+
+// applySelfCapture applies a self-capturing move, recording a warning. The
+// captured stones are returned, as for other moves.
+func (e *GameEngine) applySelfCapture(m *move.Move, u *engineUndo) (move.List, error) {
+	captured, bu, err := e.board.PlaceSelfCaptureWithUndo(m)
+	if err != nil {
+		return nil, err
+	}
+	u.changes = append(u.changes, bu)
+	w := &EngineWarning{Move: m, Kind: SelfCaptureGroup, Captured: captured}
+	if len(captured) == 1 {
+		w.Kind = SelfCapturePass
+		e.passes++
+	} else {
+		e.passes = 0
+	}
+	e.warnings = append(e.warnings, w)
+	e.toPlay = m.Color().Opposite()
+	return captured, nil
+}
+
+// ApplyNode applies the placements and the move of a node. If the node is a
+// resignation, the game ends after the node is applied. If the node can't be
+// applied, the engine is left unchanged.
+func (e *GameEngine) ApplyNode(n *Node) error {
+	if e.IsOver() {
+		return fmt.Errorf("%w: can't apply move %d", ErrGameOver, n.MoveNum())
+	}
+	u := e.saveState()
+	if len(n.Placements) > 0 {
+		_, bu, err := applyPlacementsWithUndo(e.board, n)
+		if err != nil {
+			return fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+		}
+		u.changes = append(u.changes, bu)
+	}
+	if n.Move != nil && n.Move.Color() != color.Empty {
+		if _, err := e.apply(n.Move, u); err != nil {
+			e.restore(u)
+			return fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+		}
+	}
+	if n.IsResign() {
+		e.resigned = true
+	}
+	e.history = append(e.history, u)
+	return nil
+}
+
+// Undo reverts the last move or node that was applied with Apply or
+// ApplyNode, restoring the board (including the captured stones and the ko),
+// the player to play, and whether the game is over. Moves can be undone back
+// to the start of the game.
+func (e *GameEngine) Undo() error {
+	if len(e.history) == 0 {
+		return ErrUndo
+	}
+	u := e.history[len(e.history)-1]
+	e.history = e.history[:len(e.history)-1]
+	e.restore(u)
+	return nil
+}
+
+// saveState records the current state, for undoing the next change.
+func (e *GameEngine) saveState() *engineUndo {
+	return &engineUndo{
+		toPlay:   e.toPlay,
+		passes:   e.passes,
+		resigned: e.resigned,
+		warnings: len(e.warnings),
+	}
+}
+
+// restore reverts the changes recorded in u.
+func (e *GameEngine) restore(u *engineUndo) {
+	for i := len(u.changes) - 1; i >= 0; i-- {
+		e.board.Revert(u.changes[i])
+	}
+	e.toPlay = u.toPlay
+	e.passes = u.passes
+	e.resigned = u.resigned
+	e.warnings = e.warnings[:u.warnings]
+}
+
+// SuccessorPositions returns the Zobrist hash (see board.Board.Hash) of the
+// position after each legal move by color c, keyed by the point of the move.
+// Illegal moves and passes are excluded. The hashes are computed
+// incrementally, and the board is left unchanged.
+func (e *GameEngine) SuccessorPositions(c color.Color) map[point.Point]uint64 {
+	b := e.board
+	h := b.Hash()
+	occupied := make(map[point.Point]bool)
+	for _, m := range b.StoneState() {
+		occupied[*m.Point()] = true
+	}
+	out := make(map[point.Point]uint64)
+	for y := 0; y < b.Height(); y++ {
+		for x := 0; x < b.Width(); x++ {
+			pt := point.New(x, y)
+			if occupied[*pt] {
+				continue
+			}
+			_, u, err := b.PlaceStoneWithUndo(move.New(c, pt))
+			if err != nil {
+				continue
+			}
+			out[*pt] = b.UpdateHash(h, u)
+			b.Revert(u)
+		}
+	}
+	return out
+}
@@ -0,0 +1,294 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestGameEngine_EndDetection(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		// resign marks the node at the path as a resignation.
+		resign      string
+		path        string
+		expOver     bool
+		expResigned bool
+	}{
+		{
+			desc:    "pass-pass ends the game",
+			sgf:     "(;GM[1]SZ[5];B[aa];W[];B[])",
+			path:    "0x3",
+			expOver: true,
+		},
+		{
+			desc: "a single pass doesn't end the game",
+			sgf:  "(;GM[1]SZ[5];B[aa];W[];B[bb];W[])",
+			path: "0x4",
+		},
+		{
+			desc:        "resignation from RE",
+			sgf:         "(;GM[1]SZ[5]RE[W+R];B[aa];W[bb])",
+			path:        "0x2",
+			expOver:     true,
+			expResigned: true,
+		},
+		{
+			desc: "RE doesn't apply to variations",
+			sgf:  "(;GM[1]SZ[5]RE[W+Resign](;B[aa];W[bb])(;B[cc]))",
+			path: "1",
+		},
+		{
+			desc:        "explicit resignation",
+			sgf:         "(;GM[1]SZ[5](;B[aa];W[bb])(;B[cc]))",
+			resign:      "1",
+			path:        "1",
+			expOver:     true,
+			expResigned: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.resign != "" {
+				tp, err := movetree.ParsePath(tc.resign)
+				if err != nil {
+					t.Fatal(err)
+				}
+				tp.Apply(g.Root).SetResign(true)
+			}
+			tp, err := movetree.ParsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			e, err := g.NewGameEngine()
+			if err != nil {
+				t.Fatal(err)
+			}
+			n := g.Root
+			for _, v := range tp {
+				n = n.Next(v)
+				if e.IsOver() {
+					t.Fatalf("game was over before move %d", n.MoveNum())
+				}
+				if err := e.ApplyNode(n); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if e.IsOver() != tc.expOver {
+				t.Errorf("IsOver()=%v, but expected %v", e.IsOver(), tc.expOver)
+			}
+			if e.IsResigned() != tc.expResigned {
+				t.Errorf("IsResigned()=%v, but expected %v", e.IsResigned(), tc.expResigned)
+			}
+			if n.IsResign() != tc.expResigned {
+				t.Errorf("node IsResign()=%v, but expected %v", n.IsResign(), tc.expResigned)
+			}
+
+			_, err = e.Apply(move.New(e.ToPlay(), point.New(4, 4)))
+			if tc.expOver && !errors.Is(err, movetree.ErrGameOver) {
+				t.Errorf("Apply after the end returned error %v, but expected %v", err, movetree.ErrGameOver)
+			} else if !tc.expOver && err != nil {
+				t.Errorf("Apply returned unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestGameEngine_Pass(t *testing.T) {
+	e := movetree.NewGameEngine(5)
+	before := e.Board().String()
+	if _, err := e.Apply(move.NewPass(color.Black)); err != nil {
+		t.Fatal(err)
+	}
+	if got := e.Board().String(); got != before {
+		t.Errorf("pass changed the board to\n%s", got)
+	}
+	if e.ToPlay() != color.White {
+		t.Errorf("after black passes, ToPlay()=%v, but expected %v", e.ToPlay(), color.White)
+	}
+}
+
+func TestGameEngine_SuccessorPositions(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[5]AB[ba][ab]AW[bb][ca][cb][ac];B[dd])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := g.NewGameEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.ApplyNode(g.Root.Next(0)); err != nil {
+		t.Fatal(err)
+	}
+	before := e.Board().Clone()
+
+	got := e.SuccessorPositions(color.White)
+	// Every empty point is legal for white.
+	if len(got) != 18 {
+		t.Errorf("SuccessorPositions() got %d moves, but expected 18", len(got))
+	}
+	if !e.Board().Equal(before) {
+		t.Errorf("SuccessorPositions() changed the board to\n%v, but expected\n%v", e.Board(), before)
+	}
+
+	// White captures the black stones in the corner.
+	pt := point.New(0, 0)
+	exp := before.Clone()
+	if _, err := exp.PlaceStone(move.New(color.White, pt)); err != nil {
+		t.Fatal(err)
+	}
+	if h, ok := got[*pt]; !ok || h != exp.Hash() {
+		t.Errorf("SuccessorPositions()[%v]=%x, %v, but expected %x, true", pt, h, ok, exp.Hash())
+	}
+
+	// For black, the corner is suicide.
+	if _, ok := e.SuccessorPositions(color.Black)[*pt]; ok {
+		t.Errorf("SuccessorPositions() for black got the suicidal move %v, but expected it to be excluded", pt)
+	}
+}
+
+func BenchmarkGameEngine_SuccessorPositions(b *testing.B) {
+	g := longGame(b, 150)
+	e, err := g.NewGameEngine()
+	if err != nil {
+		b.Fatal(err)
+	}
+	for n := g.Root.Next(0); n != nil; n = n.Next(0) {
+		if err := e.ApplyNode(n); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.SuccessorPositions(e.ToPlay())
+	}
+}
+
+func TestGameEngine_SelfCapture(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		sgf      string
+		opts     *movetree.EngineOptions
+		expErr   error
+		expKind  movetree.SelfCaptureKind
+		expBoard string
+	}{
+		{
+			desc:   "self-capture without the option",
+			sgf:    "(;GM[1]SZ[3]AW[ba][ab];B[aa])",
+			expErr: board.ErrSuicide,
+		},
+		{
+			desc:    "single stone self-capture",
+			sgf:     "(;GM[1]SZ[3]AW[ba][ab];B[aa])",
+			opts:    &movetree.EngineOptions{AllowSelfCapture: true},
+			expKind: movetree.SelfCapturePass,
+			expBoard: "[. W .]\n" +
+				"[W . .]\n" +
+				"[. . .]",
+		},
+		{
+			desc:    "group self-capture",
+			sgf:     "(;GM[1]SZ[3]AB[ba]AW[ca][bb][ab];B[aa])",
+			opts:    &movetree.EngineOptions{AllowSelfCapture: true},
+			expKind: movetree.SelfCaptureGroup,
+			expBoard: "[. . W]\n" +
+				"[W W .]\n" +
+				"[. . .]",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			e, err := g.NewGameEngine()
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = e.WithOptions(tc.opts).ApplyNode(g.Root.Next(0))
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("ApplyNode() got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if w := e.Warnings(); len(w) != 1 || w[0].Kind != tc.expKind {
+				t.Errorf("Warnings()=%v, but expected one %s warning", w, tc.expKind)
+			}
+			if got := e.Board().String(); got != tc.expBoard {
+				t.Errorf("Board()=\n%s\nbut expected\n%s", got, tc.expBoard)
+			}
+			if e.ToPlay() != color.White {
+				t.Errorf("ToPlay()=%v, but expected white", e.ToPlay())
+			}
+		})
+	}
+}
+
+func TestGameEngine_Undo(t *testing.T) {
+	// White captures the black stone at ba, leaving a ko.
+	g, err := sgf.Parse("(;GM[1]SZ[5]AB[ba][ab]AW[ca][bb];W[aa];B[ee];W[];B[dd])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := g.NewGameEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	type state struct {
+		board  string
+		hash   uint64
+		ko     string
+		toPlay color.Color
+		over   bool
+	}
+	save := func() state {
+		ko := ""
+		if pt := e.Board().Ko(); pt != nil {
+			ko = pt.String()
+		}
+		return state{e.Board().String(), e.Board().Hash(), ko, e.ToPlay(), e.IsOver()}
+	}
+
+	var states []state
+	for n := g.Root.Next(0); n != nil; n = n.Next(0) {
+		states = append(states, save())
+		if err := e.ApplyNode(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, m := range []*move.Move{move.NewPass(color.White), move.NewPass(color.Black)} {
+		states = append(states, save())
+		if _, err := e.Apply(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !e.IsOver() {
+		t.Fatalf("IsOver()=false after two passes, but expected true")
+	}
+
+	for i := len(states) - 1; i >= 0; i-- {
+		if err := e.Undo(); err != nil {
+			t.Fatal(err)
+		}
+		if got := save(); got != states[i] {
+			t.Errorf("after undoing to move %d, got state %+v, but expected %+v", i, got, states[i])
+		}
+	}
+	if err := e.Undo(); !errors.Is(err, movetree.ErrUndo) {
+		t.Errorf("Undo() at the start of the game got error %v, but expected %v", err, movetree.ErrUndo)
+	}
+}
@@ -0,0 +1,80 @@
+package movetree
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SuggestedFilename returns a conventional filename for the game, made from
+// the date, the players, and the event. For example:
+//
+//	2016-03-09_Lee-Sedol_vs_AlphaGo_Google-DeepMind-Challenge.sgf
+//
+// Missing fields are skipped, except that a missing player is named
+// "unknown". If all of the fields are missing, the filename is unknown.sgf.
+// Characters that are illegal in filenames are removed. To avoid collisions
+// with other files, use UniqueFilename.
+func (gi *GameInfo) SuggestedFilename() string {
+	var parts []string
+	if gi != nil {
+		if date := sanitizeFilename(strings.SplitN(gi.Date, ",", 2)[0]); date != "" {
+			parts = append(parts, date)
+		}
+		black, white := sanitizeFilename(gi.BlackPlayer), sanitizeFilename(gi.WhitePlayer)
+		if black != "" || white != "" {
+			if black == "" {
+				black = "unknown"
+			}
+			if white == "" {
+				white = "unknown"
+			}
+			parts = append(parts, black+"_vs_"+white)
+		}
+		if event := sanitizeFilename(gi.Event); event != "" {
+			parts = append(parts, event)
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "unknown")
+	}
+	return strings.Join(parts, "_") + ".sgf"
+}
+
+// UniqueFilename returns the filename, or if it's already taken, the filename
+// with a short discriminator (ex: game-2.sgf). The returned filename is added
+// to the taken filenames.
+func UniqueFilename(name string, taken map[string]bool) string {
+	base, ext := name, ""
+	if i := strings.LastIndex(name, "."); i > 0 {
+		base, ext = name[:i], name[i:]
+	}
+	out := name
+	for i := 2; taken[out]; i++ {
+		out = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+	taken[out] = true
+	return out
+}
+
+// sanitizeFilename makes text safe to use in a filename: whitespace becomes
+// '-', and characters that are illegal in filenames on common filesystems are
+// removed.
+func sanitizeFilename(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case unicode.IsSpace(r), r == '_':
+			sb.WriteRune('-')
+		case unicode.IsControl(r), strings.ContainsRune(`/\:*?"<>|`, r):
+			// Illegal on at least one common filesystem.
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	out := sb.String()
+	for strings.Contains(out, "--") {
+		out = strings.Replace(out, "--", "-", -1)
+	}
+	return strings.Trim(out, "-.")
+}
@@ -0,0 +1,69 @@
+package movetree
+
+import (
+	"testing"
+)
+
+func TestSuggestedFilename(t *testing.T) {
+	testCases := []struct {
+		desc string
+		gi   *GameInfo
+		exp  string
+	}{
+		{
+			desc: "full header",
+			gi: &GameInfo{
+				Date:        "2016-03-09",
+				BlackPlayer: "Lee Sedol",
+				WhitePlayer: "AlphaGo",
+				Event:       "Google DeepMind Challenge",
+			},
+			exp: "2016-03-09_Lee-Sedol_vs_AlphaGo_Google-DeepMind-Challenge.sgf",
+		},
+		{
+			desc: "only a date",
+			gi:   &GameInfo{Date: "1846-09-11"},
+			exp:  "1846-09-11.sgf",
+		},
+		{
+			desc: "date range and a missing player",
+			gi:   &GameInfo{Date: "1996-05-06,07", WhitePlayer: "Honinbo Shusaku"},
+			exp:  "1996-05-06_unknown_vs_Honinbo-Shusaku.sgf",
+		},
+		{
+			desc: "illegal characters",
+			gi:   &GameInfo{BlackPlayer: "a/b\\c: d?", WhitePlayer: "<w>\t_x"},
+			exp:  "abc-d_vs_w-x.sgf",
+		},
+		{
+			desc: "empty",
+			gi:   &GameInfo{},
+			exp:  "unknown.sgf",
+		},
+		{
+			desc: "nil",
+			exp:  "unknown.sgf",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.gi.SuggestedFilename(); got != tc.exp {
+				t.Errorf("SuggestedFilename()=%q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestUniqueFilename(t *testing.T) {
+	taken := make(map[string]bool)
+	var got []string
+	for i := 0; i < 3; i++ {
+		got = append(got, UniqueFilename("game.sgf", taken))
+	}
+	exp := []string{"game.sgf", "game-2.sgf", "game-3.sgf"}
+	for i := range exp {
+		if got[i] != exp[i] {
+			t.Errorf("UniqueFilename() call %d returned %q, but expected %q", i, got[i], exp[i])
+		}
+	}
+}
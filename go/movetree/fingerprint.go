@@ -0,0 +1,95 @@
+package movetree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"strconv"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+)
+
+// Fingerprint returns a hash of the game, for deduplicating archives. It's
+// computed from the sequence of board positions along the main line, along
+// with the board size and the komi.
+//
+// The fingerprint is invariant under the eight symmetries of the board (or
+// the four of a rectangular board), so a game recorded in another orientation
+// has the same fingerprint. It's also
+// invariant under swapping the colors of all the stones while negating the
+// komi. Other properties, such as the players and comments, are ignored.
+// The positions are canonicalized by taking the smallest encoding of the
+// sequence over all the transformations, so every position of the game is
+// transformed the same way.
+//
+// If the main line contains an illegal move, only the positions before it
+// are used.
+func (mt *MoveTree) Fingerprint() [32]byte {
+	var boards [][][]color.Color
+	if p, err := mt.NewPlayback(); err == nil {
+		boards = append(boards, p.Board().FullBoardState())
+		for p.Forward() == nil {
+			boards = append(boards, p.Board().FullBoardState())
+		}
+	}
+	var komi float64
+	if gi := mt.Root.GameInfo; gi != nil && gi.Komi != nil {
+		komi = *gi.Komi
+	}
+
+	width, height := mt.boardDimensions()
+	var canonical []byte
+	for _, swap := range []bool{false, true} {
+		for s := board.Symmetry(0); s < board.NumSymmetries; s++ {
+			if !s.Preserves(width, height) {
+				continue
+			}
+			enc := encodePositions(boards, width, height, komi, s, swap)
+			if canonical == nil || bytes.Compare(enc, canonical) < 0 {
+				canonical = enc
+			}
+		}
+	}
+	return sha256.Sum256(canonical)
+}
+
+// encodePositions encodes the board size, the komi, and the sequence of boards
+// of the given width and height, transformed by symmetry s and, if swap is
+// set, with the colors swapped and the komi negated.
+func encodePositions(boards [][][]color.Color, width, height int, komi float64, s board.Symmetry, swap bool) []byte {
+	if swap {
+		komi = -komi
+	}
+	if komi == 0 {
+		// Avoid encoding negative zero.
+		komi = 0
+	}
+	var buf bytes.Buffer
+	buf.WriteString(strconv.Itoa(width))
+	if height != width {
+		buf.WriteString(":" + strconv.Itoa(height))
+	}
+	buf.WriteString(":" + strconv.FormatFloat(komi, 'f', -1, 64))
+	cells := make([]byte, width*height)
+	for _, b := range boards {
+		for y, row := range b {
+			for x, c := range row {
+				tx, ty := s.TransformRect(x, y, width, height)
+				if swap {
+					c = c.Opposite()
+				}
+				switch c {
+				case color.Black:
+					cells[ty*width+tx] = 'X'
+				case color.White:
+					cells[ty*width+tx] = 'O'
+				default:
+					cells[ty*width+tx] = '.'
+				}
+			}
+		}
+		buf.WriteByte(';')
+		buf.Write(cells)
+	}
+	return buf.Bytes()
+}
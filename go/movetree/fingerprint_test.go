@@ -0,0 +1,68 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestFingerprint(t *testing.T) {
+	game := "(;GM[1]SZ[9]KM[6.5]AB[cc];W[gc];B[ge];W[ef])"
+	testCases := []struct {
+		desc    string
+		other   string
+		expSame bool
+	}{
+		{
+			desc:    "same game, other metadata",
+			other:   "(;GM[1]SZ[9]KM[6.5]PB[Someone]AB[cc];W[gc]C[Hi];B[ge];W[ef])",
+			expSame: true,
+		},
+		{
+			desc:    "rotated by 90 degrees",
+			other:   "(;GM[1]SZ[9]KM[6.5]AB[gc];W[gg];B[eg];W[de])",
+			expSame: true,
+		},
+		{
+			desc:    "reflected",
+			other:   "(;GM[1]SZ[9]KM[6.5]AB[gc];W[cc];B[ce];W[ef])",
+			expSame: true,
+		},
+		{
+			desc:    "colors swapped and komi negated",
+			other:   "(;GM[1]SZ[9]KM[-6.5]AW[cc];B[gc];W[ge];B[ef])",
+			expSame: true,
+		},
+		{
+			desc:  "colors swapped without negating the komi",
+			other: "(;GM[1]SZ[9]KM[6.5]AW[cc];B[gc];W[ge];B[ef])",
+		},
+		{
+			desc:  "different komi",
+			other: "(;GM[1]SZ[9]KM[0.5]AB[cc];W[gc];B[ge];W[ef])",
+		},
+		{
+			desc:  "different game",
+			other: "(;GM[1]SZ[9]KM[6.5]AB[cc];W[gc];B[ge];W[eg])",
+		},
+		{
+			desc:  "same moves, different order",
+			other: "(;GM[1]SZ[9]KM[6.5]AB[cc];W[ef];B[ge];W[gc])",
+		},
+	}
+	g, err := sgf.Parse(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			other, err := sgf.Parse(tc.other)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if same := g.Fingerprint() == other.Fingerprint(); same != tc.expSame {
+				t.Errorf("got equal fingerprints %v, but expected %v", same, tc.expSame)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package movetree
+
+import (
+	"github.com/otrego/clamshell/go/color"
+)
+
+// EndReason is the way the main line of a game record ends.
+type EndReason string
+
+const (
+	// EndNone indicates that the record doesn't end the game, such as an
+	// unfinished game or a problem.
+	EndNone EndReason = ""
+
+	// EndPasses indicates that the game ended with two consecutive passes.
+	EndPasses EndReason = "passes"
+
+	// EndResign indicates that the game ended by resignation.
+	EndResign EndReason = "resign"
+)
+
+// GameEnd is the terminal state of the main line of a game.
+type GameEnd struct {
+	// Node is the final node of the main line.
+	Node *Node
+
+	Reason EndReason
+
+	// Winner is the winner according to the result (RE), if any.
+	Winner color.Color
+
+	// Resigned is the player who resigned, for EndResign.
+	Resigned color.Color
+}
+
+// GameEnd returns the terminal state of the main line. Many records of
+// resigned games end without a terminal move, with only the result
+// (ex: RE[B+Resign]), so the resignation is synthesized from the result (see
+// Node.IsResign). If the main line ends with two passes, the passes take
+// precedence over a resignation in the result; Lint reports the mismatch.
+func (mt *MoveTree) GameEnd() *GameEnd {
+	n := mt.Root
+	for n.Next(0) != nil {
+		n = n.Next(0)
+	}
+	end := &GameEnd{Node: n}
+	if gi := mt.Root.GameInfo; gi != nil && gi.Result != nil {
+		end.Winner = gi.Result.Winner
+	}
+	switch {
+	case endsWithPasses(n):
+		end.Reason = EndPasses
+	case n.IsResign():
+		end.Reason = EndResign
+		// If the winner is unknown, the player to move is the one who
+		// resigned.
+		end.Resigned = mt.PlayerToMove(n)
+		if end.Winner != color.Empty {
+			end.Resigned = end.Winner.Opposite()
+		}
+	}
+	return end
+}
+
+// endsWithPasses indicates whether node n and its parent are both passes.
+func endsWithPasses(n *Node) bool {
+	return n.IsPass() && n.Parent != nil && n.Parent.IsPass()
+}
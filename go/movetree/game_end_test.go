@@ -0,0 +1,90 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestGameEnd(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		sgf         string
+		expReason   movetree.EndReason
+		expWinner   color.Color
+		expResigned color.Color
+		expLint     bool
+	}{
+		{
+			desc:        "resignation only in RE",
+			sgf:         "(;GM[1]SZ[9]RE[B+Resign];B[ee];W[cc];B[gg];W[ff])",
+			expReason:   movetree.EndResign,
+			expWinner:   color.Black,
+			expResigned: color.White,
+		},
+		{
+			desc:      "two passes",
+			sgf:       "(;GM[1]SZ[9]RE[W+2.5];B[ee];W[cc];B[];W[])",
+			expReason: movetree.EndPasses,
+			expWinner: color.White,
+		},
+		{
+			desc:      "two passes take precedence over a resignation",
+			sgf:       "(;GM[1]SZ[9]RE[B+R];B[ee];W[cc];B[];W[])",
+			expReason: movetree.EndPasses,
+			expWinner: color.Black,
+			expLint:   true,
+		},
+		{
+			desc: "unfinished",
+			sgf:  "(;GM[1]SZ[9];B[ee];W[])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			end := g.GameEnd()
+			if end.Reason != tc.expReason {
+				t.Errorf("GameEnd().Reason=%q, but expected %q", end.Reason, tc.expReason)
+			}
+			if end.Winner != tc.expWinner {
+				t.Errorf("GameEnd().Winner=%v, but expected %v", end.Winner, tc.expWinner)
+			}
+			if end.Resigned != tc.expResigned {
+				t.Errorf("GameEnd().Resigned=%v, but expected %v", end.Resigned, tc.expResigned)
+			}
+
+			// The engine should reach the same end state.
+			e, err := g.NewGameEngine()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for n := g.Root.Next(0); n != nil; n = n.Next(0) {
+				if err := e.ApplyNode(n); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if over := tc.expReason != movetree.EndNone; e.IsOver() != over {
+				t.Errorf("engine IsOver()=%v, but expected %v", e.IsOver(), over)
+			}
+			if resigned := tc.expReason == movetree.EndResign; e.IsResigned() != resigned {
+				t.Errorf("engine IsResigned()=%v, but expected %v", e.IsResigned(), resigned)
+			}
+
+			var lint bool
+			for _, li := range g.Lint() {
+				if li.Code == movetree.LintResult {
+					lint = true
+				}
+			}
+			if lint != tc.expLint {
+				t.Errorf("Lint() reported a result issue: %v, but expected %v", lint, tc.expLint)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package movetree
+
+import (
+	"strconv"
+)
+
+// FieldDiff is a game-info field that's different in two game infos.
+type FieldDiff struct {
+	// Prop is the SGF property of the field (ex: RE).
+	Prop string
+
+	// A and B are the values of the field in each game info, in the SGF
+	// format. The value is empty if the field is unspecified.
+	A, B string
+}
+
+// Diff returns the game-info fields that are different in the other game info,
+// in the order of the fields of GameInfo. This helps to tell whether two SGFs
+// are the same game with different metadata.
+//
+// Fields are compared by meaning rather than by their SGF values where
+// possible: W+R and W+Resign are the same result, 1996-05-06,07 and
+// 1996-05-06,1996-05-07 are the same dates, and an unspecified size is the
+// same as 19.
+func (gi *GameInfo) Diff(other *GameInfo) []FieldDiff {
+	if gi == nil {
+		gi = &GameInfo{}
+	}
+	if other == nil {
+		other = &GameInfo{}
+	}
+	var diffs []FieldDiff
+	add := func(prop string, same bool, a, b string) {
+		if !same {
+			diffs = append(diffs, FieldDiff{Prop: prop, A: a, B: b})
+		}
+	}
+
+	add("SZ", sizeOrDefault(gi) == sizeOrDefault(other), sizeString(gi), sizeString(other))
+	add("KM", (gi.Komi == nil) == (other.Komi == nil) && (gi.Komi == nil || *gi.Komi == *other.Komi),
+		komiString(gi.Komi), komiString(other.Komi))
+	add("HA", gi.Handicap == other.Handicap, handicapString(gi.Handicap), handicapString(other.Handicap))
+	add("RU", gi.Rules == other.Rules, string(gi.Rules), string(other.Rules))
+	add("PL", gi.Player == other.Player, string(gi.Player), string(other.Player))
+	add("AP", applicationString(gi.Application) == applicationString(other.Application), applicationString(gi.Application), applicationString(other.Application))
+	add("RE", resultString(gi.Result) == resultString(other.Result), resultString(gi.Result), resultString(other.Result))
+	add("PB", gi.BlackPlayer == other.BlackPlayer, gi.BlackPlayer, other.BlackPlayer)
+	add("PW", gi.WhitePlayer == other.WhitePlayer, gi.WhitePlayer, other.WhitePlayer)
+	add("BR", gi.BlackRank == other.BlackRank, gi.BlackRank, other.BlackRank)
+	add("WR", gi.WhiteRank == other.WhiteRank, gi.WhiteRank, other.WhiteRank)
+	add("DT", sameDates(gi.Date, other.Date), gi.Date, other.Date)
+	add("EV", gi.Event == other.Event, gi.Event, other.Event)
+	add("TM", (gi.MainTime == nil) == (other.MainTime == nil) && (gi.MainTime == nil || *gi.MainTime == *other.MainTime),
+		mainTimeString(gi.MainTime), mainTimeString(other.MainTime))
+	add("OT", gi.Overtime == other.Overtime, gi.Overtime, other.Overtime)
+	add("BT", gi.BlackTeam == other.BlackTeam, gi.BlackTeam, other.BlackTeam)
+	add("WT", gi.WhiteTeam == other.WhiteTeam, gi.WhiteTeam, other.WhiteTeam)
+	add("GN", gi.GameName == other.GameName, gi.GameName, other.GameName)
+	add("GC", gi.GameComment == other.GameComment, gi.GameComment, other.GameComment)
+	add("PC", gi.Place == other.Place, gi.Place, other.Place)
+	add("RO", gi.Round == other.Round, gi.Round, other.Round)
+	add("ON", gi.Opening == other.Opening, gi.Opening, other.Opening)
+	add("AN", gi.Annotator == other.Annotator, gi.Annotator, other.Annotator)
+	add("SO", gi.Source == other.Source, gi.Source, other.Source)
+	add("US", gi.User == other.User, gi.User, other.User)
+	add("CP", gi.Copyright == other.Copyright, gi.Copyright, other.Copyright)
+	return diffs
+}
+
+// sizeOrDefault returns the dimensions of the board, defaulting to 19x19 if
+// unspecified.
+func sizeOrDefault(gi *GameInfo) [2]int {
+	if gi.Size == 0 {
+		return [2]int{19, 19}
+	}
+	w, h := gi.Dimensions()
+	return [2]int{w, h}
+}
+
+// sizeString returns the SGF value of the size, or "" if unspecified.
+func sizeString(gi *GameInfo) string {
+	if gi.Size == 0 {
+		return ""
+	}
+	if w, h := gi.Dimensions(); w != h {
+		return strconv.Itoa(w) + ":" + strconv.Itoa(h)
+	}
+	return strconv.Itoa(gi.Size)
+}
+
+// handicapString returns the SGF value of the handicap, or "" if there's no
+// handicap.
+func handicapString(handicap int) string {
+	if handicap == 0 {
+		return ""
+	}
+	return strconv.Itoa(handicap)
+}
+
+// komiString returns the SGF value of the komi, or "" if unspecified.
+func komiString(komi *float64) string {
+	if komi == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*komi, 'f', -1, 64)
+}
+
+// mainTimeString returns the SGF value of the main time, or "" if
+// unspecified.
+func mainTimeString(tm *float64) string {
+	if tm == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*tm, 'f', -1, 64)
+}
+
+// applicationString returns the SGF value of the application, or "" if
+// unspecified.
+func applicationString(app *Application) string {
+	if app == nil {
+		return ""
+	}
+	if app.Version == "" {
+		return app.Name
+	}
+	return app.Name + ":" + app.Version
+}
+
+// resultString returns the canonical SGF value of the result, or "" if
+// unspecified.
+func resultString(res *Result) string {
+	if res == nil {
+		return ""
+	}
+	return res.String()
+}
+
+// sameDates indicates whether two dates (DT) are the same. Dates that can't be
+// parsed are compared as they are.
+func sameDates(a, b string) bool {
+	if a == b {
+		return true
+	}
+	da, erra := ParseDates(a)
+	db, errb := ParseDates(b)
+	if a == "" || b == "" || erra != nil || errb != nil || len(da) != len(db) {
+		return false
+	}
+	for i := range da {
+		if da[i] != db[i] {
+			return false
+		}
+	}
+	return true
+}
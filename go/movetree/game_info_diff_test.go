@@ -0,0 +1,76 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestGameInfo_Diff(t *testing.T) {
+	testCases := []struct {
+		desc string
+		a, b string
+		exp  []movetree.FieldDiff
+	}{
+		{
+			desc: "same game info",
+			a:    "(;GM[1]PB[Lee Sedol]PW[AlphaGo]RE[W+R]KM[7.5])",
+			b:    "(;GM[1]PB[Lee Sedol]PW[AlphaGo]RE[W+R]KM[7.5])",
+		},
+		{
+			desc: "different result, same players",
+			a:    "(;GM[1]PB[Lee Sedol]PW[AlphaGo]RE[W+R])",
+			b:    "(;GM[1]PB[Lee Sedol]PW[AlphaGo]RE[B+3.5])",
+			exp:  []movetree.FieldDiff{{Prop: "RE", A: "W+R", B: "B+3.5"}},
+		},
+		{
+			desc: "same result, written differently",
+			a:    "(;GM[1]RE[W+R])",
+			b:    "(;GM[1]RE[W+Resign])",
+		},
+		{
+			desc: "same dates, written differently",
+			a:    "(;GM[1]DT[1996-05-06,07])",
+			b:    "(;GM[1]DT[1996-05-06,1996-05-07])",
+		},
+		{
+			desc: "unspecified size",
+			a:    "(;GM[1])",
+			b:    "(;GM[1]SZ[19])",
+		},
+		{
+			desc: "rectangular size",
+			a:    "(;GM[1]SZ[19:9])",
+			b:    "(;GM[1]SZ[19])",
+			exp:  []movetree.FieldDiff{{Prop: "SZ", A: "19:9", B: "19"}},
+		},
+		{
+			desc: "missing fields",
+			a:    "(;GM[1]KM[6.5]EV[Honinbo])",
+			b:    "(;GM[1]DT[1996])",
+			exp: []movetree.FieldDiff{
+				{Prop: "KM", A: "6.5"},
+				{Prop: "DT", B: "1996"},
+				{Prop: "EV", A: "Honinbo"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, err := sgf.Parse(tc.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := sgf.Parse(tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := a.Root.GameInfo.Diff(b.Root.GameInfo)
+			if !cmp.Equal(got, tc.exp) {
+				t.Errorf("Diff()=%v, but expected %v. Diff=%s", got, tc.exp, cmp.Diff(got, tc.exp))
+			}
+		})
+	}
+}
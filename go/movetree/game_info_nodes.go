@@ -0,0 +1,248 @@
+package movetree
+
+import (
+	"github.com/otrego/clamshell/go/rules"
+)
+
+// gameInfoProps are the root and game-info properties of the SGF
+// specification, which may only appear on the root.
+var gameInfoProps = map[string]bool{
+	"AP": true, "CA": true, "FF": true, "GM": true, "ST": true, "SZ": true,
+	"AN": true, "BR": true, "BT": true, "CP": true, "DT": true, "EV": true,
+	"GC": true, "GN": true, "HA": true, "KM": true, "ON": true, "OT": true,
+	"PB": true, "PC": true, "PW": true, "RE": true, "RO": true, "RU": true,
+	"SO": true, "TM": true, "US": true, "WR": true, "WT": true,
+}
+
+// IsGameInfoProp indicates whether the property is a root or game-info
+// property in the SGF specification, which may only appear on the root.
+func IsGameInfoProp(p string) bool {
+	return gameInfoProps[p]
+}
+
+// hasGameInfo indicates whether the node carries game info, either as a
+// GameInfo or as raw game-info properties.
+func (n *Node) hasGameInfo() bool {
+	if n.GameInfo != nil {
+		return true
+	}
+	for p := range n.SGFProperties {
+		if gameInfoProps[p] {
+			return true
+		}
+	}
+	return false
+}
+
+// GameInfoNodes returns the nodes that carry game info, in depth-first order.
+// In a well-formed movetree, that's only the root, but edited files sometimes
+// duplicate the game info onto other nodes, such as the starts of variations.
+// Lint reports the game info of non-root nodes (see LintScope), and Normalize
+// consolidates it into the root.
+func (mt *MoveTree) GameInfoNodes() []*Node {
+	var nodes []*Node
+	var visit func(n *Node)
+	visit = func(n *Node) {
+		if n.hasGameInfo() {
+			nodes = append(nodes, n)
+		}
+		for _, c := range n.Children {
+			visit(c)
+		}
+	}
+	visit(mt.Root)
+	return nodes
+}
+
+// GameInfo returns the game info of the movetree. If several nodes have game
+// info (see GameInfoNodes), the root's game info wins; otherwise the first
+// node with game info, in depth-first order, wins. Returns nil if no node has
+// game info.
+func (mt *MoveTree) GameInfo() *GameInfo {
+	if mt.Root.GameInfo != nil {
+		return mt.Root.GameInfo
+	}
+	for _, n := range mt.GameInfoNodes() {
+		if n.GameInfo != nil {
+			return n.GameInfo
+		}
+	}
+	return nil
+}
+
+// consolidateGameInfo moves the game info of non-root nodes to the root, for
+// Normalize. A field is only moved if the root doesn't have it yet, so the
+// root's game info takes precedence, followed by the game info of the other
+// nodes in depth-first order.
+func (mt *MoveTree) consolidateGameInfo() {
+	root := mt.Root
+	for _, n := range mt.GameInfoNodes() {
+		if n == root {
+			continue
+		}
+		if n.GameInfo != nil {
+			if root.GameInfo == nil {
+				root.GameInfo = &GameInfo{}
+			}
+			root.GameInfo.fill(n.GameInfo, root.SGFProperties)
+			n.GameInfo = nil
+		}
+		for p, v := range n.SGFProperties {
+			if !gameInfoProps[p] {
+				continue
+			}
+			if _, ok := root.SGFProperties[p]; !ok && !root.GameInfo.has(p) {
+				root.SGFProperties[p] = v
+			}
+			delete(n.SGFProperties, p)
+		}
+	}
+}
+
+// fill sets the unspecified fields of the game info from another game info.
+// Fields with raw properties (ex: kept raw when parsing leniently) are
+// skipped, since they're already specified.
+func (gi *GameInfo) fill(other *GameInfo, raw map[string][]string) {
+	other = other.copy()
+	if gi.Size == 0 && raw["SZ"] == nil {
+		gi.Size, gi.Width, gi.Height = other.Size, other.Width, other.Height
+	}
+	if gi.Komi == nil && raw["KM"] == nil {
+		gi.Komi = other.Komi
+	}
+	if gi.Handicap == 0 && raw["HA"] == nil {
+		gi.Handicap = other.Handicap
+	}
+	if gi.Rules == rules.Unspecified && raw["RU"] == nil {
+		gi.Rules = other.Rules
+	}
+	if gi.Player == "" && raw["PL"] == nil {
+		gi.Player = other.Player
+	}
+	if gi.Application == nil && raw["AP"] == nil {
+		gi.Application = other.Application
+	}
+	if gi.Result == nil && raw["RE"] == nil {
+		gi.Result = other.Result
+	}
+	if gi.BlackPlayer == "" && raw["PB"] == nil {
+		gi.BlackPlayer = other.BlackPlayer
+	}
+	if gi.WhitePlayer == "" && raw["PW"] == nil {
+		gi.WhitePlayer = other.WhitePlayer
+	}
+	if gi.BlackRank == "" && raw["BR"] == nil {
+		gi.BlackRank = other.BlackRank
+	}
+	if gi.WhiteRank == "" && raw["WR"] == nil {
+		gi.WhiteRank = other.WhiteRank
+	}
+	if gi.Date == "" && raw["DT"] == nil {
+		gi.Date = other.Date
+	}
+	if gi.Event == "" && raw["EV"] == nil {
+		gi.Event = other.Event
+	}
+	if gi.MainTime == nil && raw["TM"] == nil {
+		gi.MainTime = other.MainTime
+	}
+	if gi.Overtime == "" && raw["OT"] == nil {
+		gi.Overtime = other.Overtime
+	}
+	if gi.BlackTeam == "" && raw["BT"] == nil {
+		gi.BlackTeam = other.BlackTeam
+	}
+	if gi.WhiteTeam == "" && raw["WT"] == nil {
+		gi.WhiteTeam = other.WhiteTeam
+	}
+	if gi.GameName == "" && raw["GN"] == nil {
+		gi.GameName = other.GameName
+	}
+	if gi.GameComment == "" && raw["GC"] == nil {
+		gi.GameComment = other.GameComment
+	}
+	if gi.Place == "" && raw["PC"] == nil {
+		gi.Place = other.Place
+	}
+	if gi.Round == "" && raw["RO"] == nil {
+		gi.Round = other.Round
+	}
+	if gi.Opening == "" && raw["ON"] == nil {
+		gi.Opening = other.Opening
+	}
+	if gi.Annotator == "" && raw["AN"] == nil {
+		gi.Annotator = other.Annotator
+	}
+	if gi.Source == "" && raw["SO"] == nil {
+		gi.Source = other.Source
+	}
+	if gi.User == "" && raw["US"] == nil {
+		gi.User = other.User
+	}
+	if gi.Copyright == "" && raw["CP"] == nil {
+		gi.Copyright = other.Copyright
+	}
+}
+
+// has indicates whether the game info has a value for the field stored from
+// the SGF property p.
+func (gi *GameInfo) has(p string) bool {
+	if gi == nil {
+		return false
+	}
+	switch p {
+	case "SZ":
+		return gi.Size != 0
+	case "KM":
+		return gi.Komi != nil
+	case "HA":
+		return gi.Handicap != 0
+	case "RU":
+		return gi.Rules != rules.Unspecified
+	case "PL":
+		return gi.Player != ""
+	case "AP":
+		return gi.Application != nil
+	case "RE":
+		return gi.Result != nil
+	case "PB":
+		return gi.BlackPlayer != ""
+	case "PW":
+		return gi.WhitePlayer != ""
+	case "BR":
+		return gi.BlackRank != ""
+	case "WR":
+		return gi.WhiteRank != ""
+	case "DT":
+		return gi.Date != ""
+	case "EV":
+		return gi.Event != ""
+	case "TM":
+		return gi.MainTime != nil
+	case "OT":
+		return gi.Overtime != ""
+	case "BT":
+		return gi.BlackTeam != ""
+	case "WT":
+		return gi.WhiteTeam != ""
+	case "GN":
+		return gi.GameName != ""
+	case "GC":
+		return gi.GameComment != ""
+	case "PC":
+		return gi.Place != ""
+	case "RO":
+		return gi.Round != ""
+	case "ON":
+		return gi.Opening != ""
+	case "AN":
+		return gi.Annotator != ""
+	case "SO":
+		return gi.Source != ""
+	case "US":
+		return gi.User != ""
+	case "CP":
+		return gi.Copyright != ""
+	}
+	return false
+}
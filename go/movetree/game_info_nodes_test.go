@@ -0,0 +1,59 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestGameInfoNodes(t *testing.T) {
+	p := sgf.FromString("(;GM[1]PB[Lee Sedol];B[aa](;W[bb]PW[AlphaGo]DT[2016-03-09])(;W[cc]PW[Fan Hui]EV[Match]))").
+		WithOptions(&prop.ParseOptions{Lenient: true})
+	g, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Replace the raw game info of the second branch with a GameInfo, as if
+	// it had been added programmatically.
+	n := g.Root.Children[0].Children[1]
+	delete(n.SGFProperties, "PW")
+	delete(n.SGFProperties, "EV")
+	n.GameInfo = &movetree.GameInfo{WhitePlayer: "Fan Hui", Event: "Match"}
+
+	var paths []string
+	for _, n := range g.GameInfoNodes() {
+		paths = append(paths, movetree.PathTo(n).CompactString())
+	}
+	if exp := []string{"-", "-0x2", "-0-1"}; !cmp.Equal(paths, exp) {
+		t.Errorf("GameInfoNodes() got nodes at %v, but expected %v", paths, exp)
+	}
+	if got := g.GameInfo(); got != g.Root.GameInfo {
+		t.Errorf("GameInfo()=%v, but expected the root's game info %v", got, g.Root.GameInfo)
+	}
+
+	scope := 0
+	for _, li := range g.Lint() {
+		if li.Code == movetree.LintScope {
+			scope++
+		}
+	}
+	if scope != 3 {
+		t.Errorf("Lint() got %d scope issues, but expected 3", scope)
+	}
+
+	g.Normalize()
+	if nodes := g.GameInfoNodes(); len(nodes) != 1 || nodes[0] != g.Root {
+		t.Errorf("GameInfoNodes() after Normalize got %d nodes, but expected only the root", len(nodes))
+	}
+	got, err := sgf.Serialize(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "(;FF[4]GM[1]CA[UTF-8]SZ[19]PB[Lee Sedol]PW[AlphaGo]DT[2016-03-09]EV[Match];B[aa](;W[bb])(;W[cc]))"
+	if got != exp {
+		t.Errorf("Serialize() after Normalize=%q, but expected %q", got, exp)
+	}
+}
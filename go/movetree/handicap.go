@@ -0,0 +1,232 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ErrHandicap indicates that the handicap (HA) is invalid.
+var ErrHandicap = errors.New("invalid handicap")
+
+// maxHandicap is the largest handicap with a standard placement.
+const maxHandicap = 9
+
+// handicapSizes are the board sizes with standard handicap placements.
+var handicapSizes = map[int]bool{9: true, 13: true, 19: true}
+
+// ValidateHandicap checks that the handicap (HA), if present, has a standard
+// placement: the board must be 9x9, 13x13, or 19x19, and the handicap must be
+// between 2 and 9. HA[0] indicates no handicap and is valid on any board.
+func (mt *MoveTree) ValidateHandicap() error {
+	count := mt.Handicap()
+	if count == 0 {
+		return nil
+	}
+	size := mt.boardSize()
+	if !handicapSizes[size] {
+		return fmt.Errorf("%w: HA[%d] on a %dx%d board, but standard handicap placements only exist for 9x9, 13x13, and 19x19 boards",
+			ErrHandicap, count, size, size)
+	}
+	if count < 2 || count > maxHandicap {
+		return fmt.Errorf("%w: HA[%d] on a %dx%d board, but the handicap must be between 2 and %d",
+			ErrHandicap, count, size, size, maxHandicap)
+	}
+	return nil
+}
+
+// Handicap returns the handicap (HA) of the game, or 0 if there's no
+// handicap.
+func (mt *MoveTree) Handicap() int {
+	if gi := mt.Root.GameInfo; gi != nil {
+		return gi.Handicap
+	}
+	return 0
+}
+
+// HandicapPoints returns the standard (fixed) placement of count handicap
+// stones on a size x size board, or nil if there's no standard placement. The
+// stones are placed on the star points: the corners first, starting with the
+// top-right and bottom-left corners, then the sides, with the center stone
+// added for odd handicaps of 5 or more.
+func HandicapPoints(size, count int) []*point.Point {
+	stars := starPoints[size]
+	if stars == nil || count < 2 || count > maxHandicap {
+		return nil
+	}
+	lo, mid, hi := stars[0], stars[1], stars[2]
+	pts := []*point.Point{
+		point.New(hi, lo), point.New(lo, hi), point.New(hi, hi), point.New(lo, lo),
+	}
+	if count < 4 {
+		return pts[:count]
+	}
+	if count >= 6 {
+		pts = append(pts, point.New(lo, mid), point.New(hi, mid))
+	}
+	if count >= 8 {
+		pts = append(pts, point.New(mid, lo), point.New(mid, hi))
+	}
+	if count%2 == 1 {
+		pts = append(pts, point.New(mid, mid))
+	}
+	return pts
+}
+
+// InferHandicap returns the handicap for placements that are exactly a
+// standard placement of handicap stones on a size x size board (see
+// HandicapPoints): only black stones, on exactly the standard points. ok is
+// false otherwise.
+func InferHandicap(placements move.List, size int) (count int, ok bool) {
+	pts := HandicapPoints(size, len(placements))
+	if pts == nil {
+		return 0, false
+	}
+	exp, seen := &move.PointSet{}, &move.PointSet{}
+	exp.Add(pts...)
+	for _, m := range placements {
+		if m.Color() != color.Black || !exp.Contains(m.Point()) || seen.Contains(m.Point()) {
+			return 0, false
+		}
+		seen.Add(m.Point())
+	}
+	return len(placements), true
+}
+
+// SetHandicap sets up a handicap game with count stones in the standard
+// placement (see HandicapPoints): the handicap (HA) is set, and the stones are
+// placed on the root, replacing any black stones already placed there. It's an
+// error if the game already has moves, or if the board has no standard
+// placement for the handicap.
+func (mt *MoveTree) SetHandicap(count int) error {
+	root := mt.Root
+	if root.Move != nil || len(root.Children) > 0 {
+		return fmt.Errorf("%w: can't set the handicap of a game with moves", ErrHandicap)
+	}
+	if gi := root.GameInfo; gi != nil {
+		if w, h := gi.Dimensions(); w != h {
+			return fmt.Errorf("%w: there's no standard handicap placement on a %dx%d board", ErrHandicap, w, h)
+		}
+	}
+	size := mt.boardSize()
+	pts := HandicapPoints(size, count)
+	if pts == nil {
+		return fmt.Errorf("%w: there's no standard placement of %d handicap stones on a %dx%d board",
+			ErrHandicap, count, size, size)
+	}
+
+	var kept move.List
+	for _, m := range root.Placements {
+		if m.Color() != color.Black {
+			kept = append(kept, m)
+		}
+	}
+	for _, pt := range pts {
+		kept = append(kept, move.New(color.Black, pt))
+	}
+	root.Placements = kept
+	if root.GameInfo == nil {
+		root.GameInfo = &GameInfo{}
+	}
+	root.GameInfo.Handicap = count
+	return nil
+}
+
+// NormalizeHandicap normalizes how the handicap stones of the game are
+// recorded, returning whether the movetree was changed:
+//
+//   - Free-placement handicap stones that were recorded as black moves at the
+//     start of the game, optionally separated by white passes (ex:
+//     ";B[pd];W[];B[dp]"), are placed on the root instead, and the handicap
+//     (HA) is set to their number. If HA is already set, it must match the
+//     number of moves. The moves must not have comments or other
+//     properties, and the root must not have black stones already.
+//   - If HA is missing, but the root has black stones in a standard handicap
+//     placement (see InferHandicap) and white moves first, HA is set.
+func (mt *MoveTree) NormalizeHandicap() bool {
+	if mt.placeHandicapMoves() {
+		return true
+	}
+	return mt.inferMissingHandicap()
+}
+
+// placeHandicapMoves places the black moves at the start of the game on the
+// root, if they're free-placement handicap stones (see NormalizeHandicap).
+func (mt *MoveTree) placeHandicapMoves() bool {
+	root := mt.Root
+	if root.Move != nil {
+		return false
+	}
+	for _, m := range root.Placements {
+		if m.Color() == color.Black {
+			return false
+		}
+	}
+
+	var stones move.List
+	var last *Node
+	seen := &move.PointSet{}
+	for cur := root; len(cur.Children) == 1; {
+		c := cur.Children[0]
+		if c.Move == nil || c.hasContent() || c.GameInfo != nil {
+			break
+		}
+		if c.Move.Color() == color.Black && !c.IsPass() && !seen.Contains(c.Move.Point()) {
+			stones = append(stones, c.Move)
+			seen.Add(c.Move.Point())
+			last = c
+		} else if c.Move.Color() != color.White || !c.IsPass() {
+			break
+		}
+		cur = c
+	}
+	if len(stones) < 2 || (mt.Handicap() != 0 && mt.Handicap() != len(stones)) {
+		return false
+	}
+	// A handicap game continues with a white move.
+	for _, c := range last.Children {
+		if c.Move == nil || c.Move.Color() != color.White {
+			return false
+		}
+	}
+
+	root.Placements = append(root.Placements, stones...)
+	root.Children = nil
+	for _, c := range last.Children {
+		c.Parent = root
+		root.AddChild(c)
+	}
+	if root.GameInfo == nil {
+		root.GameInfo = &GameInfo{}
+	}
+	root.GameInfo.Handicap = len(stones)
+	return true
+}
+
+// inferMissingHandicap sets the handicap if it's missing, but the root has a
+// standard placement of handicap stones and white moves first.
+func (mt *MoveTree) inferMissingHandicap() bool {
+	root := mt.Root
+	if mt.Handicap() != 0 || root.Move != nil {
+		return false
+	}
+	count, ok := InferHandicap(root.Placements, mt.boardSize())
+	if !ok {
+		return false
+	}
+	first := root.Next(0)
+	for first != nil && first.Move == nil {
+		first = first.Next(0)
+	}
+	if first == nil || first.Move.Color() != color.White {
+		return false
+	}
+	if root.GameInfo == nil {
+		root.GameInfo = &GameInfo{}
+	}
+	root.GameInfo.Handicap = count
+	return true
+}
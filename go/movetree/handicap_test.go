@@ -0,0 +1,272 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestValidateHandicap(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		sgf    string
+		expErr error
+	}{
+		{
+			desc: "no handicap",
+			sgf:  "(;GM[1]SZ[7])",
+		},
+		{
+			desc: "zero handicap, any board",
+			sgf:  "(;GM[1]SZ[7]HA[0])",
+		},
+		{
+			desc: "9 stones, 19x19",
+			sgf:  "(;GM[1]SZ[19]HA[9])",
+		},
+		{
+			desc: "2 stones, default size",
+			sgf:  "(;GM[1]HA[2])",
+		},
+		{
+			desc: "5 stones, 13x13",
+			sgf:  "(;GM[1]SZ[13]HA[5])",
+		},
+		{
+			desc: "4 stones, 9x9",
+			sgf:  "(;GM[1]SZ[9]HA[4])",
+		},
+		{
+			desc:   "9 stones, 7x7",
+			sgf:    "(;GM[1]SZ[7]HA[9])",
+			expErr: movetree.ErrHandicap,
+		},
+		{
+			desc:   "10 stones, 19x19",
+			sgf:    "(;GM[1]SZ[19]HA[10])",
+			expErr: movetree.ErrHandicap,
+		},
+		{
+			desc:   "1 stone",
+			sgf:    "(;GM[1]SZ[19]HA[1])",
+			expErr: movetree.ErrHandicap,
+		},
+		{
+			desc:   "not a number",
+			sgf:    "(;GM[1]SZ[19]HA[zork])",
+			expErr: movetree.ErrHandicap,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				// Malformed handicaps are already rejected by the converter.
+				if tc.expErr == nil || !errors.Is(err, tc.expErr) {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err := g.ValidateHandicap(); !errors.Is(err, tc.expErr) {
+				t.Errorf("ValidateHandicap()=%v, but expected %v", err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestInferHandicap(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		sgf      string
+		expCount int
+		expOK    bool
+	}{
+		{
+			desc:     "two stones, 19x19",
+			sgf:      "(;GM[1]SZ[19]AB[pd][dp])",
+			expCount: 2,
+			expOK:    true,
+		},
+		{
+			desc:     "five stones, 9x9",
+			sgf:      "(;GM[1]SZ[9]AB[gc][cg][gg][cc][ee])",
+			expCount: 5,
+			expOK:    true,
+		},
+		{
+			desc:     "six stones, 13x13",
+			sgf:      "(;GM[1]SZ[13]AB[jd][dj][jj][dd][dg][jg])",
+			expCount: 6,
+			expOK:    true,
+		},
+		{
+			desc:     "nine stones, 19x19",
+			sgf:      "(;GM[1]SZ[19]AB[dd][jd][pd][dj][jj][pj][dp][jp][pp])",
+			expCount: 9,
+			expOK:    true,
+		},
+		{
+			desc: "two stones in the wrong corners",
+			sgf:  "(;GM[1]SZ[19]AB[dd][pp])",
+		},
+		{
+			desc: "five stones without the center",
+			sgf:  "(;GM[1]SZ[19]AB[pd][dp][pp][dd][jd])",
+		},
+		{
+			desc: "white stones too",
+			sgf:  "(;GM[1]SZ[19]AB[pd][dp]AW[dd])",
+		},
+		{
+			desc: "one stone",
+			sgf:  "(;GM[1]SZ[19]AB[pd])",
+		},
+		{
+			desc: "non-standard board",
+			sgf:  "(;GM[1]SZ[11]AB[hc][ch])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			count, ok := movetree.InferHandicap(g.Root.Placements, g.Root.GameInfo.Size)
+			if count != tc.expCount || ok != tc.expOK {
+				t.Errorf("InferHandicap()=%d, %v, but expected %d, %v", count, ok, tc.expCount, tc.expOK)
+			}
+		})
+	}
+}
+
+func TestSetHandicap(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		sgf    string
+		count  int
+		exp    string
+		expErr error
+	}{
+		{
+			desc:  "3 stones, 9x9",
+			sgf:   "(;GM[1]SZ[9])",
+			count: 3,
+			exp:   "(;FF[4]GM[1]CA[UTF-8]SZ[9]HA[3]AB[cg][gc][gg])",
+		},
+		{
+			desc:  "replaces black stones",
+			sgf:   "(;GM[1]SZ[9]HA[2]AB[aa][bb]AW[cc])",
+			count: 2,
+			exp:   "(;FF[4]GM[1]CA[UTF-8]SZ[9]HA[2]AB[cg][gc]AW[cc])",
+		},
+		{
+			desc:   "no standard placement",
+			sgf:    "(;GM[1]SZ[7])",
+			count:  2,
+			expErr: movetree.ErrHandicap,
+		},
+		{
+			desc:   "too many stones",
+			sgf:    "(;GM[1]SZ[19])",
+			count:  10,
+			expErr: movetree.ErrHandicap,
+		},
+		{
+			desc:   "game with moves",
+			sgf:    "(;GM[1]SZ[19];B[pd])",
+			count:  2,
+			expErr: movetree.ErrHandicap,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = g.SetHandicap(tc.count)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("SetHandicap(%d) got error %v, but expected %v", tc.count, err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.exp {
+				t.Errorf("after SetHandicap(%d), got %s, but expected %s", tc.count, got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestNormalizeHandicap(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		sgf        string
+		expChanged bool
+		exp        string
+	}{
+		{
+			desc:       "free placement as moves",
+			sgf:        "(;GM[1]SZ[9]HA[2];B[cc];B[gg];W[ee];B[ce])",
+			expChanged: true,
+			exp:        "(;FF[4]GM[1]CA[UTF-8]SZ[9]HA[2]AB[cc][gg];W[ee];B[ce])",
+		},
+		{
+			desc:       "free placement with white passes, missing handicap",
+			sgf:        "(;GM[1]SZ[9];B[cc];W[];B[gg];W[];B[cg](;W[ee])(;W[dd]))",
+			expChanged: true,
+			exp:        "(;FF[4]GM[1]CA[UTF-8]SZ[9]HA[3]AB[cc][cg][gg](;W[ee])(;W[dd]))",
+		},
+		{
+			desc: "handicap doesn't match",
+			sgf:  "(;GM[1]SZ[9]HA[3];B[cc];B[gg];W[ee])",
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9]HA[3];B[cc];B[gg];W[ee])",
+		},
+		{
+			desc: "commented move",
+			sgf:  "(;GM[1]SZ[9];B[cc];B[gg]C[Oops];W[ee])",
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[cc];B[gg]C[Oops];W[ee])",
+		},
+		{
+			desc: "variations",
+			sgf:  "(;GM[1]SZ[9](;B[cc];B[gg];W[ee])(;B[dd]))",
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9](;B[cc];B[gg];W[ee])(;B[dd]))",
+		},
+		{
+			desc:       "missing handicap, standard placement",
+			sgf:        "(;GM[1]SZ[19]AB[pd][dp];W[qp])",
+			expChanged: true,
+			exp:        "(;FF[4]GM[1]CA[UTF-8]SZ[19]HA[2]AB[dp][pd];W[qp])",
+		},
+		{
+			desc: "problem setup",
+			sgf:  "(;GM[1]SZ[19]AB[pd][dp];B[qp])",
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19]AB[dp][pd];B[qp])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed := g.NormalizeHandicap(); changed != tc.expChanged {
+				t.Errorf("NormalizeHandicap()=%v, but expected %v", changed, tc.expChanged)
+			}
+			got, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.exp {
+				t.Errorf("after NormalizeHandicap(), got %q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
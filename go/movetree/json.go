@@ -0,0 +1,239 @@
+package movetree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ErrJSON indicates that a JSON-encoded movetree could not be decoded.
+var ErrJSON = errors.New("error decoding JSON movetree")
+
+// jsonNode is the JSON encoding of a node and its children. The field names
+// are part of the format, and mustn't change.
+type jsonNode struct {
+	Move               *jsonMove           `json:"move,omitempty"`
+	Placements         []jsonMove          `json:"placements,omitempty"`
+	Comment            string              `json:"comment,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	MoveAnnotation     *MoveAnnotation     `json:"moveAnnotation,omitempty"`
+	PositionAnnotation *PositionAnnotation `json:"positionAnnotation,omitempty"`
+	Hotspot            int                 `json:"hotspot,omitempty"`
+	Value              *float64            `json:"value,omitempty"`
+	Marks              []PointMark         `json:"marks,omitempty"`
+	Labels             []PointLabel        `json:"labels,omitempty"`
+	Arrows             []Line              `json:"arrows,omitempty"`
+	Lines              []Line              `json:"lines,omitempty"`
+	TimeLeft           map[string]float64  `json:"timeLeft,omitempty"`
+	OvertimeLeft       map[string]int      `json:"overtimeLeft,omitempty"`
+	Analysis           *Analysis           `json:"analysis,omitempty"`
+	GameInfo           *GameInfo           `json:"gameInfo,omitempty"`
+	Resign             bool                `json:"resign,omitempty"`
+	Properties         map[string][]string `json:"properties,omitempty"`
+	Children           []*jsonNode         `json:"children,omitempty"`
+}
+
+// jsonMove is the JSON encoding of a move or a placement. Passes don't have a
+// point.
+type jsonMove struct {
+	Color color.Color  `json:"color"`
+	Point *point.Point `json:"point,omitempty"`
+}
+
+// MarshalJSON encodes the movetree as JSON, for web front-ends that don't
+// parse SGF. The movetree is encoded as its root node (see Node.MarshalJSON).
+func (mt *MoveTree) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONNode(mt.Root))
+}
+
+// UnmarshalJSON decodes a movetree encoded with MarshalJSON, replacing the
+// contents of the movetree.
+func (mt *MoveTree) UnmarshalJSON(data []byte) error {
+	root := NewNode()
+	if err := root.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	mt.Root = root
+	return nil
+}
+
+// MarshalJSON encodes the node and its descendants as JSON. Like the binary
+// encoding (see MoveTree.MarshalBinary), the encoding is lossless, including
+// the raw SGF properties, except for the analysis data attached with
+// SetAnalysisData. For example, a node with a move and a comment is encoded
+// as:
+//
+//	{"move":{"color":"B","point":{"x":15,"y":3}},"comment":"Hi","children":[...]}
+//
+// Points are x and y coordinates from the top-left corner, starting at 0. The
+// marks and labels are sorted by x and then by y, so the encoding is
+// deterministic.
+func (n *Node) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONNode(n))
+}
+
+// UnmarshalJSON decodes a node encoded with MarshalJSON, replacing the contents
+// of the node. The node becomes the root of the decoded nodes: its parent is
+// left unchanged, and the move numbers of the children follow on from its
+// move number.
+func (n *Node) UnmarshalJSON(data []byte) error {
+	jn := &jsonNode{}
+	if err := json.Unmarshal(data, jn); err != nil {
+		return fmt.Errorf("%w: %v", ErrJSON, err)
+	}
+	parent, moveNum, varNum := n.Parent, n.moveNum, n.varNum
+	out, err := fromJSONNode(jn, nil)
+	if err != nil {
+		return err
+	}
+	*n = *out
+	n.Parent, n.varNum = parent, varNum
+	for _, c := range n.Children {
+		c.Parent = n
+	}
+	n.setMoveNum(moveNum)
+	return nil
+}
+
+// toJSONNode converts the node and its descendants to their JSON encoding.
+func toJSONNode(n *Node) *jsonNode {
+	jn := &jsonNode{
+		Comment:            n.Comment,
+		Name:               n.Name,
+		MoveAnnotation:     n.MoveAnnotation,
+		PositionAnnotation: n.PositionAnnotation,
+		Hotspot:            n.Hotspot,
+		Value:              n.Value,
+		Arrows:             n.Arrows,
+		Lines:              n.Lines,
+		Analysis:           n.Analysis,
+		GameInfo:           n.GameInfo,
+		Resign:             n.resign,
+	}
+	if m := n.Move; m != nil {
+		jn.Move = &jsonMove{Color: m.Color(), Point: m.Point()}
+	}
+	for _, m := range n.Placements {
+		jn.Placements = append(jn.Placements, jsonMove{Color: m.Color(), Point: m.Point()})
+	}
+	for pt, typ := range n.Marks {
+		jn.Marks = append(jn.Marks, PointMark{X: pt.X(), Y: pt.Y(), Type: typ})
+	}
+	sort.Slice(jn.Marks, func(i, j int) bool {
+		return pointLess(jn.Marks[i].X, jn.Marks[i].Y, jn.Marks[j].X, jn.Marks[j].Y)
+	})
+	for pt, text := range n.Labels {
+		jn.Labels = append(jn.Labels, PointLabel{X: pt.X(), Y: pt.Y(), Text: text})
+	}
+	sort.Slice(jn.Labels, func(i, j int) bool {
+		return pointLess(jn.Labels[i].X, jn.Labels[i].Y, jn.Labels[j].X, jn.Labels[j].Y)
+	})
+	if n.TimeLeft != nil {
+		jn.TimeLeft = make(map[string]float64)
+		for c, t := range n.TimeLeft {
+			jn.TimeLeft[string(c)] = t
+		}
+	}
+	if n.OvertimeLeft != nil {
+		jn.OvertimeLeft = make(map[string]int)
+		for c, o := range n.OvertimeLeft {
+			jn.OvertimeLeft[string(c)] = o
+		}
+	}
+	if len(n.SGFProperties) > 0 {
+		jn.Properties = n.SGFProperties
+	}
+	for _, c := range n.Children {
+		jn.Children = append(jn.Children, toJSONNode(c))
+	}
+	return jn
+}
+
+// fromJSONNode converts the JSON encoding of a node and its descendants to a
+// node, adding it to its parent (if any).
+func fromJSONNode(jn *jsonNode, parent *Node) (*Node, error) {
+	n := NewNode()
+	if jm := jn.Move; jm != nil {
+		m, err := jm.move()
+		if err != nil {
+			return nil, err
+		}
+		n.Move = m
+	}
+	for _, jm := range jn.Placements {
+		if jm.Point == nil {
+			return nil, fmt.Errorf("%w: placement of color %q is missing its point", ErrJSON, jm.Color)
+		}
+		m, err := jm.move()
+		if err != nil {
+			return nil, err
+		}
+		n.Placements = append(n.Placements, m)
+	}
+	n.Comment = jn.Comment
+	n.Name = jn.Name
+	n.MoveAnnotation = jn.MoveAnnotation
+	n.PositionAnnotation = jn.PositionAnnotation
+	n.Hotspot = jn.Hotspot
+	n.Value = jn.Value
+	for _, m := range jn.Marks {
+		if n.Marks == nil {
+			n.Marks = make(map[point.Point]MarkType)
+		}
+		n.Marks[*point.New(m.X, m.Y)] = m.Type
+	}
+	for _, l := range jn.Labels {
+		if n.Labels == nil {
+			n.Labels = make(map[point.Point]string)
+		}
+		n.Labels[*point.New(l.X, l.Y)] = l.Text
+	}
+	n.Arrows, n.Lines = jn.Arrows, jn.Lines
+	if jn.TimeLeft != nil {
+		n.TimeLeft = make(map[color.Color]float64)
+		for c, t := range jn.TimeLeft {
+			n.TimeLeft[color.Color(c)] = t
+		}
+	}
+	if jn.OvertimeLeft != nil {
+		n.OvertimeLeft = make(map[color.Color]int)
+		for c, o := range jn.OvertimeLeft {
+			n.OvertimeLeft[color.Color(c)] = o
+		}
+	}
+	n.Analysis = jn.Analysis
+	n.GameInfo = jn.GameInfo
+	n.resign = jn.Resign
+	for k, v := range jn.Properties {
+		n.SGFProperties[k] = v
+	}
+
+	if parent != nil {
+		n.Parent = parent
+		parent.AddChild(n)
+	}
+	for _, jc := range jn.Children {
+		if _, err := fromJSONNode(jc, n); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// move converts the JSON encoding of a move to a move.
+func (jm *jsonMove) move() (*move.Move, error) {
+	switch jm.Color {
+	case color.Black, color.White, color.Empty:
+	default:
+		return nil, fmt.Errorf("%w: unknown color %q", ErrJSON, jm.Color)
+	}
+	if jm.Point == nil {
+		return move.NewPass(jm.Color), nil
+	}
+	return move.New(jm.Color, jm.Point), nil
+}
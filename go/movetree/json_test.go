@@ -0,0 +1,106 @@
+package movetree_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	g, err := sgf.Parse(binaryTestSGF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	winRate := 0.61
+	g.Root.Next(0).Analysis = &movetree.Analysis{WinRate: &winRate}
+	mainLineEnd(g).SetResign(true)
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &movetree.MoveTree{}
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatal(err)
+	}
+
+	expSGF, err := sgf.Serialize(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotSGF, err := sgf.Serialize(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotSGF != expSGF {
+		t.Errorf("got decoded SGF\n%s\nbut expected\n%s", gotSGF, expSGF)
+	}
+	if an := got.Root.Next(0).Analysis; an == nil || an.WinRate == nil || *an.WinRate != winRate || an.ScoreLead != nil {
+		t.Errorf("got analysis %v, but expected a win rate of %v", an, winRate)
+	}
+	if !mainLineEnd(got).IsResign() {
+		t.Errorf("got no resignation at the end of the main line")
+	}
+	if n := mainLineEnd(got); n.MoveNum() != 3 || n.Parent.Parent.Parent != got.Root {
+		t.Errorf("got the end of the main line at move %d, but expected the parents and move numbers to be restored", n.MoveNum())
+	}
+
+	// The encoding is deterministic.
+	again, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("got a different encoding after decoding and encoding again:\n%s\n%s", again, data)
+	}
+}
+
+func TestMarshalJSON_Format(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[9]PB[Lee]AB[cc];B[ee]C[Hi]TR[aa]LB[bb:A];W[])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(g.Root.Next(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"move":{"color":"B","point":{"x":4,"y":4}},"comment":"Hi",` +
+		`"marks":[{"x":0,"y":0,"type":"TR"}],"labels":[{"x":1,"y":1,"text":"A"}],` +
+		`"children":[{"move":{"color":"W"}}]}`
+	if string(got) != exp {
+		t.Errorf("got JSON\n%s\nbut expected\n%s", got, exp)
+	}
+
+	root, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"gameInfo":{"size":9,"blackPlayer":"Lee"}`, `"placements":[{"color":"B","point":{"x":2,"y":2}}]`} {
+		if !strings.Contains(string(root), want) {
+			t.Errorf("got JSON %s, but expected it to contain %s", root, want)
+		}
+	}
+}
+
+func TestUnmarshalJSON_Errors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data string
+	}{
+		{desc: "not JSON", data: "(;GM[1])"},
+		{desc: "bad color", data: `{"children":[{"move":{"color":"X"}}]}`},
+		{desc: "placement without a point", data: `{"placements":[{"color":"B"}]}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			mt := movetree.New()
+			if err := mt.UnmarshalJSON([]byte(tc.data)); !errors.Is(err, movetree.ErrJSON) {
+				t.Errorf("got error %v, but expected %v", err, movetree.ErrJSON)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/rules"
+)
+
+// MoveError is an illegal move or placement, found by ValidateMoves. It
+// wraps the error from the board, so it matches board.IllegalMove (or a more
+// specific error, such as board.ErrSuperko) with errors.Is.
+type MoveError struct {
+	// Path is the path to the node with the move.
+	Path Path
+
+	// Move is the illegal move. It's nil if the placements of the node are
+	// illegal.
+	Move *move.Move
+
+	// Err is the error from the board.
+	Err error
+}
+
+// Error returns the error message.
+func (e *MoveError) Error() string {
+	if e.Move == nil {
+		return fmt.Sprintf("at %s: illegal placements: %v", e.Path.CompactString(), e.Err)
+	}
+	return fmt.Sprintf("at %s: %v", e.Path.CompactString(), e.Err)
+}
+
+// Unwrap returns the error from the board.
+func (e *MoveError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateMoves replays every variation of the movetree and checks that the
+// moves are legal under ruleset rs: a move can't be played on an occupied
+// point or off the board, retake a ko, or capture its own group unless the
+// ruleset allows it. Under rulesets with positional superko (see
+// rules.Ruleset.Superko), a move also can't repeat an earlier position of its
+// variation. If rs is unspecified, the ruleset from the RU property is used.
+//
+// The illegal moves are returned as *MoveErrors, in depth-first order. Since
+// the position after an illegal move is undefined, the nodes below it aren't
+// checked.
+func (mt *MoveTree) ValidateMoves(rs rules.Ruleset) []error {
+	if rs == rules.Unspecified && mt.Root.GameInfo != nil {
+		rs = mt.Root.GameInfo.Rules
+	}
+	b := mt.newBoard()
+	if rs.Superko() {
+		b.TrackSuperko()
+	}
+
+	var errs []error
+	var visit func(n *Node, tp Path)
+	visit = func(n *Node, tp Path) {
+		if len(n.Placements) > 0 {
+			_, u, err := applyPlacementsWithUndo(b, n)
+			if err != nil {
+				errs = append(errs, &MoveError{Path: tp, Err: err})
+				return
+			}
+			defer b.Revert(u)
+		}
+		if n.Move != nil && !n.Move.IsPass() && n.Move.Color() != color.Empty {
+			_, u, err := b.PlaceStoneWithUndo(n.Move)
+			if errors.Is(err, board.ErrSuicide) && rs.AllowsSuicide() {
+				_, u, err = b.PlaceSelfCaptureWithUndo(n.Move)
+			}
+			if err != nil {
+				errs = append(errs, &MoveError{Path: tp, Move: n.Move, Err: err})
+				return
+			}
+			defer b.Revert(u)
+		}
+		for i, c := range n.Children {
+			visit(c, append(tp.Clone(), i))
+		}
+	}
+	visit(mt.Root, Path{})
+	return errs
+}
@@ -0,0 +1,110 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/rules"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestValidateMoves(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		rs   rules.Ruleset
+
+		// clear indicates that a node that clears the board is added to the
+		// end of the main line, followed by black's first move again, which
+		// repeats the position after the first move.
+		clear bool
+
+		expPaths []string
+		expErr   error
+	}{
+		{
+			desc: "legal moves",
+			sgf:  "(;GM[1]SZ[5];B[aa];W[bb];B[];W[ab])",
+		},
+		{
+			desc:     "occupied points in every variation",
+			sgf:      "(;GM[1]SZ[5];B[aa](;W[aa];B[cc])(;W[bb];B[bb]))",
+			expPaths: []string{"-0x2", "-0-1-0"},
+			expErr:   board.IllegalMove,
+		},
+		{
+			desc:     "suicide",
+			sgf:      "(;GM[1]SZ[5]RU[Japanese]AW[ba][ab];B[aa])",
+			expPaths: []string{"-0"},
+			expErr:   board.ErrSuicide,
+		},
+		{
+			desc: "suicide is allowed by the ruleset",
+			sgf:  "(;GM[1]SZ[5]RU[Japanese]AB[ab]AW[ba][bb][ac];B[aa])",
+			rs:   rules.NewZealand,
+		},
+		{
+			// A suicide of a single stone repeats the position.
+			desc:     "single-stone suicide repeats the position",
+			sgf:      "(;GM[1]SZ[5]AW[ba][ab];B[aa])",
+			rs:       rules.NewZealand,
+			expPaths: []string{"-0"},
+			expErr:   board.ErrSuperko,
+		},
+		{
+			desc:     "ko",
+			sgf:      "(;GM[1]SZ[5]RU[Japanese]AB[ba][ab][cb][bc]AW[ca][db][cc];W[bb];B[cb])",
+			expPaths: []string{"-0x2"},
+			expErr:   board.ErrKo,
+		},
+		{
+			desc:     "positional superko",
+			sgf:      "(;GM[1]SZ[5]RU[Chinese];B[aa];W[ee])",
+			clear:    true,
+			expPaths: []string{"-0x4"},
+			expErr:   board.ErrSuperko,
+		},
+		{
+			desc:  "no superko under japanese rules",
+			sgf:   "(;GM[1]SZ[5]RU[Japanese];B[aa];W[ee])",
+			clear: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.clear {
+				clear := movetree.NewNode()
+				for _, m := range g.MainLine()[1:] {
+					clear.Placements = append(clear.Placements, move.New(color.Empty, m.Move.Point()))
+				}
+				end := g.MainLine()[len(g.MainLine())-1]
+				clear.Parent = end
+				end.AddChild(clear)
+				clear.AddVariation(g.Root.Next(0).Move)
+			}
+			var paths []string
+			for _, err := range g.ValidateMoves(tc.rs) {
+				var me *movetree.MoveError
+				if !errors.As(err, &me) {
+					t.Fatalf("got error %v, but expected a *MoveError", err)
+				}
+				if !errors.Is(err, tc.expErr) {
+					t.Errorf("got error %v, but expected %v", err, tc.expErr)
+				}
+				paths = append(paths, me.Path.CompactString())
+			}
+			if diff := cmp.Diff(tc.expPaths, paths); diff != "" {
+				t.Errorf("ValidateMoves got paths diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
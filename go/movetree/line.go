@@ -0,0 +1,28 @@
+package movetree
+
+import (
+	"fmt"
+)
+
+// Line returns a new movetree with only the line that's reached by following
+// the variations of path tp from the root, such as to share a single line
+// of a review. The nodes of the line are copied with all of their
+// properties, including the comments and markup of the moves leading up to
+// the end of the line; the original movetree is left unmodified. It's an
+// error if the path doesn't match the shape of the movetree.
+func (mt *MoveTree) Line(tp Path) (*MoveTree, error) {
+	root := mt.Root.copyNode()
+	last, n := root, mt.Root
+	for i, v := range tp {
+		if v < 0 || v >= len(n.Children) {
+			return nil, fmt.Errorf("%w: variation %d of path %v doesn't exist at move %d, which has %d variations",
+				ErrApplyTreepath, v, tp.CompactString(), i, len(n.Children))
+		}
+		n = n.Children[v]
+		c := n.copyNode()
+		c.Parent = last
+		last.AddChild(c)
+		last = c
+	}
+	return &MoveTree{Root: root}, nil
+}
@@ -0,0 +1,304 @@
+package movetree
+
+import (
+	"fmt"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// LintSeverity is the severity of a lint issue.
+type LintSeverity string
+
+const (
+	// SeverityError indicates that the SGF breaks the SGF specification.
+	SeverityError LintSeverity = "error"
+
+	// SeverityWarning indicates that the SGF is valid, but is likely to be
+	// wrong or to be handled poorly by other applications.
+	SeverityWarning LintSeverity = "warning"
+
+	// SeverityHint indicates that the SGF may be missing information.
+	SeverityHint LintSeverity = "hint"
+)
+
+// LintCode is a stable code that identifies a kind of lint issue, so that
+// known issues can be allow-listed.
+type LintCode string
+
+const (
+	// LintScope indicates a root or game-info property on a non-root node.
+	LintScope LintCode = "scope"
+
+	// LintDuplicateProp indicates a property that appears twice in a node.
+	LintDuplicateProp LintCode = "duplicate-prop"
+
+	// LintOffBoard indicates a point that isn't on the board.
+	LintOffBoard LintCode = "off-board"
+
+	// LintKomi indicates a komi with an illegal decimal value.
+	LintKomi LintCode = "komi"
+
+	// LintHandicap indicates that the handicap (HA) doesn't match the handicap
+	// stones placed on the root.
+	LintHandicap LintCode = "handicap"
+
+	// LintHandicapPlayer indicates that the player to play (PL) is black in a
+	// handicap game, where white plays first.
+	LintHandicapPlayer LintCode = "handicap-player"
+
+	// LintMissingHandicap indicates that the root has handicap stones, but no
+	// handicap (HA).
+	LintMissingHandicap LintCode = "missing-handicap"
+
+	// LintRootMove indicates a move on the root, which is usually reserved
+	// for the game info and setup.
+	LintRootMove LintCode = "root-move"
+
+	// LintResult indicates a result (RE) that doesn't match the end of the
+	// main line, such as a resignation after two passes.
+	LintResult LintCode = "result"
+
+	// LintTwoMoves indicates a node with more than one move.
+	LintTwoMoves LintCode = "two-moves"
+
+	// LintUnknownProp indicates a property that isn't in the SGF
+	// specification.
+	LintUnknownProp LintCode = "unknown-prop"
+
+	// LintMalformed indicates a property value that is malformed.
+	LintMalformed LintCode = "malformed"
+)
+
+// LintIssue is a problem found in a movetree.
+type LintIssue struct {
+	Code     LintCode
+	Severity LintSeverity
+
+	// Path is the path to the node with the issue.
+	Path Path
+
+	// Prop is the property with the issue, if any.
+	Prop string
+
+	// Msg describes the issue.
+	Msg string
+}
+
+// String returns a description of the issue.
+func (li LintIssue) String() string {
+	prop := ""
+	if li.Prop != "" {
+		prop = " " + li.Prop
+	}
+	return fmt.Sprintf("%s [%s] at %s%s: %s", li.Severity, li.Code, li.Path.CompactString(), prop, li.Msg)
+}
+
+// A LintCheck checks a node of a movetree for issues. The node is at path tp.
+type LintCheck func(mt *MoveTree, n *Node, tp Path) []LintIssue
+
+// lintChecks are the registered lint checks, in the order they're run.
+var lintChecks = []LintCheck{
+	lintGameInfoScope,
+	lintOffBoard,
+	lintHandicap,
+	lintHandicapPlayer,
+	lintRootMove,
+	lintResult,
+}
+
+// RegisterLintCheck registers an additional check that's run by Lint.
+func RegisterLintCheck(c LintCheck) {
+	lintChecks = append(lintChecks, c)
+}
+
+// Lint runs every registered check over all of the nodes of the movetree and
+// returns the issues found, in depth-first order.
+//
+// Problems that make an SGF unparsable (such as two moves in one node) can't
+// be found in a movetree; sgf.Lint additionally reports those.
+func (mt *MoveTree) Lint() []LintIssue {
+	var issues []LintIssue
+	var visit func(n *Node, tp Path)
+	visit = func(n *Node, tp Path) {
+		for _, c := range lintChecks {
+			issues = append(issues, c(mt, n, tp)...)
+		}
+		for i, c := range n.Children {
+			visit(c, append(tp.Clone(), i))
+		}
+	}
+	visit(mt.Root, Path{})
+	return issues
+}
+
+// lintGameInfoScope checks that only the root has game info.
+func lintGameInfoScope(mt *MoveTree, n *Node, tp Path) []LintIssue {
+	if n == mt.Root || n.GameInfo == nil {
+		return nil
+	}
+	return []LintIssue{{
+		Code:     LintScope,
+		Severity: SeverityError,
+		Path:     tp,
+		Msg:      "game info on a non-root node",
+	}}
+}
+
+// lintOffBoard checks that the moves, placements, and markup are on the
+// board.
+func lintOffBoard(mt *MoveTree, n *Node, tp Path) []LintIssue {
+	width, height := mt.boardDimensions()
+	var issues []LintIssue
+	check := func(prop string, pts ...*point.Point) {
+		for _, pt := range pts {
+			if pt.X() < 0 || pt.Y() < 0 || pt.X() >= width || pt.Y() >= height {
+				issues = append(issues, LintIssue{
+					Code:     LintOffBoard,
+					Severity: SeverityError,
+					Path:     tp,
+					Prop:     prop,
+					Msg:      fmt.Sprintf("point %v is not on the %dx%d board", pt, width, height),
+				})
+			}
+		}
+	}
+	if n.Move != nil && !n.Move.IsPass() {
+		check(string(n.Move.Color()), n.Move.Point())
+	}
+	for _, m := range n.Placements {
+		check("A"+string(m.Color()), m.Point())
+	}
+	for _, e := range offBoardMarkup(n, width, height) {
+		check(e.Prop, e.Point)
+	}
+	return issues
+}
+
+// lintHandicap checks that the handicap (HA) is valid and matches the number
+// of black stones placed on the root.
+func lintHandicap(mt *MoveTree, n *Node, tp Path) []LintIssue {
+	if n != mt.Root {
+		return nil
+	}
+	if err := mt.ValidateHandicap(); err != nil {
+		return []LintIssue{{
+			Code:     LintHandicap,
+			Severity: SeverityError,
+			Path:     tp,
+			Prop:     "HA",
+			Msg:      err.Error(),
+		}}
+	}
+	count := mt.Handicap()
+	black := 0
+	for _, m := range n.Placements {
+		if m.Color() == color.Black {
+			black++
+		}
+	}
+	if count < 2 || black == count {
+		return nil
+	}
+	return []LintIssue{{
+		Code:     LintHandicap,
+		Severity: SeverityWarning,
+		Path:     tp,
+		Prop:     "HA",
+		Msg:      fmt.Sprintf("HA[%d], but %d black stones are placed on the root", count, black),
+	}}
+}
+
+// starPoints are the coordinates of the star points on the boards with
+// standard handicap placements. The star points are the intersections of the
+// coordinates.
+var starPoints = map[int][]int{
+	9:  {2, 4, 6},
+	13: {3, 6, 9},
+	19: {3, 9, 15},
+}
+
+// lintHandicapPlayer checks that the player to play (PL) is consistent with the
+// handicap. In a handicap game, white plays first, since black has already
+// placed the handicap stones. If there's no handicap (HA), but the root only
+// has black stones on star points, it hints that HA might be missing.
+func lintHandicapPlayer(mt *MoveTree, n *Node, tp Path) []LintIssue {
+	if n != mt.Root {
+		return nil
+	}
+	if count := mt.Handicap(); count != 0 {
+		if count < 2 || n.GameInfo.Player != color.Black {
+			return nil
+		}
+		return []LintIssue{{
+			Code:     LintHandicapPlayer,
+			Severity: SeverityWarning,
+			Path:     tp,
+			Prop:     "PL",
+			Msg:      fmt.Sprintf("PL[B] with HA[%d], but white plays first in a handicap game", count),
+		}}
+	}
+
+	// Rectangular boards have no standard handicap points.
+	stars := starPoints[mt.boardSize()]
+	if w, h := mt.boardDimensions(); w != h {
+		stars = nil
+	}
+	if len(n.Placements) < 2 || len(stars) == 0 {
+		return nil
+	}
+	isStar := func(v int) bool {
+		for _, s := range stars {
+			if v == s {
+				return true
+			}
+		}
+		return false
+	}
+	for _, m := range n.Placements {
+		if m.Color() != color.Black || !isStar(m.Point().X()) || !isStar(m.Point().Y()) {
+			return nil
+		}
+	}
+	return []LintIssue{{
+		Code:     LintMissingHandicap,
+		Severity: SeverityHint,
+		Path:     tp,
+		Prop:     "HA",
+		Msg:      fmt.Sprintf("%d black stones are placed on star points, but there's no handicap (HA)", len(n.Placements)),
+	}}
+}
+
+// lintRootMove checks that there's no move on the root. Other applications
+// often ignore a root move or number the moves differently.
+func lintRootMove(mt *MoveTree, n *Node, tp Path) []LintIssue {
+	if n != mt.Root || !mt.hasRootMove() {
+		return nil
+	}
+	return []LintIssue{{
+		Code:     LintRootMove,
+		Severity: SeverityWarning,
+		Path:     tp,
+		Prop:     string(n.Move.Color()),
+		Msg:      "move on the root; it should be in a child of the root (see Normalize)",
+	}}
+}
+
+// lintResult checks that a resignation in the result (RE) doesn't contradict
+// a main line that ends with two passes.
+func lintResult(mt *MoveTree, n *Node, tp Path) []LintIssue {
+	gi := n.GameInfo
+	if n != mt.Root || gi == nil || gi.Result == nil || gi.Result.Reason != ReasonResign {
+		return nil
+	}
+	if end := mt.GameEnd(); end.Reason != EndPasses {
+		return nil
+	}
+	return []LintIssue{{
+		Code:     LintResult,
+		Severity: SeverityWarning,
+		Path:     tp,
+		Prop:     "RE",
+		Msg:      fmt.Sprintf("result %v is a resignation, but the main line ends with two passes", gi.Result),
+	}}
+}
@@ -0,0 +1,112 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestLint(t *testing.T) {
+	g := movetree.New()
+	g.Root.GameInfo.Size = 9
+	komi := 6.3
+	g.Root.GameInfo.Komi = &komi
+
+	child := movetree.NewNode()
+	child.Move = move.New(color.Black, point.New(4, 4))
+	child.GameInfo = &movetree.GameInfo{}
+	child.Marks = map[point.Point]movetree.MarkType{*point.New(9, 0): movetree.MarkCircle}
+	child.Parent = g.Root
+	g.Root.AddChild(child)
+
+	type issue struct {
+		Code     movetree.LintCode
+		Severity movetree.LintSeverity
+		Path     string
+		Prop     string
+	}
+	var got []issue
+	for _, li := range g.Lint() {
+		got = append(got, issue{li.Code, li.Severity, li.Path.CompactString(), li.Prop})
+	}
+	exp := []issue{
+		{movetree.LintKomi, movetree.SeverityWarning, "-", "KM"},
+		{movetree.LintScope, movetree.SeverityError, "-0", ""},
+		{movetree.LintOffBoard, movetree.SeverityError, "-0", "CR"},
+	}
+	if !cmp.Equal(got, exp) {
+		t.Errorf("Lint()=%v, but expected %v. Diff=%s", got, exp, cmp.Diff(got, exp))
+	}
+}
+
+func TestLint_HandicapPlayer(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		sgf         string
+		expCode     movetree.LintCode
+		expSeverity movetree.LintSeverity
+	}{
+		{
+			desc:        "PL[B] with a handicap",
+			sgf:         "(;GM[1]SZ[19]HA[2]PL[B]AB[dd][pp])",
+			expCode:     movetree.LintHandicapPlayer,
+			expSeverity: movetree.SeverityWarning,
+		},
+		{
+			desc: "PL[W] with a handicap",
+			sgf:  "(;GM[1]SZ[19]HA[2]PL[W]AB[dd][pp])",
+		},
+		{
+			desc: "PL[B] without a handicap",
+			sgf:  "(;GM[1]SZ[19]HA[0]PL[B])",
+		},
+		{
+			desc:        "handicap stones without HA",
+			sgf:         "(;GM[1]SZ[19]AB[dd][pp][jj];W[qd])",
+			expCode:     movetree.LintMissingHandicap,
+			expSeverity: movetree.SeverityHint,
+		},
+		{
+			desc:        "handicap stones without HA on 9x9",
+			sgf:         "(;GM[1]SZ[9]AB[cc][gg])",
+			expCode:     movetree.LintMissingHandicap,
+			expSeverity: movetree.SeverityHint,
+		},
+		{
+			desc: "setup with stones off the star points",
+			sgf:  "(;GM[1]SZ[19]AB[dd][pq])",
+		},
+		{
+			desc: "setup with white stones",
+			sgf:  "(;GM[1]SZ[19]AB[dd][pp]AW[jj])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []movetree.LintIssue
+			for _, li := range g.Lint() {
+				if li.Code == movetree.LintHandicapPlayer || li.Code == movetree.LintMissingHandicap {
+					got = append(got, li)
+				}
+			}
+			if tc.expCode == "" {
+				if len(got) > 0 {
+					t.Errorf("Lint()=%v, but expected no handicap-player issues", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0].Code != tc.expCode || got[0].Severity != tc.expSeverity {
+				t.Errorf("Lint()=%v, but expected one %s issue with severity %s", got, tc.expCode, tc.expSeverity)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ErrMarkup indicates markup that references a point that isn't on the board.
+var ErrMarkup = errors.New("markup is off the board")
+
+// markupPointProps are the markup properties whose values are points, or
+// points with labels (LB). Their values may be compressed rectangles (ex:
+// TB[aa:cc]).
+var markupPointProps = []string{"CR", "TR", "SQ", "MA", "LB", "SL", "DD", "VW", "TB", "TW"}
+
+// MarkupError is markup that references a point that isn't on the board. It
+// matches ErrMarkup with errors.Is.
+type MarkupError struct {
+	// Path is the path to the node with the markup.
+	Path Path
+
+	// Prop is the markup property.
+	Prop string
+
+	// Point is the off-board point.
+	Point *point.Point
+
+	// Width and Height are the number of columns and rows of the board.
+	Width, Height int
+}
+
+// Error returns the error message.
+func (e *MarkupError) Error() string {
+	return fmt.Sprintf("%v: at %s %s: point %v is not on the %dx%d board",
+		ErrMarkup, e.Path.CompactString(), e.Prop, e.Point, e.Width, e.Height)
+}
+
+// Is indicates whether the target is ErrMarkup.
+func (e *MarkupError) Is(target error) bool {
+	return target == ErrMarkup
+}
+
+// ValidateMarkup checks that the markup of every node is on the board: the
+// marks (CR, TR, SQ, MA), labels (LB), and the SL, DD, VW, TB, and TW
+// properties. The offending points are returned as *MarkupErrors, in
+// depth-first order. Lint reports the same problems, along with others.
+func (mt *MoveTree) ValidateMarkup() []error {
+	var errs []error
+	width, height := mt.boardDimensions()
+	var visit func(n *Node, tp Path)
+	visit = func(n *Node, tp Path) {
+		for _, e := range offBoardMarkup(n, width, height) {
+			e.Path = tp
+			errs = append(errs, e)
+		}
+		for i, c := range n.Children {
+			visit(c, append(tp.Clone(), i))
+		}
+	}
+	visit(mt.Root, Path{})
+	return errs
+}
+
+// offBoardMarkup returns the markup of node n that isn't on a width x height
+// board, without the path to the node. Malformed values are skipped.
+func offBoardMarkup(n *Node, width, height int) []*MarkupError {
+	var out []*MarkupError
+	check := func(prop string, pts ...*point.Point) {
+		for _, pt := range pts {
+			if pt.X() < 0 || pt.Y() < 0 || pt.X() >= width || pt.Y() >= height {
+				out = append(out, &MarkupError{Prop: prop, Point: pt, Width: width, Height: height})
+			}
+		}
+	}
+
+	marked, labeled := &move.PointSet{}, &move.PointSet{}
+	for pt := range n.Marks {
+		marked.Add(point.New(pt.X(), pt.Y()))
+	}
+	for pt := range n.Labels {
+		labeled.Add(point.New(pt.X(), pt.Y()))
+	}
+	for _, pt := range marked.Sorted() {
+		check(string(n.Marks[*pt]), pt)
+	}
+	check("LB", labeled.Sorted()...)
+
+	for _, prop := range markupPointProps {
+		for _, v := range n.SGFProperties[prop] {
+			if prop == "LB" {
+				// Labels are of the form point:text.
+				if i := strings.IndexByte(v, ':'); i >= 0 {
+					v = v[:i]
+				}
+			}
+			for _, s := range strings.SplitN(v, ":", 2) {
+				if pt, err := point.NewFromSGF(s); err == nil {
+					check(prop, pt)
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestValidateMarkup(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		sgf     string
+		expErrs []string
+	}{
+		{
+			desc: "markup on the board",
+			sgf:  "(;GM[1]SZ[13];B[aa]TR[mm]LB[bb:A]SQ[aa:cc])",
+		},
+		{
+			desc:    "off-board triangle",
+			sgf:     "(;GM[1]SZ[13];B[aa];W[bb]TR[cc][nn])",
+			expErrs: []string{"-0x2 TR {13,13}"},
+		},
+		{
+			desc:    "off-board dimming and label",
+			sgf:     "(;GM[1]SZ[13](;B[aa]DD[kk][on])(;B[bb]LB[pp:A]))",
+			expErrs: []string{"-0 DD {14,13}", "-1 LB {15,15}"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, err := range g.ValidateMarkup() {
+				if !errors.Is(err, movetree.ErrMarkup) {
+					t.Errorf("got error %v, but expected it to match ErrMarkup", err)
+				}
+				var me *movetree.MarkupError
+				if !errors.As(err, &me) {
+					t.Fatalf("got error %v, but expected a *MarkupError", err)
+				}
+				got = append(got, me.Path.CompactString()+" "+me.Prop+" "+me.Point.String())
+			}
+			if !cmp.Equal(got, tc.expErrs) {
+				t.Errorf("ValidateMarkup() got %v, but expected %v", got, tc.expErrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMerge indicates that two movetrees could not be merged.
+var ErrMerge = errors.New("error merging movetrees")
+
+// Merge overlays two reviews of the same game, returning a new movetree with
+// the variations of both. The main lines of the movetrees must have the same
+// moves and placements. Neither movetree is modified.
+//
+// The nodes are matched by their moves and placements. The annotations of
+// matching nodes are combined as in DedupeVariations: comments are
+// concatenated, and marks, labels, and raw properties are unioned. Where
+// they conflict (ex: different marks on the same point), the annotations of
+// this movetree are kept, as is its game info. Variations that are only in
+// other are added after the variations of this movetree.
+func (mt *MoveTree) Merge(other *MoveTree) (*MoveTree, error) {
+	if same, a, b := mt.sameBoard(other); !same {
+		return nil, fmt.Errorf("%w: board sizes %s and %s differ", ErrMerge, a, b)
+	}
+	a, b := mt.Root, other.Root
+	for a != nil || b != nil {
+		if a != nil && b != nil && sameMove(a, b) && !samePlacements(a, b) {
+			return nil, fmt.Errorf("%w: the main lines have different placements at move %d", ErrMerge, a.MoveNum())
+		}
+		if a == nil || b == nil || !sameMove(a, b) {
+			return nil, fmt.Errorf("%w: the main lines differ at move %d: %s and %s", ErrMerge,
+				mainLineNum(a, b), describeNode(a), describeNode(b))
+		}
+		a, b = a.Next(0), b.Next(0)
+	}
+
+	out := &MoveTree{Root: mt.Root.copyTree()}
+	mergeReview(out.Root, other.Root)
+	return out, nil
+}
+
+// MergeOptions contains options for MergeReviews. A nil *MergeOptions is
+// valid and means that the defaults are used.
+type MergeOptions struct {
+	// Sources, if set, are the names of the reviews (ex: the reviewers), in
+	// the order of the movetrees. The comments of each review are then tagged
+	// with its name (ex: "[Alice] Good shape"), so that the comments of
+	// merged nodes can be told apart. Otherwise, comments are concatenated as
+	// they are.
+	Sources []string
+}
+
+// MergeReviews combines several reviews of the same game into a new
+// movetree, by merging them in order as with Merge: the game info and the
+// conflicting annotations of earlier reviews are kept. None of the reviews
+// are modified.
+func MergeReviews(reviews []*MoveTree, opts *MergeOptions) (*MoveTree, error) {
+	if len(reviews) == 0 {
+		return nil, fmt.Errorf("%w: no reviews to merge", ErrMerge)
+	}
+	var sources []string
+	if opts != nil {
+		sources = opts.Sources
+	}
+	if sources != nil && len(sources) != len(reviews) {
+		return nil, fmt.Errorf("%w: got %d sources for %d reviews", ErrMerge, len(sources), len(reviews))
+	}
+	review := func(i int) *MoveTree {
+		if sources == nil {
+			return reviews[i]
+		}
+		tagged := &MoveTree{Root: reviews[i].Root.copyTree()}
+		tagged.Root.Traverse(func(n *Node) {
+			if n.Comment != "" {
+				n.Comment = "[" + sources[i] + "] " + n.Comment
+			}
+		})
+		return tagged
+	}
+
+	out := &MoveTree{Root: review(0).Root.copyTree()}
+	for i := 1; i < len(reviews); i++ {
+		merged, err := out.Merge(review(i))
+		if err != nil {
+			return nil, fmt.Errorf("review %d: %w", i+1, err)
+		}
+		out = merged
+	}
+	return out, nil
+}
+
+// mergeReview merges the annotations and variations of node b into node a.
+func mergeReview(a, b *Node) {
+	mergeAnnotations(a, b.copyNode())
+	for _, bc := range b.Children {
+		var match *Node
+		for _, ac := range a.Children {
+			if sameMove(ac, bc) && samePlacements(ac, bc) {
+				match = ac
+				break
+			}
+		}
+		if match != nil {
+			mergeReview(match, bc)
+			continue
+		}
+		cc := bc.copyTree()
+		cc.Parent = a
+		a.AddChild(cc)
+	}
+}
+
+// mainLineNum returns the move number of whichever of the main-line nodes
+// exists.
+func mainLineNum(a, b *Node) int {
+	if a != nil {
+		return a.MoveNum()
+	}
+	return b.MoveNum()
+}
+
+// describeNode describes the move of a main-line node, for errors.
+func describeNode(n *Node) string {
+	switch {
+	case n == nil:
+		return "the end of the game"
+	case n.Move == nil:
+		return "no move"
+	default:
+		return n.Move.String()
+	}
+}
@@ -0,0 +1,147 @@
+package movetree_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestMerge(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		sgf    string
+		other  string
+		exp    string
+		expErr error
+	}{
+		{
+			desc:  "reviews branching at different points",
+			sgf:   "(;GM[1]SZ[9];B[ee]C[Good](;W[cc];B[gg]CR[aa];W[cg])(;W[gc]C[Also good]))",
+			other: "(;GM[1]SZ[9];B[ee]C[Fine];W[cc](;B[gg]CR[aa]TR[bb];W[cg])(;B[cg]C[Alternative]))",
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Good\n\nFine]" +
+				"(;W[cc](;B[gg]CR[aa]TR[bb];W[cg])(;B[cg]C[Alternative]))" +
+				"(;W[gc]C[Also good]))",
+		},
+		{
+			desc:   "different main lines",
+			sgf:    "(;GM[1]SZ[9];B[ee];W[cc];B[gg])",
+			other:  "(;GM[1]SZ[9];B[ee];W[gc];B[gg])",
+			expErr: movetree.ErrMerge,
+		},
+		{
+			desc:   "main line ends early",
+			sgf:    "(;GM[1]SZ[9];B[ee];W[cc];B[gg])",
+			other:  "(;GM[1]SZ[9];B[ee];W[cc])",
+			expErr: movetree.ErrMerge,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			other, err := sgf.Parse(tc.other)
+			if err != nil {
+				t.Fatal(err)
+			}
+			before, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			merged, err := g.Merge(other)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := sgf.Serialize(merged)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.exp {
+				t.Errorf("got merged SGF\n%s\nbut expected\n%s", got, tc.exp)
+			}
+			if after, _ := sgf.Serialize(g); after != before {
+				t.Errorf("the movetree was modified; got\n%s\nbut expected\n%s", after, before)
+			}
+		})
+	}
+}
+
+func TestMergeReviews(t *testing.T) {
+	reviews := []string{
+		"(;GM[1]SZ[9];B[ee]C[Good];W[cc])",
+		"(;GM[1]SZ[9];B[ee]C[Good](;W[cc])(;W[gc]C[Better]))",
+		"(;GM[1]SZ[9];B[ee](;W[cc])(;W[cg]C[Alternative]))",
+	}
+	testCases := []struct {
+		desc   string
+		opts   *movetree.MergeOptions
+		exp    string
+		expErr error
+	}{
+		{
+			desc: "untagged",
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Good]" +
+				"(;W[cc])(;W[gc]C[Better])(;W[cg]C[Alternative]))",
+		},
+		{
+			desc: "tagged by source",
+			opts: &movetree.MergeOptions{Sources: []string{"Alice", "Bob", "Carol"}},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[[Alice\\] Good\n\n[Bob\\] Good]" +
+				"(;W[cc])(;W[gc]C[[Bob\\] Better])(;W[cg]C[[Carol\\] Alternative]))",
+		},
+		{
+			desc:   "wrong number of sources",
+			opts:   &movetree.MergeOptions{Sources: []string{"Alice"}},
+			expErr: movetree.ErrMerge,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var games []*movetree.MoveTree
+			for _, r := range reviews {
+				g, err := sgf.Parse(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+				games = append(games, g)
+			}
+			merged, err := movetree.MergeReviews(games, tc.opts)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := sgf.Serialize(merged)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.exp {
+				t.Errorf("MergeReviews() got\n%s\nbut expected\n%s", got, tc.exp)
+			}
+			if games[0].Root.Next(0).Comment != "Good" {
+				t.Errorf("MergeReviews() modified the first review")
+			}
+		})
+	}
+
+	var games []*movetree.MoveTree
+	for _, r := range []string{reviews[0], reviews[1], "(;GM[1]SZ[9];B[gg])"} {
+		g, err := sgf.Parse(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		games = append(games, g)
+	}
+	if _, err := movetree.MergeReviews(games, nil); !errors.Is(err, movetree.ErrMerge) || !strings.HasPrefix(err.Error(), "review 3:") {
+		t.Errorf("got error %v, but expected an error for review 3", err)
+	}
+}
@@ -0,0 +1,44 @@
+package movetree
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+)
+
+// NodeAtMove returns the main-line node with the given move number, where the
+// first move is move 1. Only nodes with moves (including passes) are
+// numbered, so setup nodes are skipped. A move number set with MN renumbers
+// the move on that node, and the moves after it follow on from it. If moves
+// are renumbered to reuse a number, the first node with the number is
+// returned. ok is false if there's no such node.
+func (mt *MoveTree) NodeAtMove(num int) (n *Node, ok bool) {
+	cur := 0
+	for n := mt.Root; n != nil; n = n.Next(0) {
+		if n.Move == nil || n.Move.Color() == color.Empty {
+			continue
+		}
+		cur++
+		if mn := n.SGFProperties["MN"]; len(mn) == 1 {
+			if v, err := strconv.Atoi(mn[0]); err == nil {
+				cur = v
+			}
+		}
+		if cur == num {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// BoardAtMove computes the board position after the main-line move with the
+// given move number (see NodeAtMove).
+func (mt *MoveTree) BoardAtMove(num int) (*board.Board, error) {
+	n, ok := mt.NodeAtMove(num)
+	if !ok {
+		return nil, fmt.Errorf("%w: there's no move %d on the main line", ErrBoardPosition, num)
+	}
+	return mt.BoardAt(n)
+}
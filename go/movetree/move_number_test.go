@@ -0,0 +1,95 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestNodeAtMove(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		sgf        string
+		num        int
+		expComment string
+		expOK      bool
+	}{
+		{
+			desc:       "first move after the setup root",
+			sgf:        "(;GM[1]AB[dd][pp]C[root];W[qd]C[1];B[dp]C[2])",
+			num:        1,
+			expComment: "1",
+			expOK:      true,
+		},
+		{
+			desc:       "setup node doesn't take a number",
+			sgf:        "(;GM[1];AB[dd]C[setup];W[qd]C[1];B[dp]C[2])",
+			num:        2,
+			expComment: "2",
+			expOK:      true,
+		},
+		{
+			desc:       "passes take a number",
+			sgf:        "(;GM[1];B[]C[1];W[qd]C[2])",
+			num:        2,
+			expComment: "2",
+			expOK:      true,
+		},
+		{
+			desc:       "move numbers set with MN",
+			sgf:        "(;GM[1];B[qd]MN[50]C[50];W[dp]C[51])",
+			num:        51,
+			expComment: "51",
+			expOK:      true,
+		},
+		{
+			desc: "out of range",
+			sgf:  "(;GM[1];B[qd];W[dp])",
+			num:  3,
+		},
+		{
+			desc: "root",
+			sgf:  "(;GM[1];B[qd];W[dp])",
+			num:  0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n, ok := g.NodeAtMove(tc.num)
+			if ok != tc.expOK {
+				t.Fatalf("NodeAtMove(%d) returned ok=%v, but expected %v", tc.num, ok, tc.expOK)
+			}
+			if ok && n.Comment != tc.expComment {
+				t.Errorf("NodeAtMove(%d) returned the node with comment %q, but expected %q", tc.num, n.Comment, tc.expComment)
+			}
+		})
+	}
+}
+
+func TestBoardAtMove(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[5]AB[aa];W[bb];B[cc];W[dd])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := g.BoardAtMove(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `[B . . . .]
+[. W . . .]
+[. . B . .]
+[. . . . .]
+[. . . . .]`
+	if b.String() != exp {
+		t.Errorf("got board\n%v\nbut expected\n%v", b, exp)
+	}
+	if _, err := g.BoardAtMove(4); !errors.Is(err, movetree.ErrBoardPosition) {
+		t.Errorf("got error %v, but expected %v", err, movetree.ErrBoardPosition)
+	}
+}
@@ -21,3 +21,8 @@ func New() *MoveTree {
 	g.Root.SGFProperties["CA"] = []string{"UTF-8"} // CA[UTF-8]=UTF-8 encoding
 	return g
 }
+
+// Clone returns a deep copy of the movetree.
+func (mt *MoveTree) Clone() *MoveTree {
+	return &MoveTree{Root: mt.Root.copyTree()}
+}
@@ -3,20 +3,197 @@ package movetree
 import (
 	"github.com/otrego/clamshell/go/color"
 	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/rules"
 )
 
 // GameInfo contains typed game properties that can exist only on the root.
 type GameInfo struct {
 	// Size of the board, where 19 = 19x19. Between 1 and 25 inclusive. A value of
-	// 0 should be taken to mean 'unspecified' and treated as 19x19.
-	Size int
+	// 0 should be taken to mean 'unspecified' and treated as 19x19. For
+	// rectangular boards, it's the larger of Width and Height.
+	Size int `json:"size,omitempty"`
+
+	// Width and Height are the number of columns and rows of a rectangular
+	// board (SZ[19:9]), each between 1 and 25 inclusive. They're 0 for square
+	// boards, which only have a Size.
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
 
 	// Komi are points added to the player with the white stones as compensation for playing second.
-	// Komi must have a decimal value of .0 or .5 (ex: 6.5)
-	Komi *float64
+	// Komi must have a decimal value of .0 or .5 (ex: 6.5), or under Ing rules,
+	// .25 or .75 (ex: 7.75).
+	Komi *float64 `json:"komi,omitempty"`
+
+	// Handicap is the number of handicap stones (HA), which is 0 for games
+	// without a handicap and otherwise at least 2.
+	Handicap int `json:"handicap,omitempty"`
+
+	// Rules is the ruleset used for the game (RU).
+	Rules rules.Ruleset `json:"rules,omitempty"`
 
 	// Initial player turn. This is traditionally the player with the black stones
-	Player color.Color
+	Player color.Color `json:"player,omitempty"`
+
+	// Application is the application that was used to create the SGF (AP).
+	Application *Application `json:"application,omitempty"`
+
+	// Result is the result of the game (RE). Nil if no result was recorded.
+	Result *Result `json:"result,omitempty"`
+
+	// BlackPlayer and WhitePlayer are the names of the players (PB, PW).
+	BlackPlayer string `json:"blackPlayer,omitempty"`
+	WhitePlayer string `json:"whitePlayer,omitempty"`
+
+	// BlackRank and WhiteRank are the ranks of the players (BR, WR), in the
+	// format of the SGF (ex: 5d or 3k*).
+	BlackRank string `json:"blackRank,omitempty"`
+	WhiteRank string `json:"whiteRank,omitempty"`
+
+	// Date is the date the game was played (DT), in the SGF date format (ex:
+	// 2016-03-09).
+	Date string `json:"date,omitempty"`
+
+	// Event is the name of the event the game was played at (EV).
+	Event string `json:"event,omitempty"`
+
+	// MainTime is the main time of each player, in seconds (TM). Nil if no
+	// time limit was recorded.
+	MainTime *float64 `json:"mainTime,omitempty"`
+
+	// Overtime is the description of the overtime (OT), such as
+	// "5x30 byo-yomi" (see ParseTimeControl).
+	Overtime string `json:"overtime,omitempty"`
+
+	// BlackTeam and WhiteTeam are the names of the teams of the players (BT,
+	// WT).
+	BlackTeam string `json:"blackTeam,omitempty"`
+	WhiteTeam string `json:"whiteTeam,omitempty"`
+
+	// GameName is the name of the game (GN).
+	GameName string `json:"gameName,omitempty"`
+
+	// GameComment is a comment on the game as a whole (GC), such as background
+	// information or a summary.
+	GameComment string `json:"gameComment,omitempty"`
+
+	// Place is where the game was played (PC).
+	Place string `json:"place,omitempty"`
+
+	// Round is the round of the event the game was played in (RO), such as
+	// "5 (final)".
+	Round string `json:"round,omitempty"`
+
+	// Opening is the opening played in the game (ON), such as "san-ren-sei".
+	Opening string `json:"opening,omitempty"`
+
+	// Annotator is the person who commented the game (AN).
+	Annotator string `json:"annotator,omitempty"`
+
+	// Source is the source of the game record (SO), such as a book.
+	Source string `json:"source,omitempty"`
+
+	// User is the person who entered the game record (US).
+	User string `json:"user,omitempty"`
+
+	// Copyright is the copyright of the game record (CP).
+	Copyright string `json:"copyright,omitempty"`
+}
+
+// Dimensions returns the number of columns and rows of the board, or 0, 0 if
+// the size is unspecified.
+func (gi *GameInfo) Dimensions() (width, height int) {
+	if gi.Width != 0 && gi.Height != 0 {
+		return gi.Width, gi.Height
+	}
+	return gi.Size, gi.Size
+}
+
+// Application is the name and version of an application.
+type Application struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// Analysis is the AI-review analysis of a position, as recorded by an AI
+// review tool. Values are nil if they weren't recorded.
+type Analysis struct {
+	// WinRate is the probability of winning, between 0 and 1.
+	WinRate *float64 `json:"winRate,omitempty"`
+
+	// ScoreLead is the expected number of points by which the game is won.
+	ScoreLead *float64 `json:"scoreLead,omitempty"`
+}
+
+// MarkType is a type of mark that can be drawn on a point of the board. The
+// value is the SGF property used for the mark.
+type MarkType string
+
+const (
+	// MarkCircle is a circle (CR).
+	MarkCircle MarkType = "CR"
+
+	// MarkSquare is a square (SQ).
+	MarkSquare MarkType = "SQ"
+
+	// MarkTriangle is a triangle (TR).
+	MarkTriangle MarkType = "TR"
+
+	// MarkX is an X (MA).
+	MarkX MarkType = "MA"
+)
+
+// MoveAnnotationType is a type of annotation for the move on a node. The value
+// is the SGF property used for the annotation.
+type MoveAnnotationType string
+
+const (
+	// BadMove marks the move as bad (BM).
+	BadMove MoveAnnotationType = "BM"
+
+	// Tesuji marks the move as a tesuji, i.e., a good move (TE).
+	Tesuji MoveAnnotationType = "TE"
+
+	// DoubtfulMove marks the move as doubtful (DO).
+	DoubtfulMove MoveAnnotationType = "DO"
+
+	// InterestingMove marks the move as interesting (IT).
+	InterestingMove MoveAnnotationType = "IT"
+)
+
+// MoveAnnotation is an annotation of the move on a node.
+type MoveAnnotation struct {
+	Type MoveAnnotationType `json:"type"`
+
+	// Emphasis is 1 (normal) or 2 (emphasized) for bad moves and tesujis, and
+	// is 0 for the other annotation types.
+	Emphasis int `json:"emphasis,omitempty"`
+}
+
+// PositionAnnotationType is a type of evaluation of the position at a node.
+// The value is the SGF property used for the annotation.
+type PositionAnnotationType string
+
+const (
+	// GoodForBlack marks the position as good for black (GB).
+	GoodForBlack PositionAnnotationType = "GB"
+
+	// GoodForWhite marks the position as good for white (GW).
+	GoodForWhite PositionAnnotationType = "GW"
+
+	// EvenPosition marks the position as even (DM).
+	EvenPosition PositionAnnotationType = "DM"
+
+	// UnclearPosition marks the position as unclear (UC).
+	UnclearPosition PositionAnnotationType = "UC"
+)
+
+// PositionAnnotation is an evaluation of the position at a node.
+type PositionAnnotation struct {
+	Type PositionAnnotationType `json:"type"`
+
+	// Emphasis is 1 (normal) or 2 (emphasized).
+	Emphasis int `json:"emphasis"`
 }
 
 // Node contains Properties, Children nodes, and Parent node.
@@ -46,6 +223,51 @@ type Node struct {
 	// Comment is the comment for the current node.
 	Comment string
 
+	// MoveAnnotation is the annotation for the move (BM, TE, DO, IT). Nil if
+	// the move isn't annotated.
+	MoveAnnotation *MoveAnnotation
+
+	// Name is the name of the node (N), such as the name of a joseki or of a
+	// problem's answer.
+	Name string
+
+	// PositionAnnotation is the evaluation of the position (GB, GW, DM, UC).
+	// Nil if the position isn't annotated.
+	PositionAnnotation *PositionAnnotation
+
+	// Hotspot marks the node as important (HO): 1 (normal) or 2 (emphasized),
+	// or 0 if the node isn't a hotspot.
+	Hotspot int
+
+	// Value is the value of the node (V), such as an estimated score, which is
+	// positive when black is ahead. Nil if there's no value.
+	Value *float64
+
+	// Marks are the marks drawn on the board at this node. Nil if there are no
+	// marks.
+	Marks map[point.Point]MarkType
+
+	// Labels are the text labels drawn on the board at this node. Nil if there are
+	// no labels.
+	Labels map[point.Point]string
+
+	// Arrows and Lines are the arrows (AR) and lines (LN) drawn on the board
+	// at this node, in the order they were recorded. Nil if there are none.
+	Arrows, Lines []Line
+
+	// TimeLeft is the time left for each player after the move, in seconds
+	// (BL, WL). Nil if no time is recorded.
+	TimeLeft map[color.Color]float64
+
+	// OvertimeLeft is the number of byo-yomi periods, or of moves in the
+	// Canadian period, left for each player after the move (OB, OW). Nil if no
+	// overtime is recorded.
+	OvertimeLeft map[color.Color]int
+
+	// Analysis is the AI-review analysis of the position at this node. Nil if
+	// there's no analysis.
+	Analysis *Analysis
+
 	// GameInfo contains properties only found on the root. Should be nil on
 	// non-root nodes.
 	GameInfo *GameInfo
@@ -56,6 +278,15 @@ type Node struct {
 	// analysisData contains arbitrary/untyped AnalysisData that is attached to
 	// this node.
 	analysisData interface{}
+
+	// resign indicates that the node was explicitly marked as a resignation.
+	resign bool
+
+	// capturesHint contains the stones captured by the move, as recorded by the
+	// last Playback that stepped onto this node. hasCapturesHint indicates
+	// that it's been recorded.
+	capturesHint    move.List
+	hasCapturesHint bool
 }
 
 // NewNode creates a Node.
@@ -65,11 +296,20 @@ func NewNode() *Node {
 	}
 }
 
-// AddChild adds a child node.
+// AddChild adds a child node. The move numbers of the child and its
+// descendants are updated to follow on from this node.
 func (n *Node) AddChild(nn *Node) {
-	nn.moveNum = n.moveNum + 1
 	nn.varNum = len(n.Children)
 	n.Children = append(n.Children, nn)
+	nn.setMoveNum(n.moveNum + 1)
+}
+
+// setMoveNum sets the move number of the node, and renumbers its descendants.
+func (n *Node) setMoveNum(moveNum int) {
+	n.moveNum = moveNum
+	for _, c := range n.Children {
+		c.setMoveNum(moveNum + 1)
+	}
 }
 
 // Next gets the next node, given the variation number, returning nil if no node
@@ -92,6 +332,54 @@ func (n *Node) VarNum() int {
 	return n.varNum
 }
 
+// IsPass indicates whether the move at this node is a pass.
+func (n *Node) IsPass() bool {
+	return n.Move != nil && n.Move.IsPass()
+}
+
+// SetResign marks (or unmarks) this node as a resignation, which ends the game.
+func (n *Node) SetResign(resign bool) {
+	n.resign = resign
+}
+
+// IsResign indicates whether the game ends in a resignation at this node.
+// This is the case if the node was marked with SetResign, or if the node is
+// the final node of the main line and the recorded result (RE) is a win by
+// resignation. A main line that ends with two passes ended by the passes,
+// whatever the result says.
+func (n *Node) IsResign() bool {
+	if n.resign {
+		return true
+	}
+	if len(n.Children) != 0 || endsWithPasses(n) {
+		return false
+	}
+	root := n
+	for ; root.Parent != nil; root = root.Parent {
+		if root.varNum != 0 {
+			return false
+		}
+	}
+	return root.GameInfo != nil && root.GameInfo.Result != nil &&
+		root.GameInfo.Result.Reason == ReasonResign
+}
+
+// CapturesHint returns the stones captured by the move of this node, for
+// renderers to mark the just-captured points. It's a derived value, which is
+// recorded when a Playback (and so MainLineBoards) steps onto the node, and it
+// isn't part of the SGF. The second return value is false if the node hasn't
+// been walked yet, or if its move was changed with SetMove without validation
+// since. SetMove with validation records the hint.
+func (n *Node) CapturesHint() (move.List, bool) {
+	return n.capturesHint, n.hasCapturesHint
+}
+
+// setCapturesHint records the stones captured by the move of this node.
+func (n *Node) setCapturesHint(captured move.List) {
+	n.capturesHint = captured
+	n.hasCapturesHint = true
+}
+
 // SetAnalysisData sets the analysis data.
 func (n *Node) SetAnalysisData(an interface{}) {
 	n.analysisData = an
@@ -0,0 +1,248 @@
+package movetree
+
+import ()
+
+// NormalizeOptions contains options for NormalizeWithOptions. Each
+// normalization is done unless it's turned off. A nil *NormalizeOptions is
+// valid and means that all the normalizations are done.
+type NormalizeOptions struct {
+	// KeepEmptyRoot keeps a vacuous root in front of the real content, as
+	// written by files that wrap everything in an extra node (ex: "(;;GM[1]...").
+	KeepEmptyRoot bool
+
+	// KeepSetupNodes keeps the setup nodes (nodes with placements, but no
+	// move) that follow the root, rather than collapsing them into the root.
+	KeepSetupNodes bool
+
+	// KeepRootMove keeps a move on the root, rather than pushing it into a
+	// new child of the root.
+	KeepRootMove bool
+
+	// KeepTrailingPasses keeps the passes at the ends of the variations.
+	KeepTrailingPasses bool
+
+	// KeepGameInfoNodes keeps the game info of non-root nodes, rather than
+	// consolidating it into the root.
+	KeepGameInfoNodes bool
+
+	// KeepHandicapMoves keeps free-placement handicap stones that were
+	// recorded as moves, and doesn't infer a missing handicap, rather than
+	// normalizing the handicap (see NormalizeHandicap).
+	KeepHandicapMoves bool
+
+	// KeepEmptyNodes keeps the non-root nodes that have no move and no other
+	// content, rather than removing them.
+	KeepEmptyNodes bool
+
+	// KeepDuplicateVariations keeps sibling variations that are structurally
+	// equal, rather than merging them (see DedupeVariations).
+	KeepDuplicateVariations bool
+
+	// Orient transforms the movetree to its canonical orientation (see
+	// CanonicalSymmetry), so that games that differ only by a rotation or a
+	// reflection of the board are stored the same way. Unlike the other
+	// normalizations, it's off by default, since it changes the points of the
+	// moves.
+	Orient bool
+}
+
+// fileProps are the root properties that describe the file, rather than the
+// game. They're found on the root of every movetree created with New.
+var fileProps = map[string]bool{"GM": true, "FF": true, "CA": true}
+
+// Normalize canonicalizes the structure of the movetree, so that messy
+// inputs can be compared or stored. See NormalizeWithOptions.
+func (mt *MoveTree) Normalize() {
+	mt.NormalizeWithOptions(nil)
+}
+
+// NormalizeWithOptions canonicalizes the structure of the movetree:
+//
+//   - A move on the root is pushed into a new child of the root (see
+//     LintRootMove), along with the properties of the move.
+//   - A vacuous root, which has no content other than the file properties
+//     (GM, FF, and CA) and whose only child has no move, is removed. The
+//     child becomes the root. Since the converters aren't run again, any
+//     root properties that were kept raw on the child when parsing leniently
+//     stay raw.
+//   - The game info of non-root nodes (see GameInfoNodes) is consolidated
+//     into the root. The root's game info takes precedence where both have
+//     a value.
+//   - Handicap stones recorded as black moves are placed on the root, and a
+//     missing handicap (HA) is inferred (see NormalizeHandicap).
+//   - Setup nodes that are the only child of the root are collapsed into the
+//     root, so long as their annotations can be merged safely (see
+//     DedupeVariations) and their placements don't capture stones.
+//   - Passes at the ends of the variations are dropped, unless they have
+//     comments, markup, or other properties (ex: the territory markup used
+//     to score the game) or are marked as a resignation.
+//   - Non-root nodes with no move and no other content are removed, and
+//     their children take their place.
+//   - Sibling variations that are structurally equal are merged (see
+//     DedupeVariations).
+//   - If opts.Orient is set, the movetree is transformed to its canonical
+//     orientation (see CanonicalSymmetry).
+//
+// Other than for opts.Orient, the board positions reached by the moves, and
+// so the scored result, are unchanged.
+func (mt *MoveTree) NormalizeWithOptions(opts *NormalizeOptions) {
+	if opts == nil {
+		opts = &NormalizeOptions{}
+	}
+	if !opts.KeepRootMove {
+		mt.splitRootMove()
+	}
+	if !opts.KeepEmptyRoot {
+		mt.removeEmptyRoot()
+	}
+	if !opts.KeepGameInfoNodes {
+		mt.consolidateGameInfo()
+	}
+	if !opts.KeepHandicapMoves {
+		mt.NormalizeHandicap()
+	}
+	if !opts.KeepSetupNodes {
+		for mt.collapseSetupNode() {
+		}
+	}
+	if !opts.KeepTrailingPasses {
+		mt.Root.trimTrailingPasses()
+	}
+	if !opts.KeepEmptyNodes {
+		mt.Root.removeEmptyNodes()
+	}
+	if !opts.KeepDuplicateVariations {
+		mt.DedupeVariations()
+	}
+	if opts.Orient {
+		// The canonical symmetry always preserves the board.
+		_ = mt.Transform(mt.CanonicalSymmetry())
+	}
+}
+
+// removeEmptyRoot removes the root if it's vacuous.
+func (mt *MoveTree) removeEmptyRoot() {
+	root := mt.Root
+	if len(root.Children) != 1 || root.Move != nil || root.hasContent() || root.Children[0].Move != nil {
+		return
+	}
+	child := root.Children[0]
+	for k, v := range root.SGFProperties {
+		if _, ok := child.SGFProperties[k]; !ok {
+			child.SGFProperties[k] = v
+		}
+	}
+	child.Parent = nil
+	child.varNum = 0
+	child.setMoveNum(0)
+	mt.Root = child
+}
+
+// hasContent indicates whether the node has content other than its move, the
+// file properties, and the default game info.
+func (n *Node) hasContent() bool {
+	if len(n.Placements) > 0 || n.Comment != "" || n.MoveAnnotation != nil || n.Name != "" ||
+		n.PositionAnnotation != nil || n.Hotspot != 0 || n.Value != nil ||
+		n.Marks != nil || n.Labels != nil || n.Arrows != nil || n.Lines != nil ||
+		n.TimeLeft != nil || n.OvertimeLeft != nil || n.Analysis != nil || n.analysisData != nil || n.resign {
+		return true
+	}
+	if gi := n.GameInfo; gi != nil && *gi != (GameInfo{}) && *gi != (GameInfo{Size: 19}) {
+		return true
+	}
+	for k := range n.SGFProperties {
+		if !fileProps[k] {
+			return true
+		}
+	}
+	return false
+}
+
+// collapseSetupNode collapses the only child of the root into the root, if
+// it's a setup node that can be merged safely. Returns whether the node was
+// collapsed.
+func (mt *MoveTree) collapseSetupNode() bool {
+	root := mt.Root
+	if len(root.Children) != 1 {
+		return false
+	}
+	child := root.Children[0]
+	if child.Move != nil || len(child.Placements) == 0 || child.MoveAnnotation != nil ||
+		child.GameInfo != nil || child.Analysis != nil || child.analysisData != nil || child.resign {
+		return false
+	}
+	if root.Analysis != nil || root.analysisData != nil || !canMergeAnnotations(root, child) {
+		return false
+	}
+
+	b := mt.newBoard()
+	if _, err := applyPlacements(b, root); err != nil {
+		return false
+	}
+	if captured, err := applyPlacements(b, child); err != nil || len(captured) > 0 {
+		return false
+	}
+
+	mergeAnnotations(root, child)
+	root.Placements = b.StoneState()
+	root.Children = nil
+	for _, c := range child.Children {
+		c.Parent = root
+		root.AddChild(c)
+	}
+	return true
+}
+
+// trimTrailingPasses removes the contentless passes at the ends of the
+// variations below n.
+func (n *Node) trimTrailingPasses() {
+	var kept []*Node
+	for _, c := range n.Children {
+		c.trimTrailingPasses()
+		if len(c.Children) == 0 && c.IsPass() && !c.hasContent() {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	if len(kept) != len(n.Children) {
+		n.Children = kept
+		n.renumberChildren()
+	}
+}
+
+// removeEmptyNodes removes the contentless nodes without moves below n. The
+// children of a removed node are spliced into its place.
+func (n *Node) removeEmptyNodes() {
+	var kept []*Node
+	for _, c := range n.Children {
+		c.removeEmptyNodes()
+		if c.Move != nil || c.hasContent() {
+			kept = append(kept, c)
+			continue
+		}
+		for _, gc := range c.Children {
+			gc.Parent = n
+			kept = append(kept, gc)
+		}
+	}
+	if len(kept) != len(n.Children) || !sameNodes(kept, n.Children) {
+		n.Children = kept
+		n.renumberChildren()
+		for _, c := range n.Children {
+			c.setMoveNum(n.moveNum + 1)
+		}
+	}
+}
+
+// sameNodes indicates whether the two slices hold the same nodes, in order.
+func sameNodes(a, b []*Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		opts *movetree.NormalizeOptions
+		exp  string
+	}{
+		{
+			desc: "double root",
+			sgf:  "(;;GM[1]SZ[9]PB[Black];B[ee];W[cc])",
+			exp:  "(;GM[1]SZ[9]PB[Black];B[ee];W[cc])",
+		},
+		{
+			desc: "double root kept",
+			sgf:  "(;;C[Setup];B[ee])",
+			opts: &movetree.NormalizeOptions{KeepEmptyRoot: true},
+			exp:  "(;;C[Setup];B[ee])",
+		},
+		{
+			desc: "root with content isn't removed",
+			sgf:  "(;GM[1]SZ[9]C[Start];C[Setup];B[ee])",
+			exp:  "(;GM[1]SZ[9]C[Start];C[Setup];B[ee])",
+		},
+		{
+			desc: "setup nodes are collapsed",
+			sgf:  "(;GM[1]SZ[9]C[Start]AB[aa];AW[bb]C[Setup];AB[cc];B[ee])",
+			exp:  "(;GM[1]SZ[9]C[Start\n\nSetup]AB[aa][cc]AW[bb];B[ee])",
+		},
+		{
+			desc: "setup nodes kept",
+			sgf:  "(;GM[1]SZ[9];AB[cc];B[ee])",
+			opts: &movetree.NormalizeOptions{KeepSetupNodes: true},
+			exp:  "(;GM[1]SZ[9];AB[cc];B[ee])",
+		},
+		{
+			desc: "conflicting setup node isn't collapsed",
+			sgf:  "(;GM[1]SZ[9]LB[aa:A];AB[cc]LB[aa:B];B[ee])",
+			exp:  "(;GM[1]SZ[9]LB[aa:A];AB[cc]LB[aa:B];B[ee])",
+		},
+		{
+			desc: "trailing passes are dropped",
+			sgf:  "(;GM[1]SZ[9];B[ee](;W[cc];B[];W[])(;W[];B[]))",
+			exp:  "(;GM[1]SZ[9];B[ee];W[cc])",
+		},
+		{
+			desc: "passes with properties are kept",
+			sgf:  "(;GM[1]SZ[9];B[ee];W[cc];B[];W[]TB[aa])",
+			exp:  "(;GM[1]SZ[9];B[ee];W[cc];B[];W[]TB[aa])",
+		},
+		{
+			desc: "trailing passes kept",
+			sgf:  "(;GM[1]SZ[9];B[ee];W[])",
+			opts: &movetree.NormalizeOptions{KeepTrailingPasses: true},
+			exp:  "(;GM[1]SZ[9];B[ee];W[])",
+		},
+		{
+			desc: "handicap moves are placed",
+			sgf:  "(;GM[1]SZ[9];B[cc];B[gg];W[ee])",
+			exp:  "(;GM[1]SZ[9]HA[2]AB[cc][gg];W[ee])",
+		},
+		{
+			desc: "handicap moves kept",
+			sgf:  "(;GM[1]SZ[9];B[cc];B[gg];W[ee])",
+			opts: &movetree.NormalizeOptions{KeepHandicapMoves: true},
+			exp:  "(;GM[1]SZ[9];B[cc];B[gg];W[ee])",
+		},
+		{
+			desc: "empty nodes are removed",
+			sgf:  "(;GM[1]SZ[9];B[ee];;W[cc](;;B[dd])(;B[gg];;))",
+			exp:  "(;GM[1]SZ[9];B[ee];W[cc](;B[dd])(;B[gg]))",
+		},
+		{
+			desc: "empty node with variations is spliced into its siblings",
+			sgf:  "(;GM[1]SZ[9];B[ee](;W[cc])(;(;W[dd])(;W[gg])))",
+			exp:  "(;GM[1]SZ[9];B[ee](;W[cc])(;W[dd])(;W[gg]))",
+		},
+		{
+			desc: "empty nodes kept",
+			sgf:  "(;GM[1]SZ[9];B[ee];;W[cc])",
+			opts: &movetree.NormalizeOptions{KeepEmptyNodes: true},
+			exp:  "(;GM[1]SZ[9];B[ee];;W[cc])",
+		},
+		{
+			desc: "duplicate variations are merged",
+			sgf:  "(;GM[1]SZ[9];B[ee](;W[cc]C[Solid])(;W[gg])(;W[cc]C[Slow]))",
+			exp:  "(;GM[1]SZ[9];B[ee](;W[cc]C[Solid\n\nSlow])(;W[gg]))",
+		},
+		{
+			desc: "duplicate variations kept",
+			sgf:  "(;GM[1]SZ[9];B[ee](;W[cc])(;W[cc]))",
+			opts: &movetree.NormalizeOptions{KeepDuplicateVariations: true},
+			exp:  "(;GM[1]SZ[9];B[ee](;W[cc])(;W[cc]))",
+		},
+		{
+			desc: "oriented",
+			sgf:  "(;GM[1]SZ[9];B[cf];W[dc]CR[aa:bc]LB[ab:A])",
+			opts: &movetree.NormalizeOptions{Orient: true},
+			exp:  "(;GM[1]SZ[9];B[fc];W[cd]CR[aa:cb]LB[ba:A])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.FromString(tc.sgf).WithOptions(&prop.ParseOptions{Lenient: true}).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+			before, err := g.BoardAt(mainLineEnd(g))
+			if err != nil {
+				t.Fatal(err)
+			}
+			g.NormalizeWithOptions(tc.opts)
+			after, err := g.BoardAt(mainLineEnd(g))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !after.Equal(before) && (tc.opts == nil || !tc.opts.Orient) {
+				t.Errorf("got final position\n%v\nbut expected it to be unchanged:\n%v", after, before)
+			}
+
+			// Round trip, so that any root properties that were kept raw are
+			// converted.
+			s, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			norm, err := sgf.Parse(s)
+			if err != nil {
+				t.Fatalf("parsing the normalized movetree %q: %v", s, err)
+			}
+			got, err := sgf.Serialize(norm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exp, err := sgf.FromString(tc.exp).WithOptions(&prop.ParseOptions{Lenient: true}).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := sgf.Serialize(exp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("got movetree %q, but expected %q", got, want)
+			}
+		})
+	}
+}
+
+func mainLineEnd(g *movetree.MoveTree) *movetree.Node {
+	n := g.Root
+	for n.Next(0) != nil {
+		n = n.Next(0)
+	}
+	return n
+}
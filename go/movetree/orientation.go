@@ -0,0 +1,180 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ErrTransform indicates that a movetree could not be transformed.
+var ErrTransform = errors.New("error transforming movetree")
+
+// Transform rotates or reflects the whole movetree by the symmetry, in place:
+// the moves, placements, and markup of every node are transformed (see
+// board.Symmetry.TransformRect), including the markup kept in raw
+// properties. Comments that mention points aren't changed. It's an error if
+// the symmetry doesn't preserve the board (see board.Symmetry.Preserves).
+func (mt *MoveTree) Transform(s board.Symmetry) error {
+	width, height := mt.boardDimensions()
+	if s < 0 || s >= board.NumSymmetries || !s.Preserves(width, height) {
+		return fmt.Errorf("%w: symmetry %d doesn't preserve the %dx%d board", ErrTransform, s, width, height)
+	}
+	tf := func(pt *point.Point) *point.Point {
+		x, y := s.TransformRect(pt.X(), pt.Y(), width, height)
+		return point.New(x, y)
+	}
+	mt.Root.Traverse(func(n *Node) {
+		n.transform(tf)
+	})
+	return nil
+}
+
+// transform transforms the points of the node with tf.
+func (n *Node) transform(tf func(*point.Point) *point.Point) {
+	if n.Move != nil && !n.Move.IsPass() {
+		n.Move = move.New(n.Move.Color(), tf(n.Move.Point()))
+	}
+	if len(n.Placements) > 0 {
+		var pl move.List
+		for _, m := range n.Placements {
+			pl = append(pl, move.New(m.Color(), tf(m.Point())))
+		}
+		pl.Sort()
+		n.Placements = pl
+	}
+	if n.Marks != nil {
+		marks := make(map[point.Point]MarkType)
+		for pt, m := range n.Marks {
+			marks[*tf(&pt)] = m
+		}
+		n.Marks = marks
+	}
+	if n.Labels != nil {
+		labels := make(map[point.Point]string)
+		for pt, l := range n.Labels {
+			labels[*tf(&pt)] = l
+		}
+		n.Labels = labels
+	}
+	for _, lines := range [][]Line{n.Arrows, n.Lines} {
+		for i, l := range lines {
+			from, to := tf(point.New(l.FromX, l.FromY)), tf(point.New(l.ToX, l.ToY))
+			lines[i] = Line{FromX: from.X(), FromY: from.Y(), ToX: to.X(), ToY: to.Y()}
+		}
+	}
+	for _, prop := range markupPointProps {
+		for i, v := range n.SGFProperties[prop] {
+			n.SGFProperties[prop][i] = transformRawPoints(v, prop == "LB", tf)
+		}
+	}
+	n.capturesHint, n.hasCapturesHint = nil, false
+}
+
+// transformRawPoints transforms the point, or the compressed rectangle of
+// points, of a raw property value. Labels are of the form point:text.
+// Malformed values are returned unchanged.
+func transformRawPoints(v string, label bool, tf func(*point.Point) *point.Point) string {
+	if label {
+		i := strings.IndexByte(v, ':')
+		if i < 0 {
+			return v
+		}
+		pt, err := point.NewFromSGF(v[:i])
+		if err != nil {
+			return v
+		}
+		s, err := tf(pt).ToSGF()
+		if err != nil {
+			return v
+		}
+		return s + v[i:]
+	}
+
+	corners := strings.SplitN(v, ":", 2)
+	var pts []*point.Point
+	for _, c := range corners {
+		pt, err := point.NewFromSGF(c)
+		if err != nil {
+			return v
+		}
+		pts = append(pts, tf(pt))
+	}
+	if len(pts) == 2 {
+		// The transformed corners may be any two opposite corners of the
+		// rectangle.
+		tl := point.New(minInt(pts[0].X(), pts[1].X()), minInt(pts[0].Y(), pts[1].Y()))
+		br := point.New(maxInt(pts[0].X(), pts[1].X()), maxInt(pts[0].Y(), pts[1].Y()))
+		pts = []*point.Point{tl, br}
+	}
+	var out []string
+	for _, pt := range pts {
+		s, err := pt.ToSGF()
+		if err != nil {
+			return v
+		}
+		out = append(out, s)
+	}
+	return strings.Join(out, ":")
+}
+
+// CanonicalSymmetry returns the symmetry that puts the movetree in its
+// canonical orientation, where the first move of the main line is in the
+// upper-right corner: as close as possible to the top edge, and then to the
+// right edge (ex: a 3-4 point is on the third line from the top). Where
+// several symmetries do so, such as for a first move at tengen, the later
+// moves of the main line break the tie in the same way. If the main line has
+// no moves, the identity is returned.
+func (mt *MoveTree) CanonicalSymmetry() board.Symmetry {
+	width, height := mt.boardDimensions()
+	var moves []*point.Point
+	for _, n := range mt.MainLine() {
+		if n.Move != nil && !n.Move.IsPass() {
+			moves = append(moves, n.Move.Point())
+		}
+	}
+
+	candidates := []board.Symmetry{}
+	for s := board.Symmetry(0); s < board.NumSymmetries; s++ {
+		if s.Preserves(width, height) {
+			candidates = append(candidates, s)
+		}
+	}
+	for _, pt := range moves {
+		if len(candidates) == 1 {
+			break
+		}
+		// Keep the symmetries that put the move closest to the top edge, and
+		// then to the right edge.
+		var best []board.Symmetry
+		bestTop, bestRight := 0, 0
+		for _, s := range candidates {
+			x, y := s.TransformRect(pt.X(), pt.Y(), width, height)
+			top, right := y, width-1-x
+			if len(best) == 0 || top < bestTop || top == bestTop && right < bestRight {
+				best, bestTop, bestRight = []board.Symmetry{s}, top, right
+			} else if top == bestTop && right == bestRight {
+				best = append(best, s)
+			}
+		}
+		candidates = best
+	}
+	return candidates[0]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
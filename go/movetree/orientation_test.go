@@ -0,0 +1,147 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestTransform(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		sgf    string
+		sym    board.Symmetry
+		exp    string
+		expErr error
+	}{
+		{
+			desc: "identity",
+			sgf:  "(;GM[1]SZ[9];B[cc];W[gd])",
+			sym:  0,
+			exp:  "(;GM[1]SZ[9];B[cc];W[gd])",
+		},
+		{
+			desc: "reflection",
+			sgf:  "(;GM[1]SZ[9];B[cc];W[gd];B[])",
+			sym:  1,
+			exp:  "(;GM[1]SZ[9];B[gc];W[cd];B[])",
+		},
+		{
+			desc: "quarter turn with markup",
+			sgf:  "(;GM[1]SZ[9]AB[ab][ac];B[cc]TR[aa]LB[ca:X]AR[aa:ba]LN[ab:bb](;W[dd]SQ[aa:bb])(;W[ee]))",
+			sym:  2,
+			exp:  "(;GM[1]SZ[9]AB[ha][ga];B[gc]TR[ia]LB[ic:X]AR[ia:ib]LN[ha:hb](;W[fd]SQ[ha:ib])(;W[ee]))",
+		},
+		{
+			desc: "half turn of a rectangular board",
+			sgf:  "(;GM[1]SZ[13:9];B[cc])",
+			sym:  4,
+			exp:  "(;GM[1]SZ[13:9];B[kg])",
+		},
+		{
+			desc:   "quarter turn of a rectangular board",
+			sgf:    "(;GM[1]SZ[13:9];B[cc])",
+			sym:    2,
+			expErr: movetree.ErrTransform,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = g.Transform(tc.sym)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exp, err := sgf.Parse(tc.exp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := sgf.Serialize(exp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("got movetree %q, but expected %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCanonicalSymmetry(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		exp  string
+	}{
+		{
+			desc: "3-4 point",
+			sgf:  "(;GM[1]SZ[19];B[cp];W[pd])",
+			exp:  "pc",
+		},
+		{
+			desc: "tengen, with the tie broken by the second move",
+			sgf:  "(;GM[1]SZ[9];B[ee];W[cg])",
+			exp:  "ee",
+		},
+		{
+			desc: "rectangular board",
+			sgf:  "(;GM[1]SZ[13:9];B[ch])",
+			exp:  "kb",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			canonical := ""
+			for s := board.Symmetry(0); s < board.NumSymmetries; s++ {
+				h, err := sgf.Parse(tc.sgf)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := h.Transform(s); errors.Is(err, movetree.ErrTransform) {
+					continue
+				} else if err != nil {
+					t.Fatal(err)
+				}
+				if err := h.Transform(h.CanonicalSymmetry()); err != nil {
+					t.Fatal(err)
+				}
+				got, err := sgf.Serialize(h)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if canonical == "" {
+					canonical = got
+				} else if got != canonical {
+					t.Errorf("got canonical movetree %q for symmetry %d, but expected %q", got, s, canonical)
+				}
+			}
+			if err := g.Transform(g.CanonicalSymmetry()); err != nil {
+				t.Fatal(err)
+			}
+			got, err := g.Root.Next(0).Move.ToSGF()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.exp {
+				t.Errorf("got first move %q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+)
+
+// ErrPlayback indicates an error stepping through a movetree.
+var ErrPlayback = errors.New("playback error")
+
+// Playback steps through a movetree one node at a time, keeping track of the
+// board position at the current node. Stepping forward and back is
+// incremental, so that the board isn't recomputed from the root each time.
+type Playback struct {
+	cur   *Node
+	board *board.Board
+
+	// undos contains, for each step forward, the undos for reverting the
+	// step.
+	undos [][]*board.Undo
+}
+
+// NewPlayback creates a Playback with the cursor at the root of the movetree.
+func (mt *MoveTree) NewPlayback() (*Playback, error) {
+	p := &Playback{
+		cur:   mt.Root,
+		board: mt.newBoard(),
+	}
+	if _, err := p.apply(mt.Root); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Node returns the node at the cursor.
+func (p *Playback) Node() *Node {
+	return p.cur
+}
+
+// Board returns the board position at the cursor. The board is owned by the
+// Playback and is modified when stepping, so Clone it to keep a copy.
+func (p *Playback) Board() *board.Board {
+	return p.board
+}
+
+// Forward steps forward along the main variation.
+func (p *Playback) Forward() error {
+	return p.ForwardVariation(0)
+}
+
+// ForwardVariation steps forward to the child with the given variation number.
+func (p *Playback) ForwardVariation(variation int) error {
+	next := p.cur.Next(variation)
+	if next == nil {
+		return fmt.Errorf("%w: no variation %d at move %d", ErrPlayback, variation, p.cur.MoveNum())
+	}
+	undos, err := p.apply(next)
+	if err != nil {
+		return err
+	}
+	p.undos = append(p.undos, undos)
+	p.cur = next
+	return nil
+}
+
+// Back steps back to the parent node, restoring any stones that were captured
+// and the ko.
+func (p *Playback) Back() error {
+	if len(p.undos) == 0 {
+		return fmt.Errorf("%w: already at the start", ErrPlayback)
+	}
+	undos := p.undos[len(p.undos)-1]
+	for i := len(undos) - 1; i >= 0; i-- {
+		p.board.Revert(undos[i])
+	}
+	p.undos = p.undos[:len(p.undos)-1]
+	p.cur = p.cur.Parent
+	return nil
+}
+
+// apply applies the placements and move of the node to the board, returning
+// the undos for reverting them. On error, the board is left unchanged.
+func (p *Playback) apply(n *Node) ([]*board.Undo, error) {
+	var undos []*board.Undo
+	if len(n.Placements) > 0 {
+		_, u, err := applyPlacementsWithUndo(p.board, n)
+		if err != nil {
+			return nil, fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+		}
+		undos = append(undos, u)
+	}
+	if n.Move == nil || n.Move.IsPass() || n.Move.Color() == color.Empty {
+		n.setCapturesHint(nil)
+		return undos, nil
+	}
+	captured, u, err := p.board.PlaceStoneWithUndo(n.Move)
+	if err != nil {
+		for i := len(undos) - 1; i >= 0; i-- {
+			p.board.Revert(undos[i])
+		}
+		return nil, fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+	}
+	n.setCapturesHint(captured)
+	return append(undos, u), nil
+}
@@ -0,0 +1,119 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestPlayback(t *testing.T) {
+	// Black captures the white stone at aa with ba, which also sets the ko, and
+	// then the game continues.
+	g, err := sgf.Parse(`(;GM[1]SZ[5]AB[bb][cb]AW[da][db][cc];B[ab];W[aa];B[ba];W[ea];B[ca];W[dd])`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := g.NewPlayback()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var nodes []*movetree.Node
+	for {
+		nodes = append(nodes, p.Node())
+		checkBoard(t, g, p)
+		if err := p.Forward(); err != nil {
+			if !errors.Is(err, movetree.ErrPlayback) {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+	if len(nodes) != 7 {
+		t.Fatalf("stepped through %d nodes, but expected 7", len(nodes))
+	}
+
+	for i := len(nodes) - 1; i > 0; i-- {
+		if err := p.Back(); err != nil {
+			t.Fatal(err)
+		}
+		if p.Node() != nodes[i-1] {
+			t.Fatalf("after Back, at move %d, but expected move %d", p.Node().MoveNum(), nodes[i-1].MoveNum())
+		}
+		checkBoard(t, g, p)
+	}
+	if err := p.Back(); !errors.Is(err, movetree.ErrPlayback) {
+		t.Errorf("Back at the root returned error %v, but expected %v", err, movetree.ErrPlayback)
+	}
+}
+
+func TestPlayback_Variations(t *testing.T) {
+	g, err := sgf.Parse(`(;GM[1]SZ[5](;B[aa])(;B[bb];W[cc]))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := g.NewPlayback()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.ForwardVariation(2); !errors.Is(err, movetree.ErrPlayback) {
+		t.Errorf("ForwardVariation(2) returned error %v, but expected %v", err, movetree.ErrPlayback)
+	}
+	if err := p.ForwardVariation(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Forward(); err != nil {
+		t.Fatal(err)
+	}
+	checkBoard(t, g, p)
+}
+
+// checkBoard checks that the playback board (including the ko) matches the board
+// computed from the root.
+func checkBoard(t *testing.T, g *movetree.MoveTree, p *movetree.Playback) {
+	t.Helper()
+	exp, err := g.BoardAt(p.Node())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Board().String(); got != exp.String() {
+		t.Errorf("at move %d, got board\n%s\nbut expected\n%s", p.Node().MoveNum(), got, exp.String())
+	}
+}
+
+func TestPlayback_CapturesHint(t *testing.T) {
+	const s = "(;GM[1]SZ[5]AW[aa][ba]AB[ca][ab];B[bb];W[dd])"
+	g, err := sgf.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	capture := g.Root.Next(0)
+	if _, ok := capture.CapturesHint(); ok {
+		t.Errorf("CapturesHint() was recorded before the board walk")
+	}
+
+	p, err := g.NewPlayback()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for p.Forward() == nil {
+	}
+	got, ok := capture.CapturesHint()
+	if exp := "{W aa, W ba}"; !ok || got.String() != exp {
+		t.Errorf("CapturesHint() for the capture=%v, %v, but expected %s", got, ok, exp)
+	}
+	if got, ok := capture.Next(0).CapturesHint(); !ok || len(got) != 0 {
+		t.Errorf("CapturesHint() for a non-capturing move=%v, %v, but expected no captures", got, ok)
+	}
+
+	// The hint isn't part of the SGF.
+	out, err := sgf.Serialize(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "(;FF[4]GM[1]CA[UTF-8]SZ[5]AB[ab][ca]AW[aa][ba];B[bb];W[dd])"; out != exp {
+		t.Errorf("Serialize()=%q, but expected %q", out, exp)
+	}
+}
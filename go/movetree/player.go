@@ -0,0 +1,29 @@
+package movetree
+
+import (
+	"github.com/otrego/clamshell/go/color"
+)
+
+// PlayerToMove returns the color of the player whose turn it is at node n,
+// after its move has been played. This is the opposite of the color of the
+// last move played on the path to n, so consecutive moves of the same color
+// are handled naturally.
+//
+// If no move has been played yet, the player is the one given by PL on the
+// root. Otherwise, in a handicap game (HA of 2 or more), white moves first,
+// as it plays after the handicap stones are set up. Otherwise, black moves
+// first.
+func (mt *MoveTree) PlayerToMove(n *Node) color.Color {
+	for cur := n; cur != nil; cur = cur.Parent {
+		if cur.Move != nil && cur.Move.Color() != color.Empty {
+			return cur.Move.Color().Opposite()
+		}
+	}
+	if gi := mt.Root.GameInfo; gi != nil && gi.Player != color.Empty {
+		return gi.Player
+	}
+	if mt.Handicap() >= 2 {
+		return color.White
+	}
+	return color.Black
+}
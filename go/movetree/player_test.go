@@ -0,0 +1,88 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestPlayerToMove(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		path string
+		exp  color.Color
+	}{
+		{
+			desc: "normal game, at the root",
+			sgf:  "(;GM[1]SZ[9];B[ee];W[cc])",
+			path: "-",
+			exp:  color.Black,
+		},
+		{
+			desc: "normal game, after black",
+			sgf:  "(;GM[1]SZ[9];B[ee];W[cc])",
+			path: "0",
+			exp:  color.White,
+		},
+		{
+			desc: "normal game, after white",
+			sgf:  "(;GM[1]SZ[9];B[ee];W[cc])",
+			path: "0x2",
+			exp:  color.Black,
+		},
+		{
+			desc: "handicap game, at the root",
+			sgf:  "(;GM[1]SZ[9]HA[2]AB[cc][gg];W[ee];B[cg])",
+			path: "-",
+			exp:  color.White,
+		},
+		{
+			desc: "handicap game, after white's first move",
+			sgf:  "(;GM[1]SZ[9]HA[2]AB[cc][gg];W[ee];B[cg])",
+			path: "0",
+			exp:  color.Black,
+		},
+		{
+			desc: "PL overrides the handicap",
+			sgf:  "(;GM[1]SZ[9]HA[2]PL[B]AB[cc][gg])",
+			path: "-",
+			exp:  color.Black,
+		},
+		{
+			desc: "setup-only root with PL",
+			sgf:  "(;GM[1]SZ[9]PL[W]AB[cc]AW[gg])",
+			path: "-",
+			exp:  color.White,
+		},
+		{
+			desc: "setup node after a move",
+			sgf:  "(;GM[1]SZ[9];B[ee];AW[cc])",
+			path: "0x2",
+			exp:  color.White,
+		},
+		{
+			desc: "consecutive black moves",
+			sgf:  "(;GM[1]SZ[9];B[ee];B[cc])",
+			path: "0x2",
+			exp:  color.White,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tp, err := movetree.ParsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := g.PlayerToMove(tp.Apply(g.Root)); got != tc.exp {
+				t.Errorf("PlayerToMove()=%v, but expected %v", got, tc.exp)
+			}
+		})
+	}
+}
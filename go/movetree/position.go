@@ -0,0 +1,125 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+)
+
+// ErrBoardPosition indicates that a board position could not be computed.
+var ErrBoardPosition = errors.New("error computing board position")
+
+// boardSize returns the size of the board, defaulting to 19 if unspecified.
+func (mt *MoveTree) boardSize() int {
+	if mt.Root.GameInfo == nil || mt.Root.GameInfo.Size == 0 {
+		return 19
+	}
+	return mt.Root.GameInfo.Size
+}
+
+// boardDimensions returns the number of columns and rows of the board,
+// defaulting to 19x19 if unspecified.
+func (mt *MoveTree) boardDimensions() (width, height int) {
+	if mt.Root.GameInfo == nil || mt.Root.GameInfo.Size == 0 {
+		return 19, 19
+	}
+	return mt.Root.GameInfo.Dimensions()
+}
+
+// sameBoard indicates whether the two movetrees have boards of the same
+// dimensions, and otherwise returns them for errors (ex: "19x9").
+func (mt *MoveTree) sameBoard(other *MoveTree) (bool, string, string) {
+	w, h := mt.boardDimensions()
+	ow, oh := other.boardDimensions()
+	return w == ow && h == oh, fmt.Sprintf("%dx%d", w, h), fmt.Sprintf("%dx%d", ow, oh)
+}
+
+// newBoard creates an empty board with the dimensions of the movetree's board.
+func (mt *MoveTree) newBoard() *board.Board {
+	return board.NewRect(mt.boardDimensions())
+}
+
+// BoardAt computes the board position at node n (after its placements and
+// move have been applied), by replaying the moves from the root.
+func (mt *MoveTree) BoardAt(n *Node) (*board.Board, error) {
+	b, _, err := mt.replay(n)
+	return b, err
+}
+
+// replay replays the moves from the root to node n, returning the resulting
+// board and the number of stones captured by each color.
+func (mt *MoveTree) replay(n *Node) (*board.Board, map[color.Color]int, error) {
+	var nodes []*Node
+	for cur := n; cur != nil; cur = cur.Parent {
+		nodes = append(nodes, cur)
+	}
+	if len(nodes) == 0 || nodes[len(nodes)-1] != mt.Root {
+		return nil, nil, fmt.Errorf("%w: node is not part of the movetree", ErrBoardPosition)
+	}
+
+	b := mt.newBoard()
+	captures := make(map[color.Color]int)
+	for i := len(nodes) - 1; i >= 0; i-- {
+		cur := nodes[i]
+		_, captured, err := applyNode(b, cur)
+		if err != nil {
+			return nil, nil, fmt.Errorf("at move %d: %w", cur.MoveNum(), err)
+		}
+		if len(captured) > 0 {
+			captures[cur.Move.Color()] += len(captured)
+		}
+	}
+	return b, captures, nil
+}
+
+// applyNode applies the placements and the move of node n to the board, as
+// when replaying the movetree (see applyPlacements), returning the stones
+// captured by the placements and by the move.
+func applyNode(b *board.Board, n *Node) (setup, captured move.List, err error) {
+	if setup, err = applyPlacements(b, n); err != nil {
+		return nil, nil, err
+	}
+	if n.Move == nil || n.Move.IsPass() || n.Move.Color() == color.Empty {
+		return setup, nil, nil
+	}
+	if captured, err = b.PlaceStone(n.Move); err != nil {
+		return nil, nil, err
+	}
+	return setup, captured, nil
+}
+
+// applyPlacements applies the placements of node n to the board, returning
+// the stones captured by them. Mid-game placements can fill the last liberty
+// of a group, which is then captured (see board.SetPlacementsAndCapture). The
+// walks of the movetree all apply placements this way, so that they agree on
+// the positions.
+func applyPlacements(b *board.Board, n *Node) (move.List, error) {
+	if len(n.Placements) == 0 {
+		return nil, nil
+	}
+	return b.SetPlacementsAndCapture(n.Placements)
+}
+
+// applyPlacementsWithUndo is like applyPlacements, but additionally returns an
+// Undo for reverting the placements, which is nil if there are none.
+func applyPlacementsWithUndo(b *board.Board, n *Node) (move.List, *board.Undo, error) {
+	if len(n.Placements) == 0 {
+		return nil, nil, nil
+	}
+	return b.SetPlacementsAndCaptureWithUndo(n.Placements)
+}
+
+// Passes counts the passes made by each color on the path from the root to
+// node n, such as for the pass stones of AGA scoring.
+func (mt *MoveTree) Passes(n *Node) map[color.Color]int {
+	passes := make(map[color.Color]int)
+	for cur := n; cur != nil; cur = cur.Parent {
+		if cur.IsPass() && cur.Move.Color() != color.Empty {
+			passes[cur.Move.Color()]++
+		}
+	}
+	return passes
+}
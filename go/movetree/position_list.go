@@ -0,0 +1,84 @@
+package movetree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+)
+
+// PositionRecord is a main-line move and the position it results in, in the
+// position-list layout used by some public game datasets.
+type PositionRecord struct {
+	// MoveNum is the move number of the move.
+	MoveNum int
+
+	// Color is the color of the player who moved.
+	Color color.Color
+
+	// Move is the move as a GTP vertex (ex: D4), or "pass".
+	Move string
+
+	// Board is the board after the move, encoded by EncodeBoard.
+	Board string
+}
+
+// ToPositionList returns a position record for each move of the main line.
+// Setup-only nodes don't produce a record, but their placements are part of
+// the following boards.
+func (mt *MoveTree) ToPositionList() ([]PositionRecord, error) {
+	var records []PositionRecord
+	width, height := mt.boardDimensions()
+	b := board.NewRect(width, height)
+	for n := mt.Root; n != nil; n = n.Next(0) {
+		if _, err := applyPlacements(b, n); err != nil {
+			return nil, fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+		}
+		if n.Move == nil || n.Move.Color() == color.Empty {
+			continue
+		}
+		vertex := "pass"
+		if !n.Move.IsPass() {
+			var err error
+			if vertex, err = n.Move.Point().ToGTPRect(width, height); err != nil {
+				return nil, fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+			}
+			if _, err := b.PlaceStone(n.Move); err != nil {
+				return nil, fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+			}
+		}
+		records = append(records, PositionRecord{
+			MoveNum: n.MoveNum(),
+			Color:   n.Move.Color(),
+			Move:    vertex,
+			Board:   EncodeBoard(b),
+		})
+	}
+	return records, nil
+}
+
+// EncodeBoard encodes the board as a compact string: the rows from top to
+// bottom, separated by '/', where each point is 'X' for a black stone, 'O'
+// for a white stone, or '.' if empty. For example, a 3x3 board with a black
+// stone in the top-left corner and a white stone in the center is "X../.O./...".
+// The ko isn't encoded. The encoding is stable, so it can be stored.
+func EncodeBoard(b *board.Board) string {
+	var sb strings.Builder
+	for y, row := range b.FullBoardState() {
+		if y > 0 {
+			sb.WriteByte('/')
+		}
+		for _, c := range row {
+			switch c {
+			case color.Black:
+				sb.WriteByte('X')
+			case color.White:
+				sb.WriteByte('O')
+			default:
+				sb.WriteByte('.')
+			}
+		}
+	}
+	return sb.String()
+}
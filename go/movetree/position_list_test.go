@@ -0,0 +1,29 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestToPositionList(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[5]AB[aa];B[cc];W[db];B[])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := g.ToPositionList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []movetree.PositionRecord{
+		{MoveNum: 1, Color: color.Black, Move: "C3", Board: "X..../...../..X../...../....."},
+		{MoveNum: 2, Color: color.White, Move: "D4", Board: "X..../...O./..X../...../....."},
+		{MoveNum: 3, Color: color.Black, Move: "pass", Board: "X..../...O./..X../...../....."},
+	}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Errorf("ToPositionList diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,165 @@
+package movetree_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/rules"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestBoardAt_MidGameSetup(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		sgf      string
+		path     string
+		expBoard string
+		expTurn  color.Color
+	}{
+		{
+			desc: "black stone added after white's move",
+			sgf:  "(;GM[1]SZ[5];B[aa];W[bb];AB[cc];B[dd])",
+			path: "0x3",
+			expBoard: `[B . . . .]
+[. W . . .]
+[. . B . .]
+[. . . . .]
+[. . . . .]`,
+			expTurn: color.Black,
+		},
+		{
+			desc: "move after the setup node",
+			sgf:  "(;GM[1]SZ[5];B[aa];W[bb];AB[cc];B[dd])",
+			path: "0x4",
+			expBoard: `[B . . . .]
+[. W . . .]
+[. . B . .]
+[. . . B .]
+[. . . . .]`,
+			expTurn: color.White,
+		},
+		{
+			desc: "setup after black's move",
+			sgf:  "(;GM[1]SZ[5];B[aa];AB[cc]AW[dd];W[bb])",
+			path: "0x2",
+			expBoard: `[B . . . .]
+[. . . . .]
+[. . B . .]
+[. . . W .]
+[. . . . .]`,
+			expTurn: color.White,
+		},
+		{
+			// The setup stone fills the last liberty of the white stone, which
+			// is captured, as in the capture log.
+			desc: "setup that captures",
+			sgf:  "(;GM[1]SZ[5];B[ba];W[aa];AB[ab];W[dd])",
+			path: "0x3",
+			expBoard: `[. B . . .]
+[B . . . .]
+[. . . . .]
+[. . . . .]
+[. . . . .]`,
+			expTurn: color.Black,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tp, err := movetree.ParsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n := tp.Apply(g.Root)
+			b, err := g.BoardAt(n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tc.expBoard {
+				t.Errorf("got board\n%v\nbut expected\n%v", b, tc.expBoard)
+			}
+			if got := g.PlayerToMove(n); got != tc.expTurn {
+				t.Errorf("PlayerToMove()=%v, but expected %v", got, tc.expTurn)
+			}
+
+			// The engine should agree with the replayed board.
+			e, err := g.NewGameEngine()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var path []*movetree.Node
+			for cur := n; cur != g.Root; cur = cur.Parent {
+				path = append([]*movetree.Node{cur}, path...)
+			}
+			for _, cur := range path {
+				if err := e.ApplyNode(cur); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := e.Board().String(); got != tc.expBoard {
+				t.Errorf("got engine board\n%v\nbut expected\n%v", got, tc.expBoard)
+			}
+			if got := e.ToPlay(); got != tc.expTurn {
+				t.Errorf("engine ToPlay()=%v, but expected %v", got, tc.expTurn)
+			}
+		})
+	}
+}
+
+func TestBoardAt_SetupWithoutLiberties(t *testing.T) {
+	// A setup stone that's left without liberties, and doesn't capture, is an
+	// illegal position for every walk of the movetree.
+	g, err := sgf.Parse("(;GM[1]SZ[5];B[ba];W[dd];B[ab];AW[aa])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := g.Root
+	for n.Next(0) != nil {
+		n = n.Next(0)
+	}
+	if _, err := g.BoardAt(n); !errors.Is(err, board.InvalidBoardState) {
+		t.Errorf("BoardAt() got error %v, but expected %v", err, board.InvalidBoardState)
+	}
+	if _, err := g.CaptureLog(); !errors.Is(err, board.InvalidBoardState) {
+		t.Errorf("CaptureLog() got error %v, but expected %v", err, board.InvalidBoardState)
+	}
+}
+
+func TestBoardAt_Rectangular(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[4:2];B[da];W[ca];B[ab];W[db];B[ac])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := g.Root
+	for i := 0; i < 4; i++ {
+		n = n.Next(0)
+	}
+	b, err := g.BoardAt(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "[. . W *]\n[B . . W]"; b.String() != exp {
+		t.Errorf("got board\n%v\nbut expected\n%v", b, exp)
+	}
+	if _, err := g.BoardAt(n.Next(0)); !errors.Is(err, board.ErrOffBoard) {
+		t.Errorf("BoardAt() for a move below the board got error %v, but expected %v", err, board.ErrOffBoard)
+	}
+	if errs := g.ValidateMoves(rules.Unspecified); len(errs) != 1 {
+		t.Errorf("ValidateMoves() got errors %v, but expected 1 error", errs)
+	}
+
+	out, err := sgf.Serialize(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "SZ[4:2]") {
+		t.Errorf("Serialize() got %q, but expected SZ[4:2]", out)
+	}
+}
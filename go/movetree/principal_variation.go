@@ -0,0 +1,72 @@
+package movetree
+
+import (
+	"github.com/otrego/clamshell/go/color"
+)
+
+// PrincipalVariation returns the line that the engine values recorded in the
+// tree prefer, starting after the root. At each branch, the line follows the
+// child with the best value for the player to move: the highest for black and
+// the lowest for white. The value of a node is its V property, which like in
+// the SGF specification is positive when black is ahead, or else the win rate
+// from its analysis, which is taken to be black's. If the children don't all
+// have the same kind of value, the line follows the main line at that branch.
+// Ties are broken in favor of the earlier variation.
+func (mt *MoveTree) PrincipalVariation() []*Node {
+	var out []*Node
+	for n := mt.Root; len(n.Children) > 0; {
+		best := n.Children[0]
+		if values, ok := branchValues(n.Children); ok {
+			black := mt.PlayerToMove(n) == color.Black
+			bestValue := values[0]
+			for i, c := range n.Children {
+				if (black && values[i] > bestValue) || (!black && values[i] < bestValue) {
+					bestValue, best = values[i], c
+				}
+			}
+		}
+		out = append(out, best)
+		n = best
+	}
+	return out
+}
+
+// branchValues returns the values of the children at a branch (see
+// PrincipalVariation). ok is false unless all the children have a V property,
+// or all of them have a win rate.
+func branchValues(children []*Node) (values []float64, ok bool) {
+	if values, ok := childValues(children, nodeValue); ok {
+		return values, true
+	}
+	return childValues(children, nodeWinRate)
+}
+
+// childValues returns the values of the children given by value, with ok
+// false if any child doesn't have a value.
+func childValues(children []*Node, value func(*Node) (float64, bool)) ([]float64, bool) {
+	values := make([]float64, len(children))
+	for i, c := range children {
+		v, ok := value(c)
+		if !ok {
+			return nil, false
+		}
+		values[i] = v
+	}
+	return values, true
+}
+
+// nodeValue returns the value of a node from its V property.
+func nodeValue(n *Node) (float64, bool) {
+	if n.Value == nil {
+		return 0, false
+	}
+	return *n.Value, true
+}
+
+// nodeWinRate returns the win rate of a node from its analysis.
+func nodeWinRate(n *Node) (float64, bool) {
+	if n.Analysis == nil || n.Analysis.WinRate == nil {
+		return 0, false
+	}
+	return *n.Analysis.WinRate, true
+}
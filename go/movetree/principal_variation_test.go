@@ -0,0 +1,78 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestPrincipalVariation(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		opts *prop.ParseOptions
+		exp  []string
+	}{
+		{
+			desc: "no branches",
+			sgf:  "(;GM[1];B[aa];W[bb])",
+			exp:  []string{"B[aa]", "W[bb]"},
+		},
+		{
+			desc: "black picks the highest value",
+			sgf:  "(;GM[1](;B[aa]V[-1])(;B[bb]V[3.5])(;B[cc]V[2]))",
+			exp:  []string{"B[bb]"},
+		},
+		{
+			desc: "white picks the lowest value",
+			sgf:  "(;GM[1];B[aa](;W[bb]V[1])(;W[cc]V[-2])(;W[dd]V[0]))",
+			exp:  []string{"B[aa]", "W[cc]"},
+		},
+		{
+			desc: "follows the best child at each branch",
+			sgf:  "(;GM[1](;B[aa]V[1])(;B[bb]V[2](;W[cc]V[1];B[dd])(;W[dd]V[0.5];B[cc])))",
+			exp:  []string{"B[bb]", "W[dd]", "B[cc]"},
+		},
+		{
+			desc: "missing value falls back to the main line",
+			sgf:  "(;GM[1](;B[aa]V[1])(;B[bb]))",
+			exp:  []string{"B[aa]"},
+		},
+		{
+			desc: "ties favor the earlier variation",
+			sgf:  "(;GM[1](;B[aa]V[1])(;B[bb]V[2])(;B[cc]V[2]))",
+			exp:  []string{"B[bb]"},
+		},
+		{
+			desc: "win rates from the analysis",
+			sgf:  "(;GM[1](;B[aa]C[winrate: 40%])(;B[bb]C[winrate: 55%]))",
+			opts: &prop.ParseOptions{ExtractAnalysis: true},
+			exp:  []string{"B[bb]C[winrate: 55%]"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.FromString(tc.sgf).WithOptions(tc.opts).Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, n := range g.PrincipalVariation() {
+				s, err := prop.ConvertNodeWithOptions(n, &prop.SerializeOptions{Exclude: []prop.Prop{"V"}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, s)
+			}
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Errorf("PrincipalVariation() got diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+	if pv := movetree.New().PrincipalVariation(); len(pv) != 0 {
+		t.Errorf("PrincipalVariation() of an empty tree = %v, but expected none", pv)
+	}
+}
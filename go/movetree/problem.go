@@ -0,0 +1,78 @@
+package movetree
+
+import "strings"
+
+// maxProblemLength is the longest main line (in moves) that we will still
+// consider to be a problem. Game records are nearly always much longer.
+const maxProblemLength = 30
+
+// problemCommentWords are words that, when found in a comment at the end of a
+// variation, typically indicate the variation is part of a problem solution.
+var problemCommentWords = []string{"correct", "right", "wrong", "incorrect", "failure", "success"}
+
+// IsProblem is a heuristic that indicates whether this movetree looks like a
+// problem (tsumego) rather than a game record. The heuristic is conservative;
+// when in doubt it returns false.
+//
+// A movetree is considered a problem when all of the following are true:
+//
+//   - The root has stone placements (problems start from a setup position).
+//   - The root has no handicap (HA), since handicap games also start with
+//     placements.
+//   - The main line is short (at most maxProblemLength moves).
+//
+// and additionally at least one of these signals are present:
+//
+//   - A non-root node is marked good-for-black or good-for-white (GB/GW).
+//   - A comment at the end of a variation talks about a correct/wrong answer.
+//   - There are multiple variations within the first few moves.
+func (mt *MoveTree) IsProblem() bool {
+	root := mt.Root
+	if root == nil || len(root.Placements) == 0 {
+		return false
+	}
+	if mt.Handicap() != 0 {
+		return false
+	}
+
+	mainLen := 0
+	for n := root.Next(0); n != nil; n = n.Next(0) {
+		mainLen++
+	}
+	if mainLen > maxProblemLength {
+		return false
+	}
+
+	hasGoodForMarks := false
+	hasAnswerComments := false
+	hasShallowBranches := false
+	root.Traverse(func(n *Node) {
+		if n == root {
+			return
+		}
+		if pa := n.PositionAnnotation; pa != nil && (pa.Type == GoodForBlack || pa.Type == GoodForWhite) {
+			hasGoodForMarks = true
+		}
+		if len(n.Children) == 0 && isAnswerComment(n.Comment) {
+			hasAnswerComments = true
+		}
+	})
+	for n, depth := root, 0; n != nil && depth < 3; n, depth = n.Next(0), depth+1 {
+		if len(n.Children) > 1 {
+			hasShallowBranches = true
+		}
+	}
+	return hasGoodForMarks || hasAnswerComments || hasShallowBranches
+}
+
+// isAnswerComment indicates whether a comment looks like it's describing a
+// problem answer.
+func isAnswerComment(c string) bool {
+	c = strings.ToLower(c)
+	for _, w := range problemCommentWords {
+		if strings.Contains(c, w) {
+			return true
+		}
+	}
+	return false
+}
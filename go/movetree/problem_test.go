@@ -0,0 +1,67 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestIsProblem(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		exp  bool
+	}{
+		{
+			desc: "tsumego",
+			sgf: `(;GM[1]FF[4]CA[UTF-8]AP[Glift]ST[2]SZ[19]
+C[Black to play.]
+AW[pa][qa][nb][ob][qb][oc][pc][md][pd][ne][oe]
+AB[na][ra][mb][rb][lc][qc][ld][od][qd][le][pe][qe][mf][nf][of][pg]
+(;B[mc];W[nc]C[White lives.])
+(;B[ma]
+	(;W[oa];B[nc];W[nd];B[mc]C[White dies.]GB[1])
+	(;W[nd];B[mc];W[oa];B[nc]C[White dies.]GB[1]))
+(;B[nc];W[mc]C[White lives]))`,
+			exp: true,
+		},
+		{
+			desc: "tsumego, no GB: answer comments",
+			sgf: `(;GM[1]SZ[9]AB[cc][dc]AW[cd][dd]
+(;B[ed]C[Correct!])
+(;B[ce];W[ed]C[Wrong]))`,
+			exp: true,
+		},
+		{
+			desc: "game record",
+			sgf: `(;GM[1]FF[4]SZ[19]KM[6.5]RU[Japanese];B[pd];W[dc];B[qp];W[cq];B[np]
+;W[qf];B[nc];W[qd];B[qc];W[rc];B[qe];W[rd];B[re];W[pe];B[rf];W[pc];B[od]
+;W[pb];B[qg];W[df];B[oh];W[lq];B[lo];W[jp];B[mq];W[lp];B[mo];W[qo];B[pp]
+;W[rp];B[rq];W[pl];B[qm];W[ql];B[qn];W[qi];B[nl];W[nk])`,
+			exp: false,
+		},
+		{
+			desc: "handicap game record with a variation",
+			sgf: `(;GM[1]SZ[19]HA[2]AB[pd][dp]
+(;W[dd];B[pp])
+(;W[pp];B[dd]))`,
+			exp: false,
+		},
+		{
+			desc: "setup position, no problem signals",
+			sgf:  `(;GM[1]SZ[19]AB[pd]AW[dp];B[dd];W[pp])`,
+			exp:  false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := g.IsProblem(); got != tc.exp {
+				t.Errorf("IsProblem()=%v, but expected %v", got, tc.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package movetree
+
+// CopyProperties returns a new node, without a parent or children, with deep
+// copies of the properties of node n: its move, placements, annotations,
+// markup, times, game info, and raw SGF properties. It can be used to
+// snapshot the properties of a node, to be restored with SetProperties.
+func (n *Node) CopyProperties() *Node {
+	return n.copyNode()
+}
+
+// SetProperties replaces the properties of node n with deep copies of those
+// of node c (see CopyProperties). The place of n in the tree is kept, as is
+// the state that isn't written to SGF: its analysis, analysis data, and
+// resignation mark.
+func (n *Node) SetProperties(c *Node) {
+	cp := c.copyNode()
+	cp.Parent, cp.Children, cp.moveNum, cp.varNum = n.Parent, n.Children, n.moveNum, n.varNum
+	cp.Analysis, cp.analysisData, cp.resign = n.Analysis, n.analysisData, n.resign
+	*n = *cp
+}
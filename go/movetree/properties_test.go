@@ -0,0 +1,39 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestSetProperties(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[9];B[ee]C[Before]TR[cc](;W[cc])(;W[gg]))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := g.Root.Next(0)
+	n.SetResign(true)
+	snapshot := n.CopyProperties()
+	if snapshot.Parent != nil || snapshot.Children != nil {
+		t.Fatalf("got a snapshot in the tree, but expected a detached node")
+	}
+
+	n.Comment = "After"
+	n.Marks = nil
+	n.SGFProperties["XX"] = []string{"raw"}
+	snapshot.SGFProperties["YY"] = []string{"raw"}
+	n.SetProperties(snapshot)
+	delete(snapshot.SGFProperties, "YY")
+
+	got, err := sgf.Serialize(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]C[Before]TR[cc]YY[raw](;W[cc])(;W[gg]))"
+	if got != exp {
+		t.Errorf("got %q, but expected %q", got, exp)
+	}
+	if n.Parent != g.Root || len(n.Children) != 2 || n.MoveNum() != 1 || !n.IsResign() {
+		t.Errorf("got node %+v, but expected its place in the tree and its resignation mark to be kept", n)
+	}
+}
@@ -0,0 +1,159 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/otrego/clamshell/go/color"
+)
+
+// ErrParseResult indicates that a game result could not be parsed.
+var ErrParseResult = errors.New("error parsing result")
+
+// ResultReason is the reason a game ended.
+type ResultReason string
+
+const (
+	// ReasonUnspecified indicates that a player won, but the reason is unknown.
+	// Ex: B+
+	ReasonUnspecified ResultReason = ""
+	// ReasonScore indicates that the game was won by points. Ex: B+3.5
+	ReasonScore ResultReason = "Score"
+	// ReasonResign indicates that the game was won by resignation. Ex: B+R
+	ReasonResign ResultReason = "Resign"
+	// ReasonTime indicates that the game was won on time. Ex: B+T
+	ReasonTime ResultReason = "Time"
+	// ReasonForfeit indicates that the game was won by forfeit. Ex: B+F
+	ReasonForfeit ResultReason = "Forfeit"
+	// ReasonDraw indicates that the game was a draw (jigo). Ex: 0
+	ReasonDraw ResultReason = "Draw"
+	// ReasonVoid indicates that the game has no result, because it was
+	// annulled. Ex: Void
+	ReasonVoid ResultReason = "Void"
+	// ReasonUnknown indicates that the result of the game is unknown. Ex: ?
+	ReasonUnknown ResultReason = "Unknown"
+)
+
+// Result is the result of a game, as stored in the RE property.
+type Result struct {
+	// Winner of the game. Empty for draws, void games, and unknown results.
+	Winner color.Color `json:"winner,omitempty"`
+
+	// Reason the game ended.
+	Reason ResultReason `json:"reason,omitempty"`
+
+	// Margin is the number of points the game was won by. Only set when the
+	// Reason is ReasonScore.
+	Margin *float64 `json:"margin,omitempty"`
+}
+
+// ParseResult parses a result string, such as W+3.5, B+Resign, Draw, Void, or
+// ? (unknown).
+func ParseResult(s string) (*Result, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "0", "draw", "jigo":
+		return &Result{Reason: ReasonDraw}, nil
+	case "void":
+		return &Result{Reason: ReasonVoid}, nil
+	case "?":
+		return &Result{Reason: ReasonUnknown}, nil
+	}
+
+	parts := strings.SplitN(s, "+", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%w: result %q must be of the form <color>+<reason>", ErrParseResult, s)
+	}
+	res := &Result{}
+	switch parts[0] {
+	case "B", "b":
+		res.Winner = color.Black
+	case "W", "w":
+		res.Winner = color.White
+	default:
+		return nil, fmt.Errorf("%w: result %q has unknown winner %q", ErrParseResult, s, parts[0])
+	}
+
+	switch reason := parts[1]; strings.ToLower(reason) {
+	case "":
+		res.Reason = ReasonUnspecified
+	case "r", "resign":
+		res.Reason = ReasonResign
+	case "t", "time":
+		res.Reason = ReasonTime
+	case "f", "forfeit":
+		res.Reason = ReasonForfeit
+	default:
+		margin, err := strconv.ParseFloat(reason, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: result %q has unknown reason %q", ErrParseResult, s, reason)
+		}
+		if math.IsInf(margin, 0) || math.IsNaN(margin) || margin < 0 {
+			return nil, fmt.Errorf("%w: result %q has illegal margin %q", ErrParseResult, s, reason)
+		}
+		res.Reason = ReasonScore
+		res.Margin = &margin
+	}
+	return res, nil
+}
+
+// String returns the canonical SGF form of the result.
+func (r *Result) String() string {
+	switch r.Reason {
+	case ReasonDraw:
+		return "0"
+	case ReasonVoid:
+		return "Void"
+	case ReasonUnknown:
+		return "?"
+	}
+	prefix := string(r.Winner) + "+"
+	switch r.Reason {
+	case ReasonResign:
+		return prefix + "R"
+	case ReasonTime:
+		return prefix + "T"
+	case ReasonForfeit:
+		return prefix + "F"
+	case ReasonScore:
+		if r.Margin != nil {
+			// Margins are formatted like komi: with one decimal, or two for the
+			// quarter points of Ing komi (ex: W+0.75).
+			prec := 1
+			if _, fp := math.Modf(*r.Margin); fp == 0.25 || fp == 0.75 {
+				prec = 2
+			}
+			return prefix + strconv.FormatFloat(*r.Margin, 'f', prec, 64)
+		}
+	}
+	return prefix
+}
+
+// InferKomi infers the komi from the margin of the result (RE), given the
+// score difference on the board: black's score minus white's score, without
+// komi. This reconciles games that are scored, but have no komi (KM). For
+// example, if black leads by 4 on the board and the result is W+3.5, the komi
+// is 7.5.
+//
+// ok is false if there's no result, or if the result has no margin, such as a
+// win by resignation or on time.
+func (gi *GameInfo) InferKomi(boardDiff float64) (komi float64, ok bool) {
+	if gi == nil || gi.Result == nil {
+		return 0, false
+	}
+	res := gi.Result
+	switch {
+	case res.Reason == ReasonDraw:
+		return boardDiff, true
+	case res.Reason != ReasonScore || res.Margin == nil:
+		return 0, false
+	case res.Winner == color.White:
+		return boardDiff + *res.Margin, true
+	case res.Winner == color.Black:
+		return boardDiff - *res.Margin, true
+	}
+	return 0, false
+}
@@ -0,0 +1,200 @@
+package movetree
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+)
+
+func float64p(f float64) *float64 {
+	return &f
+}
+
+func TestParseResult(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		in     string
+		exp    *Result
+		expStr string
+		expErr error
+	}{
+		{
+			desc:   "black wins by resignation",
+			in:     "B+Resign",
+			exp:    &Result{Winner: color.Black, Reason: ReasonResign},
+			expStr: "B+R",
+		},
+		{
+			desc:   "white wins by resignation, short",
+			in:     "W+R",
+			exp:    &Result{Winner: color.White, Reason: ReasonResign},
+			expStr: "W+R",
+		},
+		{
+			desc:   "white wins by points",
+			in:     "W+3.5",
+			exp:    &Result{Winner: color.White, Reason: ReasonScore, Margin: float64p(3.5)},
+			expStr: "W+3.5",
+		},
+		{
+			desc:   "white wins by points, whole",
+			in:     "W+3",
+			exp:    &Result{Winner: color.White, Reason: ReasonScore, Margin: float64p(3)},
+			expStr: "W+3.0",
+		},
+		{
+			desc:   "white wins by points, Ing komi",
+			in:     "W+0.75",
+			exp:    &Result{Winner: color.White, Reason: ReasonScore, Margin: float64p(0.75)},
+			expStr: "W+0.75",
+		},
+		{
+			desc:   "black wins on time",
+			in:     "B+T",
+			exp:    &Result{Winner: color.Black, Reason: ReasonTime},
+			expStr: "B+T",
+		},
+		{
+			desc:   "black wins by forfeit",
+			in:     "B+Forfeit",
+			exp:    &Result{Winner: color.Black, Reason: ReasonForfeit},
+			expStr: "B+F",
+		},
+		{
+			desc:   "black wins, unspecified",
+			in:     "B+",
+			exp:    &Result{Winner: color.Black, Reason: ReasonUnspecified},
+			expStr: "B+",
+		},
+		{
+			desc:   "draw",
+			in:     "Draw",
+			exp:    &Result{Reason: ReasonDraw},
+			expStr: "0",
+		},
+		{
+			desc:   "draw, zero",
+			in:     "0",
+			exp:    &Result{Reason: ReasonDraw},
+			expStr: "0",
+		},
+		{
+			desc:   "void",
+			in:     "Void",
+			exp:    &Result{Reason: ReasonVoid},
+			expStr: "Void",
+		},
+		{
+			desc:   "unknown",
+			in:     "?",
+			exp:    &Result{Reason: ReasonUnknown},
+			expStr: "?",
+		},
+		{
+			desc:   "bad winner",
+			in:     "X+R",
+			expErr: ErrParseResult,
+		},
+		{
+			desc:   "bad reason",
+			in:     "B+Zork",
+			expErr: ErrParseResult,
+		},
+		{
+			desc:   "negative margin",
+			in:     "B+-3.5",
+			expErr: ErrParseResult,
+		},
+		{
+			desc:   "infinite margin",
+			in:     "B+Inf",
+			expErr: ErrParseResult,
+		},
+		{
+			desc:   "nonsense",
+			in:     "Zork",
+			expErr: ErrParseResult,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseResult(tc.in)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if !cmp.Equal(got, tc.exp) {
+				t.Errorf("ParseResult(%q)=%v, but expected %v. Diff=%s", tc.in, got, tc.exp, cmp.Diff(got, tc.exp))
+			}
+			if s := got.String(); s != tc.expStr {
+				t.Errorf("String()=%q, but expected %q", s, tc.expStr)
+			}
+		})
+	}
+}
+
+func TestGameInfo_InferKomi(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		re        string
+		boardDiff float64
+		expKomi   float64
+		expOK     bool
+	}{
+		{
+			desc:      "white wins by points",
+			re:        "W+3.5",
+			boardDiff: 4,
+			expKomi:   7.5,
+			expOK:     true,
+		},
+		{
+			desc:      "black wins by points",
+			re:        "B+0.5",
+			boardDiff: 7,
+			expKomi:   6.5,
+			expOK:     true,
+		},
+		{
+			desc:      "draw",
+			re:        "0",
+			boardDiff: 6,
+			expKomi:   6,
+			expOK:     true,
+		},
+		{
+			desc:      "resignation",
+			re:        "W+R",
+			boardDiff: 4,
+		},
+		{
+			desc:      "time",
+			re:        "B+T",
+			boardDiff: 4,
+		},
+		{
+			desc:      "no result",
+			boardDiff: 4,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			gi := &GameInfo{}
+			if tc.re != "" {
+				res, err := ParseResult(tc.re)
+				if err != nil {
+					t.Fatal(err)
+				}
+				gi.Result = res
+			}
+			komi, ok := gi.InferKomi(tc.boardDiff)
+			if komi != tc.expKomi || ok != tc.expOK {
+				t.Errorf("InferKomi(%v)=%v, %v, but expected %v, %v", tc.boardDiff, komi, ok, tc.expKomi, tc.expOK)
+			}
+		})
+	}
+}
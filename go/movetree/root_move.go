@@ -0,0 +1,53 @@
+package movetree
+
+import (
+	"github.com/otrego/clamshell/go/color"
+)
+
+// rootMoveProps are the raw properties that belong to the move of a node, and
+// so are moved with a root move.
+var rootMoveProps = []string{"KO", "MN"}
+
+// hasRootMove indicates whether there's a move on the root. Some files put
+// the first move on the root, rather than on a child of the root.
+func (mt *MoveTree) hasRootMove() bool {
+	return mt.Root.Move != nil && mt.Root.Move.Color() != color.Empty
+}
+
+// MoveNumber returns the number of the move at node n. This is the move number
+// of the node (see Node.MoveNum), except when there's a move on the root: the
+// root move is then move 1, and the other moves are numbered after it.
+func (mt *MoveTree) MoveNumber(n *Node) int {
+	if mt.hasRootMove() {
+		return n.MoveNum() + 1
+	}
+	return n.MoveNum()
+}
+
+// splitRootMove pushes a move on the root into a new child of the root, which
+// gets the root's children.
+func (mt *MoveTree) splitRootMove() {
+	root := mt.Root
+	if !mt.hasRootMove() {
+		return
+	}
+	child := NewNode()
+	child.Move, root.Move = root.Move, nil
+	child.MoveAnnotation, root.MoveAnnotation = root.MoveAnnotation, nil
+	child.resign, root.resign = root.resign, false
+	child.TimeLeft, root.TimeLeft = root.TimeLeft, nil
+	child.OvertimeLeft, root.OvertimeLeft = root.OvertimeLeft, nil
+	for _, p := range rootMoveProps {
+		if v, ok := root.SGFProperties[p]; ok {
+			child.SGFProperties[p] = v
+			delete(root.SGFProperties, p)
+		}
+	}
+	for _, c := range root.Children {
+		c.Parent = child
+		child.AddChild(c)
+	}
+	root.Children = nil
+	child.Parent = root
+	root.AddChild(child)
+}
@@ -0,0 +1,71 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestRootMove(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		sgf           string
+		expMoveNumber int
+		expLint       bool
+		expNormalized string
+	}{
+		{
+			desc:          "move on the root",
+			sgf:           "(;GM[1]SZ[9]B[ee]BL[300];W[cc])",
+			expMoveNumber: 2,
+			expLint:       true,
+			expNormalized: "(;FF[4]GM[1]CA[UTF-8]SZ[9];B[ee]BL[300];W[cc])",
+		},
+		{
+			desc:          "placements on the root",
+			sgf:           "(;GM[1]SZ[9]AB[ee];W[cc])",
+			expMoveNumber: 1,
+			expNormalized: "(;FF[4]GM[1]CA[UTF-8]SZ[9]AB[ee];W[cc])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			last := mainLineEnd(g)
+			if got := g.MoveNumber(last); got != tc.expMoveNumber {
+				t.Errorf("MoveNumber()=%d, but expected %d", got, tc.expMoveNumber)
+			}
+			if got := g.PlayerToMove(last); got != color.Black {
+				t.Errorf("PlayerToMove()=%v, but expected %v", got, color.Black)
+			}
+			var gotLint bool
+			for _, li := range g.Lint() {
+				gotLint = gotLint || li.Code == movetree.LintRootMove
+			}
+			if gotLint != tc.expLint {
+				t.Errorf("got %s lint issue: %v, but expected %v", movetree.LintRootMove, gotLint, tc.expLint)
+			}
+
+			g.Normalize()
+			got, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.expNormalized {
+				t.Errorf("got normalized SGF %q, but expected %q", got, tc.expNormalized)
+			}
+			last = mainLineEnd(g)
+			if got := g.MoveNumber(last); got != tc.expMoveNumber {
+				t.Errorf("after normalizing, MoveNumber()=%d, but expected %d", got, tc.expMoveNumber)
+			}
+			if got := g.PlayerToMove(g.Root); got != color.Black {
+				t.Errorf("after normalizing, PlayerToMove(root)=%v, but expected %v", got, color.Black)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package movetree
+
+import (
+	"fmt"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// SetMoveOptions contains options for setting the move of a node. A nil
+// *SetMoveOptions is valid and means that the defaults are used.
+type SetMoveOptions struct {
+	// Validate indicates that the move must be legal on the board after the
+	// parent node: it's an error to play on an occupied point, to capture
+	// one's own group, or to retake a ko.
+	Validate bool
+}
+
+// validate indicates whether the move should be validated.
+func (o *SetMoveOptions) validate() bool {
+	return o != nil && o.Validate
+}
+
+// SetMove sets the move of the node to a stone of color c at point pt, or to a
+// pass if pt is nil. When validating, the move is played on the board after
+// the parent node (and the node's placements), returning the captured stones,
+// and the move isn't set if it's illegal. Otherwise, the move is set without
+// any checks, and no captures are returned.
+func (n *Node) SetMove(c color.Color, pt *point.Point, opts *SetMoveOptions) (move.List, error) {
+	m := move.New(c, pt)
+	if m.IsPass() {
+		n.Move = m
+		n.setCapturesHint(nil)
+		return nil, nil
+	}
+	if !opts.validate() {
+		n.Move = m
+		n.capturesHint, n.hasCapturesHint = nil, false
+		return nil, nil
+	}
+	b, err := n.boardBeforeMove()
+	if err != nil {
+		return nil, err
+	}
+	captured, err := b.PlaceStone(m)
+	if err != nil {
+		return nil, fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+	}
+	n.Move = m
+	n.setCapturesHint(captured)
+	return captured, nil
+}
+
+// boardBeforeMove computes the board position at node n, before its move has
+// been played.
+func (n *Node) boardBeforeMove() (*board.Board, error) {
+	root := n
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	mt := &MoveTree{Root: root}
+	b := mt.newBoard()
+	if n.Parent != nil {
+		var err error
+		if b, _, err = mt.replay(n.Parent); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := applyPlacements(b, n); err != nil {
+		return nil, fmt.Errorf("%w: at move %d: %v", ErrBoardPosition, n.MoveNum(), err)
+	}
+	return b, nil
+}
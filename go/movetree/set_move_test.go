@@ -0,0 +1,133 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestSetMove(t *testing.T) {
+	validate := &movetree.SetMoveOptions{Validate: true}
+	testCases := []struct {
+		desc        string
+		sgf         string
+		path        string
+		col         color.Color
+		pt          *point.Point
+		opts        *movetree.SetMoveOptions
+		expCaptured move.List
+		expErr      error
+	}{
+		{
+			desc: "legal move",
+			sgf:  "(;GM[1]SZ[5];B[aa];W[bb];B[])",
+			path: "0x3",
+			col:  color.White,
+			pt:   point.New(2, 2),
+			opts: validate,
+		},
+		{
+			desc:   "occupied point, validated",
+			sgf:    "(;GM[1]SZ[5];B[aa];W[bb];B[])",
+			path:   "0x3",
+			col:    color.White,
+			pt:     point.New(1, 1),
+			opts:   validate,
+			expErr: board.IllegalMove,
+		},
+		{
+			desc: "occupied point, not validated",
+			sgf:  "(;GM[1]SZ[5];B[aa];W[bb];B[])",
+			path: "0x3",
+			col:  color.White,
+			pt:   point.New(1, 1),
+		},
+		{
+			desc:   "occupied by a placement on the node",
+			sgf:    "(;GM[1]SZ[5];B[aa];AW[cc]W[dd])",
+			path:   "0x2",
+			col:    color.White,
+			pt:     point.New(2, 2),
+			opts:   validate,
+			expErr: board.IllegalMove,
+		},
+		{
+			desc:        "capture",
+			sgf:         "(;GM[1]SZ[5];B[aa];W[ba];B[cc];W[])",
+			path:        "0x4",
+			col:         color.White,
+			pt:          point.New(0, 1),
+			opts:        validate,
+			expCaptured: move.List{move.New(color.Black, point.New(0, 0))},
+		},
+		{
+			desc:   "suicide",
+			sgf:    "(;GM[1]SZ[5];B[ba];W[cc];B[ab];W[])",
+			path:   "0x4",
+			col:    color.White,
+			pt:     point.New(0, 0),
+			opts:   validate,
+			expErr: board.ErrSuicide,
+		},
+		{
+			desc:   "ko",
+			sgf:    "(;GM[1]SZ[5]AB[ba][ab][bc]AW[ca][db][cc][bb];B[cb];W[])",
+			path:   "0x2",
+			col:    color.White,
+			pt:     point.New(1, 1),
+			opts:   validate,
+			expErr: board.IllegalMove,
+		},
+		{
+			desc: "pass",
+			sgf:  "(;GM[1]SZ[5];B[aa])",
+			path: "0",
+			col:  color.Black,
+			opts: validate,
+		},
+		{
+			desc: "on the root",
+			sgf:  "(;GM[1]SZ[5]AB[aa])",
+			path: "-",
+			col:  color.White,
+			pt:   point.New(1, 1),
+			opts: validate,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tp, err := movetree.ParsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n := tp.Apply(g.Root)
+			prev := n.Move
+			captured, err := n.SetMove(tc.col, tc.pt, tc.opts)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				if n.Move != prev {
+					t.Errorf("got move %v after an error, but expected it to be unchanged", n.Move)
+				}
+				return
+			}
+			if exp := move.New(tc.col, tc.pt); !n.Move.Equal(exp) {
+				t.Errorf("got move %v, but expected %v", n.Move, exp)
+			}
+			if captured.String() != tc.expCaptured.String() {
+				t.Errorf("got captured stones %v, but expected %v", captured, tc.expCaptured)
+			}
+		})
+	}
+}
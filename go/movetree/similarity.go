@@ -0,0 +1,43 @@
+package movetree
+
+import (
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+)
+
+// PrefixSimilarity returns the fraction of the main line that's the same in
+// both movetrees, move for move from the start: the length of the common
+// prefix of the moves, divided by the length of the longer main line. Nodes
+// without a move, such as setup nodes, are skipped. Two movetrees without any
+// moves have a similarity of 1.
+//
+// Unlike Fingerprint, which only matches identical games, this finds games
+// that diverge late, such as re-uploads with a different endgame. The moves
+// are compared as recorded, so games in different orientations don't match.
+func (mt *MoveTree) PrefixSimilarity(other *MoveTree) float64 {
+	a, b := mt.mainLineMoves(), other.mainLineMoves()
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 1
+	}
+	common := 0
+	for common < len(a) && common < len(b) && a[common].Equal(b[common]) {
+		common++
+	}
+	return float64(common) / float64(longest)
+}
+
+// mainLineMoves returns the moves of the main line, skipping the nodes
+// without a move.
+func (mt *MoveTree) mainLineMoves() move.List {
+	var out move.List
+	for n := mt.Root; n != nil; n = n.Next(0) {
+		if n.Move != nil && n.Move.Color() != color.Empty {
+			out = append(out, n.Move)
+		}
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// linearGame returns a 19x19 game with the given moves, alternating colors
+// starting with black.
+func linearGame(pts []*point.Point) *movetree.MoveTree {
+	g := movetree.New()
+	n := g.Root
+	for i, pt := range pts {
+		c := color.Black
+		if i%2 == 1 {
+			c = color.White
+		}
+		child := movetree.NewNode()
+		child.Move = move.New(c, pt)
+		child.Parent = n
+		n.AddChild(child)
+		n = child
+	}
+	return g
+}
+
+func TestPrefixSimilarity(t *testing.T) {
+	// opening is 40 distinct moves.
+	var opening []*point.Point
+	for i := 0; i < 40; i++ {
+		opening = append(opening, point.New(i%19, i/19))
+	}
+	endgame := func(row, n int) []*point.Point {
+		out := append([]*point.Point{}, opening...)
+		for i := 0; i < n; i++ {
+			out = append(out, point.New(i, row))
+		}
+		return out
+	}
+
+	testCases := []struct {
+		desc string
+		a, b []*point.Point
+		exp  float64
+	}{
+		{
+			desc: "diverging after 40 moves",
+			a:    endgame(10, 10),
+			b:    endgame(11, 10),
+			exp:  0.8,
+		},
+		{
+			desc: "diverging with different lengths",
+			a:    endgame(10, 20),
+			b:    endgame(11, 10),
+			exp:  40.0 / 60,
+		},
+		{
+			desc: "prefix of the other game",
+			a:    opening,
+			b:    endgame(10, 10),
+			exp:  0.8,
+		},
+		{
+			desc: "identical",
+			a:    opening,
+			b:    opening,
+			exp:  1,
+		},
+		{
+			desc: "different first move",
+			a:    []*point.Point{point.New(3, 3)},
+			b:    []*point.Point{point.New(15, 15)},
+			exp:  0,
+		},
+		{
+			desc: "no moves",
+			exp:  1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, b := linearGame(tc.a), linearGame(tc.b)
+			if got := a.PrefixSimilarity(b); got != tc.exp {
+				t.Errorf("PrefixSimilarity()=%v, but expected %v", got, tc.exp)
+			}
+			if got := b.PrefixSimilarity(a); got != tc.exp {
+				t.Errorf("PrefixSimilarity() in reverse=%v, but expected %v", got, tc.exp)
+			}
+		})
+	}
+}
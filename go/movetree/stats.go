@@ -0,0 +1,82 @@
+package movetree
+
+// TreeStats are statistics about the nodes of a movetree.
+type TreeStats struct {
+	// Nodes is the total number of nodes, including the root.
+	Nodes int
+
+	// MainLineLength is the number of nodes on the main line after the root.
+	MainLineLength int
+
+	// Variations is the number of variations other than the main line: each
+	// node with n children starts n-1 variations.
+	Variations int
+
+	// MaxDepth is the largest move number of any node.
+	MaxDepth int
+
+	// BranchingFactor is the average number of children of the nodes that have
+	// children. It's 0 if the root has no children.
+	BranchingFactor float64
+
+	// Commented is the number of nodes with comments.
+	Commented int
+
+	// Marked is the number of nodes with markup: marks, labels, or the raw
+	// markup properties (ex: CR or LB).
+	Marked int
+}
+
+// markupProps are the SGF markup properties that can be raw properties: the
+// ones without a converter (ex: SL), and markup that was set directly as raw
+// properties.
+var markupProps = []string{"AR", "CR", "LB", "LN", "MA", "SL", "SQ", "TR"}
+
+// Stats computes statistics about the nodes of the movetree, in a single
+// traversal.
+func (mt *MoveTree) Stats() TreeStats {
+	var st TreeStats
+	inner, children := 0, 0
+	var visit func(n *Node, mainLine bool)
+	visit = func(n *Node, mainLine bool) {
+		st.Nodes++
+		if mainLine {
+			st.MainLineLength = n.MoveNum()
+		}
+		if n.MoveNum() > st.MaxDepth {
+			st.MaxDepth = n.MoveNum()
+		}
+		if n.Comment != "" {
+			st.Commented++
+		}
+		if n.hasMarkup() {
+			st.Marked++
+		}
+		if len(n.Children) > 0 {
+			inner++
+			children += len(n.Children)
+			st.Variations += len(n.Children) - 1
+		}
+		for i, c := range n.Children {
+			visit(c, mainLine && i == 0)
+		}
+	}
+	visit(mt.Root, true)
+	if inner > 0 {
+		st.BranchingFactor = float64(children) / float64(inner)
+	}
+	return st
+}
+
+// hasMarkup indicates whether the node has any markup.
+func (n *Node) hasMarkup() bool {
+	if len(n.Marks) > 0 || len(n.Labels) > 0 || len(n.Arrows) > 0 || len(n.Lines) > 0 {
+		return true
+	}
+	for _, p := range markupProps {
+		if _, ok := n.SGFProperties[p]; ok {
+			return true
+		}
+	}
+	return false
+}
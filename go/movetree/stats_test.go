@@ -0,0 +1,53 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestStats(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		exp  movetree.TreeStats
+	}{
+		{
+			desc: "root only",
+			sgf:  "(;GM[1])",
+			exp:  movetree.TreeStats{Nodes: 1},
+		},
+		{
+			desc: "branching tree",
+			sgf: `(;GM[1]C[Review]
+;B[aa]CR[bb]
+(;W[ab];B[ac]LB[cc:A];W[ad])
+(;W[ba]C[Variation]
+  (;B[ca];W[da];B[ea];W[fa])
+  (;B[cb]))
+(;W[bb]C[Another]))`,
+			exp: movetree.TreeStats{
+				Nodes:           12,
+				MainLineLength:  4,
+				Variations:      3,
+				MaxDepth:        6,
+				BranchingFactor: 11.0 / 8.0,
+				Commented:       3,
+				Marked:          2,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := g.Stats(); !cmp.Equal(got, tc.exp) {
+				t.Errorf("Stats()=%+v, but expected %+v. Diff=%s", got, tc.exp, cmp.Diff(got, tc.exp))
+			}
+		})
+	}
+}
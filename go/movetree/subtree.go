@@ -0,0 +1,173 @@
+package movetree
+
+import (
+	"strconv"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// Subtree returns a new movetree rooted at node n. The board position at n
+// becomes the placements on the new root, and the nodes below n are copied
+// under the new root, so the original movetree is left unmodified. Note that
+// placements can't represent a ko, so any ko at n is lost.
+//
+// The game info and raw root properties of the original movetree are copied
+// to the new root. Since the moves restart at 1 in the new movetree, the
+// original move numbers are preserved with MN on the first moves.
+func (mt *MoveTree) Subtree(n *Node) (*MoveTree, error) {
+	b, err := mt.BoardAt(n)
+	if err != nil {
+		return nil, err
+	}
+
+	root := NewNode()
+	for k, v := range mt.Root.SGFProperties {
+		root.SGFProperties[k] = append([]string{}, v...)
+	}
+	if n != mt.Root {
+		for k, v := range n.SGFProperties {
+			root.SGFProperties[k] = append([]string{}, v...)
+		}
+		n.copyTime(root)
+	}
+	root.Comment = n.Comment
+	n.copyMarkup(root)
+	root.Placements = b.StoneState()
+	root.GameInfo = mt.Root.GameInfo.copy()
+	if root.GameInfo == nil {
+		root.GameInfo = &GameInfo{}
+	}
+	if n.Move != nil {
+		root.GameInfo.Player = n.Move.Color().Opposite()
+	}
+
+	for _, c := range n.Children {
+		cc := c.copyTree()
+		if n.MoveNum() > 0 && cc.Move != nil {
+			cc.SGFProperties["MN"] = []string{strconv.Itoa(c.MoveNum())}
+		}
+		cc.Parent = root
+		root.AddChild(cc)
+	}
+	return &MoveTree{Root: root}, nil
+}
+
+// copy returns a copy of the game info.
+func (gi *GameInfo) copy() *GameInfo {
+	if gi == nil {
+		return nil
+	}
+	out := *gi
+	if gi.Komi != nil {
+		komi := *gi.Komi
+		out.Komi = &komi
+	}
+	if gi.MainTime != nil {
+		tm := *gi.MainTime
+		out.MainTime = &tm
+	}
+	if gi.Application != nil {
+		app := *gi.Application
+		out.Application = &app
+	}
+	if gi.Result != nil {
+		res := *gi.Result
+		if gi.Result.Margin != nil {
+			margin := *gi.Result.Margin
+			res.Margin = &margin
+		}
+		out.Result = &res
+	}
+	return &out
+}
+
+// copyTree returns a deep copy of the node and its descendants. The copy has no
+// parent. The copied nodes keep their move and variation numbers, rather than
+// being renumbered as each child is added, so that copying is linear in the
+// size of the tree; adding the copy to another node renumbers it once.
+func (n *Node) copyTree() *Node {
+	out := n.copyNode()
+	out.moveNum, out.varNum = n.moveNum, n.varNum
+	for _, c := range n.Children {
+		cc := c.copyTree()
+		cc.Parent = out
+		out.Children = append(out.Children, cc)
+	}
+	return out
+}
+
+// copyNode returns a deep copy of the node, without its parent or children.
+func (n *Node) copyNode() *Node {
+	out := NewNode()
+	out.Move = n.Move
+	out.Placements = append(out.Placements, n.Placements...)
+	out.Comment = n.Comment
+	if n.MoveAnnotation != nil {
+		ma := *n.MoveAnnotation
+		out.MoveAnnotation = &ma
+	}
+	out.Name = n.Name
+	if n.PositionAnnotation != nil {
+		pa := *n.PositionAnnotation
+		out.PositionAnnotation = &pa
+	}
+	out.Hotspot = n.Hotspot
+	if n.Value != nil {
+		v := *n.Value
+		out.Value = &v
+	}
+	n.copyMarkup(out)
+	n.copyTime(out)
+	if n.Analysis != nil {
+		an := *n.Analysis
+		out.Analysis = &an
+	}
+	out.GameInfo = n.GameInfo.copy()
+	out.analysisData = n.analysisData
+	out.resign = n.resign
+	for k, v := range n.SGFProperties {
+		out.SGFProperties[k] = append([]string{}, v...)
+	}
+	return out
+}
+
+// copyMarkup copies the marks, labels, arrows, and lines of the node to
+// another node.
+func (n *Node) copyMarkup(to *Node) {
+	if n.Marks != nil {
+		to.Marks = make(map[point.Point]MarkType)
+		for pt, m := range n.Marks {
+			to.Marks[pt] = m
+		}
+	}
+	if n.Labels != nil {
+		to.Labels = make(map[point.Point]string)
+		for pt, l := range n.Labels {
+			to.Labels[pt] = l
+		}
+	}
+	if n.Arrows != nil {
+		to.Arrows = append([]Line{}, n.Arrows...)
+	}
+	if n.Lines != nil {
+		to.Lines = append([]Line{}, n.Lines...)
+	}
+}
+
+// copyTime copies the time left and the overtime left of the node to another
+// node.
+func (n *Node) copyTime(to *Node) {
+	if n.TimeLeft != nil {
+		to.TimeLeft = make(map[color.Color]float64)
+		for c, t := range n.TimeLeft {
+			to.TimeLeft[c] = t
+		}
+	}
+	if n.OvertimeLeft != nil {
+		to.OvertimeLeft = make(map[color.Color]int)
+		for c, o := range n.OvertimeLeft {
+			to.OvertimeLeft[c] = o
+		}
+	}
+}
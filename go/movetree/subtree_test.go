@@ -0,0 +1,130 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestSubtree(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		path string
+		// expMN is the expected MN value on the first move of the subtree.
+		expMN     []string
+		expPlayer color.Color
+	}{
+		{
+			desc:      "from the root",
+			sgf:       "(;GM[1]SZ[9]PB[Zork]AB[cc];W[dd];B[ee])",
+			path:      "",
+			expPlayer: color.Empty,
+		},
+		{
+			desc:      "mid-game, with capture",
+			sgf:       "(;GM[1]SZ[9]PB[Zork];B[ba];W[aa];B[ab];W[cc];B[dd])",
+			path:      "0x3",
+			expMN:     []string{"4"},
+			expPlayer: color.White,
+		},
+		{
+			desc:      "in a variation",
+			sgf:       "(;GM[1]SZ[9]KM[6.5](;B[aa];W[bb])(;B[cc];W[dd];B[ee](;W[ff])(;W[gg])))",
+			path:      "1-0-0",
+			expMN:     []string{"4"},
+			expPlayer: color.White,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path, err := movetree.ParsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n := path.Apply(g.Root)
+
+			sub, err := g.Subtree(n)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			exp, err := g.BoardAt(n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := sub.BoardAt(sub.Root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The ko point can't be represented with placements, so only the
+			// stones are compared.
+			if !cmp.Equal(got.FullBoardState(), exp.FullBoardState()) {
+				t.Errorf("subtree starting board was\n%v\nbut expected\n%v", got, exp)
+			}
+
+			if !cmp.Equal(sub.Root.GameInfo, g.Root.GameInfo, cmp.FilterPath(func(p cmp.Path) bool {
+				return p.Last().String() == ".Player"
+			}, cmp.Ignore())) {
+				t.Errorf("subtree game info was %+v, but expected %+v", sub.Root.GameInfo, g.Root.GameInfo)
+			}
+			if got := sub.Root.GameInfo.Player; got != tc.expPlayer {
+				t.Errorf("subtree player was %q, but expected %q", got, tc.expPlayer)
+			}
+			if got := sub.Root.GameInfo.BlackPlayer; got != g.Root.GameInfo.BlackPlayer {
+				t.Errorf("subtree black player was %q, but expected %q", got, g.Root.GameInfo.BlackPlayer)
+			}
+
+			if len(sub.Root.Children) != len(n.Children) {
+				t.Fatalf("subtree root had %d children, but expected %d", len(sub.Root.Children), len(n.Children))
+			}
+			for i, c := range sub.Root.Children {
+				if c.Parent != sub.Root {
+					t.Errorf("child %d was not parented to the new root", i)
+				}
+				if c.MoveNum() != 1 {
+					t.Errorf("child %d had move number %d, but expected 1", i, c.MoveNum())
+				}
+				if got := c.SGFProperties["MN"]; !cmp.Equal(got, tc.expMN) {
+					t.Errorf("child %d had MN %v, but expected %v", i, got, tc.expMN)
+				}
+				if n.Children[i].Parent != n {
+					t.Errorf("original child %d was re-parented", i)
+				}
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[9](;B[aa];W[bb](;B[cc];W[dd])(;B[ee]))(;B[ff];W[gg];B[hh](;W[ii])(;W[ah];B[bh])))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := g.Clone()
+	var compare func(got, exp, parent *movetree.Node)
+	compare = func(got, exp, parent *movetree.Node) {
+		path := movetree.PathTo(exp).CompactString()
+		if got == exp || got.Parent != parent {
+			t.Errorf("node %s wasn't copied under its copied parent", path)
+		}
+		if got.MoveNum() != exp.MoveNum() || got.VarNum() != exp.VarNum() {
+			t.Errorf("node %s had move %d in variation %d, but expected move %d in variation %d",
+				path, got.MoveNum(), got.VarNum(), exp.MoveNum(), exp.VarNum())
+		}
+		if len(got.Children) != len(exp.Children) {
+			t.Fatalf("node %s had %d children, but expected %d", path, len(got.Children), len(exp.Children))
+		}
+		for i := range exp.Children {
+			compare(got.Children[i], exp.Children[i], got)
+		}
+	}
+	compare(c.Root, g.Root, nil)
+}
@@ -0,0 +1,173 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/otrego/clamshell/go/color"
+)
+
+// ErrTimeControl indicates that the time control could not be parsed.
+var ErrTimeControl = errors.New("error parsing time control")
+
+// OvertimeSystem is the overtime system of a time control.
+type OvertimeSystem string
+
+const (
+	// OvertimeUnknown indicates an overtime (OT) that isn't recognized.
+	OvertimeUnknown OvertimeSystem = "Unknown"
+
+	// OvertimeAbsolute indicates that there's no overtime: the game is lost
+	// when the main time runs out.
+	OvertimeAbsolute OvertimeSystem = "Absolute"
+
+	// OvertimeByoYomi indicates Japanese byo-yomi: a number of periods of a
+	// fixed length, where a period is used up when a move takes longer than
+	// it. Ex: 5x30 byo-yomi
+	OvertimeByoYomi OvertimeSystem = "ByoYomi"
+
+	// OvertimeCanadian indicates Canadian overtime: a number of moves must be
+	// played in each period. Ex: 25/600 Canadian
+	OvertimeCanadian OvertimeSystem = "Canadian"
+
+	// OvertimeFischer indicates a Fischer clock, where time is added after
+	// each move. Ex: 10 Fischer
+	OvertimeFischer OvertimeSystem = "Fischer"
+)
+
+var (
+	// canadianRegexp finds the number of moves and the period length of
+	// Canadian overtime (ex: "25/600 Canadian").
+	canadianRegexp = regexp.MustCompile(`(\d+)\s*(?:moves?)?\s*/\s*(\d+(?:\.\d+)?)`)
+
+	// incrementRegexp finds the increment of a Fischer clock (ex: "10 Fischer",
+	// "Fischer +10").
+	incrementRegexp = regexp.MustCompile(`\+?\s*(\d+(?:\.\d+)?)`)
+)
+
+// TimeControl is the time control of a game, from the main time (TM) and the
+// overtime (OT).
+type TimeControl struct {
+	System OvertimeSystem
+
+	// MainTime is the main time of each player, in seconds.
+	MainTime float64
+
+	// Periods is the number of byo-yomi periods.
+	Periods int
+
+	// Moves is the number of moves to be played in each Canadian period.
+	Moves int
+
+	// PeriodTime is the length of a byo-yomi or Canadian period, in seconds.
+	PeriodTime float64
+
+	// Increment is the time added after each move by a Fischer clock, in
+	// seconds.
+	Increment float64
+
+	// TM and OT are the SGF values of the properties, which are empty if the
+	// properties aren't set.
+	TM, OT string
+}
+
+// ParseTimeControl parses a time control from the main time (TM) and the
+// overtime (OT), either of which may be empty. Without an overtime, the time
+// control is absolute. An unrecognized overtime is OvertimeUnknown, which
+// isn't an error; only an invalid main time is.
+func ParseTimeControl(tm, ot string) (*TimeControl, error) {
+	tc := &TimeControl{System: OvertimeAbsolute, TM: tm, OT: ot}
+	if s := strings.TrimSpace(tm); s != "" {
+		t, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid main time TM[%s]", ErrTimeControl, tm)
+		}
+		tc.MainTime = t
+	}
+
+	// The regexps guarantee that the values are numbers.
+	lower := strings.ToLower(strings.TrimSpace(ot))
+	switch {
+	case lower == "", lower == "none", strings.Contains(lower, "absolute"):
+	case strings.Contains(lower, "canadian"):
+		m := canadianRegexp.FindStringSubmatch(ot)
+		if m == nil {
+			tc.System = OvertimeUnknown
+			break
+		}
+		tc.System = OvertimeCanadian
+		tc.Moves, _ = strconv.Atoi(m[1])
+		tc.PeriodTime, _ = strconv.ParseFloat(m[2], 64)
+	case strings.Contains(lower, "fischer"):
+		m := incrementRegexp.FindStringSubmatch(ot)
+		if m == nil {
+			tc.System = OvertimeUnknown
+			break
+		}
+		tc.System = OvertimeFischer
+		tc.Increment, _ = strconv.ParseFloat(m[1], 64)
+	default:
+		// Byo-yomi is the most common overtime, and is often recorded
+		// without a name (ex: "3x30").
+		m := overtimeRegexp.FindStringSubmatch(ot)
+		if m == nil {
+			tc.System = OvertimeUnknown
+			break
+		}
+		tc.System = OvertimeByoYomi
+		tc.Periods, _ = strconv.Atoi(m[1])
+		tc.PeriodTime, _ = strconv.ParseFloat(m[2], 64)
+	}
+	return tc, nil
+}
+
+// TimeControl returns the time control of the game, from the main time (TM)
+// and the overtime (OT) on the root, or nil if neither is set.
+func (mt *MoveTree) TimeControl() (*TimeControl, error) {
+	tm, ot, ok := timeControlValues(mt.Root)
+	if !ok {
+		return nil, nil
+	}
+	return ParseTimeControl(tm, ot)
+}
+
+// timeControlValues returns the SGF values of the main time (TM) and the
+// overtime (OT) in the game info of the root, and whether either is set.
+func timeControlValues(root *Node) (tm, ot string, ok bool) {
+	gi := root.GameInfo
+	if gi == nil || (gi.MainTime == nil && gi.Overtime == "") {
+		return "", "", false
+	}
+	if gi.MainTime != nil {
+		tm = strconv.FormatFloat(*gi.MainTime, 'f', -1, 64)
+	}
+	return tm, gi.Overtime, true
+}
+
+// MainTimeDuration returns the main time of each player (TM) as a duration,
+// and whether it was recorded.
+func (gi *GameInfo) MainTimeDuration() (time.Duration, bool) {
+	if gi == nil || gi.MainTime == nil {
+		return 0, false
+	}
+	return seconds(*gi.MainTime), true
+}
+
+// TimeLeftDuration returns the time left for the player after the move (BL or
+// WL) as a duration, and whether it was recorded.
+func (n *Node) TimeLeftDuration(c color.Color) (time.Duration, bool) {
+	t, ok := n.TimeLeft[c]
+	if !ok {
+		return 0, false
+	}
+	return seconds(t), true
+}
+
+// seconds converts a time in seconds, as recorded in SGFs, to a duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,124 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestParseTimeControl(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		tm, ot string
+		exp    *movetree.TimeControl
+		expErr error
+	}{
+		{
+			desc: "byo-yomi",
+			tm:   "1800",
+			ot:   "5x30 byo-yomi",
+			exp: &movetree.TimeControl{System: movetree.OvertimeByoYomi, MainTime: 1800,
+				Periods: 5, PeriodTime: 30, TM: "1800", OT: "5x30 byo-yomi"},
+		},
+		{
+			desc: "unnamed byo-yomi",
+			tm:   "600",
+			ot:   "3x20",
+			exp: &movetree.TimeControl{System: movetree.OvertimeByoYomi, MainTime: 600,
+				Periods: 3, PeriodTime: 20, TM: "600", OT: "3x20"},
+		},
+		{
+			desc: "canadian",
+			tm:   "3600",
+			ot:   "25/600 Canadian",
+			exp: &movetree.TimeControl{System: movetree.OvertimeCanadian, MainTime: 3600,
+				Moves: 25, PeriodTime: 600, TM: "3600", OT: "25/600 Canadian"},
+		},
+		{
+			desc: "fischer",
+			tm:   "300",
+			ot:   "Fischer +10",
+			exp: &movetree.TimeControl{System: movetree.OvertimeFischer, MainTime: 300,
+				Increment: 10, TM: "300", OT: "Fischer +10"},
+		},
+		{
+			desc: "absolute",
+			tm:   "900",
+			exp:  &movetree.TimeControl{System: movetree.OvertimeAbsolute, MainTime: 900, TM: "900"},
+		},
+		{
+			desc: "unknown overtime",
+			tm:   "900",
+			ot:   "hourglass",
+			exp:  &movetree.TimeControl{System: movetree.OvertimeUnknown, MainTime: 900, TM: "900", OT: "hourglass"},
+		},
+		{
+			desc:   "invalid main time",
+			tm:     "30 minutes",
+			expErr: movetree.ErrTimeControl,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := movetree.ParseTimeControl(tc.tm, tc.ot)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("ParseTimeControl(%q, %q) got error %v, but expected %v", tc.tm, tc.ot, err, tc.expErr)
+			}
+			if !cmp.Equal(got, tc.exp) {
+				t.Errorf("ParseTimeControl(%q, %q)=%+v, but expected %+v. Diff=%s", tc.tm, tc.ot, got, tc.exp, cmp.Diff(got, tc.exp))
+			}
+		})
+	}
+}
+
+func TestTimeControl(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]TM[1800]OT[5x30 byo-yomi];B[aa])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc, err := g.TimeControl()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tc.System != movetree.OvertimeByoYomi || tc.Periods != 5 || tc.PeriodTime != 30 || tc.MainTime != 1800 {
+		t.Errorf("TimeControl()=%+v, but expected byo-yomi with 5 periods of 30s", tc)
+	}
+
+	g, err = sgf.Parse("(;GM[1];B[aa])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tc, err := g.TimeControl(); tc != nil || err != nil {
+		t.Errorf("TimeControl() without TM or OT=%+v, %v, but expected nil", tc, err)
+	}
+}
+
+func TestDurations(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]TM[1800];B[aa]BL[1795.5];W[bb]WL[1790])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, ok := g.Root.GameInfo.MainTimeDuration(); !ok || d != 30*time.Minute {
+		t.Errorf("MainTimeDuration()=%v, %v, but expected %v, true", d, ok, 30*time.Minute)
+	}
+	b := g.Root.Children[0]
+	if d, ok := b.TimeLeftDuration(color.Black); !ok || d != 1795500*time.Millisecond {
+		t.Errorf("TimeLeftDuration(Black)=%v, %v, but expected %v, true", d, ok, 1795500*time.Millisecond)
+	}
+	if d, ok := b.TimeLeftDuration(color.White); ok {
+		t.Errorf("TimeLeftDuration(White)=%v, %v, but expected 0, false", d, ok)
+	}
+
+	g, err = sgf.Parse("(;GM[1];B[aa])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, ok := g.Root.GameInfo.MainTimeDuration(); ok {
+		t.Errorf("MainTimeDuration()=%v, %v without TM, but expected 0, false", d, ok)
+	}
+}
@@ -0,0 +1,87 @@
+package movetree
+
+import (
+	"fmt"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+)
+
+// TrainingSample is a single position from a game, for training a
+// policy-network: the board before a move, and the move that was played.
+type TrainingSample struct {
+	// Board is the board position before the move was played.
+	Board *board.Board
+
+	// Move is the move played from the position. It may be a pass.
+	Move *move.Move
+
+	// ToPlay is the color of the player to move.
+	ToPlay color.Color
+}
+
+// Positions walks the main line of the movetree and returns a training sample
+// for each move. Nodes without a move (such as setup-only nodes, which just
+// have placements) don't produce a sample, but their placements are applied
+// to the boards of the following samples.
+func (mt *MoveTree) Positions() ([]TrainingSample, error) {
+	var samples []TrainingSample
+	b := mt.newBoard()
+	for n := mt.Root; n != nil; n = n.Next(0) {
+		if _, err := applyPlacements(b, n); err != nil {
+			return nil, fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+		}
+		if n.Move == nil || n.Move.Color() == color.Empty {
+			continue
+		}
+		samples = append(samples, TrainingSample{
+			Board:  b.Clone(),
+			Move:   n.Move,
+			ToPlay: n.Move.Color(),
+		})
+		if n.Move.IsPass() {
+			continue
+		}
+		if _, err := b.PlaceStone(n.Move); err != nil {
+			return nil, fmt.Errorf("at move %d: %w", n.MoveNum(), err)
+		}
+	}
+	return samples, nil
+}
+
+// Action returns the move as an index into a policy vector of size*size+1
+// actions: y*size+x for a point on the board, and size*size for a pass.
+func (s TrainingSample) Action() int {
+	size := len(s.Board.FullBoardState())
+	if s.Move.IsPass() {
+		return size * size
+	}
+	pt := s.Move.Point()
+	return pt.Y()*size + pt.X()
+}
+
+// Planes returns the board as color planes, for conversion to a tensor. The
+// first plane has a 1 for each stone of the player to move and the second a 1
+// for each stone of the opponent. Planes are indexed by [plane][y][x].
+func (s TrainingSample) Planes() [2][][]int8 {
+	var planes [2][][]int8
+	state := s.Board.FullBoardState()
+	for i := range planes {
+		planes[i] = make([][]int8, len(state))
+		for y, row := range state {
+			planes[i][y] = make([]int8, len(row))
+		}
+	}
+	for y, row := range state {
+		for x, c := range row {
+			switch c {
+			case s.ToPlay:
+				planes[0][y][x] = 1
+			case s.ToPlay.Opposite():
+				planes[1][y][x] = 1
+			}
+		}
+	}
+	return planes
+}
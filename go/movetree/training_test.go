@@ -0,0 +1,54 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestPositions(t *testing.T) {
+	// The second node is setup-only and the game ends with passes.
+	g, err := sgf.Parse("(;GM[1]SZ[3];B[aa];AW[cc];W[bb](;B[ab];W[];B[])(;B[ca]))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples, err := g.Positions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expColors := []color.Color{color.Black, color.White, color.Black, color.White, color.Black}
+	expActions := []int{0, 4, 3, 9, 9}
+	if len(samples) != len(expColors) {
+		t.Fatalf("got %d samples, but expected %d", len(samples), len(expColors))
+	}
+	for i, s := range samples {
+		if s.ToPlay != expColors[i] {
+			t.Errorf("sample %d: ToPlay=%v, but expected %v", i, s.ToPlay, expColors[i])
+		}
+		if s.Action() != expActions[i] {
+			t.Errorf("sample %d: Action()=%d, but expected %d", i, s.Action(), expActions[i])
+		}
+	}
+
+	// Before B[ab]: black at aa, white at bb and cc. Black is to play.
+	planes := samples[2].Planes()
+	expPlayer := [][]int8{
+		{1, 0, 0},
+		{0, 0, 0},
+		{0, 0, 0},
+	}
+	expOpponent := [][]int8{
+		{0, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+	if !cmp.Equal(planes[0], expPlayer) {
+		t.Errorf("player plane got %v, but expected %v", planes[0], expPlayer)
+	}
+	if !cmp.Equal(planes[1], expOpponent) {
+		t.Errorf("opponent plane got %v, but expected %v", planes[1], expOpponent)
+	}
+}
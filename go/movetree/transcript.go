@@ -0,0 +1,57 @@
+package movetree
+
+import (
+	"github.com/otrego/clamshell/go/move"
+)
+
+// CommentEntry is a commented node in a transcript.
+type CommentEntry struct {
+	// Path is the path from the root to the node.
+	Path Path
+
+	// MoveNum is the move number of the node.
+	MoveNum int
+
+	// Move is the move of the node. Nil for nodes without a move, such as the
+	// root.
+	Move *move.Move
+
+	// Comment is the comment text of the node.
+	Comment string
+}
+
+// TranscriptOptions contains options for Transcript. A nil *TranscriptOptions
+// is valid and means that the defaults are used.
+type TranscriptOptions struct {
+	// IncludeVariations includes the comments of the variations. The
+	// comments of a variation follow the comment of the main-line move that
+	// the variation is an alternative to.
+	IncludeVariations bool
+}
+
+// Transcript returns the comments of the movetree, in main-line order, which
+// is useful for exporting a review. Nodes without comments are skipped.
+func (mt *MoveTree) Transcript(opts *TranscriptOptions) []CommentEntry {
+	var entries []CommentEntry
+	variations := opts != nil && opts.IncludeVariations
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		for ; n != nil; n = n.Next(0) {
+			if n.Comment != "" {
+				entries = append(entries, CommentEntry{
+					Path:    PathTo(n),
+					MoveNum: n.MoveNum(),
+					Move:    n.Move,
+					Comment: n.Comment,
+				})
+			}
+			if variations && n.Parent != nil && n.VarNum() == 0 {
+				for i := 1; i < len(n.Parent.Children); i++ {
+					walk(n.Parent.Children[i])
+				}
+			}
+		}
+	}
+	walk(mt.Root)
+	return entries
+}
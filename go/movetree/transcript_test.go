@@ -0,0 +1,75 @@
+package movetree_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+type transcriptLine struct {
+	Path    string
+	MoveNum int
+	Comment string
+}
+
+func TestTranscript(t *testing.T) {
+	g, err := sgf.Parse(`(;GM[1]SZ[9]C[Review]
+;B[ee]C[Standard opening]
+;W[cc]
+(;B[gg]C[Solid]
+;W[cg]C[Approach]
+(;B[gc])
+(;B[ec]C[Also fine]))
+(;B[gc]C[Too loose]
+;W[gg]C[White takes the point])
+(;B[dd]))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc string
+		opts *movetree.TranscriptOptions
+		exp  []transcriptLine
+	}{
+		{
+			desc: "main line",
+			exp: []transcriptLine{
+				{Path: "[]", MoveNum: 0, Comment: "Review"},
+				{Path: "[0]", MoveNum: 1, Comment: "Standard opening"},
+				{Path: "[0 0 0]", MoveNum: 3, Comment: "Solid"},
+				{Path: "[0 0 0 0]", MoveNum: 4, Comment: "Approach"},
+			},
+		},
+		{
+			desc: "with variations",
+			opts: &movetree.TranscriptOptions{IncludeVariations: true},
+			exp: []transcriptLine{
+				{Path: "[]", MoveNum: 0, Comment: "Review"},
+				{Path: "[0]", MoveNum: 1, Comment: "Standard opening"},
+				{Path: "[0 0 0]", MoveNum: 3, Comment: "Solid"},
+				{Path: "[0 0 1]", MoveNum: 3, Comment: "Too loose"},
+				{Path: "[0 0 1 0]", MoveNum: 4, Comment: "White takes the point"},
+				{Path: "[0 0 0 0]", MoveNum: 4, Comment: "Approach"},
+				{Path: "[0 0 0 0 1]", MoveNum: 5, Comment: "Also fine"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []transcriptLine
+			for _, e := range g.Transcript(tc.opts) {
+				got = append(got, transcriptLine{Path: fmt.Sprint([]int(e.Path)), MoveNum: e.MoveNum, Comment: e.Comment})
+				if e.MoveNum > 0 && e.Move == nil {
+					t.Errorf("entry %v has no move", e)
+				}
+			}
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Errorf("Transcript diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
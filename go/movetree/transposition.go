@@ -0,0 +1,154 @@
+package movetree
+
+import (
+	"github.com/otrego/clamshell/go/board"
+)
+
+// TranspositionOptions contains options for Transpositions. A nil
+// *TranspositionOptions is valid and means that the defaults are used.
+type TranspositionOptions struct {
+	// Canonical also groups positions that are rotations or reflections of
+	// each other.
+	Canonical bool
+}
+
+// position is a node and the board position at the node.
+type position struct {
+	node  *Node
+	board *board.Board
+}
+
+// Transpositions returns the groups of nodes, in different variations, where
+// the board positions are identical. Positions are bucketed by Zobrist hash,
+// and the boards are compared to guard against hash collisions. The ko and
+// the player to play are ignored.
+//
+// A node only counts as reaching a position if the position doesn't already
+// occur earlier in its variation, so that passes, nodes without moves, and
+// repetitions within a variation aren't reported. The groups, and the nodes
+// in each group, are in depth-first order. Variations with illegal moves are
+// skipped from the illegal move on.
+func (mt *MoveTree) Transpositions(opts *TranspositionOptions) [][]*Node {
+	canonical := opts != nil && opts.Canonical
+	hash := (*board.Board).Hash
+	same := (*board.Board).Equal
+	if canonical {
+		hash = (*board.Board).CanonicalHash
+		same = (*board.Board).Equivalent
+	}
+
+	p, err := mt.NewPlayback()
+	if err != nil {
+		return nil
+	}
+	buckets := make(map[uint64][]position)
+	var order []uint64
+	var onPath []*board.Board
+	var walk func()
+	walk = func() {
+		b := p.Board().Clone()
+		h := hash(b)
+		repeated := false
+		for _, prev := range onPath {
+			if hash(prev) == h && same(prev, b) {
+				repeated = true
+				break
+			}
+		}
+		if !repeated {
+			if _, ok := buckets[h]; !ok {
+				order = append(order, h)
+			}
+			buckets[h] = append(buckets[h], position{node: p.Node(), board: b})
+		}
+		onPath = append(onPath, b)
+		for i := range p.Node().Children {
+			if p.ForwardVariation(i) != nil {
+				continue
+			}
+			walk()
+			p.Back()
+		}
+		onPath = onPath[:len(onPath)-1]
+	}
+	walk()
+
+	var groups [][]*Node
+	for _, h := range order {
+		// Split the bucket into groups of equal positions, in case of hash
+		// collisions.
+		var bucketGroups [][]position
+	bucket:
+		for _, pos := range buckets[h] {
+			for i, g := range bucketGroups {
+				if same(g[0].board, pos.board) {
+					bucketGroups[i] = append(g, pos)
+					continue bucket
+				}
+			}
+			bucketGroups = append(bucketGroups, []position{pos})
+		}
+		for _, g := range bucketGroups {
+			if len(g) < 2 {
+				continue
+			}
+			var nodes []*Node
+			for _, pos := range g {
+				nodes = append(nodes, pos.node)
+			}
+			groups = append(groups, nodes)
+		}
+	}
+	return groups
+}
+
+// Repetition is a move whose board position repeats an earlier position of its
+// variation, as in a triple ko.
+type Repetition struct {
+	// Node is the node with the repeating move.
+	Node *Node
+
+	// Earlier is the earliest node in the variation with the same position.
+	Earlier *Node
+}
+
+// Repetitions returns the moves that repeat an earlier board position of
+// their variation, which are illegal under positional superko (see
+// board.ErrSuperko), in depth-first order. As with Transpositions, positions
+// are compared by Zobrist hash and then by their boards, and the ko and the
+// player to play are ignored. Passes and nodes without moves aren't reported,
+// and variations with illegal moves are skipped from the illegal move on.
+func (mt *MoveTree) Repetitions() []Repetition {
+	p, err := mt.NewPlayback()
+	if err != nil {
+		return nil
+	}
+	var reps []Repetition
+	var onPath []position
+	var hashes []uint64
+	var walk func()
+	walk = func() {
+		n, b := p.Node(), p.Board().Clone()
+		h := b.Hash()
+		if n.Move != nil && !n.IsPass() {
+			for i, prev := range onPath {
+				if hashes[i] == h && prev.board.Equal(b) {
+					reps = append(reps, Repetition{Node: n, Earlier: prev.node})
+					break
+				}
+			}
+		}
+		onPath = append(onPath, position{node: n, board: b})
+		hashes = append(hashes, h)
+		for i := range n.Children {
+			if p.ForwardVariation(i) != nil {
+				continue
+			}
+			walk()
+			p.Back()
+		}
+		onPath, hashes = onPath[:len(onPath)-1], hashes[:len(hashes)-1]
+	}
+	walk()
+	return reps
+}
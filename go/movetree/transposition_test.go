@@ -0,0 +1,105 @@
+package movetree_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestTranspositions(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		opts *movetree.TranspositionOptions
+		// exp contains the paths of the nodes in each group.
+		exp [][]string
+	}{
+		{
+			desc: "two branches transpose",
+			sgf:  "(;GM[1]SZ[9](;B[ee];W[cc];B[gg];W[cg])(;B[gg];W[cg];B[ee];W[cc]))",
+			exp:  [][]string{{"[0 0 0 0]", "[1 0 0 0]"}},
+		},
+		{
+			desc: "passes and empty nodes aren't transpositions",
+			sgf:  "(;GM[1]SZ[9];B[ee];W[];C[Comment];B[cc])",
+		},
+		{
+			desc: "rotations only transpose when canonical",
+			sgf:  "(;GM[1]SZ[9](;B[aa];W[ee])(;B[ia];W[ee]))",
+		},
+		{
+			desc: "canonical",
+			sgf:  "(;GM[1]SZ[9](;B[aa];W[ee])(;B[ia];W[ee]))",
+			opts: &movetree.TranspositionOptions{Canonical: true},
+			exp:  [][]string{{"[0]", "[1]"}, {"[0 0]", "[1 0]"}},
+		},
+		{
+			desc: "identical variations after placements",
+			sgf:  "(;GM[1]SZ[9](;AB[ee];W[cc])(;B[ee];W[cc]))",
+			exp:  [][]string{{"[0]", "[1]"}, {"[0 0]", "[1 0]"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got [][]string
+			for _, group := range g.Transpositions(tc.opts) {
+				var paths []string
+				for _, n := range group {
+					paths = append(paths, movetree.PathTo(n).String())
+				}
+				got = append(got, paths)
+			}
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Errorf("Transpositions diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRepetitions(t *testing.T) {
+	// cycle is a game on a 4x4 board where the last move, a capture, repeats
+	// the position after B[bc].
+	const cycle = "(;GM[1]SZ[4];B[ab];W[da];B[db];W[ad];B[cc];W[cb];B[cd];W[dc];B[bc]"
+	testCases := []struct {
+		desc string
+		sgf  string
+		// exp contains the paths of the repeating node and the earlier node.
+		exp [][]string
+	}{
+		{
+			desc: "no repetitions",
+			sgf:  "(;GM[1]SZ[9];B[ee];W[cc];B[gg];W[])",
+		},
+		{
+			desc: "repeated position",
+			sgf:  cycle + ";W[dd];B[db];W[dc])",
+			exp:  [][]string{{"[0 0 0 0 0 0 0 0 0 0 0 0]", "[0 0 0 0 0 0 0 0 0]"}},
+		},
+		{
+			desc: "repeated position in one variation",
+			sgf:  cycle + "(;W[aa])(;W[dd];B[db];W[dc]))",
+			exp:  [][]string{{"[0 0 0 0 0 0 0 0 0 1 0 0]", "[0 0 0 0 0 0 0 0 0]"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got [][]string
+			for _, r := range g.Repetitions() {
+				got = append(got, []string{movetree.PathTo(r.Node).String(), movetree.PathTo(r.Earlier).String()})
+			}
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Errorf("Repetitions diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
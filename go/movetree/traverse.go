@@ -0,0 +1,56 @@
+package movetree
+
+import (
+	"errors"
+)
+
+// SkipChildren is returned by a WalkFunc to skip the descendants of the node
+// it was called with. It's not returned as an error by Walk.
+var SkipChildren = errors.New("skip the children of this node")
+
+// A WalkFunc is called by Walk for each node, with the path to the node from
+// the node where the walk started. The path is reused between calls, so it
+// must be cloned to be kept (see Path.Clone). If it returns an error other
+// than SkipChildren, the walk stops and Walk returns the error.
+type WalkFunc func(n *Node, tp Path) error
+
+// Walk visits node n and its descendants depth-first, visiting a node before
+// its children, and the children in the order of their variations. Unlike
+// Traverse, the whole of a variation is visited before the next one, and fn
+// can skip a subtree or stop the walk.
+func (n *Node) Walk(fn WalkFunc) error {
+	err := n.walk(fn, Path{})
+	if err == SkipChildren {
+		return nil
+	}
+	return err
+}
+
+func (n *Node) walk(fn WalkFunc, tp Path) error {
+	if err := fn(n, tp); err != nil {
+		return err
+	}
+	for i, c := range n.Children {
+		err := c.walk(fn, append(tp, i))
+		if err != nil && err != SkipChildren {
+			return err
+		}
+	}
+	return nil
+}
+
+// MainLine returns node n and the nodes that follow it on the main line (the
+// 0th variations), up to the end of the line.
+func (n *Node) MainLine() []*Node {
+	var out []*Node
+	for cur := n; cur != nil; cur = cur.Next(0) {
+		out = append(out, cur)
+	}
+	return out
+}
+
+// MainLine returns the nodes of the main line of the movetree, starting with
+// the root.
+func (mt *MoveTree) MainLine() []*Node {
+	return mt.Root.MainLine()
+}
@@ -0,0 +1,94 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestWalk(t *testing.T) {
+	errStop := errors.New("stop")
+	testCases := []struct {
+		desc string
+		// fn returns the result of visiting the node at the given path.
+		fn     func(tp movetree.Path) error
+		exp    []string
+		expErr error
+	}{
+		{
+			desc: "depth-first",
+			fn:   func(movetree.Path) error { return nil },
+			exp:  []string{"-", "-0", "-0x2", "-0x3", "-0-1", "-1"},
+		},
+		{
+			desc: "skip children",
+			fn: func(tp movetree.Path) error {
+				if tp.CompactString() == "-0x2" {
+					return movetree.SkipChildren
+				}
+				return nil
+			},
+			exp: []string{"-", "-0", "-0x2", "-0-1", "-1"},
+		},
+		{
+			desc: "skip root's children",
+			fn:   func(movetree.Path) error { return movetree.SkipChildren },
+			exp:  []string{"-"},
+		},
+		{
+			desc: "stop",
+			fn: func(tp movetree.Path) error {
+				if tp.CompactString() == "-0-1" {
+					return errStop
+				}
+				return nil
+			},
+			exp:    []string{"-", "-0", "-0x2", "-0x3", "-0-1"},
+			expErr: errStop,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse("(;GM[1](;B[aa](;W[bb];B[cc])(;W[dd]))(;B[ee]))")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			err = g.Root.Walk(func(n *movetree.Node, tp movetree.Path) error {
+				if r, err := tp.Resolve(g.Root); err != nil || r != n {
+					t.Errorf("path %v resolves to %v, %v, but expected the visited node", tp, r, err)
+				}
+				got = append(got, tp.CompactString())
+				return tc.fn(tp)
+			})
+			if err != tc.expErr {
+				t.Errorf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Errorf("Walk got diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMainLine(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1];B[aa](;W[bb];B[cc])(;W[dd]))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []movetree.Path
+	for _, n := range g.MainLine() {
+		got = append(got, movetree.PathTo(n))
+	}
+	exp := []movetree.Path{nil, {0}, {0, 0}, {0, 0, 0}}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Errorf("MainLine got diff (-want +got):\n%s", diff)
+	}
+	if got := len(g.Root.Next(0).Next(0).MainLine()); got != 2 {
+		t.Errorf("got %d nodes on the main line from W[bb], but expected 2", got)
+	}
+}
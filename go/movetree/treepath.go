@@ -8,7 +8,6 @@ import (
 	"unicode"
 
 	"github.com/otrego/clamshell/go/board"
-	"github.com/otrego/clamshell/go/color"
 	"github.com/otrego/clamshell/go/move"
 )
 
@@ -178,6 +177,20 @@ func (tp Path) Apply(n *Node) *Node {
 	return curNode
 }
 
+// Resolve returns the node that's reached by following the variations of the
+// treepath from node n. Unlike Apply, it's an error if a variation of the
+// path doesn't exist.
+func (tp Path) Resolve(n *Node) (*Node, error) {
+	for i, v := range tp {
+		if v < 0 || v >= len(n.Children) {
+			return nil, fmt.Errorf("%w: variation %d of path %v doesn't exist at move %d, which has %d variations",
+				ErrApplyTreepath, v, tp.CompactString(), i, len(n.Children))
+		}
+		n = n.Children[v]
+	}
+	return n, nil
+}
+
 // ApplyToBoard applies a treepath to a Go-Board, returning the captured stones,
 // or an error if the application was unsuccessful.
 //
@@ -186,14 +199,11 @@ func (tp Path) ApplyToBoard(n *Node, b *board.Board) (*board.Board, move.List, e
 	b = b.Clone()
 
 	applyStones := func(n *Node, bb *board.Board) (move.List, error) {
-		err := bb.SetPlacements(n.Placements)
+		setup, captured, err := applyNode(bb, n)
 		if err != nil {
 			return nil, err
 		}
-		if n.Move != nil && n.Move.Color() != color.Empty {
-			return bb.PlaceStone(n.Move)
-		}
-		return nil, nil
+		return append(setup, captured...), nil
 	}
 
 	var traversed Path
@@ -233,6 +243,18 @@ func (tp Path) String() string {
 	return fmt.Sprintf("%v", strArr)
 }
 
+// PathTo returns the path from the root of the tree to node n.
+func PathTo(n *Node) Path {
+	var tp Path
+	for cur := n; cur.Parent != nil; cur = cur.Parent {
+		tp = append(tp, cur.VarNum())
+	}
+	for i, j := 0, len(tp)-1; i < j; i, j = i+1, j-1 {
+		tp[i], tp[j] = tp[j], tp[i]
+	}
+	return tp
+}
+
 // Clone makes a copy of the treepath.
 func (tp Path) Clone() Path {
 	newPath := make(Path, len(tp))
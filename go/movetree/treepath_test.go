@@ -176,6 +176,58 @@ func TestApplyPath(t *testing.T) {
 	}
 }
 
+func TestResolvePath(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		path    string
+		expMove *move.Move
+		expErr  error
+	}{
+		{
+			desc: "root",
+			path: "-",
+		},
+		{
+			desc:    "variation",
+			path:    "-0-1",
+			expMove: move.New(color.White, point.New(2, 2)),
+		},
+		{
+			desc:   "missing variation",
+			path:   "-0-2",
+			expErr: movetree.ErrApplyTreepath,
+		},
+		{
+			desc:   "past the end",
+			path:   "-0x3",
+			expErr: movetree.ErrApplyTreepath,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse("(;GM[1];B[aa](;W[bb])(;W[cc]))")
+			if err != nil {
+				t.Fatal(err)
+			}
+			path, err := movetree.ParsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n, err := path.Resolve(g.Root)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if (n.Move == nil) != (tc.expMove == nil) || (n.Move != nil && !n.Move.Equal(tc.expMove)) {
+				t.Errorf("path.Resolve(root) got move %v, but expected %v", n.Move, tc.expMove)
+			}
+		})
+	}
+}
+
 func TestString(t *testing.T) {
 	testCases := []struct {
 		desc string
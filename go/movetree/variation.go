@@ -0,0 +1,107 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/move"
+)
+
+// ErrVariation indicates an invalid edit of the variations of a node.
+var ErrVariation = errors.New("variation editing error")
+
+// AddVariation adds a child to node n with move m, and returns it. If n
+// already has a child with the same move, no child is added, and the
+// existing child is returned instead. added indicates whether a child was
+// added.
+func (n *Node) AddVariation(m *move.Move) (child *Node, added bool) {
+	nn := NewNode()
+	nn.Move = m
+	for _, c := range n.Children {
+		if sameMove(c, nn) {
+			return c, false
+		}
+	}
+	nn.Parent = n
+	n.AddChild(nn)
+	return nn, true
+}
+
+// PromoteVariation makes the line from the root to node n the main line, by
+// making each node on the line the first variation of its parent. The order
+// of the other variations is kept.
+func (n *Node) PromoteVariation() {
+	for cur := n; cur.Parent != nil; cur = cur.Parent {
+		p := cur.Parent
+		if cur.varNum == 0 {
+			continue
+		}
+		copy(p.Children[1:cur.varNum+1], p.Children[:cur.varNum])
+		p.Children[0] = cur
+		p.renumberChildren()
+	}
+}
+
+// DeleteBranch removes node n and its descendants from the tree. The later
+// variations of its parent are renumbered; if n was the main line, the next
+// variation becomes the main line. It's an error to delete the root.
+func (n *Node) DeleteBranch() error {
+	p := n.Parent
+	if p == nil {
+		return fmt.Errorf("%w: the root can't be deleted", ErrVariation)
+	}
+	p.Children = append(p.Children[:n.varNum], p.Children[n.varNum+1:]...)
+	p.renumberChildren()
+	n.Parent = nil
+	n.varNum = 0
+	return nil
+}
+
+// InsertVariation inserts node c, with its descendants, as the i-th
+// variation of node n. The later variations are renumbered. It's the inverse
+// of DeleteBranch, so c must not have a parent, and i must be between 0 and
+// the number of variations of n.
+func (n *Node) InsertVariation(i int, c *Node) error {
+	if c.Parent != nil {
+		return fmt.Errorf("%w: the node to insert already has a parent", ErrVariation)
+	}
+	if i < 0 || i > len(n.Children) {
+		return fmt.Errorf("%w: can't insert variation %d of a node with %d variations", ErrVariation, i, len(n.Children))
+	}
+	n.Children = append(n.Children, nil)
+	copy(n.Children[i+1:], n.Children[i:])
+	n.Children[i] = c
+	c.Parent = n
+	n.renumberChildren()
+	c.setMoveNum(n.moveNum + 1)
+	return nil
+}
+
+// ReorderChildren reorders the variations of node n, so that the i-th
+// variation is the variation that was previously at order[i]. order must be a
+// permutation of the variation numbers of n.
+func (n *Node) ReorderChildren(order []int) error {
+	if len(order) != len(n.Children) {
+		return fmt.Errorf("%w: got an order of %d variations, but the node has %d", ErrVariation, len(order), len(n.Children))
+	}
+	children := make([]*Node, len(order))
+	seen := make(map[int]bool)
+	for i, v := range order {
+		if v < 0 || v >= len(n.Children) || seen[v] {
+			return fmt.Errorf("%w: order %v isn't a permutation of the variations", ErrVariation, order)
+		}
+		seen[v] = true
+		children[i] = n.Children[v]
+	}
+	n.Children = children
+	n.renumberChildren()
+	return nil
+}
+
+// renumberChildren sets the variation numbers of the children of n to their
+// index.
+func (n *Node) renumberChildren() {
+	for i, c := range n.Children {
+		c.varNum = i
+	}
+}
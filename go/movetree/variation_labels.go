@@ -0,0 +1,54 @@
+package movetree
+
+import "github.com/otrego/clamshell/go/point"
+
+// VariationLabelOptions contains options for LabelVariations. A nil
+// *VariationLabelOptions is valid and means that the defaults are used.
+type VariationLabelOptions struct {
+	// InjectLabels adds each variation's label to the labels (LB) of the
+	// parent node, at the point of the variation's move, so that diagrams of the
+	// parent position show where the branches diverge. Points that already have
+	// a label are left unchanged.
+	InjectLabels bool
+}
+
+// LabelVariations assigns display labels (A, B, C, ...) to the children of
+// each node with more than one child, in variation order, so the main-line
+// continuation is labeled A. The returned map contains the label of each
+// labeled node.
+func (mt *MoveTree) LabelVariations(opts *VariationLabelOptions) map[*Node]string {
+	inject := opts != nil && opts.InjectLabels
+	labels := make(map[*Node]string)
+	mt.Root.Traverse(func(n *Node) {
+		if len(n.Children) < 2 {
+			return
+		}
+		for i, c := range n.Children {
+			label := variationLabel(i)
+			labels[c] = label
+			if !inject || c.Move == nil || c.Move.IsPass() {
+				continue
+			}
+			pt := *c.Move.Point()
+			if _, ok := n.Labels[pt]; ok {
+				continue
+			}
+			if n.Labels == nil {
+				n.Labels = make(map[point.Point]string)
+			}
+			n.Labels[pt] = label
+		}
+	})
+	return labels
+}
+
+// variationLabel returns the display label for the variation with the given
+// number: A to Z, then AA, AB, and so on.
+func variationLabel(i int) string {
+	var label string
+	for i++; i > 0; i /= 26 {
+		i--
+		label = string(rune('A'+i%26)) + label
+	}
+	return label
+}
@@ -0,0 +1,69 @@
+package movetree
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestLabelVariations(t *testing.T) {
+	mt := New()
+	parent := NewNode()
+	parent.Move = move.New(color.Black, point.New(4, 4))
+	parent.Labels = map[point.Point]string{*point.New(2, 2): "x"}
+	parent.Parent = mt.Root
+	mt.Root.AddChild(parent)
+	var children []*Node
+	for _, pt := range []*point.Point{point.New(2, 6), point.New(6, 2), point.New(2, 2)} {
+		c := NewNode()
+		c.Move = move.New(color.White, pt)
+		c.Parent = parent
+		parent.AddChild(c)
+		children = append(children, c)
+	}
+	pass := NewNode()
+	pass.Move = move.NewPass(color.Black)
+	pass.Parent = children[0]
+	children[0].AddChild(pass)
+
+	got := mt.LabelVariations(&VariationLabelOptions{InjectLabels: true})
+	exp := map[*Node]string{children[0]: "A", children[1]: "B", children[2]: "C"}
+	if !cmp.Equal(got, exp) {
+		t.Errorf("LabelVariations()=%v, but expected %v", got, exp)
+	}
+
+	// The existing label at the third child's move is kept.
+	expLabels := map[point.Point]string{
+		*point.New(2, 6): "A",
+		*point.New(6, 2): "B",
+		*point.New(2, 2): "x",
+	}
+	if !cmp.Equal(parent.Labels, expLabels) {
+		t.Errorf("got labels %v, but expected %v", parent.Labels, expLabels)
+	}
+	if children[0].Labels != nil || mt.Root.Labels != nil {
+		t.Errorf("got labels on nodes without variations")
+	}
+}
+
+func TestVariationLabel(t *testing.T) {
+	testCases := []struct {
+		num int
+		exp string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{27, "AB"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+	for _, tc := range testCases {
+		if got := variationLabel(tc.num); got != tc.exp {
+			t.Errorf("variationLabel(%d)=%q, but expected %q", tc.num, got, tc.exp)
+		}
+	}
+}
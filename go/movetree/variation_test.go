@@ -0,0 +1,161 @@
+package movetree_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+// variations returns the tree as an SGF string, checking that the variation
+// numbers match the positions of the nodes.
+func variations(t *testing.T, mt *movetree.MoveTree) string {
+	t.Helper()
+	mt.Root.Traverse(func(n *movetree.Node) {
+		if n.Parent != nil && n.Parent.Children[n.VarNum()] != n {
+			t.Errorf("node %v has variation number %d, which is another node", n.Move, n.VarNum())
+		}
+	})
+	out, err := sgf.Serialize(mt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestAddVariation(t *testing.T) {
+	g, err := sgf.Parse("(;FF[4]GM[1]CA[UTF-8]SZ[19](;B[aa])(;B[bb]))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, added := g.Root.AddVariation(move.New(color.Black, point.New(1, 1)))
+	if added || c != g.Root.Children[1] {
+		t.Errorf("AddVariation(B[bb]) got %v, %v, but expected the existing variation", c, added)
+	}
+	c, added = g.Root.AddVariation(move.New(color.Black, point.New(2, 2)))
+	if !added || c.Parent != g.Root || c.MoveNum() != 1 {
+		t.Errorf("AddVariation(B[cc]) got %v, %v, but expected a new variation", c, added)
+	}
+	if got, exp := variations(t, g), "(;FF[4]GM[1]CA[UTF-8]SZ[19](;B[aa])(;B[bb])(;B[cc]))"; got != exp {
+		t.Errorf("got %s, but expected %s", got, exp)
+	}
+}
+
+func TestEditVariations(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		path   string
+		edit   func(n *movetree.Node) error
+		exp    string
+		expErr error
+	}{
+		{
+			desc: "promote",
+			path: "-1-1",
+			edit: func(n *movetree.Node) error { n.PromoteVariation(); return nil },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19](;B[cc](;W[ee])(;W[dd]))(;B[aa])(;B[bb]))",
+		},
+		{
+			desc: "promote the main line",
+			path: "-0",
+			edit: func(n *movetree.Node) error { n.PromoteVariation(); return nil },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19](;B[aa])(;B[cc](;W[dd])(;W[ee]))(;B[bb]))",
+		},
+		{
+			desc: "delete",
+			path: "-0",
+			edit: func(n *movetree.Node) error { return n.DeleteBranch() },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19](;B[cc](;W[dd])(;W[ee]))(;B[bb]))",
+		},
+		{
+			desc:   "delete the root",
+			path:   "-",
+			edit:   func(n *movetree.Node) error { return n.DeleteBranch() },
+			expErr: movetree.ErrVariation,
+		},
+		{
+			desc: "insert",
+			path: "-",
+			edit: func(n *movetree.Node) error {
+				c := movetree.NewNode()
+				c.Move = move.New(color.Black, point.New(3, 3))
+				return n.InsertVariation(1, c)
+			},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[19](;B[aa])(;B[dd])(;B[cc](;W[dd])(;W[ee]))(;B[bb]))",
+		},
+		{
+			desc: "insert a deleted branch",
+			path: "-1",
+			edit: func(n *movetree.Node) error {
+				parent := n.Parent
+				if err := n.DeleteBranch(); err != nil {
+					return err
+				}
+				return parent.InsertVariation(2, n)
+			},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[19](;B[aa])(;B[bb])(;B[cc](;W[dd])(;W[ee])))",
+		},
+		{
+			desc:   "insert a node that has a parent",
+			path:   "-",
+			edit:   func(n *movetree.Node) error { return n.InsertVariation(0, n.Children[1]) },
+			expErr: movetree.ErrVariation,
+		},
+		{
+			desc:   "insert out of range",
+			path:   "-",
+			edit:   func(n *movetree.Node) error { return n.InsertVariation(4, movetree.NewNode()) },
+			expErr: movetree.ErrVariation,
+		},
+		{
+			desc: "reorder",
+			path: "-",
+			edit: func(n *movetree.Node) error { return n.ReorderChildren([]int{2, 0, 1}) },
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19](;B[bb])(;B[aa])(;B[cc](;W[dd])(;W[ee])))",
+		},
+		{
+			desc:   "reorder with a duplicate",
+			path:   "-",
+			edit:   func(n *movetree.Node) error { return n.ReorderChildren([]int{0, 0, 1}) },
+			expErr: movetree.ErrVariation,
+		},
+		{
+			desc:   "reorder with too few variations",
+			path:   "-",
+			edit:   func(n *movetree.Node) error { return n.ReorderChildren([]int{1, 0}) },
+			expErr: movetree.ErrVariation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse("(;FF[4]GM[1]CA[UTF-8]SZ[19](;B[aa])(;B[cc](;W[dd])(;W[ee]))(;B[bb]))")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tp, err := movetree.ParsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n, err := tp.Resolve(g.Root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = tc.edit(n)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.exp, variations(t, g)); diff != "" {
+				t.Errorf("got diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
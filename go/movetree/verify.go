@@ -0,0 +1,173 @@
+package movetree
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/rules"
+	"github.com/otrego/clamshell/go/scoring"
+)
+
+// ErrVerifyResult indicates that the result of a game could not be verified.
+var ErrVerifyResult = errors.New("error verifying result")
+
+// VerifyResult replays the main line to the final position, scores it with
+// the scoring system for ruleset rs, and compares the computed result to the
+// recorded result (RE). If rs is unspecified, the ruleset from the RU
+// property is used.
+//
+// The dead stones are estimated from the territory markup (TB/TW) on the final
+// node: a stone lying in the opponent's territory is considered dead. If the
+// final node has no territory markup, the dead stones can't be determined and
+// an error is returned; use VerifyResultWithDeadStones to supply them.
+//
+// Returns whether the computed result agrees with the recorded result, along
+// with the computed result.
+func (mt *MoveTree) VerifyResult(rs rules.Ruleset) (bool, *Result, error) {
+	end := mt.mainLineEnd()
+	b, err := mt.BoardAt(end)
+	if err != nil {
+		return false, nil, fmt.Errorf("%w: %v", ErrVerifyResult, err)
+	}
+	dead, err := deadStonesFromTerritory(b, end)
+	if err != nil {
+		return false, nil, err
+	}
+	return mt.VerifyResultWithDeadStones(rs, dead)
+}
+
+// VerifyResultWithDeadStones is like VerifyResult, but uses the provided dead
+// stones for scoring.
+func (mt *MoveTree) VerifyResultWithDeadStones(rs rules.Ruleset, dead []*point.Point) (bool, *Result, error) {
+	recorded, err := mt.recordedResult()
+	if err != nil {
+		return false, nil, err
+	}
+	if recorded.Reason != ReasonScore && recorded.Reason != ReasonDraw {
+		return false, nil, fmt.Errorf("%w: recorded result %v was not decided by counting", ErrVerifyResult, recorded)
+	}
+	computed, err := mt.scoreResult(rs, dead)
+	if err != nil {
+		return false, nil, err
+	}
+	return resultsAgree(recorded, computed), computed, nil
+}
+
+// ScoreResult scores the final position of the main line with the scoring
+// system for ruleset rs, such as to fill in a missing result (RE). If rs is
+// unspecified, the ruleset from the RU property is used.
+//
+// The dead stones are estimated from the territory markup on the final node,
+// as with VerifyResult. If the final node has no territory markup, the dead
+// stones are estimated from the position (see scoring.EstimateDead).
+func (mt *MoveTree) ScoreResult(rs rules.Ruleset) (*Result, error) {
+	end := mt.mainLineEnd()
+	b, err := mt.BoardAt(end)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVerifyResult, err)
+	}
+	dead, err := deadStonesFromTerritory(b, end)
+	if err != nil {
+		dead = scoring.EstimateDead(b)
+	}
+	return mt.scoreResult(rs, dead)
+}
+
+// scoreResult scores the final position of the main line with the dead
+// stones.
+func (mt *MoveTree) scoreResult(rs rules.Ruleset, dead []*point.Point) (*Result, error) {
+	if rs == rules.Unspecified && mt.Root.GameInfo != nil {
+		rs = mt.Root.GameInfo.Rules
+	}
+
+	end := mt.mainLineEnd()
+	b, captures, err := mt.replay(end)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVerifyResult, err)
+	}
+	pos := &scoring.Position{
+		Board:    b,
+		Dead:     dead,
+		Captures: captures,
+		Passes:   mt.Passes(end),
+	}
+	if mt.Root.GameInfo != nil && mt.Root.GameInfo.Komi != nil {
+		pos.Komi = *mt.Root.GameInfo.Komi
+	}
+	score, err := scoring.Compute(rs, pos)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVerifyResult, err)
+	}
+
+	computed := &Result{Winner: score.Winner(), Reason: ReasonScore}
+	if computed.Winner == color.Empty {
+		computed.Reason = ReasonDraw
+	} else {
+		margin := score.Margin()
+		computed.Margin = &margin
+	}
+	return computed, nil
+}
+
+// recordedResult gets the result recorded in the RE property.
+func (mt *MoveTree) recordedResult() (*Result, error) {
+	if mt.Root.GameInfo == nil || mt.Root.GameInfo.Result == nil {
+		return nil, fmt.Errorf("%w: no result (RE) recorded", ErrVerifyResult)
+	}
+	return mt.Root.GameInfo.Result, nil
+}
+
+// mainLineEnd returns the final node of the main line.
+func (mt *MoveTree) mainLineEnd() *Node {
+	n := mt.Root
+	for next := n.Next(0); next != nil; next = n.Next(0) {
+		n = next
+	}
+	return n
+}
+
+// deadStonesFromTerritory estimates the dead stones on board b from the
+// territory markup on node n, returning an error if there is no territory
+// markup.
+func deadStonesFromTerritory(b *board.Board, n *Node) ([]*point.Point, error) {
+	tb, okB := n.SGFProperties["TB"]
+	tw, okW := n.SGFProperties["TW"]
+	if !okB && !okW {
+		return nil, fmt.Errorf("%w: dead stones can't be determined: no territory markup (TB/TW) on the final node", ErrVerifyResult)
+	}
+	territory := make(map[color.Color]*move.PointSet)
+	for col, pts := range map[color.Color][]string{color.Black: tb, color.White: tw} {
+		territory[col] = &move.PointSet{}
+		for _, sgfPt := range pts {
+			pt, err := point.NewFromSGF(sgfPt)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrVerifyResult, err)
+			}
+			territory[col].Add(pt)
+		}
+	}
+
+	var dead []*point.Point
+	for _, mv := range b.StoneState() {
+		if opp := mv.Color().Opposite(); territory[opp] != nil && territory[opp].Contains(mv.Point()) {
+			dead = append(dead, mv.Point())
+		}
+	}
+	return dead, nil
+}
+
+// resultsAgree indicates whether two results have the same winner and margin.
+func resultsAgree(a, b *Result) bool {
+	if a.Winner != b.Winner || a.Reason != b.Reason {
+		return false
+	}
+	if a.Margin == nil || b.Margin == nil {
+		return a.Margin == b.Margin
+	}
+	return math.Abs(*a.Margin-*b.Margin) < 1e-9
+}
@@ -0,0 +1,136 @@
+package movetree_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/rules"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+// finishedGame is a small game on a 5x5 board, where black controls the two
+// left-most columns and white the right-most column. A white stone at ba is
+// left dead in black's territory.
+const finishedGame = `(;GM[1]SZ[5]KM[0.5]RU[%s]RE[%s]
+AB[ca][cb][cc][cd]AW[da][db][dc][dd]
+;B[ce];W[de];B[];W[ba];B[];W[]
+TB[aa][ab][ac][ad][ae][ba][bb][bc][bd][be]TW[ea][eb][ec][ed][ee])`
+
+func TestVerifyResult(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		sgf       string
+		rs        rules.Ruleset
+		expAgrees bool
+		expResult string
+		expErr    error
+	}{
+		{
+			desc:      "area scoring, matching result",
+			sgf:       fmt.Sprintf(finishedGame, "Chinese", "B+4.5"),
+			expAgrees: true,
+			expResult: "B+4.5",
+		},
+		{
+			desc:      "territory scoring, matching result",
+			sgf:       fmt.Sprintf(finishedGame, "Japanese", "B+5.5"),
+			expAgrees: true,
+			expResult: "B+5.5",
+		},
+		{
+			desc:      "explicit ruleset overrides RU",
+			sgf:       fmt.Sprintf(finishedGame, "Chinese", "B+5.5"),
+			rs:        rules.Japanese,
+			expAgrees: true,
+			expResult: "B+5.5",
+		},
+		{
+			// Black passes twice and white once, which hands white a pass
+			// stone more than area scoring.
+			desc:      "aga scoring with pass stones",
+			sgf:       fmt.Sprintf(finishedGame, "AGA", "B+3.5"),
+			expAgrees: true,
+			expResult: "B+3.5",
+		},
+		{
+			desc:      "mismatched result",
+			sgf:       fmt.Sprintf(finishedGame, "Chinese", "W+0.5"),
+			expAgrees: false,
+			expResult: "B+4.5",
+		},
+		{
+			desc:   "resignation can't be verified",
+			sgf:    fmt.Sprintf(finishedGame, "Chinese", "B+R"),
+			expErr: movetree.ErrVerifyResult,
+		},
+		{
+			desc:   "no territory markup",
+			sgf:    `(;GM[1]SZ[5]RE[B+4.5]AB[ca][cb][cc][cd][ce]AW[da][db][dc][dd][de];B[];W[])`,
+			expErr: movetree.ErrVerifyResult,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			agrees, computed, err := g.VerifyResult(tc.rs)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if agrees != tc.expAgrees {
+				t.Errorf("VerifyResult agrees=%v, but expected %v", agrees, tc.expAgrees)
+			}
+			if got := computed.String(); got != tc.expResult {
+				t.Errorf("VerifyResult computed %q, but expected %q", got, tc.expResult)
+			}
+		})
+	}
+}
+
+func TestScoreResult(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		sgf       string
+		expResult string
+	}{
+		{
+			desc:      "territory markup",
+			sgf:       fmt.Sprintf(finishedGame, "Japanese", "?"),
+			expResult: "B+5.5",
+		},
+		{
+			desc: "estimated dead stones",
+			sgf: `(;GM[1]SZ[5]KM[0.5]RU[Japanese]
+AB[ca][cb][cc][cd]AW[da][db][dc][dd]
+;B[ce];W[de];B[];W[ba];B[];W[])`,
+			expResult: "B+5.5",
+		},
+		{
+			desc:      "no result recorded",
+			sgf:       `(;GM[1]SZ[5]KM[0.5]AB[ca][cb][cc][cd][ce]AW[da][db][dc][dd][de];B[];W[])`,
+			expResult: "B+4.5",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := g.ScoreResult(rules.Unspecified)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tc.expResult {
+				t.Errorf("ScoreResult got %q, but expected %q", got, tc.expResult)
+			}
+		})
+	}
+}
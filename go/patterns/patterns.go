@@ -0,0 +1,203 @@
+// Package patterns searches collections of games for board patterns, such as
+// joseki and fuseki.
+package patterns
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ErrPattern indicates that a pattern couldn't be created.
+var ErrPattern = errors.New("invalid pattern")
+
+// Pattern is an arrangement of stones in a region of the board. A position
+// matches the pattern if the points of the region have the same stones, and
+// the points of the region without stones are empty. The rest of the board
+// doesn't matter.
+type Pattern struct {
+	// size is the size of the board of the pattern.
+	size int
+
+	// points are the points of the region, with their colors.
+	points []patternPoint
+}
+
+// patternPoint is a point of a pattern, and its color (which may be empty).
+type patternPoint struct {
+	x, y int
+	c    color.Color
+}
+
+// New creates a pattern from the rectangle of the board from the top-left
+// point tl to the bottom-right point br, inclusive. Patterns are only
+// supported on square boards.
+func New(b *board.Board, tl, br *point.Point) (*Pattern, error) {
+	size := b.Size()
+	if b.Width() != b.Height() {
+		return nil, fmt.Errorf("%w: the %dx%d board isn't square", ErrPattern, b.Width(), b.Height())
+	}
+	if tl.X() < 0 || tl.Y() < 0 || br.X() >= size || br.Y() >= size {
+		return nil, fmt.Errorf("%w: rectangle from %v to %v is off the %dx%d board", ErrPattern, tl, br, size, size)
+	}
+	if br.X() < tl.X() || br.Y() < tl.Y() {
+		return nil, fmt.Errorf("%w: the bottom-right corner %v is above or left of the top-left corner %v", ErrPattern, br, tl)
+	}
+	state := b.FullBoardState()
+	p := &Pattern{size: size}
+	for y := tl.Y(); y <= br.Y(); y++ {
+		for x := tl.X(); x <= br.X(); x++ {
+			p.points = append(p.points, patternPoint{x: x, y: y, c: state[y][x]})
+		}
+	}
+	return p, nil
+}
+
+// Corner creates a pattern from the n x n square in the top-left corner of the
+// board, for joseki. Since patterns match under the symmetries of the board,
+// the pattern matches in any corner.
+func Corner(b *board.Board, n int) (*Pattern, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: corner size %d isn't positive", ErrPattern, n)
+	}
+	return New(b, point.New(0, 0), point.New(n-1, n-1))
+}
+
+// FullBoard creates a pattern from the whole board, for fuseki. It returns nil
+// for rectangular boards.
+func FullBoard(b *board.Board) *Pattern {
+	p, _ := New(b, point.New(0, 0), point.New(b.Size()-1, b.Size()-1))
+	return p
+}
+
+// Options contains options for Search. A nil *Options is valid and means that
+// the defaults are used.
+type Options struct {
+	// NoSymmetry only matches the pattern as it is, rather than also matching
+	// its rotations and reflections.
+	NoSymmetry bool
+
+	// NoColorSwap only matches the pattern with its colors, rather than also
+	// matching it with the colors of the stones swapped.
+	NoColorSwap bool
+}
+
+// transform is a symmetry of the board, and whether the colors are swapped.
+type transform struct {
+	sym     board.Symmetry
+	swapped bool
+}
+
+// transforms returns the transforms under which patterns match.
+func (o *Options) transforms() []transform {
+	numSyms, swaps := board.NumSymmetries, []bool{false, true}
+	if o != nil && o.NoSymmetry {
+		numSyms = 1
+	}
+	if o != nil && o.NoColorSwap {
+		swaps = swaps[:1]
+	}
+	var out []transform
+	for s := board.Symmetry(0); s < numSyms; s++ {
+		for _, swapped := range swaps {
+			out = append(out, transform{sym: s, swapped: swapped})
+		}
+	}
+	return out
+}
+
+// Match is a node of a game where the position matches a pattern.
+type Match struct {
+	// Game is the index of the game in the searched games.
+	Game int
+
+	// Path is the path to the node in the game.
+	Path movetree.Path
+
+	// Node is the node.
+	Node *movetree.Node
+
+	// Symmetry is the symmetry that maps the pattern onto the position, and
+	// Swapped indicates whether the colors of the pattern are swapped.
+	Symmetry board.Symmetry
+	Swapped  bool
+}
+
+// Search returns the nodes of the games where the position matches the
+// pattern, in the order of the games and then in depth-first order. Only games
+// with the same board size as the pattern are searched.
+//
+// A node is only reported where the pattern starts to match, rather than at
+// every following node where it still matches, so each match is the move that
+// completes the pattern (or the root, for patterns of setup stones). If the
+// pattern starts to match in several ways at once, only the first is
+// reported. Variations with illegal moves are skipped from the illegal move
+// on.
+func Search(games []*movetree.MoveTree, p *Pattern, opts *Options) []Match {
+	transforms := opts.transforms()
+	var matches []Match
+	for i, g := range games {
+		if g.Root.GameInfo == nil {
+			continue
+		}
+		if w, h := g.Root.GameInfo.Dimensions(); w != p.size || h != p.size {
+			continue
+		}
+		pb, err := g.NewPlayback()
+		if err != nil {
+			continue
+		}
+		var walk func(parentMatched map[transform]bool)
+		walk = func(parentMatched map[transform]bool) {
+			state := pb.Board().FullBoardState()
+			matched := make(map[transform]bool)
+			reported := false
+			for _, t := range transforms {
+				if !p.matches(state, t) {
+					continue
+				}
+				matched[t] = true
+				if !parentMatched[t] && !reported {
+					n := pb.Node()
+					matches = append(matches, Match{
+						Game:     i,
+						Path:     movetree.PathTo(n),
+						Node:     n,
+						Symmetry: t.sym,
+						Swapped:  t.swapped,
+					})
+					reported = true
+				}
+			}
+			for v := range pb.Node().Children {
+				if pb.ForwardVariation(v) != nil {
+					continue
+				}
+				walk(matched)
+				pb.Back()
+			}
+		}
+		walk(nil)
+	}
+	return matches
+}
+
+// matches indicates whether the position matches the pattern under the
+// transform.
+func (p *Pattern) matches(state [][]color.Color, t transform) bool {
+	for _, pt := range p.points {
+		x, y := t.sym.Transform(pt.x, pt.y, p.size)
+		c := pt.c
+		if t.swapped {
+			c = c.Opposite()
+		}
+		if state[y][x] != c {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package patterns_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/patterns"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestSearch(t *testing.T) {
+	// The pattern is a 3-3 point with an approach, in the top-left corner.
+	b := board.New(9)
+	if err := b.SetPlacements(move.List{
+		move.New(color.Black, point.New(2, 2)),
+		move.New(color.White, point.New(4, 2)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	p, err := patterns.Corner(b, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var games []*movetree.MoveTree
+	for _, s := range []string{
+		"(;GM[1]SZ[9];B[cc];W[ec];B[gg])",
+		"(;GM[1]SZ[9];W[gg];B[ge])",
+		"(;GM[1]SZ[13];B[cc];W[ec])",
+		"(;GM[1]SZ[9];B[cc](;W[ec])(;W[ce])(;W[dc];B[ec]))",
+	} {
+		g, err := sgf.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		games = append(games, g)
+	}
+
+	testCases := []struct {
+		desc string
+		opts *patterns.Options
+		// exp contains the game, the path, and whether the colors are swapped
+		// for each match.
+		exp []string
+	}{
+		{
+			desc: "symmetries and color swap",
+			exp:  []string{"0 [0 0] false", "1 [0 0] true", "3 [0 0] false", "3 [0 1] false"},
+		},
+		{
+			desc: "no color swap",
+			opts: &patterns.Options{NoColorSwap: true},
+			exp:  []string{"0 [0 0] false", "3 [0 0] false", "3 [0 1] false"},
+		},
+		{
+			desc: "no symmetry",
+			opts: &patterns.Options{NoSymmetry: true},
+			exp:  []string{"0 [0 0] false", "3 [0 0] false"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []string
+			for _, m := range patterns.Search(games, p, tc.opts) {
+				if m.Node != movetree.PathTo(m.Node).Apply(games[m.Game].Root) {
+					t.Errorf("match %v has node %v, which isn't at its path", m, m.Node)
+				}
+				got = append(got, fmt.Sprintf("%d %v %v", m.Game, m.Path, m.Swapped))
+			}
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Errorf("Search diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	b := board.New(9)
+	if _, err := patterns.New(b, point.New(5, 5), point.New(9, 9)); !errors.Is(err, patterns.ErrPattern) {
+		t.Errorf("got err %v for a rectangle off the board, but expected %v", err, patterns.ErrPattern)
+	}
+	if _, err := patterns.New(b, point.New(5, 5), point.New(4, 4)); !errors.Is(err, patterns.ErrPattern) {
+		t.Errorf("got err %v for an inverted rectangle, but expected %v", err, patterns.ErrPattern)
+	}
+	if _, err := patterns.Corner(b, 0); !errors.Is(err, patterns.ErrPattern) {
+		t.Errorf("got err %v for an empty corner, but expected %v", err, patterns.ErrPattern)
+	}
+}
@@ -0,0 +1,54 @@
+package point
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrGTPConversion indicates that a point couldn't be converted to or from a
+// GTP vertex.
+var ErrGTPConversion = errors.New("error converting point to GTP vertex")
+
+// GTPColumns are the GTP column letters, which skip I to avoid confusion with
+// J and 1. They're also used for the column labels of boards.
+const GTPColumns = "ABCDEFGHJKLMNOPQRSTUVWXYZ"
+
+// ToGTP converts the point to a GTP vertex (ex: D4) on a size x size board.
+// GTP columns are lettered from the left, skipping I, and rows are numbered
+// from the bottom, starting at 1. So, on a 19x19 board, {0,0} is A19 and
+// {8,18} is J1.
+func (pt *Point) ToGTP(size int) (string, error) {
+	return pt.ToGTPRect(size, size)
+}
+
+// ToGTPRect is like ToGTP, for a rectangular board with the given number of
+// columns and rows. So, on a 19x9 board, {0,0} is A9.
+func (pt *Point) ToGTPRect(width, height int) (string, error) {
+	if width > len(GTPColumns) || height > len(GTPColumns) || pt.X() < 0 || pt.X() >= width || pt.Y() < 0 || pt.Y() >= height {
+		return "", fmt.Errorf("%w: point %v is off a %dx%d board, or the board is larger than %dx%d", ErrGTPConversion, pt, width, height, len(GTPColumns), len(GTPColumns))
+	}
+	return string(GTPColumns[pt.X()]) + strconv.Itoa(height-pt.Y()), nil
+}
+
+// NewFromGTP converts a GTP vertex (ex: D4) on a size x size board to a point.
+// The column letter may be lower or upper case. It's the inverse of ToGTP, so
+// passes aren't vertices and must be handled by the caller.
+func NewFromGTP(vertex string, size int) (*Point, error) {
+	return NewFromGTPRect(vertex, size, size)
+}
+
+// NewFromGTPRect is like NewFromGTP, for a rectangular board with the given
+// number of columns and rows. It's the inverse of ToGTPRect.
+func NewFromGTPRect(vertex string, width, height int) (*Point, error) {
+	if len(vertex) < 2 || width > len(GTPColumns) || height > len(GTPColumns) {
+		return nil, fmt.Errorf("%w: %q is not a vertex on a %dx%d board", ErrGTPConversion, vertex, width, height)
+	}
+	x := strings.IndexByte(GTPColumns, strings.ToUpper(vertex[:1])[0])
+	row, err := strconv.Atoi(vertex[1:])
+	if x < 0 || x >= width || err != nil || row < 1 || row > height {
+		return nil, fmt.Errorf("%w: %q is not a vertex on a %dx%d board", ErrGTPConversion, vertex, width, height)
+	}
+	return New(x, height-row), nil
+}
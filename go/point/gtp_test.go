@@ -0,0 +1,158 @@
+package point
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestToGTP(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		pt     *Point
+		size   int
+		exp    string
+		expErr error
+	}{
+		{
+			desc: "top left",
+			pt:   New(0, 0),
+			size: 19,
+			exp:  "A19",
+		},
+		{
+			desc: "skips I",
+			pt:   New(8, 18),
+			size: 19,
+			exp:  "J1",
+		},
+		{
+			desc: "9x9 tengen",
+			pt:   New(4, 4),
+			size: 9,
+			exp:  "E5",
+		},
+		{
+			desc:   "off the board",
+			pt:     New(9, 0),
+			size:   9,
+			expErr: ErrGTPConversion,
+		},
+		{
+			desc:   "board too large",
+			pt:     New(0, 0),
+			size:   26,
+			expErr: ErrGTPConversion,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.pt.ToGTP(tc.size)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if got != tc.exp {
+				t.Errorf("ToGTP(%d)=%q, but expected %q", tc.size, got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestNewFromGTP(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		vertex string
+		size   int
+		exp    *Point
+		expErr error
+	}{
+		{
+			desc:   "top left",
+			vertex: "A19",
+			size:   19,
+			exp:    New(0, 0),
+		},
+		{
+			desc:   "skips I, lower case",
+			vertex: "j1",
+			size:   19,
+			exp:    New(8, 18),
+		},
+		{
+			desc:   "9x9 tengen",
+			vertex: "E5",
+			size:   9,
+			exp:    New(4, 4),
+		},
+		{
+			desc:   "I isn't a column",
+			vertex: "I5",
+			size:   19,
+			expErr: ErrGTPConversion,
+		},
+		{
+			desc:   "off the board",
+			vertex: "A10",
+			size:   9,
+			expErr: ErrGTPConversion,
+		},
+		{
+			desc:   "pass",
+			vertex: "pass",
+			size:   19,
+			expErr: ErrGTPConversion,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := NewFromGTP(tc.vertex, tc.size)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if tc.exp != nil && !got.Equal(tc.exp) {
+				t.Errorf("NewFromGTP(%q, %d)=%v, but expected %v", tc.vertex, tc.size, got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestGTPRect(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		pt     *Point
+		exp    string
+		expErr error
+	}{
+		{
+			desc: "top left",
+			pt:   New(0, 0),
+			exp:  "A9",
+		},
+		{
+			desc: "bottom right",
+			pt:   New(12, 8),
+			exp:  "N1",
+		},
+		{
+			desc:   "below the board",
+			pt:     New(0, 9),
+			expErr: ErrGTPConversion,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.pt.ToGTPRect(13, 9)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if got != tc.exp {
+				t.Errorf("ToGTPRect(13, 9)=%q, but expected %q", got, tc.exp)
+			}
+			if err != nil {
+				return
+			}
+			if back, err := NewFromGTPRect(got, 13, 9); err != nil || !back.Equal(tc.pt) {
+				t.Errorf("NewFromGTPRect(%q, 13, 9)=%v, %v, but expected %v", got, back, err, tc.pt)
+			}
+		})
+	}
+}
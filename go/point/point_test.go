@@ -145,6 +145,52 @@ func TestSGFToPointTranslate(t *testing.T) {
 	}
 }
 
+func TestNewListFromSGF(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		in      string
+		want    []*Point
+		wantErr bool
+	}{
+		{
+			desc: "single point",
+			in:   "ab",
+			want: []*Point{New(0, 1)},
+		},
+		{
+			desc: "rectangle",
+			in:   "aa:bb",
+			want: []*Point{New(0, 0), New(0, 1), New(1, 0), New(1, 1)},
+		},
+		{
+			desc: "degenerate rectangle",
+			in:   "aa:aa",
+			want: []*Point{New(0, 0)},
+		},
+		{
+			desc:    "reversed rectangle",
+			in:      "bb:aa",
+			wantErr: true,
+		},
+		{
+			desc:    "bad corner",
+			in:      "aa:b",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := NewListFromSGF(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewListFromSGF(%q) got error %v, but wanted error: %v", tc.in, err, tc.wantErr)
+			}
+			if !cmp.Equal(got, tc.want, cmp.AllowUnexported(Point{})) {
+				t.Errorf("NewListFromSGF(%q)=%v, but wanted %v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestEquality(t *testing.T) {
 	pt := New(1, 2)
 	another := New(1, 2)
@@ -3,6 +3,7 @@ package point
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var SGFConversionErr = errors.New("error converting point from sgf pointF")
@@ -46,6 +47,38 @@ func NewFromSGF(sgfPt string) (*Point, error) {
 	return New(intX, intY), nil
 }
 
+// NewListFromSGF converts an SGF point, or a rectangle of points in the
+// compressed form topleft:bottomright (ex: aa:cc), to the points it covers,
+// column by column. A rectangle of a single point (ex: aa:aa) is that point.
+func NewListFromSGF(v string) ([]*Point, error) {
+	i := strings.IndexByte(v, ':')
+	if i < 0 {
+		pt, err := NewFromSGF(v)
+		if err != nil {
+			return nil, err
+		}
+		return []*Point{pt}, nil
+	}
+	tl, err := NewFromSGF(v[:i])
+	if err != nil {
+		return nil, err
+	}
+	br, err := NewFromSGF(v[i+1:])
+	if err != nil {
+		return nil, err
+	}
+	if br.X() < tl.X() || br.Y() < tl.Y() {
+		return nil, fmt.Errorf("%w: the rectangle %s must be written as top-left:bottom-right", SGFConversionErr, v)
+	}
+	var pts []*Point
+	for x := tl.X(); x <= br.X(); x++ {
+		for y := tl.Y(); y <= br.Y(); y++ {
+			pts = append(pts, New(x, y))
+		}
+	}
+	return pts, nil
+}
+
 // toSGF converts a point to an SGF coordinate.
 func toSGF(pt *Point) (string, error) {
 	if pt.X() < 0 || pt.X() > 51 || pt.Y() < 0 || pt.Y() > 51 {
@@ -0,0 +1,201 @@
+package problems
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/bbox"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ErrExtract indicates that a problem couldn't be extracted from a game.
+var ErrExtract = errors.New("problem extraction error")
+
+const (
+	// Correct is the comment that marks the end of a correct variation of a
+	// problem.
+	Correct = "CORRECT"
+
+	// Incorrect is the comment that marks the end of an incorrect variation of
+	// a problem.
+	Incorrect = "INCORRECT"
+)
+
+// defaultMargin is the default number of lines around the moves of a problem
+// that are kept when cropping.
+const defaultMargin = 2
+
+// ExtractOptions are options for Extract. A nil *ExtractOptions uses the
+// defaults.
+type ExtractOptions struct {
+	// Blunder indicates that the move of interest is a mistake (ex: as found
+	// by an AI review), so that its variation is incorrect and the other
+	// variations are correct. By default, the move of interest is the correct
+	// answer, and the other variations are incorrect.
+	Blunder bool
+
+	// Region is the region of the board to keep, where the bottom-right of the
+	// box is just outside of the region. If nil, the region is the bounding box
+	// of the moves of the problem, widened by Margin lines on each side.
+	Region *bbox.BoundingBox
+
+	// Margin is the number of lines kept around the moves of the problem, when
+	// Region is nil. If 0, two lines are kept.
+	Margin int
+}
+
+func (o *ExtractOptions) blunder() bool {
+	return o != nil && o.Blunder
+}
+
+func (o *ExtractOptions) region() *bbox.BoundingBox {
+	if o == nil {
+		return nil
+	}
+	return o.Region
+}
+
+func (o *ExtractOptions) margin() int {
+	if o == nil || o.Margin == 0 {
+		return defaultMargin
+	}
+	return o.Margin
+}
+
+// Extract extracts a standalone problem from a game, for the move of interest
+// at node n: the problem starts at the position before the move, and its
+// variations are n and its siblings, with the moves that follow them.
+//
+// The stones of the position become the placements of the problem, cropped to
+// a region of the board (see ExtractOptions), which is recorded with VW when
+// it's not the whole board. The end of each variation is labeled with a
+// Correct or Incorrect comment. The player to move is the player of n.
+func Extract(mt *movetree.MoveTree, n *movetree.Node, opts *ExtractOptions) (*movetree.MoveTree, error) {
+	if n.Parent == nil || n.Move == nil {
+		return nil, fmt.Errorf("%w: the node of interest must have a move", ErrExtract)
+	}
+	prob, err := mt.Subtree(n.Parent)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExtract, err)
+	}
+
+	// The problem only keeps the board and the player from the game, and the
+	// file properties of a new movetree.
+	root := prob.Root
+	width, height := root.GameInfo.Dimensions()
+	if width == 0 {
+		width, height = 19, 19
+	}
+	root.GameInfo = &movetree.GameInfo{
+		Size:   root.GameInfo.Size,
+		Width:  root.GameInfo.Width,
+		Height: root.GameInfo.Height,
+		Player: n.Move.Color(),
+	}
+	root.SGFProperties = movetree.New().Root.SGFProperties
+	root.Comment = ""
+
+	var moves []*point.Point
+	for _, c := range root.Children {
+		c.Walk(func(v *movetree.Node, _ movetree.Path) error {
+			delete(v.SGFProperties, "MN")
+			if v.Move != nil && !v.Move.IsPass() {
+				moves = append(moves, v.Move.Point())
+			}
+			return nil
+		})
+	}
+
+	region := opts.region()
+	if region == nil {
+		region, err = moveRegion(moves, width, height, opts.margin())
+		if err != nil {
+			return nil, err
+		}
+	}
+	var placements move.List
+	for _, m := range root.Placements {
+		if inRegion(region, m.Point()) {
+			placements = append(placements, m)
+		}
+	}
+	root.Placements = placements
+	if region.Left() > 0 || region.Top() > 0 || region.Right() < width || region.Bottom() < height {
+		tl, err := region.TopLeft().ToSGF()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrExtract, err)
+		}
+		br, err := point.New(region.Right()-1, region.Bottom()-1).ToSGF()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrExtract, err)
+		}
+		root.SGFProperties["VW"] = []string{tl + ":" + br}
+	}
+
+	for i, c := range root.Children {
+		label := Incorrect
+		if (i == n.VarNum()) != opts.blunder() {
+			label = Correct
+		}
+		labelLeaves(c, label)
+	}
+	return prob, nil
+}
+
+// moveRegion returns the bounding box of the moves, widened by margin lines,
+// on a board of the given size. It's the whole board if there are no moves.
+func moveRegion(moves []*point.Point, width, height, margin int) (*bbox.BoundingBox, error) {
+	left, top, right, bottom := 0, 0, width, height
+	if len(moves) > 0 {
+		left, top, right, bottom = moves[0].X(), moves[0].Y(), moves[0].X()+1, moves[0].Y()+1
+		for _, pt := range moves {
+			left, top = minInt(left, pt.X()), minInt(top, pt.Y())
+			right, bottom = maxInt(right, pt.X()+1), maxInt(bottom, pt.Y()+1)
+		}
+		left, top = maxInt(left-margin, 0), maxInt(top-margin, 0)
+		right, bottom = minInt(right+margin, width), minInt(bottom+margin, height)
+	}
+	region, err := bbox.New(point.New(left, top), point.New(right, bottom))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExtract, err)
+	}
+	return region, nil
+}
+
+// inRegion indicates whether point pt is within the region, whose
+// bottom-right is just outside of it.
+func inRegion(region *bbox.BoundingBox, pt *point.Point) bool {
+	return pt.X() >= region.Left() && pt.X() < region.Right() &&
+		pt.Y() >= region.Top() && pt.Y() < region.Bottom()
+}
+
+// labelLeaves adds the label to the comments of the ends of the variation
+// starting at node n.
+func labelLeaves(n *movetree.Node, label string) {
+	n.Walk(func(v *movetree.Node, _ movetree.Path) error {
+		if len(v.Children) > 0 {
+			return nil
+		}
+		if v.Comment != "" {
+			v.Comment += "\n\n"
+		}
+		v.Comment += label
+		return nil
+	})
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,88 @@
+package problems_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/bbox"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/problems"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestExtract(t *testing.T) {
+	region, err := bbox.New(point.New(0, 0), point.New(9, 9))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		desc   string
+		path   string
+		opts   *problems.ExtractOptions
+		exp    string
+		expErr error
+	}{
+		{
+			desc: "correct answer",
+			path: "-0x4-0",
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9]AB[cc][dc]AW[cd]PL[B]VW[ab:ff]" +
+				"(;B[dd]C[CORRECT])(;B[bd]C[Too slow.];W[dd]C[INCORRECT]))",
+		},
+		{
+			desc: "blunder",
+			path: "-0x4-1",
+			opts: &problems.ExtractOptions{Blunder: true, Margin: 1},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9]AB[cc][dc]AW[cd]PL[B]VW[ac:ee]" +
+				"(;B[dd]C[CORRECT])(;B[bd]C[Too slow.];W[dd]C[INCORRECT]))",
+		},
+		{
+			desc: "whole board",
+			path: "-0x4-0",
+			opts: &problems.ExtractOptions{Region: region},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9]AB[cc][dc]AW[cd][gg]PL[B]" +
+				"(;B[dd]C[CORRECT])(;B[bd]C[Too slow.];W[dd]C[INCORRECT]))",
+		},
+		{
+			desc:   "root",
+			path:   "-",
+			expErr: problems.ErrExtract,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse("(;GM[1]SZ[9]PB[Black]RE[W+R];B[cc];W[gg];B[dc]C[Hmm.];W[cd]" +
+				"(;B[dd])(;B[bd]C[Too slow.];W[dd]))")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tp, err := movetree.ParsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n, err := tp.Resolve(g.Root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prob, err := problems.Extract(g, n, tc.opts)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := sgf.Serialize(prob)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Errorf("got diff (-want +got):\n%s", diff)
+			}
+			if !prob.IsProblem() {
+				t.Errorf("the extracted problem isn't recognized as a problem")
+			}
+		})
+	}
+}
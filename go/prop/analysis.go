@@ -0,0 +1,87 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ErrAnalysis indicates an error extracting AI-review analysis.
+var ErrAnalysis = errors.New("error extracting analysis")
+
+// An AnalysisExtractor extracts AI-review analysis from a node, whose
+// properties have already been converted. It returns nil if the node has no
+// analysis in the extractor's format.
+//
+// Since AI-review tools record their analysis in different ways (in comments,
+// in vendor properties), each format has its own extractor.
+type AnalysisExtractor func(n *movetree.Node) (*movetree.Analysis, error)
+
+// analysisExtractors are the registered extractors, in the order they're
+// tried.
+var analysisExtractors = []AnalysisExtractor{
+	kataGoCommentExtractor,
+}
+
+// RegisterAnalysisExtractor registers an extractor for an AI-review format.
+// Registered extractors are tried in order, after the built-in ones.
+func RegisterAnalysisExtractor(e AnalysisExtractor) {
+	analysisExtractors = append(analysisExtractors, e)
+}
+
+// ExtractAnalysis sets the analysis of the node, using the first registered
+// extractor that finds analysis. Nothing is done unless the options ask for
+// analysis to be extracted.
+func ExtractAnalysis(n *movetree.Node, opts *ParseOptions) error {
+	if !opts.extractAnalysis() {
+		return nil
+	}
+	for _, e := range analysisExtractors {
+		an, err := e(n)
+		if err != nil {
+			return fmt.Errorf("%w: at move %d: %v", ErrAnalysis, n.MoveNum(), err)
+		}
+		if an != nil {
+			n.Analysis = an
+			return nil
+		}
+	}
+	return nil
+}
+
+// kataGoStatRegexp matches a KataGo statistic in a comment, such as
+// "winrate: 0.553" or "scoreMean=2.4".
+var kataGoStatRegexp = regexp.MustCompile(`(?i)\b(winrate|scoreMean|scoreLead)\s*[:=]\s*([-+]?[0-9]*\.?[0-9]+)(%?)`)
+
+// kataGoCommentExtractor extracts the KataGo statistics that review tools
+// write into comments: the win rate (winrate, either as a fraction or as a
+// percentage) and the score lead (scoreLead, or the older scoreMean).
+func kataGoCommentExtractor(n *movetree.Node) (*movetree.Analysis, error) {
+	var an *movetree.Analysis
+	for _, m := range kataGoStatRegexp.FindAllStringSubmatch(n.Comment, -1) {
+		v, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			return nil, err
+		}
+		if an == nil {
+			an = &movetree.Analysis{}
+		}
+		switch strings.ToLower(m[1]) {
+		case "winrate":
+			if m[3] == "%" {
+				v /= 100
+			}
+			if v < 0 || v > 1 {
+				return nil, fmt.Errorf("win rate %s%s out of range", m[2], m[3])
+			}
+			an.WinRate = &v
+		default:
+			an.ScoreLead = &v
+		}
+	}
+	return an, nil
+}
@@ -0,0 +1,99 @@
+package prop
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+func float64p(f float64) *float64 {
+	return &f
+}
+
+func TestExtractAnalysis(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		comment string
+		opts    *ParseOptions
+		exp     *movetree.Analysis
+		expErr  error
+	}{
+		{
+			desc:    "win rate and score lead",
+			comment: "Black is ahead.\nwinrate: 0.553\nscoreLead: 2.4",
+			opts:    &ParseOptions{ExtractAnalysis: true},
+			exp:     &movetree.Analysis{WinRate: float64p(0.553), ScoreLead: float64p(2.4)},
+		},
+		{
+			desc:    "percentage win rate and score mean",
+			comment: "winrate=42.5% scoreMean=-1.5",
+			opts:    &ParseOptions{ExtractAnalysis: true},
+			exp:     &movetree.Analysis{WinRate: float64p(0.425), ScoreLead: float64p(-1.5)},
+		},
+		{
+			desc:    "only the win rate",
+			comment: "WinRate: 0.9",
+			opts:    &ParseOptions{ExtractAnalysis: true},
+			exp:     &movetree.Analysis{WinRate: float64p(0.9)},
+		},
+		{
+			desc:    "no analysis",
+			comment: "A good move",
+			opts:    &ParseOptions{ExtractAnalysis: true},
+		},
+		{
+			desc:    "extraction not enabled",
+			comment: "winrate: 0.553",
+		},
+		{
+			desc:    "win rate out of range",
+			comment: "winrate: 1.5",
+			opts:    &ParseOptions{ExtractAnalysis: true},
+			expErr:  ErrAnalysis,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			n := movetree.NewNode()
+			n.Comment = tc.comment
+			err := ExtractAnalysis(n, tc.opts)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if !cmp.Equal(n.Analysis, tc.exp) {
+				t.Errorf("got analysis %v, but expected %v. Diff=%s", n.Analysis, tc.exp, cmp.Diff(n.Analysis, tc.exp))
+			}
+		})
+	}
+}
+
+func TestRegisterAnalysisExtractor(t *testing.T) {
+	defer func(orig []AnalysisExtractor) { analysisExtractors = orig }(analysisExtractors)
+
+	// A vendor property with the win rate as a percentage.
+	RegisterAnalysisExtractor(func(n *movetree.Node) (*movetree.Analysis, error) {
+		v, ok := n.SGFProperties["XW"]
+		if !ok {
+			return nil, nil
+		}
+		if v[0] != "60" {
+			return nil, errors.New("unexpected value")
+		}
+		return &movetree.Analysis{WinRate: float64p(0.6)}, nil
+	})
+
+	n := movetree.NewNode()
+	n.SGFProperties["XW"] = []string{"60"}
+	if err := ExtractAnalysis(n, &ParseOptions{ExtractAnalysis: true}); err != nil {
+		t.Fatal(err)
+	}
+	exp := &movetree.Analysis{WinRate: float64p(0.6)}
+	if !cmp.Equal(n.Analysis, exp) {
+		t.Errorf("got analysis %v, but expected %v", n.Analysis, exp)
+	}
+}
@@ -0,0 +1,91 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ErrApplication indicates an error converting the application property AP.
+var ErrApplication = errors.New("error converting application property AP")
+
+// applicationConv converts the application property AP, which is composed of
+// the name and version of the application: AP[CGoban:3].
+//
+// Values without a version (AP[SomeTool]), which are common in real files,
+// are taken to be the name with an empty version, and an empty version is
+// written that way too.
+var applicationConv = &SGFConverter{
+	Props: []Prop{"AP"},
+	Scope: GameInfoScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		if len(data) != 1 {
+			return fmt.Errorf("%w: application only allows one prop-value, found %v", ErrApplication, data)
+		}
+		name, version, _ := splitCompose(data[0])
+		if name == "" {
+			if !opts.lenient() {
+				return fmt.Errorf("%w: empty application name in %q", ErrApplication, data[0])
+			}
+			return &Warning{Prop: prop, Msg: fmt.Sprintf("empty application name in %q; dropping the property", data[0])}
+		}
+		if n.GameInfo == nil {
+			// For safety, make sure to set create gameinfo if it doesn't exist.
+			n.GameInfo = &movetree.GameInfo{}
+		}
+		n.GameInfo.Application = &movetree.Application{Name: name, Version: version}
+		return nil
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		if n.GameInfo == nil || n.GameInfo.Application == nil {
+			return "", nil
+		}
+		app := n.GameInfo.Application
+		if app.Name == "" {
+			return "", fmt.Errorf("%w: application name must not be empty", ErrApplication)
+		}
+		if app.Version == "" {
+			return "AP[" + escapeCompose(app.Name) + "]", nil
+		}
+		return "AP[" + escapeCompose(app.Name) + ":" + escapeText(app.Version) + "]", nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.GameInfo != nil {
+			n.GameInfo.Application = nil
+		}
+	},
+}
+
+// splitCompose splits a composed value (a:b) at the first unescaped colon,
+// unescaping any escaped colons. If there's no colon, the entire value is
+// returned as the first part and ok is false.
+func splitCompose(v string) (first, second string, ok bool) {
+	for i := 0; i < len(v); i++ {
+		if v[i] == '\\' {
+			i++
+			continue
+		}
+		if v[i] == ':' {
+			return unescapeCompose(v[:i]), unescapeCompose(v[i+1:]), true
+		}
+	}
+	return unescapeCompose(v), "", false
+}
+
+// unescapeCompose removes the escaping from colons in part of a composed value.
+func unescapeCompose(s string) string {
+	return strings.Replace(s, "\\:", ":", -1)
+}
+
+// escapeCompose escapes the first part of a composed value, so that it can be
+// written as SGF.
+func escapeCompose(s string) string {
+	return strings.Replace(escapeText(s), ":", "\\:", -1)
+}
+
+// escapeText escapes text so that it can be written as an SGF value.
+func escapeText(s string) string {
+	return strings.Replace(s, "]", "\\]", -1)
+}
@@ -0,0 +1,113 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+func TestConvertFromSGF_Application(t *testing.T) {
+	testCases := []fromSGFTestCase{
+		{
+			desc: "name and version",
+			prop: "AP",
+			data: []string{"CGoban:3"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Application: &movetree.Application{Name: "CGoban", Version: "3"},
+				}
+			},
+		},
+		{
+			desc: "escaped colon",
+			prop: "AP",
+			data: []string{"Zork\\:Bork:1.2"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Application: &movetree.Application{Name: "Zork:Bork", Version: "1.2"},
+				}
+			},
+		},
+		{
+			desc: "missing version",
+			prop: "AP",
+			data: []string{"SomeTool"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Application: &movetree.Application{Name: "SomeTool"},
+				}
+			},
+		},
+		{
+			desc: "missing version, lenient",
+			prop: "AP",
+			data: []string{"SomeTool"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Application: &movetree.Application{Name: "SomeTool"},
+				}
+			},
+		},
+		{
+			desc:        "empty name",
+			prop:        "AP",
+			data:        []string{":1"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrApplication,
+		},
+		{
+			desc:        "empty name, lenient",
+			prop:        "AP",
+			data:        []string{":1"},
+			opts:        &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {},
+			expWarn:     true,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertNode_Application(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "name and version",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Application: &movetree.Application{Name: "clamshell", Version: "0.1"},
+				}
+			},
+			expOut: "AP[clamshell:0.1]",
+		},
+		{
+			desc: "empty version",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Application: &movetree.Application{Name: "SomeTool"},
+				}
+			},
+			expOut: "AP[SomeTool]",
+		},
+		{
+			desc: "escaped name",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Application: &movetree.Application{Name: "Zork:Bork]", Version: "1:2"},
+				}
+			},
+			expOut: "AP[Zork\\:Bork\\]:1:2]",
+		},
+		{
+			desc: "empty name",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Application: &movetree.Application{Version: "1"},
+				}
+			},
+			expErr: ErrApplication,
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
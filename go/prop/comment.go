@@ -10,29 +10,75 @@ import (
 
 var ErrComment = errors.New("error converting comment property C")
 
-// commentConv is an SGF converter for the comment property C.
+// commentConv is an SGF converter for the comment property C. When parsing
+// leniently, several values are joined into one comment (see joinValues).
 var commentConv = &SGFConverter{
 	Props: []Prop{"C"},
 	Scope: AllScope,
-	From: func(n *movetree.Node, prop string, data []string) error {
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
 		if n.Comment != "" {
 			return fmt.Errorf("%w: already found on node: %q", ErrComment, n.Comment)
 		}
 		if len(data) == 0 {
 			// Edgecase where Comment-property is set, but there is no data.
 			return nil
-		} else if len(data) != 1 {
+		} else if len(data) != 1 && !opts.lenient() {
 			return fmt.Errorf("%w: comment only allows one prop-value, found %v", ErrComment, data)
+		} else if len(data) != 1 {
+			// C[line1][line2] was most likely meant to be one comment.
+			var warn *Warning
+			n.Comment, warn = joinValues(prop, data, "\n")
+			n.Comment = unescapeText(n.Comment)
+			return warn
 		}
-		// The parser does escaping for the property data on the input side.
-		n.Comment = data[0]
+		n.Comment = unescapeText(data[0])
 		return nil
 	},
-	To: func(n *movetree.Node) (string, error) {
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
 		c := n.Comment
 		if c == "" {
 			return "", nil
 		}
-		return "C[" + strings.Replace(c, "]", "\\]", -1) + "]", nil
+		return "C[" + escapeComment(c) + "]", nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		n.Comment = ""
 	},
 }
+
+// unescapeText removes the backslashes that escape characters in a text value
+// (ex: \\ and \:), except for soft line breaks (a backslash before a newline),
+// which are kept so that they're written back out. The parser has already
+// unescaped the closing brackets.
+func unescapeText(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && s[i+1] != '\n' {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// escapeComment escapes a comment so that it can be written as an SGF value:
+// closing brackets and backslashes are escaped, except for the backslashes of
+// soft line breaks (see unescapeText). Colons only need escaping in composed
+// values, so they're written as-is.
+func escapeComment(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == ']':
+			sb.WriteString("\\]")
+		case s[i] == '\\' && (i+1 == len(s) || s[i+1] != '\n'):
+			sb.WriteString("\\\\")
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
@@ -16,6 +16,39 @@ func TestConvertFromSGF_Comment(t *testing.T) {
 				n.Comment = "Ima comment"
 			},
 		},
+		{
+			desc: "escaped characters",
+			prop: "C",
+			data: []string{`a \\ backslash, a \: colon, and a ] bracket`},
+			makeExpNode: func(n *movetree.Node) {
+				n.Comment = `a \ backslash, a : colon, and a ] bracket`
+			},
+		},
+		{
+			desc: "soft line break",
+			prop: "C",
+			data: []string{"one \\\nline\nand another"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Comment = "one \\\nline\nand another"
+			},
+		},
+		{
+			desc:        "several values",
+			prop:        "C",
+			data:        []string{"a", "b"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrComment,
+		},
+		{
+			desc: "several values, lenient",
+			prop: "C",
+			data: []string{"a", "b"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.Comment = "a\nb"
+			},
+			expWarn: true,
+		},
 	}
 	testConvertFromSGFCases(t, testCases)
 }
@@ -29,6 +62,20 @@ func TestConvertNode_Comment(t *testing.T) {
 			},
 			expOut: "C[Ima comment]",
 		},
+		{
+			desc: "escaped characters",
+			makeNode: func(n *movetree.Node) {
+				n.Comment = `a \ backslash, a : colon, and a ] bracket`
+			},
+			expOut: `C[a \\ backslash, a : colon, and a \] bracket]`,
+		},
+		{
+			desc: "multi-line comment with a soft line break",
+			makeNode: func(n *movetree.Node) {
+				n.Comment = "one \\\nline\nand another"
+			},
+			expOut: "C[one \\\nline\nand another]",
+		},
 	}
 
 	testConvertNodeCases(t, testCases)
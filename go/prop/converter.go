@@ -1,31 +1,75 @@
 package prop
 
 import (
+	"errors"
 	"fmt"
-	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/otrego/clamshell/go/movetree"
 )
 
-// ProcessPropertyData uses converters to process property data.
+// ProcessPropertyData uses converters to process property data, using strict
+// parsing.
 func ProcessPropertyData(n *movetree.Node, p string, propData []string) error {
+	return ProcessPropertyDataWithOptions(n, p, propData, nil)
+}
+
+// ProcessPropertyDataWithOptions uses converters to process property data. When
+// parsing leniently, the property may be converted despite being malformed, in
+// which case a *Warning is returned.
+func ProcessPropertyDataWithOptions(n *movetree.Node, p string, propData []string, opts *ParseOptions) error {
 	if !HasConverter(p) {
+		if opts.rejectUnknown() && !Validate(Prop(p)) {
+			return fmt.Errorf("%w: %s%v at {move:%d, variation: %d}",
+				ErrUnknownProp, p, propData, n.MoveNum(), n.VarNum())
+		}
 		// For properties without an explicit converter, add to unprocessed
-		// Properties.
+		// Properties. Point lists are expanded, so that the points can be read
+		// without handling the compressed form.
+		var warn error
+		if pointListProps[Prop(p)] {
+			propData, warn = cleanPoints(n, p, propData, opts)
+			propData = expandPointList(propData)
+		}
 		n.SGFProperties[p] = propData
-		return nil
+		return warn
 	}
 	conv := Converter(p)
+	if conv.Scope == GameInfoScope && (n.MoveNum() != 0 || n.VarNum() != 0) {
+		// Edited files often copy the game info onto other nodes, so it's kept
+		// raw, for Lint to report and Normalize to consolidate.
+		n.SGFProperties[p] = propData
+		return nil
+	}
 	if conv.Scope == RootScope && (n.MoveNum() != 0 || n.VarNum() != 0) {
+		if opts.lenient() {
+			n.SGFProperties[p] = propData
+			return &Warning{
+				Prop: p,
+				Msg:  "root-only property on a non-root node; kept as a raw property",
+				Code: movetree.LintScope,
+			}
+		}
 		return fmt.Errorf("%w: for property %s: property is a root-node only property, but was found at {move:%d, variation: %d}",
-			ErrConvertingProp, p, n.MoveNum(), n.VarNum())
+			ErrScope, p, n.MoveNum(), n.VarNum())
 	}
 
-	if err := conv.From(n, p, propData); err != nil {
+	if !opts.lenient() {
+		return conv.From(n, p, propData, opts)
+	}
+	// When parsing leniently, a property that the converter can't read is kept
+	// as a raw property, as it was before the conversion, rather than failing
+	// the parse. Converters that recover in a better way return a *Warning.
+	before := n.CopyProperties()
+	err := conv.From(n, p, propData, opts)
+	var warn *Warning
+	if err == nil || errors.As(err, &warn) {
 		return err
 	}
-	return nil
+	n.SetProperties(before)
+	n.SGFProperties[p] = propData
+	return &Warning{Prop: p, Msg: fmt.Sprintf("%v; keeping it as a raw property", err)}
 }
 
 // Scope indicates the scope for a property.
@@ -35,15 +79,20 @@ const (
 	// RootScope indicates a property that only applies to the root node.
 	RootScope Scope = "RootScope"
 
+	// GameInfoScope indicates a game-info property, which belongs on the
+	// root. Unlike root properties, it's not an error to find game-info
+	// properties on other nodes, where they're kept as raw properties.
+	GameInfoScope Scope = "GameInfoScope"
+
 	// AllScope indicates a property that applies to all nodes.
 	AllScope Scope = "AllScope"
 )
 
 // FromSGF converts an SGF Property to node property
-type FromSGF func(node *movetree.Node, prop string, values []string) error
+type FromSGF func(node *movetree.Node, prop string, values []string, opts *ParseOptions) error
 
 // ToSGF converts an Node property to an SGF property list.
-type ToSGF func(node *movetree.Node) (string, error)
+type ToSGF func(node *movetree.Node, opts *SerializeOptions) (string, error)
 
 // A SGFConverter converts SGF properties to / from node properties.
 type SGFConverter struct {
@@ -57,6 +106,12 @@ type SGFConverter struct {
 	From FromSGF
 	// To converts to SGF data
 	To ToSGF
+	// Clear removes the values of one of the properties from a node, so that
+	// they can be replaced (see ClearProperty).
+	Clear func(node *movetree.Node, prop string)
+	// Examples are optional round-trip examples, which document the behavior
+	// of the converter and are checked by VerifyConverters.
+	Examples []RoundTripExample
 }
 
 // HasConverter indicates whether there's a known SGF Property converter.
@@ -71,32 +126,130 @@ func Converter(prop string) *SGFConverter {
 	return propToConv[Prop(prop)]
 }
 
-// ConvertNode converts all the properties in a node
+// ClearProperty removes the values of the property from the node, whether
+// they were converted or kept as a raw property, so that the property can be
+// set again with ProcessPropertyData. Game info that's left empty is removed.
+func ClearProperty(n *movetree.Node, prop string) {
+	delete(n.SGFProperties, prop)
+	if conv := Converter(prop); conv != nil && conv.Clear != nil {
+		conv.Clear(n, prop)
+	}
+	if n.GameInfo != nil && *n.GameInfo == (movetree.GameInfo{}) {
+		n.GameInfo = nil
+	}
+}
+
+// ConvertNode converts all the properties in a node, using the default
+// serialization options.
 func ConvertNode(n *movetree.Node) (string, error) {
-	var sb strings.Builder
+	return ConvertNodeWithOptions(n, nil)
+}
+
+// ConvertNodeWithOptions converts all the properties in a node. The properties
+// are written in the order given by CanonicalOrder, unless the options
+// override it.
+func ConvertNodeWithOptions(n *movetree.Node, opts *SerializeOptions) (string, error) {
+	props, err := ConvertNodeProps(n, opts)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(props, ""), nil
+}
+
+// ConvertNodeProps is like ConvertNodeWithOptions, but returns each of the
+// properties of the node separately, in the order they're written (ex:
+// []string{"B[aa]", "C[Hi]"}), so that the properties can be laid out by the
+// caller.
+func ConvertNodeProps(n *movetree.Node, opts *SerializeOptions) ([]string, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	var props []convertedProp
 	for _, c := range converters {
-		if c.Scope == RootScope && n.MoveNum() != 0 {
+		if (c.Scope == RootScope || c.Scope == GameInfoScope) && n.MoveNum() != 0 {
 			// skip non-root-scoped properties for non-root nodes.
 			continue
 		}
-		s, err := c.To(n)
+		s, err := c.To(n, opts)
 		if err != nil {
-			return "", err
+			return nil, err
+		}
+		// Converters can write several properties, so the filtering and
+		// ordering is done on the converted output.
+		props = append(props, splitProps(s)...)
+	}
+
+	for key, values := range n.SGFProperties {
+		if pointListProps[Prop(key)] && opts.compressPointLists() {
+			// Point-list properties without a converter (ex: markup) can still be
+			// compressed, as long as they're well-formed.
+			if pts, err := pointsFromSGF(values); err == nil && len(pts) > 0 {
+				s, err := writePointList(key, pts, opts)
+				if err != nil {
+					return nil, err
+				}
+				props = append(props, convertedProp{prop: Prop(key), sgf: s})
+				continue
+			}
+		}
+		var sb strings.Builder
+		sb.WriteString(key)
+		if len(values) == 0 {
+			// A property always has a value, so a property that's present
+			// without values is written with an empty value. For example, VW[]
+			// clears an inherited view.
+			values = []string{""}
+		}
+		for _, value := range values {
+			// The parser only unescapes the closing brackets of raw values, so
+			// escaping them again restores the original value.
+			sb.WriteString("[" + escapeText(value) + "]")
 		}
-		sb.WriteString(s)
+		props = append(props, convertedProp{prop: Prop(key), sgf: sb.String()})
 	}
 
-	var keys []string
-	for key := range n.SGFProperties {
-		keys = append(keys, key)
+	if _, ok := n.SGFProperties["HA"]; !ok && opts.inferHandicap() && n.Parent == nil && (n.GameInfo == nil || n.GameInfo.Handicap == 0) {
+		if count, ok := movetree.InferHandicap(n.Placements, nodeBoardSize(n)); ok {
+			props = append(props, convertedProp{prop: "HA", sgf: fmt.Sprintf("HA[%d]", count)})
+		}
 	}
-	sort.Strings(keys)
 
-	for _, key := range keys {
-		sb.WriteString(key)
-		for _, value := range n.SGFProperties[key] {
-			sb.WriteString("[" + value + "]")
+	if opts.fileProps() && n.Parent == nil {
+		for _, p := range fileProps {
+			if _, ok := n.SGFProperties[string(p.prop)]; !ok {
+				props = append(props, p)
+			}
+		}
+	}
+
+	sortProps(props, opts)
+	var out []string
+	for _, p := range props {
+		if opts.allowed(p.prop) {
+			out = append(out, opts.applyLineEnding(p.sgf))
+		}
+	}
+	return out, nil
+}
+
+// fileProps are the file properties written by SerializeOptions.FileProps.
+var fileProps = []convertedProp{
+	{prop: "FF", sgf: "FF[4]"},
+	{prop: "GM", sgf: "GM[1]"},
+	{prop: "CA", sgf: "CA[UTF-8]"},
+}
+
+// fileFormat returns the SGF file format (FF) of the movetree containing node
+// n, defaulting to 4 if it's unspecified or invalid.
+func fileFormat(n *movetree.Node) int {
+	root := n
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	if ff := root.SGFProperties["FF"]; len(ff) == 1 {
+		if v, err := strconv.Atoi(ff[0]); err == nil && v > 0 {
+			return v
 		}
 	}
-	return sb.String(), nil
+	return 4
 }
@@ -6,8 +6,41 @@ var converters = []*SGFConverter{
 	placementsConv,
 	movesConv,
 	komiConv,
+	handicapConv,
 	initPlayerConv,
+	rulesConv,
+	resultConv,
+	blackPlayerConv,
+	whitePlayerConv,
+	blackRankConv,
+	whiteRankConv,
+	dateConv,
+	eventConv,
+	mainTimeConv,
+	overtimeConv,
+	blackTeamConv,
+	whiteTeamConv,
+	gameNameConv,
+	gameCommentConv,
+	placeConv,
+	roundConv,
+	openingConv,
+	annotatorConv,
+	sourceConv,
+	userConv,
+	copyrightConv,
+	applicationConv,
 	commentConv,
+	moveAnnotationConv,
+	nameConv,
+	positionAnnotationConv,
+	hotspotConv,
+	valueConv,
+	marksConv,
+	labelsConv,
+	linesConv,
+	timeLeftConv,
+	overtimeLeftConv,
 }
 
 var propToConv = func(conv []*SGFConverter) map[Prop]*SGFConverter {
@@ -19,3 +52,25 @@ var propToConv = func(conv []*SGFConverter) map[Prop]*SGFConverter {
 	}
 	return mp
 }(converters)
+
+// processFirst lists the properties that the conversion of other properties in
+// the same node depends on, in the order they should be processed. For
+// example, the valid komi values depend on the ruleset, and the board size is
+// used to recover from mangled points when parsing leniently. The black stones
+// are checked against the handicap, and the stones of the node are needed to
+// convert the FF[3] M marks. The properties of each group share a rank.
+var processFirst = [][]Prop{{"SZ"}, {"RU"}, {"AB"}, {"AW", "B", "W"}}
+
+// ProcessOrder returns the rank of a property in the order in which the
+// properties of a node should be processed: properties with lower ranks are
+// processed first. Properties with equal ranks can be processed in any order.
+func ProcessOrder(p string) int {
+	for i, group := range processFirst {
+		for _, q := range group {
+			if Prop(p) == q {
+				return i
+			}
+		}
+	}
+	return len(processFirst)
+}
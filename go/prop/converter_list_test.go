@@ -17,11 +17,16 @@ type propGetter func(*movetree.Node) interface{}
 type setprops func(*movetree.Node) *movetree.Node
 
 type fromSGFTestCase struct {
-	desc        string
-	prop        string
-	data        []string
+	desc string
+	prop string
+	data []string
+	opts *ParseOptions
+	// makeNode optionally sets up the node before the property is processed.
+	makeNode    func(*movetree.Node)
 	makeExpNode func(*movetree.Node)
 	expErr      error
+	// expWarn indicates that a *Warning is expected rather than an error.
+	expWarn bool
 }
 
 func testConvertFromSGFCases(t *testing.T, testCases []fromSGFTestCase) {
@@ -29,10 +34,22 @@ func testConvertFromSGFCases(t *testing.T, testCases []fromSGFTestCase) {
 		tc := tci
 		t.Run(tc.desc, func(t *testing.T) {
 			n := movetree.NewNode()
+			if tc.makeNode != nil {
+				tc.makeNode(n)
+			}
 			expNode := movetree.NewNode()
 			tc.makeExpNode(expNode)
 
-			err := ProcessPropertyData(n, tc.prop, tc.data)
+			err := ProcessPropertyDataWithOptions(n, tc.prop, tc.data, tc.opts)
+			var warn *Warning
+			if errors.As(err, &warn) {
+				if !tc.expWarn {
+					t.Fatalf("got warning %v, but expected none", warn)
+				}
+				err = nil
+			} else if tc.expWarn {
+				t.Fatalf("got error %v, but expected a warning", err)
+			}
 			if !errors.Is(err, tc.expErr) {
 				t.Fatalf("got error %v, but expected err %v", err, tc.expErr)
 			}
@@ -49,6 +66,7 @@ func testConvertFromSGFCases(t *testing.T, testCases []fromSGFTestCase) {
 type convertNodeTestCase struct {
 	desc     string
 	makeNode func(*movetree.Node)
+	opts     *SerializeOptions
 	expOut   string
 	expErr   error
 }
@@ -59,7 +77,7 @@ func testConvertNodeCases(t *testing.T, testCases []convertNodeTestCase) {
 		t.Run(tc.desc, func(t *testing.T) {
 			node := movetree.NewNode()
 			tc.makeNode(node)
-			out, err := ConvertNode(node)
+			out, err := ConvertNodeWithOptions(node, tc.opts)
 			if !errors.Is(err, tc.expErr) {
 				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
 			}
@@ -75,6 +93,10 @@ func testConvertNodeCases(t *testing.T, testCases []convertNodeTestCase) {
 	}
 }
 
+func TestVerifyConverters(t *testing.T) {
+	VerifyConverters(t)
+}
+
 func TestConvertFromSGF_Collection(t *testing.T) {
 	testCases := []fromSGFTestCase{
 		{
@@ -85,6 +107,22 @@ func TestConvertFromSGF_Collection(t *testing.T) {
 				n.SGFProperties["ZZ"] = []string{"Zork"}
 			},
 		},
+		{
+			desc: "raw point list with a rectangle",
+			prop: "TB",
+			data: []string{"aa:ab", "ab", "cc"},
+			makeExpNode: func(n *movetree.Node) {
+				n.SGFProperties["TB"] = []string{"aa", "ab", "cc"}
+			},
+		},
+		{
+			desc: "malformed raw point list is kept",
+			prop: "TW",
+			data: []string{"aa:zzz"},
+			makeExpNode: func(n *movetree.Node) {
+				n.SGFProperties["TW"] = []string{"aa:zzz"}
+			},
+		},
 	}
 
 	testConvertFromSGFCases(t, testCases)
@@ -110,7 +148,86 @@ func TestConvertNode_Collection(t *testing.T) {
 			},
 			expOut: "B[ab]AA[ark]BB[bark]ZZ[zork]",
 		},
+		{
+			desc: "extra point-list properties, compressed",
+			makeNode: func(n *movetree.Node) {
+				n.SGFProperties["TR"] = []string{"aa", "ab", "ba", "bb"}
+				n.SGFProperties["ZZ"] = []string{"aa", "ab"}
+			},
+			opts:   &SerializeOptions{CompressPointLists: true},
+			expOut: "TR[aa:bb]ZZ[aa][ab]",
+		},
+		{
+			desc: "extra point-list properties, not compressible",
+			makeNode: func(n *movetree.Node) {
+				n.SGFProperties["TR"] = []string{"aa", "zork"}
+			},
+			opts:   &SerializeOptions{CompressPointLists: true},
+			expOut: "TR[aa][zork]",
+		},
 	}
 
 	testConvertNodeCases(t, testCases)
 }
+
+func TestClearProperty(t *testing.T) {
+	makeNode := func() *movetree.Node {
+		n := movetree.NewNode()
+		n.Move = move.New(color.Black, point.New(2, 2))
+		n.Placements = move.List{move.New(color.Black, point.New(0, 0)), move.New(color.White, point.New(1, 1))}
+		n.Marks = map[point.Point]movetree.MarkType{
+			*point.New(3, 3): movetree.MarkTriangle,
+			*point.New(4, 4): movetree.MarkSquare,
+		}
+		n.GameInfo = &movetree.GameInfo{BlackPlayer: "Black"}
+		n.SGFProperties["ZZ"] = []string{"zork"}
+		return n
+	}
+	testCases := []struct {
+		desc        string
+		prop        string
+		makeExpNode func(n *movetree.Node)
+	}{
+		{
+			desc:        "move",
+			prop:        "B",
+			makeExpNode: func(n *movetree.Node) { n.Move = nil },
+		},
+		{
+			desc:        "move of the other player",
+			prop:        "W",
+			makeExpNode: func(n *movetree.Node) {},
+		},
+		{
+			desc:        "placements of one color",
+			prop:        "AB",
+			makeExpNode: func(n *movetree.Node) { n.Placements = n.Placements[1:] },
+		},
+		{
+			desc:        "marks of one type",
+			prop:        "TR",
+			makeExpNode: func(n *movetree.Node) { delete(n.Marks, *point.New(3, 3)) },
+		},
+		{
+			desc:        "last game info",
+			prop:        "PB",
+			makeExpNode: func(n *movetree.Node) { n.GameInfo = nil },
+		},
+		{
+			desc:        "raw property",
+			prop:        "ZZ",
+			makeExpNode: func(n *movetree.Node) { delete(n.SGFProperties, "ZZ") },
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			n := makeNode()
+			ClearProperty(n, tc.prop)
+			exp := makeNode()
+			tc.makeExpNode(exp)
+			if !reflect.DeepEqual(n, exp) {
+				t.Errorf("ClearProperty(%q) got node %#v, but expected %#v", tc.prop, n, exp)
+			}
+		})
+	}
+}
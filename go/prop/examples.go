@@ -0,0 +1,106 @@
+package prop
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// RoundTripExample is an example conversion of an SGF property, from the SGF
+// to the node and back.
+type RoundTripExample struct {
+	// Desc describes the example.
+	Desc string
+
+	// In is the SGF property, with its values (ex: "SZ[9]").
+	In string
+
+	// Expect sets up the expected node, given a new node. The property is
+	// converted on a root node.
+	Expect func(n *movetree.Node)
+
+	// Out is the expected SGF written by the converter. If empty, it's In.
+	Out string
+}
+
+// Reporter reports the failures of VerifyConverters. It's satisfied by
+// *testing.T, without this package depending on the testing package.
+type Reporter interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// VerifyConverters checks the examples of all the converters: the input
+// property must convert to the expected node, the node must convert back to
+// the expected output, and the output must convert to the expected node
+// again. Clearing the property must then leave an empty node. Call it from a
+// test, so that converters with examples are checked automatically.
+func VerifyConverters(r Reporter) {
+	r.Helper()
+	for _, c := range converters {
+		if c.Clear == nil {
+			r.Errorf("%s: the converter can't clear its properties", c.Props[0])
+		}
+		for _, ex := range c.Examples {
+			if err := verifyExample(c, ex); err != nil {
+				r.Errorf("%s/%s: %v", c.Props[0], ex.Desc, err)
+			}
+		}
+	}
+}
+
+// verifyExample round-trips an example of the converter.
+func verifyExample(c *SGFConverter, ex RoundTripExample) error {
+	expNode := movetree.NewNode()
+	ex.Expect(expNode)
+	out := ex.Out
+	if out == "" {
+		out = ex.In
+	}
+
+	for _, in := range []string{ex.In, out} {
+		p, data, err := splitExample(in)
+		if err != nil {
+			return fmt.Errorf("splitting example %q: %v", in, err)
+		}
+		if Converter(p) != c {
+			return fmt.Errorf("example property %q isn't converted by this converter", in)
+		}
+		n := movetree.NewNode()
+		if err := ProcessPropertyData(n, p, data); err != nil {
+			return fmt.Errorf("converting %q: %v", in, err)
+		}
+		if !reflect.DeepEqual(n, expNode) {
+			return fmt.Errorf("converting %q: got node %#v, but expected node %#v", in, n, expNode)
+		}
+		if c.Clear == nil {
+			continue
+		}
+		ClearProperty(n, p)
+		if empty := movetree.NewNode(); !reflect.DeepEqual(n, empty) {
+			return fmt.Errorf("clearing %q: got node %#v, but expected an empty node", in, n)
+		}
+	}
+
+	got, err := c.To(expNode, nil)
+	if err != nil {
+		return fmt.Errorf("converting the expected node: %v", err)
+	}
+	if got != out {
+		return fmt.Errorf("converting the expected node: got %q, but expected %q", got, out)
+	}
+	return nil
+}
+
+// splitExample splits an example property (ex: "AB[aa][bb]") into the
+// property and its values.
+func splitExample(s string) (string, []string, error) {
+	i := strings.IndexByte(s, '[')
+	if i < 0 {
+		return s, nil, nil
+	}
+	data, err := SplitValues(s[i:])
+	return s[:i], data, err
+}
@@ -0,0 +1,47 @@
+package prop
+
+import "strings"
+
+// convertedProp is a property converted to SGF, with all of its values.
+type convertedProp struct {
+	prop Prop
+	sgf  string
+}
+
+// splitProps splits the SGF properties in s (ex: B[aa]BL[30]C[foo]) into
+// individual properties.
+func splitProps(s string) []convertedProp {
+	var out []convertedProp
+	for len(s) > 0 {
+		end := propEnd(s)
+		ident := s[:end]
+		if i := strings.IndexByte(ident, '['); i >= 0 {
+			ident = ident[:i]
+		}
+		out = append(out, convertedProp{prop: Prop(ident), sgf: s[:end]})
+		s = s[end:]
+	}
+	return out
+}
+
+// propEnd returns the index just past the end of the first property in s,
+// including all of its values.
+func propEnd(s string) int {
+	i := strings.IndexByte(s, '[')
+	if i < 0 {
+		return len(s)
+	}
+	for i < len(s) && s[i] == '[' {
+		// Skip to the closing bracket, ignoring escaped brackets.
+		for i++; i < len(s) && s[i] != ']'; i++ {
+			if s[i] == '\\' {
+				i++
+			}
+		}
+		i++
+	}
+	if i > len(s) {
+		return len(s)
+	}
+	return i
+}
@@ -0,0 +1,50 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestConvertNode_Filter(t *testing.T) {
+	makeNode := func(n *movetree.Node) {
+		n.Move = move.New(color.Black, point.New(0, 1))
+		n.Comment = "Nice [move]"
+		n.Placements = []*move.Move{
+			move.New(color.Black, point.New(2, 2)),
+			move.New(color.White, point.New(3, 3)),
+		}
+		n.SGFProperties["BL"] = []string{"300"}
+		n.SGFProperties["OB"] = []string{"5"}
+	}
+	testCases := []convertNodeTestCase{
+		{
+			desc:     "no filter",
+			makeNode: makeNode,
+			expOut:   "AB[cc]AW[dd]B[ab]C[Nice [move\\]]BL[300]OB[5]",
+		},
+		{
+			desc:     "exclude",
+			makeNode: makeNode,
+			opts:     &SerializeOptions{Exclude: []Prop{"BL", "OB", "AW"}},
+			expOut:   "AB[cc]B[ab]C[Nice [move\\]]",
+		},
+		{
+			desc:     "include",
+			makeNode: makeNode,
+			opts:     &SerializeOptions{Include: []Prop{"B", "W", "C"}},
+			expOut:   "B[ab]C[Nice [move\\]]",
+		},
+		{
+			desc:     "include and exclude",
+			makeNode: makeNode,
+			opts:     &SerializeOptions{Include: []Prop{"B"}, Exclude: []Prop{"C"}},
+			expErr:   ErrSerializeOptions,
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
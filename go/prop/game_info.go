@@ -0,0 +1,117 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ErrGameInfo indicates an error converting a game-info text property.
+var ErrGameInfo = errors.New("error converting game-info property")
+
+// gameInfoTextConv creates a converter for a game-info property with a simple
+// text value, which is stored in the game-info field returned by field. The
+// value is unescaped and escaped like a comment (ex: a name with a \]). When
+// parsing leniently, several values are joined into one (see joinValues).
+func gameInfoTextConv(p Prop, field func(gi *movetree.GameInfo) *string) *SGFConverter {
+	return &SGFConverter{
+		Props: []Prop{p},
+		Scope: GameInfoScope,
+		From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+			if len(data) != 1 && (len(data) == 0 || !opts.lenient()) {
+				return fmt.Errorf("%w: %s only allows one prop-value, found %v", ErrGameInfo, prop, data)
+			}
+			if n.GameInfo == nil {
+				// For safety, make sure to set create gameinfo if it doesn't exist.
+				n.GameInfo = &movetree.GameInfo{}
+			}
+			if len(data) != 1 {
+				var warn *Warning
+				var joined string
+				joined, warn = joinValues(prop, data, " ")
+				*field(n.GameInfo) = unescapeText(joined)
+				return warn
+			}
+			*field(n.GameInfo) = unescapeText(data[0])
+			return nil
+		},
+		To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+			if n.GameInfo == nil || *field(n.GameInfo) == "" {
+				return "", nil
+			}
+			return string(p) + "[" + escapeComment(*field(n.GameInfo)) + "]", nil
+		},
+		Clear: func(n *movetree.Node, prop string) {
+			if n.GameInfo != nil {
+				*field(n.GameInfo) = ""
+			}
+		},
+	}
+}
+
+var (
+	blackPlayerConv = gameInfoTextConv("PB", func(gi *movetree.GameInfo) *string { return &gi.BlackPlayer })
+	whitePlayerConv = gameInfoTextConv("PW", func(gi *movetree.GameInfo) *string { return &gi.WhitePlayer })
+	blackRankConv   = gameInfoTextConv("BR", func(gi *movetree.GameInfo) *string { return &gi.BlackRank })
+	whiteRankConv   = gameInfoTextConv("WR", func(gi *movetree.GameInfo) *string { return &gi.WhiteRank })
+	eventConv       = gameInfoTextConv("EV", func(gi *movetree.GameInfo) *string { return &gi.Event })
+	blackTeamConv   = gameInfoTextConv("BT", func(gi *movetree.GameInfo) *string { return &gi.BlackTeam })
+	whiteTeamConv   = gameInfoTextConv("WT", func(gi *movetree.GameInfo) *string { return &gi.WhiteTeam })
+	gameNameConv    = gameInfoTextConv("GN", func(gi *movetree.GameInfo) *string { return &gi.GameName })
+	gameCommentConv = gameInfoTextConv("GC", func(gi *movetree.GameInfo) *string { return &gi.GameComment })
+	placeConv       = gameInfoTextConv("PC", func(gi *movetree.GameInfo) *string { return &gi.Place })
+	roundConv       = gameInfoTextConv("RO", func(gi *movetree.GameInfo) *string { return &gi.Round })
+	openingConv     = gameInfoTextConv("ON", func(gi *movetree.GameInfo) *string { return &gi.Opening })
+	annotatorConv   = gameInfoTextConv("AN", func(gi *movetree.GameInfo) *string { return &gi.Annotator })
+	sourceConv      = gameInfoTextConv("SO", func(gi *movetree.GameInfo) *string { return &gi.Source })
+	userConv        = gameInfoTextConv("US", func(gi *movetree.GameInfo) *string { return &gi.User })
+	copyrightConv   = gameInfoTextConv("CP", func(gi *movetree.GameInfo) *string { return &gi.Copyright })
+)
+
+// dateConv converts the date property DT. The dates must exist (see
+// movetree.ParseDates) and be in chronological order. When parsing leniently,
+// invalid dates are kept as they are, with a warning.
+var dateConv = func() *SGFConverter {
+	conv := gameInfoTextConv("DT", func(gi *movetree.GameInfo) *string { return &gi.Date })
+	from := conv.From
+	conv.From = func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		var warn error
+		if len(data) > 1 && opts.lenient() {
+			// DT is a list of dates, so several values are joined into the
+			// list, rather than with spaces.
+			joined, jw := joinValues(prop, data, ",")
+			data, warn = []string{joined}, jw
+		}
+		if len(data) != 1 {
+			return from(n, prop, data, opts)
+		}
+		err := validateDates(data[0])
+		if err != nil && !opts.lenient() {
+			return fmt.Errorf("for property %s: %w", prop, err)
+		}
+		if ferr := from(n, prop, data, opts); ferr != nil {
+			return ferr
+		}
+		if err != nil {
+			return &Warning{Prop: prop, Msg: fmt.Sprintf("%v; keeping the date as-is", err), Code: movetree.LintMalformed}
+		}
+		return warn
+	}
+	return conv
+}()
+
+// validateDates checks that the dates of a date property exist and are in
+// chronological order.
+func validateDates(dt string) error {
+	dates, err := movetree.ParseDates(dt)
+	if err != nil {
+		return err
+	}
+	for i := 1; i < len(dates); i++ {
+		if dates[i].Before(dates[i-1]) {
+			return fmt.Errorf("%w: %s comes before %s in %q", movetree.ErrDate, dates[i], dates[i-1], dt)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+func TestConvertFromSGF_GameInfoText(t *testing.T) {
+	testCases := []fromSGFTestCase{
+		{
+			desc: "black player",
+			prop: "PB",
+			data: []string{"Lee Sedol"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{BlackPlayer: "Lee Sedol"}
+			},
+		},
+		{
+			desc: "escaped name",
+			prop: "PW",
+			data: []string{`Go\\Bot [v2]`},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{WhitePlayer: `Go\Bot [v2]`}
+			},
+		},
+		{
+			desc: "black rank",
+			prop: "BR",
+			data: []string{"9d"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{BlackRank: "9d"}
+			},
+		},
+		{
+			desc: "game comment",
+			prop: "GC",
+			data: []string{"Game 4 of the match.\nA famous wedge at move 78."},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{GameComment: "Game 4 of the match.\nA famous wedge at move 78."}
+			},
+		},
+		{
+			desc: "black team",
+			prop: "BT",
+			data: []string{"Korea"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{BlackTeam: "Korea"}
+			},
+		},
+		{
+			desc: "date",
+			prop: "DT",
+			data: []string{"2016-03-09"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Date: "2016-03-09"}
+			},
+		},
+		{
+			desc: "date range",
+			prop: "DT",
+			data: []string{"1996-05-06,07,08"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Date: "1996-05-06,07,08"}
+			},
+		},
+		{
+			desc:        "feb 29 in a non-leap year",
+			prop:        "DT",
+			data:        []string{"1997-02-29"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      movetree.ErrDate,
+		},
+		{
+			desc:        "dates out of order",
+			prop:        "DT",
+			data:        []string{"1996-05-06,1996-04-30"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      movetree.ErrDate,
+		},
+		{
+			desc: "dates out of order, lenient",
+			prop: "DT",
+			data: []string{"1996-05-06,1996-04-30"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Date: "1996-05-06,1996-04-30"}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "multiple values, lenient",
+			prop: "PB",
+			data: []string{"Lee", "Sedol"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{BlackPlayer: "Lee Sedol"}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "multiple dates, lenient",
+			prop: "DT",
+			data: []string{"1996-05-06", "1996-05-07"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Date: "1996-05-06,1996-05-07"}
+			},
+			expWarn: true,
+		},
+		{
+			desc:        "multiple values",
+			prop:        "EV",
+			data:        []string{"a", "b"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrGameInfo,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertNode_GameInfoText(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "players and event",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					BlackPlayer: "Lee Sedol",
+					WhitePlayer: "AlphaGo",
+					Event:       "[Match]",
+				}
+			},
+			expOut: "PB[Lee Sedol]PW[AlphaGo]EV[[Match\\]]",
+		},
+		{
+			desc: "players and ranks",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					BlackPlayer: `Go\Bot`,
+					BlackRank:   "3k*",
+					WhitePlayer: "Honinbo",
+					WhiteRank:   "9p",
+				}
+			},
+			expOut: `PB[Go\\Bot]BR[3k*]PW[Honinbo]WR[9p]`,
+		},
+		{
+			desc: "game record metadata",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					GameName:  "Lee Sedol vs AlphaGo, game 4",
+					Place:     "Seoul",
+					Round:     "4",
+					Annotator: "An Younggil",
+					Source:    "Go Game Guru",
+					Copyright: "CC BY",
+				}
+			},
+			expOut: "GN[Lee Sedol vs AlphaGo, game 4]RO[4]PC[Seoul]SO[Go Game Guru]AN[An Younggil]CP[CC BY]",
+		},
+		{
+			desc: "empty",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{}
+			},
+			expOut: "",
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
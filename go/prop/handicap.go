@@ -0,0 +1,120 @@
+package prop
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ErrBadHandicap indicates a handicap that isn't a number, or that is
+// negative or 1. It wraps movetree.ErrHandicap.
+var ErrBadHandicap = fmt.Errorf("%w: error converting handicap property HA", movetree.ErrHandicap)
+
+// handicapConv converts the handicap property HA.
+//
+// The black stones placed on the root (AB) are processed first, so that a
+// handicap that doesn't match their number is reported with a *Warning. Files
+// with free placement sometimes only place the stones after the root, so a
+// root without black stones isn't a mismatch. With ParseOptions.PlaceHandicap,
+// the standard handicap stones are placed on such a root instead.
+//
+// When parsing leniently, HA[1], which some applications write for games
+// without a handicap, is read as no handicap, and other malformed handicaps
+// are kept as raw properties, each with a *Warning.
+var handicapConv = &SGFConverter{
+	Props: []Prop{"HA"},
+	Scope: GameInfoScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		count, err := parseHandicap(data)
+		if err != nil {
+			if !opts.lenient() {
+				return err
+			}
+			if count != 1 {
+				n.SGFProperties[prop] = data
+				return &Warning{Prop: prop, Msg: fmt.Sprintf("%v; keeping it as a raw property", err), Code: movetree.LintHandicap}
+			}
+		}
+		if n.GameInfo == nil {
+			// For safety, make sure to set create gameinfo if it doesn't exist.
+			n.GameInfo = &movetree.GameInfo{}
+		}
+		if count == 1 {
+			n.GameInfo.Handicap = 0
+			return &Warning{Prop: prop, Msg: "HA[1] is read as no handicap", Code: movetree.LintHandicap}
+		}
+		n.GameInfo.Handicap = count
+		if count == 0 {
+			return nil
+		}
+
+		black := 0
+		for _, m := range n.Placements {
+			if m.Color() == color.Black {
+				black++
+			}
+		}
+		switch {
+		case black == 0 && opts.placeHandicap():
+			placeHandicap(n, count)
+		case black != 0 && black != count:
+			return &Warning{
+				Prop: prop,
+				Msg:  fmt.Sprintf("HA[%d], but %d black stones are placed on the root", count, black),
+				Code: movetree.LintHandicap,
+			}
+		}
+		return nil
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		if n.GameInfo == nil || n.GameInfo.Handicap == 0 {
+			return "", nil
+		}
+		return fmt.Sprintf("HA[%d]", n.GameInfo.Handicap), nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.GameInfo != nil {
+			n.GameInfo.Handicap = 0
+		}
+	},
+	Examples: []RoundTripExample{
+		{
+			Desc: "two stones",
+			In:   "HA[2]",
+			Expect: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Handicap: 2}
+			},
+		},
+	},
+}
+
+// parseHandicap parses the values of HA. The handicap is returned with the
+// error for HA[1], which is well-formed but not a valid handicap.
+func parseHandicap(data []string) (int, error) {
+	if len(data) != 1 {
+		return 0, fmt.Errorf("%w: handicap only allows one prop-value, found %v", ErrBadHandicap, data)
+	}
+	count, err := strconv.Atoi(data[0])
+	if err != nil {
+		return 0, fmt.Errorf("%w: HA[%s] is not a number", ErrBadHandicap, data[0])
+	}
+	if count < 0 || count == 1 {
+		return count, fmt.Errorf("%w: HA[%d], but the handicap must be 0 or at least 2", ErrBadHandicap, count)
+	}
+	return count, nil
+}
+
+// placeHandicap places the standard handicap stones on the root n, if the
+// board has a standard placement for the handicap (see
+// movetree.HandicapPoints).
+func placeHandicap(n *movetree.Node, count int) {
+	if w, h := n.GameInfo.Dimensions(); w != h {
+		return
+	}
+	for _, pt := range movetree.HandicapPoints(nodeBoardSize(n), count) {
+		n.Placements = append(n.Placements, move.New(color.Black, pt))
+	}
+}
@@ -0,0 +1,125 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestConvertFromSGF_Handicap(t *testing.T) {
+	twoStones := func(n *movetree.Node) {
+		n.Placements = move.List{
+			move.New(color.Black, point.New(15, 3)),
+			move.New(color.Black, point.New(3, 15)),
+		}
+	}
+	testCases := []fromSGFTestCase{
+		{
+			desc:     "two stones",
+			prop:     "HA",
+			data:     []string{"2"},
+			makeNode: twoStones,
+			makeExpNode: func(n *movetree.Node) {
+				twoStones(n)
+				n.GameInfo = &movetree.GameInfo{Handicap: 2}
+			},
+		},
+		{
+			desc: "no handicap",
+			prop: "HA",
+			data: []string{"0"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{}
+			},
+		},
+		{
+			desc:     "stones don't match",
+			prop:     "HA",
+			data:     []string{"3"},
+			makeNode: twoStones,
+			makeExpNode: func(n *movetree.Node) {
+				twoStones(n)
+				n.GameInfo = &movetree.GameInfo{Handicap: 3}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "stones placed later",
+			prop: "HA",
+			data: []string{"2"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Handicap: 2}
+			},
+		},
+		{
+			desc: "standard stones placed",
+			prop: "HA",
+			data: []string{"2"},
+			opts: &ParseOptions{PlaceHandicap: true},
+			makeExpNode: func(n *movetree.Node) {
+				twoStones(n)
+				n.GameInfo = &movetree.GameInfo{Handicap: 2}
+			},
+		},
+		{
+			desc:        "one stone",
+			prop:        "HA",
+			data:        []string{"1"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrBadHandicap,
+		},
+		{
+			desc: "one stone, lenient",
+			prop: "HA",
+			data: []string{"1"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{}
+			},
+			expWarn: true,
+		},
+		{
+			desc:        "not a number",
+			prop:        "HA",
+			data:        []string{"zork"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      movetree.ErrHandicap,
+		},
+		{
+			desc: "not a number, lenient",
+			prop: "HA",
+			data: []string{"zork"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.SGFProperties["HA"] = []string{"zork"}
+			},
+			expWarn: true,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertNode_Handicap(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "handicap",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Handicap: 3}
+			},
+			expOut: "HA[3]",
+		},
+		{
+			desc: "no handicap",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{}
+			},
+			expOut: "",
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
@@ -0,0 +1,42 @@
+package prop
+
+import "github.com/otrego/clamshell/go/movetree"
+
+// inheritableProps are the properties whose values are inherited: a value set
+// on a node applies to all of its descendants, until it's set again. The SGF
+// specification makes DD, PM, and VW inheritable.
+var inheritableProps = map[Prop]bool{"DD": true, "PM": true, "VW": true}
+
+// SetInheritable sets whether a property is inheritable, so that Inherited
+// resolves it through the ancestors of a node. This can be used to declare
+// vendor properties as inheritable.
+func SetInheritable(p Prop, inheritable bool) {
+	if inheritable {
+		inheritableProps[p] = true
+	} else {
+		delete(inheritableProps, p)
+	}
+}
+
+// IsInheritable indicates whether a property is inheritable.
+func IsInheritable(p Prop) bool {
+	return inheritableProps[p]
+}
+
+// Inherited returns the raw values of a property that apply at node n. For
+// inheritable properties, these are the values on n, or else on its nearest
+// ancestor with the property. For other properties, these are the values on
+// n. ok is false if the property doesn't apply at n. A node can clear an
+// inherited property with an empty value (ex: VW[]), in which case the values
+// are [""].
+func Inherited(n *movetree.Node, p Prop) (values []string, ok bool) {
+	for cur := n; cur != nil; cur = cur.Parent {
+		if values, ok := cur.SGFProperties[string(p)]; ok {
+			return values, true
+		}
+		if !inheritableProps[p] {
+			break
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,79 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+func TestInherited(t *testing.T) {
+	SetInheritable("XV", true)
+	defer SetInheritable("XV", false)
+
+	root := movetree.NewNode()
+	root.SGFProperties["PM"] = []string{"2"}
+	root.SGFProperties["XV"] = []string{"custom"}
+	root.SGFProperties["C"] = []string{"comment"}
+	child := movetree.NewNode()
+	child.Parent = root
+	root.AddChild(child)
+	child.SGFProperties["VW"] = []string{"aa", "ab"}
+	grandchild := movetree.NewNode()
+	grandchild.Parent = child
+	child.AddChild(grandchild)
+	grandchild.SGFProperties["PM"] = []string{"1"}
+
+	testCases := []struct {
+		desc      string
+		n         *movetree.Node
+		prop      Prop
+		expValues []string
+		expOK     bool
+	}{
+		{
+			desc:      "set on the node",
+			n:         grandchild,
+			prop:      "PM",
+			expValues: []string{"1"},
+			expOK:     true,
+		},
+		{
+			desc:      "inherited from the parent",
+			n:         grandchild,
+			prop:      "VW",
+			expValues: []string{"aa", "ab"},
+			expOK:     true,
+		},
+		{
+			desc:      "custom property inherited from the root",
+			n:         grandchild,
+			prop:      "XV",
+			expValues: []string{"custom"},
+			expOK:     true,
+		},
+		{
+			desc: "not set on an ancestor",
+			n:    root,
+			prop: "VW",
+		},
+		{
+			desc: "not inheritable",
+			n:    child,
+			prop: "C",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			values, ok := Inherited(tc.n, tc.prop)
+			if ok != tc.expOK || !cmp.Equal(values, tc.expValues) {
+				t.Errorf("Inherited(%s)=%v, %v, but expected %v, %v", tc.prop, values, ok, tc.expValues, tc.expOK)
+			}
+		})
+	}
+
+	SetInheritable("XV", false)
+	if _, ok := Inherited(grandchild, "XV"); ok {
+		t.Errorf("got XV inherited after making it not inheritable")
+	}
+}
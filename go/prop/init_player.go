@@ -13,7 +13,7 @@ var ErrInitPlayer = errors.New("error converting initial player property PL")
 var initPlayerConv = &SGFConverter{
 	Props: []Prop{"PL"},
 	Scope: RootScope,
-	From: func(n *movetree.Node, prop string, data []string) error {
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
 		if len(data) != 1 {
 			return fmt.Errorf("requires exactly 1 Value, but had %d: %w", len(data), ErrInitPlayer)
 		}
@@ -33,7 +33,7 @@ var initPlayerConv = &SGFConverter{
 
 		return nil
 	},
-	To: func(n *movetree.Node) (string, error) {
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
 		if n.GameInfo == nil {
 			return "", nil
 		}
@@ -46,4 +46,26 @@ var initPlayerConv = &SGFConverter{
 		}
 		return "", fmt.Errorf("can only have value W or B, but was %s: %w", n.GameInfo.Player, ErrInitPlayer)
 	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.GameInfo != nil {
+			n.GameInfo.Player = color.Empty
+		}
+	},
+	Examples: []RoundTripExample{
+		{
+			Desc: "white",
+			In:   "PL[W]",
+			Expect: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Player: color.White}
+			},
+		},
+		{
+			Desc: "lowercase black",
+			In:   "PL[b]",
+			Expect: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Player: color.Black}
+			},
+			Out: "PL[B]",
+		},
+	},
 }
@@ -7,22 +7,45 @@ import (
 	"strconv"
 
 	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/rules"
 )
 
 var ErrKomi = errors.New("error converting komi proprtey KM")
 
-// komiConv converts the komi property KM.
+// ErrBadKomi indicates a komi that isn't a number, or that has an illegal
+// decimal value. It wraps ErrKomi.
+var ErrBadKomi = fmt.Errorf("%w: illegal komi value", ErrKomi)
+
+// komiConv converts the komi property KM. Komi may be negative (reverse komi).
+//
+// Any finite komi is allowed, unless ParseOptions.StrictKomi is set (see
+// validateKomi). When parsing leniently, values within the komi tolerance of a
+// legal value (ex: KM[6.500001]) are snapped to that value, and with
+// StrictKomi, other values with an illegal decimal value are kept, with a
+// warning. Komi is written as it's stored.
 var komiConv = &SGFConverter{
 	Props: []Prop{"KM"},
 	Scope: RootScope,
-	From: func(n *movetree.Node, prop string, data []string) error {
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
 		komi, err := strconv.ParseFloat(data[0], 64)
 		if err != nil {
-			return err
+			return fmt.Errorf("parsing %q as a number: %v: %w", data[0], err, ErrBadKomi)
+		}
+		if math.IsInf(komi, 0) || math.IsNaN(komi) {
+			return fmt.Errorf("value was %s, but komi must be a finite number: %w", data[0], ErrBadKomi)
 		}
-		_, fp := math.Modf(komi)
-		if !(fp == 0.5 || fp == 0.0) {
-			return fmt.Errorf("value was %f, but the only decimal-value allowed for komi is .0 or .5: %w", komi, ErrKomi)
+		var warn error
+		if opts.lenient() {
+			if snapped := snapKomi(n, komi, opts.komiTolerance()); snapped != komi {
+				warn = &Warning{Prop: prop, Msg: fmt.Sprintf("komi %s is not a legal value; snapping it to %v", data[0], snapped)}
+				komi = snapped
+			}
+		}
+		if err := validateKomi(n, komi); err != nil && opts.strictKomi() {
+			if !opts.lenient() {
+				return err
+			}
+			warn = &Warning{Prop: prop, Msg: fmt.Sprintf("%v; keeping it as-is", err), Code: movetree.LintKomi}
 		}
 		if n.GameInfo == nil {
 			// For safety, make sure to set create gameinfo if it doesn't exist.
@@ -30,21 +53,91 @@ var komiConv = &SGFConverter{
 		}
 		n.GameInfo.Komi = new(float64)
 		*n.GameInfo.Komi = komi
-		return nil
+		return warn
 	},
-	To: func(n *movetree.Node) (string, error) {
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
 		if n.GameInfo == nil {
 			return "", nil
 		}
 		if n.GameInfo.Komi == nil {
 			return "", nil
 		}
-		komi := *n.GameInfo.Komi
-		_, fp := math.Modf(komi)
-		if !(fp == 0.5 || fp == 0.0) {
-			return "", fmt.Errorf("komi value %f, but the only decimal-value allowed for komi is .0 or .5: %w", komi, ErrKomi)
+		return fmt.Sprintf("KM[%s]", formatKomi(*n.GameInfo.Komi)), nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.GameInfo != nil {
+			n.GameInfo.Komi = nil
 		}
-		s := strconv.FormatFloat(komi, 'f', 1, 64)
-		return fmt.Sprintf("KM[%s]", s), nil
 	},
+	Examples: []RoundTripExample{
+		{
+			Desc: "half point",
+			In:   "KM[6.5]",
+			Expect: func(n *movetree.Node) {
+				komi := 6.5
+				n.GameInfo = &movetree.GameInfo{Komi: &komi}
+			},
+		},
+		{
+			Desc: "negative",
+			In:   "KM[-3.5]",
+			Expect: func(n *movetree.Node) {
+				komi := -3.5
+				n.GameInfo = &movetree.GameInfo{Komi: &komi}
+			},
+		},
+		{
+			Desc: "written with one decimal",
+			In:   "KM[0]",
+			Expect: func(n *movetree.Node) {
+				komi := 0.0
+				n.GameInfo = &movetree.GameInfo{Komi: &komi}
+			},
+			Out: "KM[0.0]",
+		},
+	},
+}
+
+// validateKomi checks the decimal value of komi. Normally, komi must have a
+// decimal value of .0 or .5, but Ing rules also allow quarter points (.25 or
+// .75). The ruleset is read from the node's game info, so RU must be processed
+// before KM.
+func validateKomi(n *movetree.Node, komi float64) error {
+	_, fp := math.Modf(math.Abs(komi))
+	if fp == 0.5 || fp == 0.0 {
+		return nil
+	}
+	if n.GameInfo != nil && n.GameInfo.Rules == rules.Ing {
+		if fp == 0.25 || fp == 0.75 {
+			return nil
+		}
+		return fmt.Errorf("value was %f, but the only decimal-values allowed for komi under Ing rules are .0, .25, .5, or .75: %w", komi, ErrBadKomi)
+	}
+	return fmt.Errorf("value was %f, but the only decimal-value allowed for komi is .0 or .5: %w", komi, ErrBadKomi)
+}
+
+// formatKomi formats komi with one decimal, or two for quarter points (ex:
+// 7.75). Other values are formatted with as many decimals as needed.
+func formatKomi(komi float64) string {
+	switch _, fp := math.Modf(math.Abs(komi)); fp {
+	case 0, 0.5:
+		return strconv.FormatFloat(komi, 'f', 1, 64)
+	case 0.25, 0.75:
+		return strconv.FormatFloat(komi, 'f', 2, 64)
+	}
+	return strconv.FormatFloat(komi, 'f', -1, 64)
+}
+
+// snapKomi returns the legal komi value nearest to komi, if it's within the
+// tolerance. Otherwise, komi is returned unchanged.
+func snapKomi(n *movetree.Node, komi, tolerance float64) float64 {
+	step := 0.5
+	if n.GameInfo != nil && n.GameInfo.Rules == rules.Ing {
+		step = 0.25
+	}
+	snapped := math.Round(komi/step) * step
+	if math.Abs(komi-snapped) > tolerance {
+		return komi
+	}
+	return snapped
 }
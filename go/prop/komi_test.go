@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/rules"
 )
 
 func TestConvertFromSGF_Komi(t *testing.T) {
@@ -20,12 +21,125 @@ func TestConvertFromSGF_Komi(t *testing.T) {
 			},
 		},
 		{
-			desc:        "Bad Komi",
+			desc: "any decimal value",
+			prop: "KM",
+			data: []string{"3.25"},
+			makeExpNode: func(n *movetree.Node) {
+				komi := 3.25
+				n.GameInfo = &movetree.GameInfo{Komi: &komi}
+			},
+		},
+		{
+			desc:        "Bad Komi, strict",
 			prop:        "KM",
 			data:        []string{"3.25"},
+			opts:        &ParseOptions{StrictKomi: true},
 			makeExpNode: func(n *movetree.Node) {},
 			expErr:      ErrKomi,
 		},
+		{
+			desc: "quarter komi, Ing rules",
+			prop: "KM",
+			data: []string{"7.75"},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Rules: rules.Ing}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Rules: rules.Ing,
+					Komi:  new(float64),
+				}
+				*n.GameInfo.Komi = 7.75
+			},
+		},
+		{
+			desc: "bad komi, Ing rules",
+			prop: "KM",
+			data: []string{"7.3"},
+			opts: &ParseOptions{StrictKomi: true},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Rules: rules.Ing}
+			},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrKomi,
+		},
+		{
+			desc: "quarter komi, Japanese rules",
+			prop: "KM",
+			data: []string{"7.75"},
+			opts: &ParseOptions{StrictKomi: true},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Rules: rules.Japanese}
+			},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrKomi,
+		},
+		{
+			desc:        "almost half komi, strict",
+			prop:        "KM",
+			data:        []string{"6.500001"},
+			opts:        &ParseOptions{StrictKomi: true},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrKomi,
+		},
+		{
+			desc: "almost half komi, lenient",
+			prop: "KM",
+			data: []string{"6.500001"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Komi: new(float64),
+				}
+				*n.GameInfo.Komi = 6.5
+			},
+			expWarn: true,
+		},
+		{
+			desc: "almost quarter komi, Ing rules, lenient",
+			prop: "KM",
+			data: []string{"7.7499"},
+			opts: &ParseOptions{Lenient: true, KomiTolerance: 0.001},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Rules: rules.Ing}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Rules: rules.Ing,
+					Komi:  new(float64),
+				}
+				*n.GameInfo.Komi = 7.75
+			},
+			expWarn: true,
+		},
+		{
+			desc: "bad komi, strict and lenient",
+			prop: "KM",
+			data: []string{"6.3"},
+			opts: &ParseOptions{Lenient: true, StrictKomi: true},
+			makeExpNode: func(n *movetree.Node) {
+				komi := 6.3
+				n.GameInfo = &movetree.GameInfo{Komi: &komi}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "bad komi, lenient",
+			prop: "KM",
+			data: []string{"6.3"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				komi := 6.3
+				n.GameInfo = &movetree.GameInfo{Komi: &komi}
+			},
+		},
+		{
+			desc:        "infinite komi",
+			prop:        "KM",
+			data:        []string{"Inf"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrBadKomi,
+		},
 	}
 
 	testConvertFromSGFCases(t, testCases)
@@ -65,14 +179,35 @@ func TestConvertNode_Komi(t *testing.T) {
 			expOut: "",
 		},
 		{
-			desc: "komi, invalid",
+			desc: "komi, quarter point without Ing rules",
 			makeNode: func(n *movetree.Node) {
 				n.GameInfo = &movetree.GameInfo{
 					Komi: new(float64),
 				}
 				*n.GameInfo.Komi = 3.25
 			},
-			expErr: ErrKomi,
+			expOut: "KM[3.25]",
+		},
+		{
+			desc: "komi, any decimal value",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Komi: new(float64),
+				}
+				*n.GameInfo.Komi = -6.3
+			},
+			expOut: "KM[-6.3]",
+		},
+		{
+			desc: "quarter komi, Ing rules",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Rules: rules.Ing,
+					Komi:  new(float64),
+				}
+				*n.GameInfo.Komi = 7.75
+			},
+			expOut: "KM[7.75]RU[GOE]",
 		},
 	}
 
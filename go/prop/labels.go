@@ -0,0 +1,189 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ErrLabels indicates an error converting a label property.
+var ErrLabels = errors.New("error converting label property")
+
+// labelsConv converts the text labels drawn on the board. An LB value pairs a
+// point with its label (ex: LB[aa:A]), and the label is escaped like a
+// comment.
+//
+// The FF[3] L (letters) property is the predecessor of LB: it lists points
+// that are labeled with consecutive letters, starting from A, so L[aa][bb] is
+// equivalent to LB[aa:A][bb:B]. When the movetree is still FF[3] and the labels
+// can be expressed as consecutive letters, they're written back as L.
+var labelsConv = &SGFConverter{
+	Props: []Prop{"LB", "L"},
+	Scope: AllScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		if prop == "LB" {
+			return labelsFromSGF(n, data, opts)
+		}
+		data, warn := cleanPoints(n, prop, data, opts)
+		pts, err := pointsFromSGF(data)
+		if err != nil {
+			return fmt.Errorf("%w: for property %s: %v", ErrLabels, prop, err)
+		}
+		if n.Labels == nil {
+			n.Labels = make(map[point.Point]string)
+		}
+		var dropped []string
+		for i, pt := range pts {
+			l, err := legacyLetter(i)
+			if err != nil {
+				return err
+			}
+			if _, ok := n.Labels[*pt]; ok {
+				sgfPt, err := pt.ToSGF()
+				if err != nil {
+					return err
+				}
+				if !opts.lenient() {
+					return fmt.Errorf("%w: for property L: point %s is labeled twice", ErrLabels, sgfPt)
+				}
+				dropped = append(dropped, sgfPt)
+				continue
+			}
+			n.Labels[*pt] = l
+		}
+		if dropped != nil {
+			return duplicateLabelsWarning(prop, dropped)
+		}
+		return warn
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		if len(n.Labels) == 0 {
+			return "", nil
+		}
+		if fileFormat(n) < 4 {
+			if s, ok := legacyLabels(n.Labels); ok {
+				return s, nil
+			}
+		}
+
+		pts := &move.PointSet{}
+		for pt := range n.Labels {
+			pts.Add(point.New(pt.X(), pt.Y()))
+		}
+		var sb strings.Builder
+		sb.WriteString("LB")
+		for _, pt := range pts.Sorted() {
+			sgfPt, err := pt.ToSGF()
+			if err != nil {
+				return "", err
+			}
+			text := escapeComment(n.Labels[*pt])
+			sb.WriteString("[" + sgfPt + ":" + text + "]")
+		}
+		return sb.String(), nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		// The FF[3] labels (L) are written as LB.
+		n.Labels = nil
+	},
+}
+
+// labelsFromSGF adds the labels of an LB property to the node. Each value is
+// split at the first unescaped colon into the point and the label. A point
+// can only be labeled once: when parsing leniently, the first label is kept.
+func labelsFromSGF(n *movetree.Node, data []string, opts *ParseOptions) error {
+	if n.Labels == nil {
+		n.Labels = make(map[point.Point]string)
+	}
+	var dropped []string
+	for _, v := range data {
+		i := composeIndex(v)
+		if i < 0 {
+			return fmt.Errorf("%w: for property LB: expected a point and a label, like aa:A, but got %q", ErrLabels, v)
+		}
+		pt, err := point.NewFromSGF(v[:i])
+		if err != nil {
+			return fmt.Errorf("%w: for property LB: %v", ErrLabels, err)
+		}
+		if _, ok := n.Labels[*pt]; ok {
+			if !opts.lenient() {
+				return fmt.Errorf("%w: for property LB: point %s is labeled twice", ErrLabels, v[:i])
+			}
+			dropped = append(dropped, v)
+			continue
+		}
+		n.Labels[*pt] = unescapeText(v[i+1:])
+	}
+	if dropped != nil {
+		return duplicateLabelsWarning("LB", dropped)
+	}
+	return nil
+}
+
+// duplicateLabelsWarning returns the warning for the values of a label
+// property that were dropped because their points were already labeled.
+func duplicateLabelsWarning(prop string, dropped []string) *Warning {
+	return &Warning{
+		Prop: prop,
+		Msg:  fmt.Sprintf("found points that are labeled twice; dropped %s%v", prop, dropped),
+		Code: movetree.LintDuplicateProp,
+	}
+}
+
+// composeIndex returns the index of the first unescaped colon of a composed
+// value, or -1 if there's none.
+func composeIndex(v string) int {
+	for i := 0; i < len(v); i++ {
+		switch v[i] {
+		case '\\':
+			i++
+		case ':':
+			return i
+		}
+	}
+	return -1
+}
+
+// legacyLetter returns the letter for the i-th point of an L property: A-Z and
+// then a-z.
+func legacyLetter(i int) (string, error) {
+	switch {
+	case i < 0:
+	case i < 26:
+		return string(rune('A' + i)), nil
+	case i < 52:
+		return string(rune('a' + i - 26)), nil
+	}
+	return "", fmt.Errorf("%w: too many points for property L: %d", ErrLabels, i+1)
+}
+
+// legacyLabels writes the labels as an L property, returning false if the
+// labels aren't consecutive letters.
+func legacyLabels(labels map[point.Point]string) (string, bool) {
+	byLetter := make(map[string]point.Point)
+	for pt, l := range labels {
+		byLetter[l] = pt
+	}
+	var sb strings.Builder
+	sb.WriteString("L")
+	for i := 0; i < len(labels); i++ {
+		l, err := legacyLetter(i)
+		if err != nil {
+			return "", false
+		}
+		pt, ok := byLetter[l]
+		if !ok {
+			return "", false
+		}
+		sgfPt, err := pt.ToSGF()
+		if err != nil {
+			return "", false
+		}
+		sb.WriteString("[" + sgfPt + "]")
+	}
+	return sb.String(), true
+}
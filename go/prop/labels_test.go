@@ -0,0 +1,154 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestConvertFromSGF_Labels(t *testing.T) {
+	testCases := []fromSGFTestCase{
+		{
+			desc: "legacy letters",
+			prop: "L",
+			data: []string{"cc", "aa", "bb"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Labels = map[point.Point]string{
+					*point.New(2, 2): "A",
+					*point.New(0, 0): "B",
+					*point.New(1, 1): "C",
+				}
+			},
+		},
+		{
+			desc: "labels",
+			prop: "LB",
+			data: []string{"aa:A", "bb:a\\:b\\\\c"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Labels = map[point.Point]string{
+					*point.New(0, 0): "A",
+					*point.New(1, 1): "a:b\\c",
+				}
+			},
+		},
+		{
+			desc:        "point labeled twice",
+			prop:        "LB",
+			data:        []string{"aa:x", "aa:y"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrLabels,
+		},
+		{
+			desc: "point labeled twice, lenient",
+			prop: "LB",
+			data: []string{"aa:x", "bb:z", "aa:y"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.Labels = map[point.Point]string{
+					*point.New(0, 0): "x",
+					*point.New(1, 1): "z",
+				}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "legacy letter on a labeled point",
+			prop: "L",
+			data: []string{"aa"},
+			makeNode: func(n *movetree.Node) {
+				n.Labels = map[point.Point]string{*point.New(0, 0): "x"}
+			},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrLabels,
+		},
+		{
+			desc: "legacy letter on a labeled point, lenient",
+			prop: "L",
+			data: []string{"aa", "bb"},
+			opts: &ParseOptions{Lenient: true},
+			makeNode: func(n *movetree.Node) {
+				n.Labels = map[point.Point]string{*point.New(0, 0): "x"}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.Labels = map[point.Point]string{
+					*point.New(0, 0): "x",
+					*point.New(1, 1): "B",
+				}
+			},
+			expWarn: true,
+		},
+		{
+			desc:        "label without a point",
+			prop:        "LB",
+			data:        []string{"A"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrLabels,
+		},
+		{
+			desc:        "bad point",
+			prop:        "L",
+			data:        []string{"a"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrLabels,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertNode_Labels(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "labels",
+			makeNode: func(n *movetree.Node) {
+				n.Labels = map[point.Point]string{
+					*point.New(2, 2): "A",
+					*point.New(0, 0): "B",
+				}
+			},
+			expOut: "LB[aa:B][cc:A]",
+		},
+		{
+			desc: "labels, escaped",
+			makeNode: func(n *movetree.Node) {
+				n.Labels = map[point.Point]string{
+					*point.New(0, 0): "[1]",
+				}
+			},
+			expOut: "LB[aa:[1\\]]",
+		},
+		{
+			desc: "labels, escaped like comments",
+			makeNode: func(n *movetree.Node) {
+				n.Labels = map[point.Point]string{
+					*point.New(0, 0): "a\\b:c",
+				}
+			},
+			expOut: "LB[aa:a\\\\b:c]",
+		},
+		{
+			desc: "labels, FF[3]",
+			makeNode: func(n *movetree.Node) {
+				n.SGFProperties["FF"] = []string{"3"}
+				n.Labels = map[point.Point]string{
+					*point.New(2, 2): "A",
+					*point.New(0, 0): "B",
+				}
+			},
+			expOut: "FF[3]L[cc][aa]",
+		},
+		{
+			desc: "labels, FF[3], not expressible as letters",
+			makeNode: func(n *movetree.Node) {
+				n.SGFProperties["FF"] = []string{"3"}
+				n.Labels = map[point.Point]string{
+					*point.New(0, 0): "1",
+				}
+			},
+			expOut: "FF[3]LB[aa:1]",
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
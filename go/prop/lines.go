@@ -0,0 +1,109 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ErrLines indicates an error converting an arrow or line property.
+var ErrLines = errors.New("error converting arrow or line property")
+
+// linesConv converts the arrows (AR) and lines (LN) drawn on the board. Each
+// value is a pair of points, from:to (ex: AR[aa:cc]), and the two points must
+// be different. When parsing leniently, malformed values are dropped, with a
+// warning. The arrows and lines are written in the order they were read.
+var linesConv = &SGFConverter{
+	Props: []Prop{"AR", "LN"},
+	Scope: AllScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		var lines []movetree.Line
+		var dropped []string
+		for _, v := range data {
+			l, err := lineFromSGF(prop, v)
+			if err != nil {
+				if !opts.lenient() {
+					return err
+				}
+				dropped = append(dropped, v)
+				continue
+			}
+			lines = append(lines, l)
+		}
+		if prop == "AR" {
+			n.Arrows = append(n.Arrows, lines...)
+		} else {
+			n.Lines = append(n.Lines, lines...)
+		}
+		if len(dropped) > 0 {
+			return &Warning{Prop: prop, Msg: fmt.Sprintf("dropped malformed values %v", dropped)}
+		}
+		return nil
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		var sb strings.Builder
+		for _, l := range []struct {
+			prop  string
+			lines []movetree.Line
+		}{{"AR", n.Arrows}, {"LN", n.Lines}} {
+			if len(l.lines) == 0 {
+				continue
+			}
+			sb.WriteString(l.prop)
+			for _, line := range l.lines {
+				from, err := point.New(line.FromX, line.FromY).ToSGF()
+				if err != nil {
+					return "", err
+				}
+				to, err := point.New(line.ToX, line.ToY).ToSGF()
+				if err != nil {
+					return "", err
+				}
+				sb.WriteString("[" + from + ":" + to + "]")
+			}
+		}
+		return sb.String(), nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		if prop == "AR" {
+			n.Arrows = nil
+		} else {
+			n.Lines = nil
+		}
+	},
+	Examples: []RoundTripExample{
+		{
+			Desc: "arrows",
+			In:   "AR[aa:cc][dd:bb]",
+			Expect: func(n *movetree.Node) {
+				n.Arrows = []movetree.Line{
+					{FromX: 0, FromY: 0, ToX: 2, ToY: 2},
+					{FromX: 3, FromY: 3, ToX: 1, ToY: 1},
+				}
+			},
+		},
+	},
+}
+
+// lineFromSGF converts an arrow or line value (ex: aa:cc) to a line.
+func lineFromSGF(prop, v string) (movetree.Line, error) {
+	i := strings.IndexByte(v, ':')
+	if i < 0 {
+		return movetree.Line{}, fmt.Errorf("%w: %s[%s] must be a pair of points, from:to", ErrLines, prop, v)
+	}
+	from, err := point.NewFromSGF(v[:i])
+	if err != nil {
+		return movetree.Line{}, fmt.Errorf("%w: for property %s: %v", ErrLines, prop, err)
+	}
+	to, err := point.NewFromSGF(v[i+1:])
+	if err != nil {
+		return movetree.Line{}, fmt.Errorf("%w: for property %s: %v", ErrLines, prop, err)
+	}
+	if from.Equal(to) {
+		return movetree.Line{}, fmt.Errorf("%w: %s[%s] must be between two different points", ErrLines, prop, v)
+	}
+	return movetree.Line{FromX: from.X(), FromY: from.Y(), ToX: to.X(), ToY: to.Y()}, nil
+}
@@ -0,0 +1,80 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+func TestConvertFromSGF_Lines(t *testing.T) {
+	testCases := []fromSGFTestCase{
+		{
+			desc: "arrows",
+			prop: "AR",
+			data: []string{"aa:cc", "dd:bb"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Arrows = []movetree.Line{
+					{FromX: 0, FromY: 0, ToX: 2, ToY: 2},
+					{FromX: 3, FromY: 3, ToX: 1, ToY: 1},
+				}
+			},
+		},
+		{
+			desc: "lines",
+			prop: "LN",
+			data: []string{"dd:ef"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Lines = []movetree.Line{{FromX: 3, FromY: 3, ToX: 4, ToY: 5}}
+			},
+		},
+		{
+			desc:        "single point",
+			prop:        "LN",
+			data:        []string{"xx"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrLines,
+		},
+		{
+			desc:        "same point",
+			prop:        "AR",
+			data:        []string{"aa:aa"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrLines,
+		},
+		{
+			desc: "malformed, lenient",
+			prop: "LN",
+			data: []string{"dd:ef", "xx"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.Lines = []movetree.Line{{FromX: 3, FromY: 3, ToX: 4, ToY: 5}}
+			},
+			expWarn: true,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertNode_Lines(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "arrows and lines",
+			makeNode: func(n *movetree.Node) {
+				n.Arrows = []movetree.Line{{FromX: 3, FromY: 3, ToX: 1, ToY: 1}}
+				n.Lines = []movetree.Line{
+					{FromX: 0, FromY: 0, ToX: 2, ToY: 2},
+					{FromX: 3, FromY: 3, ToX: 4, ToY: 5},
+				}
+			},
+			expOut: "AR[dd:bb]LN[aa:cc][dd:ef]",
+		},
+		{
+			desc:     "no arrows or lines",
+			makeNode: func(n *movetree.Node) {},
+			expOut:   "",
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
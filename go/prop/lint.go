@@ -0,0 +1,84 @@
+package prop
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+func init() {
+	movetree.RegisterLintCheck(lintPropScope)
+	movetree.RegisterLintCheck(lintUnknownProps)
+	movetree.RegisterLintCheck(lintKomi)
+}
+
+// sortedRawProps returns the raw properties of the node, in sorted order.
+func sortedRawProps(n *movetree.Node) []string {
+	var props []string
+	for p := range n.SGFProperties {
+		props = append(props, p)
+	}
+	sort.Strings(props)
+	return props
+}
+
+// lintPropScope checks that the raw properties of non-root nodes aren't root
+// or game-info properties.
+func lintPropScope(mt *movetree.MoveTree, n *movetree.Node, tp movetree.Path) []movetree.LintIssue {
+	if n == mt.Root {
+		return nil
+	}
+	var issues []movetree.LintIssue
+	for _, p := range sortedRawProps(n) {
+		conv := Converter(p)
+		if !movetree.IsGameInfoProp(p) && (conv == nil || conv.Scope == AllScope) {
+			continue
+		}
+		issues = append(issues, movetree.LintIssue{
+			Code:     movetree.LintScope,
+			Severity: movetree.SeverityError,
+			Path:     tp,
+			Prop:     p,
+			Msg:      "root-only property on a non-root node",
+		})
+	}
+	return issues
+}
+
+// lintUnknownProps checks that the raw properties are in the SGF
+// specification.
+func lintUnknownProps(mt *movetree.MoveTree, n *movetree.Node, tp movetree.Path) []movetree.LintIssue {
+	var issues []movetree.LintIssue
+	for _, p := range sortedRawProps(n) {
+		if Validate(Prop(p)) {
+			continue
+		}
+		issues = append(issues, movetree.LintIssue{
+			Code:     movetree.LintUnknownProp,
+			Severity: movetree.SeverityWarning,
+			Path:     tp,
+			Prop:     p,
+			Msg:      "property is not in the SGF specification",
+		})
+	}
+	return issues
+}
+
+// lintKomi checks the decimal value of the komi. Komi with an illegal decimal
+// value is parsed by default, so it's only a warning.
+func lintKomi(mt *movetree.MoveTree, n *movetree.Node, tp movetree.Path) []movetree.LintIssue {
+	if n.GameInfo == nil || n.GameInfo.Komi == nil {
+		return nil
+	}
+	if err := validateKomi(n, *n.GameInfo.Komi); err != nil {
+		return []movetree.LintIssue{{
+			Code:     movetree.LintKomi,
+			Severity: movetree.SeverityWarning,
+			Path:     tp,
+			Prop:     "KM",
+			Msg:      fmt.Sprint(err),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ErrMarks indicates an error converting a mark property.
+var ErrMarks = errors.New("error converting mark property")
+
+// markTypeOrder is the order in which the mark properties are written.
+var markTypeOrder = []movetree.MarkType{
+	movetree.MarkCircle,
+	movetree.MarkX,
+	movetree.MarkSquare,
+	movetree.MarkTriangle,
+}
+
+// marksConv converts the marks drawn on the board: circles (CR), X marks (MA),
+// squares (SQ), and triangles (TR). The points of each mark type are written
+// as a single property (ex: TR[aa][bb]).
+//
+// The FF[3] M (mark) property is read as a modern mark. FF[4] split M into MA
+// and TR, and in practice M was drawn as a triangle on stones and as an X on
+// empty points. So, an M on a stone played or placed in the same node becomes
+// a triangle, and otherwise it becomes an X. When the movetree is still FF[3],
+// X and triangle marks are written back as M.
+var marksConv = &SGFConverter{
+	Props: []Prop{"CR", "MA", "SQ", "TR", "M"},
+	Scope: AllScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		data, warn := cleanPoints(n, prop, data, opts)
+		pts, err := pointsFromSGF(data)
+		if err != nil {
+			return fmt.Errorf("%w: for property %s: %v", ErrMarks, prop, err)
+		}
+		if n.Marks == nil {
+			n.Marks = make(map[point.Point]movetree.MarkType)
+		}
+		for _, pt := range pts {
+			mt := movetree.MarkType(prop)
+			if prop == "M" {
+				mt = movetree.MarkX
+				if hasStoneInNode(n, pt) {
+					mt = movetree.MarkTriangle
+				}
+			}
+			n.Marks[*pt] = mt
+		}
+		return warn
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		if len(n.Marks) == 0 {
+			return "", nil
+		}
+		byType := make(map[movetree.MarkType]*move.PointSet)
+		for _, mt := range markTypeOrder {
+			byType[mt] = &move.PointSet{}
+		}
+		for pt, mt := range n.Marks {
+			if byType[mt] == nil {
+				return "", fmt.Errorf("%w: unknown mark type %q", ErrMarks, mt)
+			}
+			byType[mt].Add(point.New(pt.X(), pt.Y()))
+		}
+
+		var sb strings.Builder
+		if fileFormat(n) < 4 {
+			legacy := byType[movetree.MarkX].Union(byType[movetree.MarkTriangle])
+			byType[movetree.MarkX] = &move.PointSet{}
+			byType[movetree.MarkTriangle] = &move.PointSet{}
+			s, err := writePointList("M", legacy.Sorted(), opts)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+		}
+		for _, mt := range markTypeOrder {
+			s, err := writePointList(string(mt), byType[mt].Sorted(), opts)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+		}
+		return sb.String(), nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		// The FF[3] marks (M) are written as MA or TR, so clearing M clears
+		// both.
+		for pt, mt := range n.Marks {
+			if string(mt) == prop || prop == "M" && (mt == movetree.MarkX || mt == movetree.MarkTriangle) {
+				delete(n.Marks, pt)
+			}
+		}
+		if len(n.Marks) == 0 {
+			n.Marks = nil
+		}
+	},
+}
+
+// hasStoneInNode indicates whether a stone is played or placed at pt in node n.
+func hasStoneInNode(n *movetree.Node, pt *point.Point) bool {
+	if n.Move != nil && pt.Equal(n.Move.Point()) {
+		return true
+	}
+	for _, mv := range n.Placements {
+		if pt.Equal(mv.Point()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestConvertFromSGF_Marks(t *testing.T) {
+	testCases := []fromSGFTestCase{
+		{
+			desc: "legacy marks on empty points",
+			prop: "M",
+			data: []string{"aa", "bb"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Marks = map[point.Point]movetree.MarkType{
+					*point.New(0, 0): movetree.MarkX,
+					*point.New(1, 1): movetree.MarkX,
+				}
+			},
+		},
+		{
+			desc: "triangles",
+			prop: "TR",
+			data: []string{"aa", "bb"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Marks = map[point.Point]movetree.MarkType{
+					*point.New(0, 0): movetree.MarkTriangle,
+					*point.New(1, 1): movetree.MarkTriangle,
+				}
+			},
+		},
+		{
+			desc: "squares, compressed",
+			prop: "SQ",
+			data: []string{"aa:ab"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Marks = map[point.Point]movetree.MarkType{
+					*point.New(0, 0): movetree.MarkSquare,
+					*point.New(0, 1): movetree.MarkSquare,
+				}
+			},
+		},
+		{
+			desc:        "bad circle",
+			prop:        "CR",
+			data:        []string{"a"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrMarks,
+		},
+		{
+			desc:        "bad point",
+			prop:        "M",
+			data:        []string{"a"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrMarks,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertFromSGF_LegacyMarkOnStone(t *testing.T) {
+	n := movetree.NewNode()
+	if err := ProcessPropertyData(n, "B", []string{"cc"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ProcessPropertyData(n, "M", []string{"cc", "dd"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := n.Marks[*point.New(2, 2)]; got != movetree.MarkTriangle {
+		t.Errorf("mark on the move was %q, but expected %q", got, movetree.MarkTriangle)
+	}
+	if got := n.Marks[*point.New(3, 3)]; got != movetree.MarkX {
+		t.Errorf("mark on an empty point was %q, but expected %q", got, movetree.MarkX)
+	}
+}
+
+func TestConvertNode_Marks(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "marks, grouped by type",
+			makeNode: func(n *movetree.Node) {
+				n.Marks = map[point.Point]movetree.MarkType{
+					*point.New(1, 1): movetree.MarkX,
+					*point.New(0, 0): movetree.MarkX,
+					*point.New(2, 2): movetree.MarkTriangle,
+					*point.New(3, 3): movetree.MarkCircle,
+				}
+			},
+			expOut: "CR[dd]MA[aa][bb]TR[cc]",
+		},
+		{
+			desc: "marks, FF[3]",
+			makeNode: func(n *movetree.Node) {
+				n.SGFProperties["FF"] = []string{"3"}
+				n.Move = move.New(color.Black, point.New(2, 2))
+				n.Marks = map[point.Point]movetree.MarkType{
+					*point.New(1, 1): movetree.MarkX,
+					*point.New(2, 2): movetree.MarkTriangle,
+				}
+			},
+			expOut: "FF[3]B[cc]M[bb][cc]",
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
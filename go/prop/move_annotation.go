@@ -0,0 +1,74 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ErrMoveAnnotation indicates an error converting a move annotation property.
+var ErrMoveAnnotation = errors.New("error converting move annotation property")
+
+// moveAnnotationConv converts the move annotation properties BM (bad move), TE
+// (tesuji), DO (doubtful), and IT (interesting).
+//
+// BM and TE take an emphasis of 1 or 2. Some files omit the emphasis (BM[]),
+// which is treated as an emphasis of 1. The emphasis is always written
+// explicitly.
+var moveAnnotationConv = &SGFConverter{
+	Props: []Prop{"BM", "TE", "DO", "IT"},
+	Scope: AllScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		if n.MoveAnnotation != nil {
+			return fmt.Errorf("%w: found %s, but the move is already annotated with %s", ErrMoveAnnotation, prop, n.MoveAnnotation.Type)
+		}
+		if len(data) > 1 {
+			return fmt.Errorf("%w: %s only allows one prop-value, found %v", ErrMoveAnnotation, prop, data)
+		}
+		typ := movetree.MoveAnnotationType(prop)
+		if typ == movetree.DoubtfulMove || typ == movetree.InterestingMove {
+			n.MoveAnnotation = &movetree.MoveAnnotation{Type: typ}
+			return nil
+		}
+
+		var warn error
+		emphasis := 1
+		if len(data) == 0 || data[0] == "" {
+			if opts.lenient() {
+				warn = &Warning{Prop: prop, Msg: "missing emphasis; treating it as 1"}
+			}
+		} else if data[0] == "2" {
+			emphasis = 2
+		} else if data[0] != "1" {
+			return fmt.Errorf("%w: %s emphasis must be 1 or 2, but was %q", ErrMoveAnnotation, prop, data[0])
+		}
+		n.MoveAnnotation = &movetree.MoveAnnotation{Type: typ, Emphasis: emphasis}
+		return warn
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		ma := n.MoveAnnotation
+		if ma == nil {
+			return "", nil
+		}
+		switch ma.Type {
+		case movetree.DoubtfulMove, movetree.InterestingMove:
+			return string(ma.Type) + "[]", nil
+		case movetree.BadMove, movetree.Tesuji:
+			emphasis := ma.Emphasis
+			if emphasis == 0 {
+				emphasis = 1
+			}
+			if emphasis != 1 && emphasis != 2 {
+				return "", fmt.Errorf("%w: %s emphasis must be 1 or 2, but was %d", ErrMoveAnnotation, ma.Type, ma.Emphasis)
+			}
+			return fmt.Sprintf("%s[%d]", ma.Type, emphasis), nil
+		}
+		return "", fmt.Errorf("%w: unknown move annotation type %q", ErrMoveAnnotation, ma.Type)
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.MoveAnnotation != nil && string(n.MoveAnnotation.Type) == prop {
+			n.MoveAnnotation = nil
+		}
+	},
+}
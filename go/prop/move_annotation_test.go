@@ -0,0 +1,108 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+func TestConvertFromSGF_MoveAnnotation(t *testing.T) {
+	testCases := []fromSGFTestCase{
+		{
+			desc: "bad move",
+			prop: "BM",
+			data: []string{"1"},
+			makeExpNode: func(n *movetree.Node) {
+				n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.BadMove, Emphasis: 1}
+			},
+		},
+		{
+			desc: "emphasized tesuji",
+			prop: "TE",
+			data: []string{"2"},
+			makeExpNode: func(n *movetree.Node) {
+				n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.Tesuji, Emphasis: 2}
+			},
+		},
+		{
+			desc: "bad move, missing emphasis",
+			prop: "BM",
+			data: []string{""},
+			makeExpNode: func(n *movetree.Node) {
+				n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.BadMove, Emphasis: 1}
+			},
+		},
+		{
+			desc: "bad move, missing emphasis, lenient",
+			prop: "BM",
+			data: []string{""},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.BadMove, Emphasis: 1}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "doubtful",
+			prop: "DO",
+			data: []string{""},
+			makeExpNode: func(n *movetree.Node) {
+				n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.DoubtfulMove}
+			},
+		},
+		{
+			desc:        "bad emphasis",
+			prop:        "TE",
+			data:        []string{"3"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrMoveAnnotation,
+		},
+		{
+			desc: "already annotated",
+			prop: "TE",
+			data: []string{"1"},
+			makeNode: func(n *movetree.Node) {
+				n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.BadMove, Emphasis: 1}
+			},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrMoveAnnotation,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertNode_MoveAnnotation(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "bad move",
+			makeNode: func(n *movetree.Node) {
+				n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.BadMove, Emphasis: 1}
+			},
+			expOut: "BM[1]",
+		},
+		{
+			desc: "tesuji, emphasis unset",
+			makeNode: func(n *movetree.Node) {
+				n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.Tesuji}
+			},
+			expOut: "TE[1]",
+		},
+		{
+			desc: "interesting",
+			makeNode: func(n *movetree.Node) {
+				n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.InterestingMove}
+			},
+			expOut: "IT[]",
+		},
+		{
+			desc: "bad emphasis",
+			makeNode: func(n *movetree.Node) {
+				n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.BadMove, Emphasis: 3}
+			},
+			expErr: ErrMoveAnnotation,
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
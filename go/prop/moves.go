@@ -11,17 +11,39 @@ import (
 
 var ErrMove = errors.New("error converting move property B or W")
 
+// ErrDuplicateMove indicates a node with more than one move. It wraps ErrMove.
+var ErrDuplicateMove = fmt.Errorf("%w: two moves on one node", ErrMove)
+
+// legacyPass is the point used for passes in FF[3], which is still commonly
+// used in FF[4] on boards up to 19x19.
+const legacyPass = "tt"
+
+// legacyPassAllowed indicates whether tt is a pass on the board of the node's
+// movetree, rather than a point: it is unless tt is on the board, which needs
+// at least 20 columns and 20 rows.
+func legacyPassAllowed(n *movetree.Node) bool {
+	w, h := nodeBoardDimensions(n)
+	return w <= 19 || h <= 19
+}
+
 // movesConv is an SGF converter for moves B,W.
 var movesConv = &SGFConverter{
 	Props: []Prop{"B", "W"},
 	Scope: AllScope,
-	From: func(n *movetree.Node, prop string, data []string) error {
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
 		col, err := color.FromSGFProp(prop)
 		if err != nil {
 			return err
 		}
 		if n.Move != nil {
-			return fmt.Errorf("found two moves on one node at move: %w", ErrMove)
+			if opts.lenient() {
+				return &Warning{
+					Prop: prop,
+					Msg:  fmt.Sprintf("found two moves on one node; dropped %s%v", prop, data),
+					Code: movetree.LintTwoMoves,
+				}
+			}
+			return fmt.Errorf("found two moves on one node at move: %w", ErrDuplicateMove)
 		}
 		if len(data) != 1 && len(data) != 0 {
 			return fmt.Errorf("expected black move data to have exactly one value or zero values: %w", ErrMove)
@@ -29,14 +51,18 @@ var movesConv = &SGFConverter{
 		if len(data) == 0 {
 			data = []string{""}
 		}
+		if data[0] == legacyPass && legacyPassAllowed(n) {
+			data = []string{""}
+		}
+		data, warn := cleanPoints(n, prop, data, opts)
 		move, err := move.FromSGFPoint(col, data[0])
 		if err != nil {
 			return err
 		}
 		n.Move = move
-		return nil
+		return warn
 	},
-	To: func(n *movetree.Node) (string, error) {
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
 		mv := n.Move
 		if mv == nil {
 			return "", nil
@@ -48,6 +74,9 @@ var movesConv = &SGFConverter{
 			col = "W"
 		}
 		if mv.IsPass() {
+			if fileFormat(n) < 4 && legacyPassAllowed(n) {
+				return col + "[" + legacyPass + "]", nil
+			}
 			// Return non-nil slice to indicate it should be stored.
 			return col + "[]", nil
 		}
@@ -57,4 +86,9 @@ var movesConv = &SGFConverter{
 		}
 		return col + "[" + sgfPt + "]", nil
 	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.Move != nil && string(n.Move.Color()) == prop {
+			n.Move = nil
+		}
+	},
 }
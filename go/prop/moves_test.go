@@ -27,6 +27,38 @@ func TestConvertFromSGF_Moves(t *testing.T) {
 				n.Move = move.NewPass(color.Black)
 			},
 		},
+		{
+			desc: "black move: pass as tt",
+			prop: "B",
+			data: []string{"tt"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Move = move.NewPass(color.Black)
+			},
+		},
+		{
+			desc: "black move: tt on a large board",
+			prop: "B",
+			data: []string{"tt"},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 21}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 21}
+				n.Move = move.New(color.Black, point.New(19, 19))
+			},
+		},
+		{
+			desc: "black move: tt on a wide board",
+			prop: "B",
+			data: []string{"tt"},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 21, Width: 21, Height: 19}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 21, Width: 21, Height: 19}
+				n.Move = move.NewPass(color.Black)
+			},
+		},
 		{
 			desc: "black move",
 			prop: "B",
@@ -43,6 +75,123 @@ func TestConvertFromSGF_Moves(t *testing.T) {
 				n.Move = move.New(color.White, point.New(0, 1))
 			},
 		},
+		{
+			desc: "two moves",
+			prop: "W",
+			data: []string{"ab"},
+			makeNode: func(n *movetree.Node) {
+				n.Move = move.New(color.Black, point.New(0, 0))
+			},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrMove,
+		},
+		{
+			desc: "two moves, lenient",
+			prop: "W",
+			data: []string{"ab"},
+			opts: &ParseOptions{Lenient: true},
+			makeNode: func(n *movetree.Node) {
+				n.Move = move.New(color.Black, point.New(0, 0))
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.Move = move.New(color.Black, point.New(0, 0))
+			},
+			expWarn: true,
+		},
+		{
+			desc:        "whitespace around the point",
+			prop:        "B",
+			data:        []string{" qd "},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      point.SGFConversionErr,
+		},
+		{
+			desc: "whitespace around the point, lenient",
+			prop: "B",
+			data: []string{" qd\n"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.Move = move.New(color.Black, point.New(16, 3))
+			},
+			expWarn: true,
+		},
+		{
+			desc: "uppercase point off the board",
+			prop: "B",
+			data: []string{"AA"},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 9}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 9}
+				n.Move = move.New(color.Black, point.New(26, 26))
+			},
+		},
+		{
+			desc: "uppercase point off the board, lenient",
+			prop: "B",
+			data: []string{"AA"},
+			opts: &ParseOptions{Lenient: true},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 9}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 9}
+				n.Move = move.New(color.Black, point.New(0, 0))
+			},
+			expWarn: true,
+		},
+		{
+			desc: "uppercase point on the board, lenient",
+			prop: "B",
+			data: []string{"Ab"},
+			opts: &ParseOptions{Lenient: true},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 52}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 52}
+				n.Move = move.New(color.Black, point.New(26, 1))
+			},
+		},
+		{
+			desc: "uppercase point off a narrow board, lenient",
+			prop: "B",
+			data: []string{"AE"},
+			opts: &ParseOptions{Lenient: true},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 19, Width: 19, Height: 9}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 19, Width: 19, Height: 9}
+				n.Move = move.New(color.Black, point.New(0, 4))
+			},
+			expWarn: true,
+		},
+		{
+			desc: "uppercase point that is off a narrow board either way, lenient",
+			prop: "B",
+			data: []string{"AK"},
+			opts: &ParseOptions{Lenient: true},
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 19, Width: 19, Height: 9}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 19, Width: 19, Height: 9}
+				n.Move = move.New(color.Black, point.New(26, 36))
+			},
+		},
+		{
+			// The move can't be recovered, so it's kept as a raw property.
+			desc: "whitespace within the point, lenient",
+			prop: "B",
+			data: []string{" q d "},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.SGFProperties["B"] = []string{" q d "}
+			},
+			expWarn: true,
+		},
 	}
 
 	testConvertFromSGFCases(t, testCases)
@@ -57,6 +206,23 @@ func TestConvertNode_Moves(t *testing.T) {
 			},
 			expOut: "B[]",
 		},
+		{
+			desc: "black move: pass, FF[3]",
+			makeNode: func(n *movetree.Node) {
+				n.SGFProperties["FF"] = []string{"3"}
+				n.Move = move.NewPass(color.Black)
+			},
+			expOut: "FF[3]B[tt]",
+		},
+		{
+			desc: "black move: pass, FF[3] on a large board",
+			makeNode: func(n *movetree.Node) {
+				n.SGFProperties["FF"] = []string{"3"}
+				n.GameInfo = &movetree.GameInfo{Size: 21}
+				n.Move = move.NewPass(color.Black)
+			},
+			expOut: "FF[3]SZ[21]B[]",
+		},
 		{
 			desc: "black move: non-pass",
 			makeNode: func(n *movetree.Node) {
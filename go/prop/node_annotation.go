@@ -0,0 +1,161 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ErrNodeAnnotation indicates an error converting a node name, position
+// annotation, hotspot, or value property.
+var ErrNodeAnnotation = errors.New("error converting node annotation property")
+
+// nameConv converts the node name property N. The name is unescaped and
+// escaped like a comment. When parsing leniently, several values are joined
+// into one name (see joinValues).
+var nameConv = &SGFConverter{
+	Props: []Prop{"N"},
+	Scope: AllScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		if len(data) != 1 && (len(data) == 0 || !opts.lenient()) {
+			return fmt.Errorf("%w: N only allows one prop-value, found %v", ErrNodeAnnotation, data)
+		}
+		if len(data) != 1 {
+			joined, warn := joinValues(prop, data, " ")
+			n.Name = unescapeText(joined)
+			return warn
+		}
+		n.Name = unescapeText(data[0])
+		return nil
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		if n.Name == "" {
+			return "", nil
+		}
+		return "N[" + escapeComment(n.Name) + "]", nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		n.Name = ""
+	},
+}
+
+// parseEmphasis parses the emphasis of an annotation, which is 1 (normal) or 2
+// (emphasized). Like for move annotations, a missing emphasis is treated as 1,
+// with a warning when parsing leniently.
+func parseEmphasis(prop string, data []string, opts *ParseOptions) (int, error) {
+	if len(data) > 1 {
+		return 0, fmt.Errorf("%w: %s only allows one prop-value, found %v", ErrNodeAnnotation, prop, data)
+	}
+	switch {
+	case len(data) == 0 || data[0] == "":
+		if opts.lenient() {
+			return 1, &Warning{Prop: prop, Msg: "missing emphasis; treating it as 1"}
+		}
+		return 1, nil
+	case data[0] == "1":
+		return 1, nil
+	case data[0] == "2":
+		return 2, nil
+	}
+	return 0, fmt.Errorf("%w: %s emphasis must be 1 or 2, but was %q", ErrNodeAnnotation, prop, data[0])
+}
+
+// positionAnnotationConv converts the position annotation properties GB (good
+// for black), GW (good for white), DM (even), and UC (unclear). Only one of
+// them is allowed per node.
+var positionAnnotationConv = &SGFConverter{
+	Props: []Prop{"GB", "GW", "DM", "UC"},
+	Scope: AllScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		if n.PositionAnnotation != nil {
+			return fmt.Errorf("%w: found %s, but the position is already annotated with %s", ErrNodeAnnotation, prop, n.PositionAnnotation.Type)
+		}
+		emphasis, err := parseEmphasis(prop, data, opts)
+		if emphasis == 0 {
+			return err
+		}
+		n.PositionAnnotation = &movetree.PositionAnnotation{Type: movetree.PositionAnnotationType(prop), Emphasis: emphasis}
+		return err
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		pa := n.PositionAnnotation
+		if pa == nil {
+			return "", nil
+		}
+		switch pa.Type {
+		case movetree.GoodForBlack, movetree.GoodForWhite, movetree.EvenPosition, movetree.UnclearPosition:
+		default:
+			return "", fmt.Errorf("%w: unknown position annotation type %q", ErrNodeAnnotation, pa.Type)
+		}
+		emphasis := pa.Emphasis
+		if emphasis == 0 {
+			emphasis = 1
+		}
+		if emphasis != 1 && emphasis != 2 {
+			return "", fmt.Errorf("%w: %s emphasis must be 1 or 2, but was %d", ErrNodeAnnotation, pa.Type, pa.Emphasis)
+		}
+		return fmt.Sprintf("%s[%d]", pa.Type, emphasis), nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.PositionAnnotation != nil && string(n.PositionAnnotation.Type) == prop {
+			n.PositionAnnotation = nil
+		}
+	},
+}
+
+// hotspotConv converts the hotspot property HO, whose value is an emphasis of
+// 1 or 2.
+var hotspotConv = &SGFConverter{
+	Props: []Prop{"HO"},
+	Scope: AllScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		emphasis, err := parseEmphasis(prop, data, opts)
+		if emphasis == 0 {
+			return err
+		}
+		n.Hotspot = emphasis
+		return err
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		switch n.Hotspot {
+		case 0:
+			return "", nil
+		case 1, 2:
+			return fmt.Sprintf("HO[%d]", n.Hotspot), nil
+		}
+		return "", fmt.Errorf("%w: HO emphasis must be 1 or 2, but was %d", ErrNodeAnnotation, n.Hotspot)
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		n.Hotspot = 0
+	},
+}
+
+// valueConv converts the value property V, a real number such as an
+// estimated score, which is positive when black is ahead.
+var valueConv = &SGFConverter{
+	Props: []Prop{"V"},
+	Scope: AllScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		if len(data) != 1 {
+			return fmt.Errorf("%w: V only allows one prop-value, found %v", ErrNodeAnnotation, data)
+		}
+		v, err := strconv.ParseFloat(data[0], 64)
+		if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+			return fmt.Errorf("%w: V must be a finite number, but was %q", ErrNodeAnnotation, data[0])
+		}
+		n.Value = &v
+		return nil
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		if n.Value == nil {
+			return "", nil
+		}
+		return "V[" + strconv.FormatFloat(*n.Value, 'f', -1, 64) + "]", nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		n.Value = nil
+	},
+}
@@ -0,0 +1,153 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+func TestConvertFromSGF_NodeAnnotation(t *testing.T) {
+	testCases := []fromSGFTestCase{
+		{
+			desc: "name",
+			prop: "N",
+			data: []string{"Taisha [a\\] joseki"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Name = "Taisha [a] joseki"
+			},
+		},
+		{
+			desc:        "name, several values",
+			prop:        "N",
+			data:        []string{"one", "two"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrNodeAnnotation,
+		},
+		{
+			desc: "name, several values, lenient",
+			prop: "N",
+			data: []string{"one", "two"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.Name = "one two"
+			},
+			expWarn: true,
+		},
+		{
+			desc: "good for black",
+			prop: "GB",
+			data: []string{"1"},
+			makeExpNode: func(n *movetree.Node) {
+				n.PositionAnnotation = &movetree.PositionAnnotation{Type: movetree.GoodForBlack, Emphasis: 1}
+			},
+		},
+		{
+			desc: "emphasized unclear",
+			prop: "UC",
+			data: []string{"2"},
+			makeExpNode: func(n *movetree.Node) {
+				n.PositionAnnotation = &movetree.PositionAnnotation{Type: movetree.UnclearPosition, Emphasis: 2}
+			},
+		},
+		{
+			desc: "even, missing emphasis, lenient",
+			prop: "DM",
+			data: []string{""},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.PositionAnnotation = &movetree.PositionAnnotation{Type: movetree.EvenPosition, Emphasis: 1}
+			},
+			expWarn: true,
+		},
+		{
+			desc:        "bad emphasis",
+			prop:        "GW",
+			data:        []string{"3"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrNodeAnnotation,
+		},
+		{
+			desc: "already annotated",
+			prop: "GW",
+			data: []string{"1"},
+			makeNode: func(n *movetree.Node) {
+				n.PositionAnnotation = &movetree.PositionAnnotation{Type: movetree.GoodForBlack, Emphasis: 1}
+			},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrNodeAnnotation,
+		},
+		{
+			desc: "hotspot",
+			prop: "HO",
+			data: []string{"2"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Hotspot = 2
+			},
+		},
+		{
+			desc: "value",
+			prop: "V",
+			data: []string{"-3.5"},
+			makeExpNode: func(n *movetree.Node) {
+				v := -3.5
+				n.Value = &v
+			},
+		},
+		{
+			desc:        "value, not a number",
+			prop:        "V",
+			data:        []string{"B+3"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrNodeAnnotation,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertNode_NodeAnnotation(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "name",
+			makeNode: func(n *movetree.Node) {
+				n.Name = "Taisha [a] joseki"
+			},
+			expOut: "N[Taisha [a\\] joseki]",
+		},
+		{
+			desc: "position annotation, emphasis unset",
+			makeNode: func(n *movetree.Node) {
+				n.PositionAnnotation = &movetree.PositionAnnotation{Type: movetree.GoodForWhite}
+			},
+			expOut: "GW[1]",
+		},
+		{
+			desc: "unknown position annotation",
+			makeNode: func(n *movetree.Node) {
+				n.PositionAnnotation = &movetree.PositionAnnotation{Type: "XX", Emphasis: 1}
+			},
+			expErr: ErrNodeAnnotation,
+		},
+		{
+			desc: "all",
+			makeNode: func(n *movetree.Node) {
+				v := 12.25
+				n.Comment = "Black is ahead"
+				n.Name = "Variation A"
+				n.PositionAnnotation = &movetree.PositionAnnotation{Type: movetree.GoodForBlack, Emphasis: 2}
+				n.Hotspot = 1
+				n.Value = &v
+			},
+			expOut: "N[Variation A]C[Black is ahead]GB[2]HO[1]V[12.25]",
+		},
+		{
+			desc: "bad hotspot",
+			makeNode: func(n *movetree.Node) {
+				n.Hotspot = 3
+			},
+			expErr: ErrNodeAnnotation,
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
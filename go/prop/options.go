@@ -0,0 +1,292 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSerializeOptions indicates that the serialization options are invalid.
+var ErrSerializeOptions = errors.New("invalid serialization options")
+
+// ParseOptions contains options for converting SGF properties to node
+// properties. A nil *ParseOptions is valid and means that the defaults (strict
+// parsing) are used.
+type ParseOptions struct {
+	// Lenient indicates that malformed property values should be recovered from
+	// where possible, rather than resulting in an error. Each recovery is
+	// reported with a *Warning. Root properties on non-root nodes, and
+	// properties whose values can't be read at all, are kept as raw
+	// properties. The SGF parser also recovers from malformed syntax (see
+	// sgf.ErrSyntax).
+	Lenient bool
+
+	// RejectUnknown indicates that properties that aren't in the SGF
+	// specification, and have no registered converter, are errors
+	// (ErrUnknownProp), for validators that only allow standard properties.
+	// By default, unknown properties are kept as raw properties.
+	RejectUnknown bool
+
+	// Charset, if set, is the charset used to decode raw SGFs, instead of the
+	// charset in the CA property or a guessed charset. Ex: Shift_JIS.
+	Charset string
+
+	// ExtractAnalysis indicates that AI-review analysis should be extracted
+	// into Node.Analysis, using the registered analysis extractors.
+	ExtractAnalysis bool
+
+	// KomiTolerance is how far a komi value (KM) may be from a legal value and
+	// still be snapped to it when parsing leniently (ex: 6.500001 to 6.5), to
+	// recover from the floating-point formatting of some tools. If 0,
+	// DefaultKomiTolerance is used.
+	KomiTolerance float64
+
+	// StrictKomi indicates that komi (KM) must have a legal decimal value: .0
+	// or .5, or also .25 or .75 under Ing rules. By default, any finite komi
+	// is kept (ex: 6.3), since some servers don't follow the .0 or .5 rule.
+	// When parsing leniently, illegal komi is kept with a warning.
+	StrictKomi bool
+
+	// PlaceHandicap indicates that the standard handicap stones (see
+	// movetree.HandicapPoints) should be placed on a root that has a handicap
+	// (HA), but no black stones (AB).
+	PlaceHandicap bool
+
+	// MaxDepth, MaxValueBytes, and MaxNodes limit the nesting of variations,
+	// the size of a property value in bytes, and the number of nodes, so that
+	// pathological SGFs fail fast rather than exhausting resources. If 0, the
+	// defaults are used (DefaultMaxDepth, etc.). If negative, there's no limit.
+	MaxDepth      int
+	MaxValueBytes int
+	MaxNodes      int
+}
+
+// The default parsing limits, which are generous enough for real games.
+const (
+	DefaultMaxDepth      = 10000
+	DefaultMaxValueBytes = 1 << 20
+	DefaultMaxNodes      = 1000000
+)
+
+// DepthLimit returns the maximum nesting of variations, or a negative number if
+// there's no limit.
+func (o *ParseOptions) DepthLimit() int {
+	if o == nil {
+		return DefaultMaxDepth
+	}
+	return limit(o.MaxDepth, DefaultMaxDepth)
+}
+
+// ValueBytesLimit returns the maximum size of a property value in bytes, or a
+// negative number if there's no limit.
+func (o *ParseOptions) ValueBytesLimit() int {
+	if o == nil {
+		return DefaultMaxValueBytes
+	}
+	return limit(o.MaxValueBytes, DefaultMaxValueBytes)
+}
+
+// NodeLimit returns the maximum number of nodes, or a negative number if
+// there's no limit.
+func (o *ParseOptions) NodeLimit() int {
+	if o == nil {
+		return DefaultMaxNodes
+	}
+	return limit(o.MaxNodes, DefaultMaxNodes)
+}
+
+// limit returns the limit v, or def if v is unset.
+func limit(v, def int) int {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// DefaultKomiTolerance is the default tolerance for snapping komi values to a
+// legal value.
+const DefaultKomiTolerance = 1e-4
+
+// komiTolerance returns the tolerance for snapping komi values.
+func (o *ParseOptions) komiTolerance() float64 {
+	if o == nil || o.KomiTolerance == 0 {
+		return DefaultKomiTolerance
+	}
+	return o.KomiTolerance
+}
+
+// extractAnalysis indicates whether AI-review analysis should be extracted.
+func (o *ParseOptions) extractAnalysis() bool {
+	return o != nil && o.ExtractAnalysis
+}
+
+// CharsetOverride returns the charset that raw SGFs should be decoded with, or
+// the empty string if it should be determined from the SGF.
+func (o *ParseOptions) CharsetOverride() string {
+	if o == nil {
+		return ""
+	}
+	return o.Charset
+}
+
+// lenient indicates whether malformed properties should be recovered from.
+func (o *ParseOptions) lenient() bool {
+	return o != nil && o.Lenient
+}
+
+// strictKomi indicates whether komi must have a legal decimal value.
+func (o *ParseOptions) strictKomi() bool {
+	return o != nil && o.StrictKomi
+}
+
+// placeHandicap indicates whether missing handicap stones should be placed.
+func (o *ParseOptions) placeHandicap() bool {
+	return o != nil && o.PlaceHandicap
+}
+
+// rejectUnknown indicates whether unknown properties are errors.
+func (o *ParseOptions) rejectUnknown() bool {
+	return o != nil && o.RejectUnknown
+}
+
+// SerializeOptions contains options for converting node properties to SGF. A
+// nil *SerializeOptions is valid and means that the defaults are used.
+type SerializeOptions struct {
+	// CompressPointLists indicates that point-list properties (AB, AW, AE, VW,
+	// and the markup properties) should be written using the compressed
+	// rectangle form where possible. For example, instead of
+	// AB[aa][ab][ba][bb], write AB[aa:bb].
+	CompressPointLists bool
+
+	// Include, if non-empty, lists the only properties that are written. All
+	// other properties are skipped. Include and Exclude are mutually exclusive.
+	Include []Prop
+
+	// Exclude lists properties that are skipped when writing. For example, to
+	// strip the timing information: []Prop{"BL", "WL", "OB", "OW"}.
+	Exclude []Prop
+
+	// LineEnding is the newline written between variations and inside text
+	// property values: either LF ("\n", the default) or CRLF ("\r\n"), for
+	// tools that expect Windows line endings.
+	LineEnding string
+
+	// PropertyOrder, if non-empty, overrides the order in which the properties
+	// of a node are written (see CanonicalOrder). Properties that aren't listed
+	// are written after the listed properties, in the canonical order.
+	PropertyOrder []Prop
+
+	// InferHandicap indicates that a handicap (HA) should be written for a root
+	// whose placements are exactly a standard placement of handicap stones
+	// (see movetree.HandicapPoints), if the root has no handicap. This helps
+	// editors that rely on HA to display handicap games.
+	InferHandicap bool
+
+	// FileProps indicates that the file properties FF[4], GM[1], and CA[UTF-8]
+	// should be written on the root when it doesn't have them, for tools that
+	// require them. To skip the file properties instead, Exclude them.
+	FileProps bool
+
+	// VariationPerLine indicates that each variation should start a new line.
+	// By default, the SGF is written without newlines between nodes or
+	// variations.
+	VariationPerLine bool
+
+	// NodePerLine indicates that each node should be written on its own line,
+	// for diff-friendly output. Variations start new lines too.
+	NodePerLine bool
+
+	// Indent, if set, indents each line by the nesting of the variation it's
+	// in, repeating Indent once per level (ex: two spaces). It must only
+	// contain spaces and tabs. Variations start new lines, as with
+	// VariationPerLine.
+	Indent string
+
+	// MaxLineWidth, if positive, is the width (in characters) at which lines
+	// are wrapped. Lines are only wrapped between properties, so a property
+	// that's wider than the limit is written on a line of its own.
+	MaxLineWidth int
+}
+
+// Line endings for SerializeOptions.LineEnding.
+const (
+	LF   = "\n"
+	CRLF = "\r\n"
+)
+
+// validate checks that the options are consistent.
+func (o *SerializeOptions) validate() error {
+	if o != nil && len(o.Include) > 0 && len(o.Exclude) > 0 {
+		return fmt.Errorf("%w: Include and Exclude are mutually exclusive", ErrSerializeOptions)
+	}
+	if le := o.Newline(); le != LF && le != CRLF {
+		return fmt.Errorf("%w: line ending must be LF or CRLF, but was %q", ErrSerializeOptions, le)
+	}
+	if o != nil && strings.Trim(o.Indent, " \t") != "" {
+		return fmt.Errorf("%w: indent must only contain spaces and tabs, but was %q", ErrSerializeOptions, o.Indent)
+	}
+	if o != nil && o.MaxLineWidth < 0 {
+		return fmt.Errorf("%w: max line width must not be negative, but was %d", ErrSerializeOptions, o.MaxLineWidth)
+	}
+	return nil
+}
+
+// Newline returns the line ending that should be written, defaulting to LF.
+func (o *SerializeOptions) Newline() string {
+	if o == nil || o.LineEnding == "" {
+		return LF
+	}
+	return o.LineEnding
+}
+
+// applyLineEnding converts the newlines in the converted properties s to the
+// line ending of the options. Any CRLFs in s are taken to be newlines too.
+func (o *SerializeOptions) applyLineEnding(s string) string {
+	if !strings.Contains(s, "\r") && o.Newline() == LF {
+		return s
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(s, CRLF, LF), LF, o.Newline())
+}
+
+// allowed indicates whether property p should be written.
+func (o *SerializeOptions) allowed(p Prop) bool {
+	if o == nil {
+		return true
+	}
+	if len(o.Include) > 0 {
+		return containsProp(o.Include, p)
+	}
+	return !containsProp(o.Exclude, p)
+}
+
+func containsProp(props []Prop, p Prop) bool {
+	for _, q := range props {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
+
+// propertyOrder returns the custom property order, if any.
+func (o *SerializeOptions) propertyOrder() []Prop {
+	if o == nil {
+		return nil
+	}
+	return o.PropertyOrder
+}
+
+// inferHandicap indicates whether a missing handicap should be inferred.
+func (o *SerializeOptions) inferHandicap() bool {
+	return o != nil && o.InferHandicap
+}
+
+// fileProps indicates whether missing file properties should be written.
+func (o *SerializeOptions) fileProps() bool {
+	return o != nil && o.FileProps
+}
+
+// compressPointLists indicates whether the point lists should be compressed.
+func (o *SerializeOptions) compressPointLists() bool {
+	return o != nil && o.CompressPointLists
+}
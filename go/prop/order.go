@@ -0,0 +1,68 @@
+package prop
+
+import "sort"
+
+// canonicalOrder is the order in which the properties of a node are written:
+// root and game info properties, setup, the move, move annotations, position
+// annotations, markup, and timing. Unknown properties are written last, in
+// alphabetical order.
+var canonicalOrder = []Prop{
+	// Root properties.
+	"FF", "GM", "CA", "AP", "ST", "SZ",
+	// Game info.
+	"GN", "PB", "BR", "BT", "PW", "WR", "WT", "HA", "KM", "RU", "RE", "DT",
+	"EV", "RO", "PC", "TM", "OT", "GC", "ON", "SO", "AN", "US", "CP",
+	// Setup.
+	"AB", "AW", "AE", "PL",
+	// Move.
+	"B", "W", "KO", "MN",
+	// Move annotations.
+	"BM", "DO", "IT", "TE",
+	// Position annotations.
+	"N", "C", "DM", "GB", "GW", "HO", "UC", "V",
+	// Markup, including the FF[3] M and L.
+	"CR", "MA", "SQ", "TR", "M", "LB", "L", "AR", "LN", "DD", "SL", "TB", "TW",
+	"FG", "PM", "VW",
+	// Timing.
+	"BL", "WL", "OB", "OW",
+}
+
+var canonicalRank = func() map[Prop]int {
+	mp := make(map[Prop]int)
+	for i, p := range canonicalOrder {
+		mp[p] = i
+	}
+	return mp
+}()
+
+// CanonicalOrder returns the order in which the serializer writes the
+// properties of a node, unless overridden with SerializeOptions.PropertyOrder.
+// Properties that aren't listed are written after the listed properties, in
+// alphabetical order.
+func CanonicalOrder() []Prop {
+	return append([]Prop{}, canonicalOrder...)
+}
+
+// sortProps sorts the converted properties into the order given by the
+// options.
+func sortProps(props []convertedProp, opts *SerializeOptions) {
+	custom := opts.propertyOrder()
+	rank := func(p Prop) int {
+		for i, q := range custom {
+			if p == q {
+				return i
+			}
+		}
+		if r, ok := canonicalRank[p]; ok {
+			return len(custom) + r
+		}
+		return len(custom) + len(canonicalOrder)
+	}
+	sort.SliceStable(props, func(i, j int) bool {
+		ri, rj := rank(props[i].prop), rank(props[j].prop)
+		if ri != rj {
+			return ri < rj
+		}
+		return props[i].prop < props[j].prop
+	})
+}
@@ -0,0 +1,71 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestConvertNode_Order(t *testing.T) {
+	makeNode := func(n *movetree.Node) {
+		komi := 6.5
+		n.GameInfo = &movetree.GameInfo{Size: 9, Komi: &komi, BlackPlayer: "Black"}
+		n.Move = move.New(color.Black, point.New(0, 1))
+		n.Comment = "Nice"
+		n.MoveAnnotation = &movetree.MoveAnnotation{Type: movetree.Tesuji, Emphasis: 1}
+		n.Placements = []*move.Move{move.New(color.White, point.New(3, 3))}
+		n.Marks = map[point.Point]movetree.MarkType{*point.New(2, 2): movetree.MarkTriangle}
+		n.SGFProperties["ZZ"] = []string{"zork"}
+		n.SGFProperties["BL"] = []string{"300"}
+		n.SGFProperties["YY"] = []string{"yak"}
+		n.SGFProperties["GM"] = []string{"1"}
+		n.SGFProperties["FF"] = []string{"4"}
+		n.SGFProperties["AR"] = []string{"aa:bb"}
+	}
+	testCases := []convertNodeTestCase{
+		{
+			desc:     "canonical order",
+			makeNode: makeNode,
+			expOut:   "FF[4]GM[1]SZ[9]PB[Black]KM[6.5]AW[dd]B[ab]TE[1]C[Nice]TR[cc]AR[aa:bb]BL[300]YY[yak]ZZ[zork]",
+		},
+		{
+			desc:     "custom order",
+			makeNode: makeNode,
+			opts:     &SerializeOptions{PropertyOrder: []Prop{"B", "C", "ZZ", "SZ"}},
+			expOut:   "B[ab]C[Nice]ZZ[zork]SZ[9]FF[4]GM[1]PB[Black]KM[6.5]AW[dd]TE[1]TR[cc]AR[aa:bb]BL[300]YY[yak]",
+		},
+		{
+			desc:     "custom order with filtering",
+			makeNode: makeNode,
+			opts:     &SerializeOptions{PropertyOrder: []Prop{"C", "B"}, Include: []Prop{"B", "C", "KM"}},
+			expOut:   "C[Nice]B[ab]KM[6.5]",
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
+
+func TestCanonicalOrder(t *testing.T) {
+	order := CanonicalOrder()
+	seen := make(map[Prop]bool)
+	for _, p := range order {
+		if seen[p] {
+			t.Errorf("CanonicalOrder() lists %s twice", p)
+		}
+		seen[p] = true
+	}
+	for p := range ValidProperties {
+		if !seen[p] {
+			t.Errorf("CanonicalOrder() doesn't list the SGF property %s", p)
+		}
+	}
+
+	// Modifying the returned order doesn't affect serialization.
+	order[0] = "ZZ"
+	if got := CanonicalOrder()[0]; got != "FF" {
+		t.Errorf("CanonicalOrder()[0]=%s after modifying a copy, but expected FF", got)
+	}
+}
@@ -1,59 +1,61 @@
 package prop
 
 import (
-	"strings"
-
 	"github.com/otrego/clamshell/go/color"
 	"github.com/otrego/clamshell/go/move"
 	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
 )
 
 // placementsConv converts stone-placements AW, AB.
 var placementsConv = &SGFConverter{
 	Props: []Prop{"AB", "AW"},
 	Scope: AllScope,
-	From: func(n *movetree.Node, prop string, data []string) error {
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
 		col, err := color.FromSGFProp(prop)
 		if err != nil {
 			return err
 		}
-		moves, err := move.ListFromSGFPoints(col, data)
+		data, warn := cleanPoints(n, prop, data, opts)
+		pts, err := pointsFromSGF(data)
 		if err != nil {
 			return err
 		}
-		n.Placements = append(n.Placements, moves...)
-		return nil
+		for _, pt := range pts {
+			n.Placements = append(n.Placements, move.New(col, pt))
+		}
+		return warn
 	},
-	To: func(n *movetree.Node) (string, error) {
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
 		if len(n.Placements) == 0 {
 			return "", nil
 		}
-		var black []string
-		var white []string
+		var black []*point.Point
+		var white []*point.Point
 		for _, mv := range n.Placements {
-			sgfPt, err := mv.Point().ToSGF()
-			if err != nil {
-				return "", err
-			}
 			if mv.Color() == color.Black {
-				black = append(black, sgfPt)
+				black = append(black, mv.Point())
 			} else if mv.Color() == color.White {
-				white = append(white, sgfPt)
+				white = append(white, mv.Point())
 			}
 		}
-		var out strings.Builder
-		if len(black) > 0 {
-			out.WriteString("AB")
-		}
-		for _, pt := range black {
-			out.WriteString("[" + pt + "]")
+		ab, err := writePointList("AB", black, opts)
+		if err != nil {
+			return "", err
 		}
-		if len(white) > 0 {
-			out.WriteString("AW")
+		aw, err := writePointList("AW", white, opts)
+		if err != nil {
+			return "", err
 		}
-		for _, pt := range white {
-			out.WriteString("[" + pt + "]")
+		return ab + aw, nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		var pl move.List
+		for _, m := range n.Placements {
+			if "A"+string(m.Color()) != prop {
+				pl = append(pl, m)
+			}
 		}
-		return out.String(), nil
+		n.Placements = pl
 	},
 }
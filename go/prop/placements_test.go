@@ -33,6 +33,62 @@ func TestConvertFromSGF_Placements(t *testing.T) {
 				}
 			},
 		},
+		{
+			desc: "whitespace around the points, lenient",
+			prop: "AB",
+			data: []string{" aa", "bb:bc\n"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.Placements = []*move.Move{
+					move.New(color.Black, point.New(0, 0)),
+					move.New(color.Black, point.New(1, 1)),
+					move.New(color.Black, point.New(1, 2)),
+				}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "uppercase rectangle, lenient",
+			prop: "AB",
+			data: []string{"aa:BA"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.Placements = []*move.Move{
+					move.New(color.Black, point.New(0, 0)),
+					move.New(color.Black, point.New(1, 0)),
+				}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "overlapping rectangles",
+			prop: "AB",
+			data: []string{"aa:ab", "ab:bb"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Placements = []*move.Move{
+					move.New(color.Black, point.New(0, 0)),
+					move.New(color.Black, point.New(0, 1)),
+					move.New(color.Black, point.New(1, 1)),
+				}
+			},
+		},
+		{
+			desc: "single-point rectangle",
+			prop: "AW",
+			data: []string{"aa:aa"},
+			makeExpNode: func(n *movetree.Node) {
+				n.Placements = []*move.Move{
+					move.New(color.White, point.New(0, 0)),
+				}
+			},
+		},
+		{
+			desc:        "rectangle isn't top-left:bottom-right",
+			prop:        "AB",
+			data:        []string{"bb:aa"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      point.SGFConversionErr,
+		},
 	}
 
 	testConvertFromSGFCases(t, testCases)
@@ -60,6 +116,54 @@ func TestConvertNode_Placements(t *testing.T) {
 			},
 			expOut: "AW[ab][ac]",
 		},
+		{
+			desc: "black placements, compression disabled",
+			makeNode: func(n *movetree.Node) {
+				n.Placements = []*move.Move{
+					move.New(color.Black, point.New(0, 0)),
+					move.New(color.Black, point.New(0, 1)),
+					move.New(color.Black, point.New(1, 0)),
+					move.New(color.Black, point.New(1, 1)),
+				}
+			},
+			opts:   &SerializeOptions{},
+			expOut: "AB[aa][ab][ba][bb]",
+		},
+		{
+			desc: "full board placements, compressed",
+			makeNode: func(n *movetree.Node) {
+				for x := 0; x < 19; x++ {
+					for y := 0; y < 19; y++ {
+						n.Placements = append(n.Placements, move.New(color.Black, point.New(x, y)))
+					}
+				}
+			},
+			opts:   &SerializeOptions{CompressPointLists: true},
+			expOut: "AB[aa:ss]",
+		},
+		{
+			desc: "mixed placements, compressed",
+			makeNode: func(n *movetree.Node) {
+				n.Placements = []*move.Move{
+					move.New(color.Black, point.New(0, 0)),
+					move.New(color.Black, point.New(0, 1)),
+					move.New(color.White, point.New(5, 5)),
+				}
+			},
+			opts:   &SerializeOptions{CompressPointLists: true},
+			expOut: "AB[aa:ab]AW[ff]",
+		},
+		{
+			desc: "unsorted placements with duplicates",
+			makeNode: func(n *movetree.Node) {
+				n.Placements = []*move.Move{
+					move.New(color.Black, point.New(2, 0)),
+					move.New(color.Black, point.New(0, 2)),
+					move.New(color.Black, point.New(2, 0)),
+				}
+			},
+			expOut: "AB[ac][ca]",
+		},
 	}
 
 	testConvertNodeCases(t, testCases)
@@ -0,0 +1,240 @@
+package prop
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// pointListProps are the properties whose values are lists of points, which can
+// be written in the compressed rectangle form (ex: AB[aa:cc]).
+var pointListProps = map[Prop]bool{
+	"AB": true, "AW": true, "AE": true, "VW": true,
+	"CR": true, "TR": true, "SQ": true, "MA": true, "SL": true,
+	"DD": true, "TB": true, "TW": true,
+}
+
+// writePointList writes a point-list property, with the points in sorted order.
+// Duplicate points are only written once. If the options ask for compressed
+// point lists, the points are covered with rectangles, each of which is
+// written either as a single point or as a range (topleft:bottomright).
+func writePointList(prop string, pts []*point.Point, opts *SerializeOptions) (string, error) {
+	if len(pts) == 0 {
+		return "", nil
+	}
+	var values []string
+	if opts.compressPointLists() {
+		for _, r := range coverWithRectangles(pts) {
+			tl, err := r[0].ToSGF()
+			if err != nil {
+				return "", err
+			}
+			if r[0].Equal(r[1]) {
+				values = append(values, tl)
+				continue
+			}
+			br, err := r[1].ToSGF()
+			if err != nil {
+				return "", err
+			}
+			values = append(values, tl+":"+br)
+		}
+	} else {
+		for _, pt := range move.NewPointSet(pts...).Sorted() {
+			sgfPt, err := pt.ToSGF()
+			if err != nil {
+				return "", err
+			}
+			values = append(values, sgfPt)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(prop)
+	for _, v := range values {
+		sb.WriteString("[" + v + "]")
+	}
+	return sb.String(), nil
+}
+
+// coverWithRectangles covers a set of points with non-overlapping, axis-aligned
+// rectangles, returned as (topleft, bottomright) pairs. Every point is covered
+// by exactly one rectangle.
+//
+// The covering is greedy: the uncovered points are visited column by column
+// (by x, then y). From each, the rectangle is first extended down as far as
+// possible and then right for as long as each new column is entirely present
+// and uncovered.
+func coverWithRectangles(pts []*point.Point) [][2]*point.Point {
+	present := move.NewPointSet(pts...)
+	covered := &move.PointSet{}
+	available := func(x, y int) bool {
+		pt := point.New(x, y)
+		return present.Contains(pt) && !covered.Contains(pt)
+	}
+
+	var out [][2]*point.Point
+	for _, start := range present.Sorted() {
+		if covered.Contains(start) {
+			continue
+		}
+		x0, y0 := start.X(), start.Y()
+		y1 := y0
+		for available(x0, y1+1) {
+			y1++
+		}
+		x1 := x0
+		for {
+			full := true
+			for y := y0; y <= y1; y++ {
+				if !available(x1+1, y) {
+					full = false
+					break
+				}
+			}
+			if !full {
+				break
+			}
+			x1++
+		}
+		for x := x0; x <= x1; x++ {
+			for y := y0; y <= y1; y++ {
+				covered.Add(point.New(x, y))
+			}
+		}
+		out = append(out, [2]*point.Point{point.New(x0, y0), point.New(x1, y1)})
+	}
+	return out
+}
+
+// pointsFromSGF converts a list of SGF points into points. Values in the
+// compressed rectangle form (ex: aa:cc) are expanded into all the points of
+// the rectangle, column by column. Duplicate points, including those in
+// overlapping rectangles, are only returned once, in the order they're first
+// found.
+func pointsFromSGF(values []string) ([]*point.Point, error) {
+	var pts []*point.Point
+	seen := &move.PointSet{}
+	add := func(pt *point.Point) {
+		if !seen.Contains(pt) {
+			seen.Add(pt)
+			pts = append(pts, pt)
+		}
+	}
+	for _, v := range values {
+		rect, err := point.NewListFromSGF(v)
+		if err != nil {
+			return nil, err
+		}
+		for _, pt := range rect {
+			add(pt)
+		}
+	}
+	return pts, nil
+}
+
+// cleanPoints recovers from two kinds of mangled point values when parsing
+// leniently, returning a *Warning if any values were changed:
+//
+//   - Whitespace around a value (ex: B[ qd ]), which is found in some
+//     hand-edited and OCR'd files, is trimmed. Whitespace within a value is
+//     left as-is, so that the value still fails to convert.
+//   - Uppercase coordinates that are off the board, but whose lowercase
+//     equivalents are on the board (ex: B[AA] on a 9x9 board), are taken to
+//     be lowercase, since some broken files uppercase everything.
+//
+// When parsing strictly, the values are returned unchanged.
+func cleanPoints(n *movetree.Node, prop string, values []string, opts *ParseOptions) ([]string, error) {
+	if !opts.lenient() {
+		return values, nil
+	}
+	width, height := nodeBoardDimensions(n)
+	var out []string
+	var msgs []string
+	set := func(i int, v, msg string) {
+		if out == nil {
+			out = append([]string{}, values...)
+		}
+		out[i] = v
+		if len(msgs) == 0 || msgs[len(msgs)-1] != msg {
+			msgs = append(msgs, msg)
+		}
+	}
+	for i, v := range values {
+		if t := strings.TrimSpace(v); t != v {
+			set(i, t, "trimmed the whitespace around points")
+			v = t
+		}
+		parts := strings.Split(v, ":")
+		changed := false
+		for k, part := range parts {
+			if lower := strings.ToLower(part); lower != part && !onBoard(part, width, height) && onBoard(lower, width, height) {
+				parts[k] = lower
+				changed = true
+			}
+		}
+		if changed {
+			set(i, strings.Join(parts, ":"), fmt.Sprintf("took uppercase points that are off the %dx%d board to be lowercase", width, height))
+		}
+	}
+	if out == nil {
+		return values, nil
+	}
+	return out, &Warning{Prop: prop, Msg: fmt.Sprintf("malformed points %v; %s", values, strings.Join(msgs, " and "))}
+}
+
+// onBoard indicates whether an SGF point is on a board with the given number
+// of columns and rows.
+func onBoard(sgfPt string, width, height int) bool {
+	pt, err := point.NewFromSGF(sgfPt)
+	return err == nil && pt.X() < width && pt.Y() < height
+}
+
+// nodeBoardSize returns the board size given by the game info on the root of
+// a node's movetree, which is 19 if unspecified.
+func nodeBoardSize(n *movetree.Node) int {
+	root := n
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	if root.GameInfo == nil || root.GameInfo.Size == 0 {
+		return 19
+	}
+	return root.GameInfo.Size
+}
+
+// nodeBoardDimensions returns the number of columns and rows of the board
+// given by the game info on the root of a node's movetree, which is 19x19 if
+// unspecified.
+func nodeBoardDimensions(n *movetree.Node) (width, height int) {
+	root := n
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	if root.GameInfo == nil || root.GameInfo.Size == 0 {
+		return 19, 19
+	}
+	return root.GameInfo.Dimensions()
+}
+
+// expandPointList expands the compressed rectangles in the values of a
+// point-list property, returning the values unchanged if they're malformed.
+func expandPointList(values []string) []string {
+	if !strings.Contains(strings.Join(values, ""), ":") {
+		return values
+	}
+	pts, err := pointsFromSGF(values)
+	if err != nil {
+		return values
+	}
+	out := make([]string, 0, len(pts))
+	for _, pt := range pts {
+		// The points were converted from SGF, so they can be converted back.
+		sgfPt, _ := pt.ToSGF()
+		out = append(out, sgfPt)
+	}
+	return out
+}
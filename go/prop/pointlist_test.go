@@ -0,0 +1,111 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestCoverWithRectangles(t *testing.T) {
+	testCases := []struct {
+		desc string
+		pts  []string
+		exp  []string
+	}{
+		{
+			desc: "single point",
+			pts:  []string{"cc"},
+			exp:  []string{"cc:cc"},
+		},
+		{
+			desc: "full 19x19 board",
+			pts: func() []string {
+				var out []string
+				for x := 0; x < 19; x++ {
+					for y := 0; y < 19; y++ {
+						pt, _ := point.New(x, y).ToSGF()
+						out = append(out, pt)
+					}
+				}
+				return out
+			}(),
+			exp: []string{"aa:ss"},
+		},
+		{
+			desc: "L-shaped region",
+			// x x
+			// x
+			// x
+			pts: []string{"aa", "ab", "ac", "ba"},
+			exp: []string{"aa:ac", "ba:ba"},
+		},
+		{
+			desc: "duplicate points",
+			pts:  []string{"aa", "aa", "ab"},
+			exp:  []string{"aa:ab"},
+		},
+		{
+			desc: "disjoint rectangles",
+			pts:  []string{"aa", "ab", "ba", "bb", "dd", "de"},
+			exp:  []string{"aa:bb", "dd:de"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pts, err := pointsFromSGF(tc.pts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range coverWithRectangles(pts) {
+				tl, _ := r[0].ToSGF()
+				br, _ := r[1].ToSGF()
+				got = append(got, tl+":"+br)
+			}
+			if !cmp.Equal(got, tc.exp) {
+				t.Errorf("coverWithRectangles(%v)=%v, but expected %v", tc.pts, got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestCoverWithRectangles_ExactCover(t *testing.T) {
+	// An irregular region: every point must be covered exactly once.
+	region := []string{
+		"aa", "ba", "ca",
+		"ab", "cb", "db",
+		"ac", "bc", "cc", "dc",
+		"ed", "ee", "fe",
+	}
+	pts, err := pointsFromSGF(region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := make(map[point.Point]bool)
+	for _, pt := range pts {
+		want[*pt] = true
+	}
+
+	got := make(map[point.Point]int)
+	for _, r := range coverWithRectangles(pts) {
+		for x := r[0].X(); x <= r[1].X(); x++ {
+			for y := r[0].Y(); y <= r[1].Y(); y++ {
+				got[*point.New(x, y)]++
+			}
+		}
+	}
+	for pt, count := range got {
+		if !want[pt] {
+			t.Errorf("point %v covered, but not in the region", pt)
+		}
+		if count != 1 {
+			t.Errorf("point %v covered %d times, but expected exactly once", pt, count)
+		}
+	}
+	for pt := range want {
+		if got[pt] == 0 {
+			t.Errorf("point %v is in the region, but was not covered", pt)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 // Package prop adds methods for handling SGF properties, including validation.
 package prop
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Prop is used to store a label parsed from SGF
 type Prop string
@@ -21,3 +24,12 @@ func Validate(prop Prop) bool {
 }
 
 var ErrConvertingProp = errors.New("error converting property")
+
+// ErrScope indicates a property on a node where it isn't allowed, such as a
+// root-only property on a non-root node. It wraps ErrConvertingProp.
+var ErrScope = fmt.Errorf("%w: property not allowed on this node", ErrConvertingProp)
+
+// ErrUnknownProp indicates a property that isn't in the SGF specification,
+// when unknown properties are rejected (see ParseOptions.RejectUnknown). It
+// wraps ErrConvertingProp.
+var ErrUnknownProp = fmt.Errorf("%w: unknown property", ErrConvertingProp)
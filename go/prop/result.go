@@ -0,0 +1,56 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ErrResult indicates an error converting the result property RE.
+var ErrResult = errors.New("error converting result property RE")
+
+// resultConv converts the result property RE. Games that were annulled
+// (RE[Void]) and games whose result is unknown (RE[?]) are kept distinct from
+// games with no RE property, which have a nil result.
+//
+// When parsing leniently, results that can't be parsed (ex: RE[Black wins])
+// are kept as raw properties, with a warning.
+var resultConv = &SGFConverter{
+	Props: []Prop{"RE"},
+	Scope: GameInfoScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		var res *movetree.Result
+		err := fmt.Errorf("%w: result only allows one prop-value, found %v", ErrResult, data)
+		if len(data) == 1 {
+			res, err = movetree.ParseResult(data[0])
+			if err != nil {
+				err = fmt.Errorf("%w: %v", ErrResult, err)
+			}
+		}
+		if err != nil {
+			if !opts.lenient() {
+				return err
+			}
+			n.SGFProperties[prop] = data
+			return &Warning{Prop: prop, Msg: fmt.Sprintf("%v; keeping it as a raw property", err)}
+		}
+		if n.GameInfo == nil {
+			// For safety, make sure to set create gameinfo if it doesn't exist.
+			n.GameInfo = &movetree.GameInfo{}
+		}
+		n.GameInfo.Result = res
+		return nil
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		if n.GameInfo == nil || n.GameInfo.Result == nil {
+			return "", nil
+		}
+		return "RE[" + escapeText(n.GameInfo.Result.String()) + "]", nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.GameInfo != nil {
+			n.GameInfo.Result = nil
+		}
+	},
+}
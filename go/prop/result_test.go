@@ -0,0 +1,130 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+func TestConvertFromSGF_Result(t *testing.T) {
+	testCases := []fromSGFTestCase{
+		{
+			desc: "win by resignation",
+			prop: "RE",
+			data: []string{"B+Resign"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Result: &movetree.Result{Winner: color.Black, Reason: movetree.ReasonResign},
+				}
+			},
+		},
+		{
+			desc: "void",
+			prop: "RE",
+			data: []string{"Void"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Result: &movetree.Result{Reason: movetree.ReasonVoid},
+				}
+			},
+		},
+		{
+			desc: "unknown",
+			prop: "RE",
+			data: []string{"?"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Result: &movetree.Result{Reason: movetree.ReasonUnknown},
+				}
+			},
+		},
+		{
+			desc:        "malformed",
+			prop:        "RE",
+			data:        []string{"Zork"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrResult,
+		},
+		{
+			desc: "malformed, leniently",
+			prop: "RE",
+			data: []string{"Black wins"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.SGFProperties["RE"] = []string{"Black wins"}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "points spelled out, leniently",
+			prop: "RE",
+			data: []string{"B+12.5 points"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.SGFProperties["RE"] = []string{"B+12.5 points"}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "multiple values, leniently",
+			prop: "RE",
+			data: []string{"B+R", "W+R"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.SGFProperties["RE"] = []string{"B+R", "W+R"}
+			},
+			expWarn: true,
+		},
+		{
+			desc:        "multiple values",
+			prop:        "RE",
+			data:        []string{"B+R", "W+R"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrResult,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertNode_Result(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "win by time",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Result: &movetree.Result{Winner: color.White, Reason: movetree.ReasonTime},
+				}
+			},
+			expOut: "RE[W+T]",
+		},
+		{
+			desc: "void",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Result: &movetree.Result{Reason: movetree.ReasonVoid},
+				}
+			},
+			expOut: "RE[Void]",
+		},
+		{
+			desc: "unknown",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Result: &movetree.Result{Reason: movetree.ReasonUnknown},
+				}
+			},
+			expOut: "RE[?]",
+		},
+		{
+			desc: "no result",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{}
+			},
+			expOut: "",
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
@@ -0,0 +1,41 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/rules"
+)
+
+// ErrRules indicates an error converting the ruleset property RU.
+var ErrRules = errors.New("error converting ruleset property RU")
+
+// rulesConv converts the ruleset property RU. Known rulesets are normalized
+// (ex: RU[japanese] becomes RU[Japanese]), and unknown rulesets are kept as-is.
+var rulesConv = &SGFConverter{
+	Props: []Prop{"RU"},
+	Scope: GameInfoScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		if len(data) != 1 {
+			return fmt.Errorf("%w: ruleset only allows one prop-value, found %v", ErrRules, data)
+		}
+		if n.GameInfo == nil {
+			// For safety, make sure to set create gameinfo if it doesn't exist.
+			n.GameInfo = &movetree.GameInfo{}
+		}
+		n.GameInfo.Rules = rules.Parse(data[0])
+		return nil
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		if n.GameInfo == nil || n.GameInfo.Rules == rules.Unspecified {
+			return "", nil
+		}
+		return "RU[" + escapeText(string(n.GameInfo.Rules)) + "]", nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.GameInfo != nil {
+			n.GameInfo.Rules = rules.Unspecified
+		}
+	},
+}
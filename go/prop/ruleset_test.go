@@ -0,0 +1,59 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/rules"
+)
+
+func TestConvertFromSGF_Rules(t *testing.T) {
+	testCases := []fromSGFTestCase{
+		{
+			desc: "known ruleset",
+			prop: "RU",
+			data: []string{"japanese"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Rules: rules.Japanese}
+			},
+		},
+		{
+			desc: "unknown ruleset",
+			prop: "RU",
+			data: []string{"Zork"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Rules: rules.Ruleset("Zork")}
+			},
+		},
+		{
+			desc:        "multiple values",
+			prop:        "RU",
+			data:        []string{"Japanese", "Chinese"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrRules,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertNode_Rules(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "ruleset",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Rules: rules.Ing}
+			},
+			expOut: "RU[GOE]",
+		},
+		{
+			desc: "unspecified",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{}
+			},
+			expOut: "",
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
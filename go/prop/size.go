@@ -4,46 +4,105 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/otrego/clamshell/go/movetree"
 )
 
 var ErrSize = errors.New("error converting size property SZ")
 
-// sizeConv converts the size property SZ.
+// ErrInvalidBoardSize indicates a board size that isn't an integer between 1
+// and 25. It wraps ErrSize.
+var ErrInvalidBoardSize = fmt.Errorf("%w: invalid board size", ErrSize)
+
+// sizeConv converts the size property SZ, which is either a single size for
+// square boards (SZ[19]) or the columns and rows of a rectangular board
+// (SZ[19:9]).
 var sizeConv = &SGFConverter{
 	Props: []Prop{"SZ"},
 	Scope: RootScope,
-	From: func(n *movetree.Node, prop string, data []string) error {
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
 		if l := len(data); l != 1 {
 			return fmt.Errorf("data must be exactly 1, was %d: %w", l, ErrSize)
 		}
-		sz, err := strconv.Atoi(data[0])
-		if err != nil {
-			return fmt.Errorf("parsing data %v as integer %v: %w", data, err, ErrSize)
-		}
-		if sz < 1 || sz > 25 {
-			return fmt.Errorf("size was %d, but must be between 1 and 25 %w", sz, ErrSize)
+		dims := strings.SplitN(data[0], ":", 2)
+		var sizes []int
+		for _, d := range dims {
+			sz, err := strconv.Atoi(d)
+			if err != nil {
+				return fmt.Errorf("parsing data %v as integer %v: %w", data, err, ErrInvalidBoardSize)
+			}
+			if err := checkSize(sz); err != nil {
+				return err
+			}
+			sizes = append(sizes, sz)
 		}
 		if n.GameInfo == nil {
 			// For safety, make sure to set create gameinfo if it doesn't exist.
 			n.GameInfo = &movetree.GameInfo{}
 		}
-		n.GameInfo.Size = sz
+		n.GameInfo.Size, n.GameInfo.Width, n.GameInfo.Height = sizes[0], 0, 0
+		if len(sizes) == 2 && sizes[0] != sizes[1] {
+			n.GameInfo.Width, n.GameInfo.Height = sizes[0], sizes[1]
+			if sizes[1] > sizes[0] {
+				n.GameInfo.Size = sizes[1]
+			}
+		}
 		return nil
 	},
-	To: func(n *movetree.Node) (string, error) {
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
 		if n.GameInfo == nil {
 			return "", nil
 		}
-		sz := n.GameInfo.Size
-		if sz == 0 {
+		if n.GameInfo.Size == 0 {
 			// BoardSize is unspecified.
 			return "", nil
 		}
-		if sz < 1 || sz > 25 {
-			return "", fmt.Errorf("size was %d but only values between 1 and 25 are allowed: %w", sz, ErrSize)
+		w, h := n.GameInfo.Dimensions()
+		for _, sz := range []int{w, h} {
+			if err := checkSize(sz); err != nil {
+				return "", err
+			}
 		}
-		return "SZ[" + strconv.Itoa(sz) + "]", nil
+		if w != h {
+			return "SZ[" + strconv.Itoa(w) + ":" + strconv.Itoa(h) + "]", nil
+		}
+		return "SZ[" + strconv.Itoa(w) + "]", nil
 	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.GameInfo != nil {
+			n.GameInfo.Size, n.GameInfo.Width, n.GameInfo.Height = 0, 0, 0
+		}
+	},
+	Examples: []RoundTripExample{
+		{
+			Desc: "9x9",
+			In:   "SZ[9]",
+			Expect: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 9}
+			},
+		},
+		{
+			Desc: "19x19",
+			In:   "SZ[19]",
+			Expect: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 19}
+			},
+		},
+		{
+			Desc: "rectangular",
+			In:   "SZ[19:9]",
+			Expect: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Size: 19, Width: 19, Height: 9}
+			},
+		},
+	},
+}
+
+// checkSize checks that a board dimension is between 1 and 25.
+func checkSize(sz int) error {
+	if sz < 1 || sz > 25 {
+		return fmt.Errorf("size was %d, but must be between 1 and 25: %w", sz, ErrInvalidBoardSize)
+	}
+	return nil
 }
@@ -18,6 +18,42 @@ func TestConvertFromSGF_Size(t *testing.T) {
 				}
 			},
 		},
+		{
+			desc: "rectangular size",
+			prop: "SZ",
+			data: []string{"9:19"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Size:   19,
+					Width:  9,
+					Height: 19,
+				}
+			},
+		},
+		{
+			desc: "square size in the rectangular form",
+			prop: "SZ",
+			data: []string{"19:19"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Size: 19,
+				}
+			},
+		},
+		{
+			desc:        "rectangular size, invalid",
+			prop:        "SZ",
+			data:        []string{"19:26"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrInvalidBoardSize,
+		},
+		{
+			desc:        "rectangular size, malformed",
+			prop:        "SZ",
+			data:        []string{"19:"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrInvalidBoardSize,
+		},
 	}
 
 	testConvertFromSGFCases(t, testCases)
@@ -34,6 +70,28 @@ func TestConvertNode_Size(t *testing.T) {
 			},
 			expOut: "SZ[13]",
 		},
+		{
+			desc: "size, rectangular",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Size:   19,
+					Width:  19,
+					Height: 9,
+				}
+			},
+			expOut: "SZ[19:9]",
+		},
+		{
+			desc: "size, rectangular, invalid",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{
+					Size:   30,
+					Width:  30,
+					Height: 9,
+				}
+			},
+			expErr: ErrInvalidBoardSize,
+		},
 		{
 			desc: "size, empty",
 			makeNode: func(n *movetree.Node) {
@@ -0,0 +1,187 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ErrTime indicates an error converting a time property (TM, BL, WL, OB, OW).
+var ErrTime = errors.New("error converting time property")
+
+// timeColors are the colors of the time-left (BL, WL) and overtime-left (OB,
+// OW) properties.
+var timeColors = map[Prop]color.Color{
+	"BL": color.Black, "WL": color.White,
+	"OB": color.Black, "OW": color.White,
+}
+
+// parseSeconds parses a time in seconds, which may be fractional (ex:
+// BL[299.5]).
+func parseSeconds(prop string, data []string) (float64, error) {
+	if len(data) != 1 {
+		return 0, fmt.Errorf("%w: %s only allows one prop-value, found %v", ErrTime, prop, data)
+	}
+	t, err := strconv.ParseFloat(strings.TrimSpace(data[0]), 64)
+	if err != nil || math.IsInf(t, 0) || math.IsNaN(t) {
+		return 0, fmt.Errorf("%w: %s[%s] is not a number of seconds", ErrTime, prop, data[0])
+	}
+	return t, nil
+}
+
+// formatSeconds formats a time in seconds, with as many decimals as needed for
+// it to be parsed back to the same value.
+func formatSeconds(t float64) string {
+	return strconv.FormatFloat(t, 'f', -1, 64)
+}
+
+// keepRawTime keeps a malformed time property as a raw property when parsing
+// leniently, with a warning. Otherwise, the error is returned.
+func keepRawTime(n *movetree.Node, prop string, data []string, err error, opts *ParseOptions) error {
+	if !opts.lenient() {
+		return err
+	}
+	n.SGFProperties[prop] = data
+	return &Warning{Prop: prop, Msg: fmt.Sprintf("%v; kept as a raw property", err)}
+}
+
+// mainTimeConv converts the main time property TM, in seconds.
+var mainTimeConv = &SGFConverter{
+	Props: []Prop{"TM"},
+	Scope: GameInfoScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		t, err := parseSeconds(prop, data)
+		if err == nil && t < 0 {
+			err = fmt.Errorf("%w: TM[%s], but the main time can't be negative", ErrTime, data[0])
+		}
+		if err != nil {
+			return keepRawTime(n, prop, data, err, opts)
+		}
+		if n.GameInfo == nil {
+			// For safety, make sure to set create gameinfo if it doesn't exist.
+			n.GameInfo = &movetree.GameInfo{}
+		}
+		n.GameInfo.MainTime = &t
+		return nil
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		if n.GameInfo == nil || n.GameInfo.MainTime == nil {
+			return "", nil
+		}
+		return "TM[" + formatSeconds(*n.GameInfo.MainTime) + "]", nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		if n.GameInfo != nil {
+			n.GameInfo.MainTime = nil
+		}
+	},
+	Examples: []RoundTripExample{
+		{
+			Desc: "fractional seconds",
+			In:   "TM[1800.5]",
+			Expect: func(n *movetree.Node) {
+				tm := 1800.5
+				n.GameInfo = &movetree.GameInfo{MainTime: &tm}
+			},
+		},
+	},
+}
+
+// overtimeConv converts the overtime property OT, which describes the overtime
+// system in free text (see movetree.ParseTimeControl).
+var overtimeConv = gameInfoTextConv("OT", func(gi *movetree.GameInfo) *string { return &gi.Overtime })
+
+// timeLeftConv converts the time-left properties BL and WL, in seconds. They
+// usually appear on the node of a move, alongside B or W.
+var timeLeftConv = &SGFConverter{
+	Props: []Prop{"BL", "WL"},
+	Scope: AllScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		t, err := parseSeconds(prop, data)
+		if err != nil {
+			return keepRawTime(n, prop, data, err, opts)
+		}
+		if n.TimeLeft == nil {
+			n.TimeLeft = make(map[color.Color]float64)
+		}
+		n.TimeLeft[timeColors[Prop(prop)]] = t
+		return nil
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		var sb strings.Builder
+		for _, p := range []Prop{"BL", "WL"} {
+			if t, ok := n.TimeLeft[timeColors[p]]; ok {
+				sb.WriteString(string(p) + "[" + formatSeconds(t) + "]")
+			}
+		}
+		return sb.String(), nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		delete(n.TimeLeft, timeColors[Prop(prop)])
+		if len(n.TimeLeft) == 0 {
+			n.TimeLeft = nil
+		}
+	},
+	Examples: []RoundTripExample{
+		{
+			Desc: "fractional seconds",
+			In:   "WL[298.125]",
+			Expect: func(n *movetree.Node) {
+				n.TimeLeft = map[color.Color]float64{color.White: 298.125}
+			},
+		},
+	},
+}
+
+// overtimeLeftConv converts the overtime-left properties OB and OW: the number
+// of byo-yomi periods, or of moves in the Canadian period, that are left.
+var overtimeLeftConv = &SGFConverter{
+	Props: []Prop{"OB", "OW"},
+	Scope: AllScope,
+	From: func(n *movetree.Node, prop string, data []string, opts *ParseOptions) error {
+		var err error
+		var left int
+		if len(data) != 1 {
+			err = fmt.Errorf("%w: %s only allows one prop-value, found %v", ErrTime, prop, data)
+		} else if left, err = strconv.Atoi(strings.TrimSpace(data[0])); err != nil || left < 0 {
+			err = fmt.Errorf("%w: %s[%s] is not a number of periods or moves", ErrTime, prop, data[0])
+		}
+		if err != nil {
+			return keepRawTime(n, prop, data, err, opts)
+		}
+		if n.OvertimeLeft == nil {
+			n.OvertimeLeft = make(map[color.Color]int)
+		}
+		n.OvertimeLeft[timeColors[Prop(prop)]] = left
+		return nil
+	},
+	To: func(n *movetree.Node, opts *SerializeOptions) (string, error) {
+		var sb strings.Builder
+		for _, p := range []Prop{"OB", "OW"} {
+			if left, ok := n.OvertimeLeft[timeColors[p]]; ok {
+				sb.WriteString(string(p) + "[" + strconv.Itoa(left) + "]")
+			}
+		}
+		return sb.String(), nil
+	},
+	Clear: func(n *movetree.Node, prop string) {
+		delete(n.OvertimeLeft, timeColors[Prop(prop)])
+		if len(n.OvertimeLeft) == 0 {
+			n.OvertimeLeft = nil
+		}
+	},
+	Examples: []RoundTripExample{
+		{
+			Desc: "periods left",
+			In:   "OB[3]",
+			Expect: func(n *movetree.Node) {
+				n.OvertimeLeft = map[color.Color]int{color.Black: 3}
+			},
+		},
+	},
+}
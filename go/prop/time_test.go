@@ -0,0 +1,134 @@
+package prop
+
+import (
+	"testing"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestConvertFromSGF_Time(t *testing.T) {
+	testCases := []fromSGFTestCase{
+		{
+			desc: "main time",
+			prop: "TM",
+			data: []string{"1800"},
+			makeExpNode: func(n *movetree.Node) {
+				tm := 1800.0
+				n.GameInfo = &movetree.GameInfo{MainTime: &tm}
+			},
+		},
+		{
+			desc: "overtime",
+			prop: "OT",
+			data: []string{"5x30 byo-yomi"},
+			makeExpNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{Overtime: "5x30 byo-yomi"}
+			},
+		},
+		{
+			desc:        "negative main time",
+			prop:        "TM",
+			data:        []string{"-1"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrTime,
+		},
+		{
+			desc: "time left, alongside a move",
+			prop: "BL",
+			data: []string{"299.5"},
+			makeNode: func(n *movetree.Node) {
+				n.Move = move.New(color.Black, point.New(15, 3))
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.Move = move.New(color.Black, point.New(15, 3))
+				n.TimeLeft = map[color.Color]float64{color.Black: 299.5}
+			},
+		},
+		{
+			desc: "time left, for both players",
+			prop: "WL",
+			data: []string{"0.25"},
+			makeNode: func(n *movetree.Node) {
+				n.TimeLeft = map[color.Color]float64{color.Black: 30}
+			},
+			makeExpNode: func(n *movetree.Node) {
+				n.TimeLeft = map[color.Color]float64{color.Black: 30, color.White: 0.25}
+			},
+		},
+		{
+			desc:        "time left, not a number",
+			prop:        "BL",
+			data:        []string{"soon"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrTime,
+		},
+		{
+			desc: "time left, not a number, lenient",
+			prop: "BL",
+			data: []string{"soon"},
+			opts: &ParseOptions{Lenient: true},
+			makeExpNode: func(n *movetree.Node) {
+				n.SGFProperties["BL"] = []string{"soon"}
+			},
+			expWarn: true,
+		},
+		{
+			desc: "overtime left",
+			prop: "OW",
+			data: []string{"3"},
+			makeExpNode: func(n *movetree.Node) {
+				n.OvertimeLeft = map[color.Color]int{color.White: 3}
+			},
+		},
+		{
+			desc:        "overtime left, fractional",
+			prop:        "OB",
+			data:        []string{"2.5"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrTime,
+		},
+		{
+			desc:        "overtime left, negative",
+			prop:        "OB",
+			data:        []string{"-1"},
+			makeExpNode: func(n *movetree.Node) {},
+			expErr:      ErrTime,
+		},
+	}
+
+	testConvertFromSGFCases(t, testCases)
+}
+
+func TestConvertNode_Time(t *testing.T) {
+	testCases := []convertNodeTestCase{
+		{
+			desc: "main time and overtime",
+			makeNode: func(n *movetree.Node) {
+				tm := 600.5
+				n.GameInfo = &movetree.GameInfo{MainTime: &tm, Overtime: "3x30 byo-yomi"}
+			},
+			expOut: "TM[600.5]OT[3x30 byo-yomi]",
+		},
+		{
+			desc: "time left and overtime left",
+			makeNode: func(n *movetree.Node) {
+				n.Move = move.New(color.White, point.New(3, 15))
+				n.TimeLeft = map[color.Color]float64{color.White: 28.125, color.Black: 31}
+				n.OvertimeLeft = map[color.Color]int{color.White: 2}
+			},
+			expOut: "W[dp]BL[31]WL[28.125]OW[2]",
+		},
+		{
+			desc: "no time recorded",
+			makeNode: func(n *movetree.Node) {
+				n.GameInfo = &movetree.GameInfo{}
+			},
+			expOut: "",
+		},
+	}
+
+	testConvertNodeCases(t, testCases)
+}
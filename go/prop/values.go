@@ -0,0 +1,73 @@
+package prop
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ErrSplitValues indicates that property values could not be split.
+var ErrSplitValues = errors.New("error splitting property values")
+
+// SplitValues splits the raw text of a property's values, between the
+// property ident and the next ident (ex: "[aa][bb][cc]"), into the list of
+// values, using the same escaping rules as the parser: "\]" is an escaped
+// closing bracket, and other backslashes are kept as-is, along with the
+// character that follows them (so "\\]" doesn't end with an escape).
+// Whitespace between the values is ignored.
+func SplitValues(raw string) ([]string, error) {
+	var values []string
+	rs := []rune(raw)
+	for i := 0; i < len(rs); i++ {
+		if unicode.IsSpace(rs[i]) {
+			continue
+		}
+		if rs[i] != '[' {
+			return nil, fmt.Errorf("%w: expected '[' at offset %d of %q, but found %q", ErrSplitValues, i, raw, rs[i])
+		}
+		var sb strings.Builder
+		closed := false
+		for i++; i < len(rs); i++ {
+			if rs[i] == '\\' && i+1 < len(rs) {
+				// As in the parser, a backslash only escapes a closing bracket,
+				// but it's kept with any other following character.
+				if rs[i+1] != ']' {
+					sb.WriteRune(rs[i])
+				}
+				sb.WriteRune(rs[i+1])
+				i++
+				continue
+			}
+			if rs[i] == ']' {
+				closed = true
+				break
+			}
+			sb.WriteRune(rs[i])
+		}
+		if !closed {
+			return nil, fmt.Errorf("%w: unterminated value in %q", ErrSplitValues, raw)
+		}
+		values = append(values, sb.String())
+	}
+	return values, nil
+}
+
+// joinValues joins the values of a single-valued text property that was
+// written with several values (ex: C[line1][line2]), returning the joined
+// value and a warning. It's only used when parsing leniently. Text values (C)
+// are joined with newlines, and simple-text values (ex: PB), which can't
+// contain newlines, are joined with spaces.
+//
+// This is unlike a duplicate property (ex: C[line1]C[line2]), which the parser
+// converts again, as when parsing strictly, and reports when parsing
+// leniently.
+func joinValues(prop string, data []string, sep string) (string, *Warning) {
+	return strings.Join(data, sep), &Warning{
+		Prop: prop,
+		Msg:  fmt.Sprintf("only allows one prop-value, found %d; joined them", len(data)),
+		Code: movetree.LintMalformed,
+	}
+}
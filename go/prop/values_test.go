@@ -0,0 +1,68 @@
+package prop
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplitValues(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		raw    string
+		exp    []string
+		expErr error
+	}{
+		{
+			desc: "point list",
+			raw:  "[aa][bb][cc]",
+			exp:  []string{"aa", "bb", "cc"},
+		},
+		{
+			desc: "labels with escaped brackets",
+			raw:  `[aa:\]][bb:[x\]] [cc:a\b]`,
+			exp:  []string{"aa:]", "bb:[x]", `cc:a\b`},
+		},
+		{
+			desc: "escaped backslash",
+			raw:  `[a\\][b]`,
+			exp:  []string{`a\\`, "b"},
+		},
+		{
+			desc: "whitespace between values",
+			raw:  " [aa]\n  [bb] ",
+			exp:  []string{"aa", "bb"},
+		},
+		{
+			desc: "empty value",
+			raw:  "[]",
+			exp:  []string{""},
+		},
+		{
+			desc: "no values",
+			raw:  "",
+		},
+		{
+			desc:   "unterminated value",
+			raw:    `[aa][bb\]`,
+			expErr: ErrSplitValues,
+		},
+		{
+			desc:   "text between values",
+			raw:    "[aa]x[bb]",
+			expErr: ErrSplitValues,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := SplitValues(tc.raw)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if diff := cmp.Diff(tc.exp, got); diff != "" {
+				t.Errorf("SplitValues(%q) diff (-want +got):\n%s", tc.raw, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package prop
+
+import (
+	"fmt"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// Warning is returned by the converters when parsing leniently and a malformed
+// property value has been recovered from. Unlike other errors, the property has
+// still been converted.
+type Warning struct {
+	// Prop is the property that was malformed.
+	Prop string
+
+	// Msg describes the problem and how it was recovered from.
+	Msg string
+
+	// Code is the lint code for the problem. If empty, the problem is a
+	// malformed property value (movetree.LintMalformed).
+	Code movetree.LintCode
+}
+
+// Error returns the warning message.
+func (w *Warning) Error() string {
+	return fmt.Sprintf("warning for property %s: %s", w.Prop, w.Msg)
+}
@@ -0,0 +1,476 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	imgcolor "image/color"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"github.com/otrego/clamshell/go/bbox"
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/snapshot"
+	"github.com/otrego/clamshell/snapshot/symbol"
+)
+
+// DiagramOptions contains options for rendering diagrams. A nil
+// *DiagramOptions is valid and means that the defaults are used.
+type DiagramOptions struct {
+	// NumberFrom, if positive, numbers the moves of the node's variation from
+	// move NumberFrom to the node, as in a figure (see NewFigure). By default,
+	// the moves aren't numbered.
+	NumberFrom int
+
+	// NoMarkup leaves out the markup of the node (marks and labels), which is
+	// shown by default.
+	NoMarkup bool
+
+	// Region, if set, is the region of the board that's shown, where the
+	// bottom-right of the box is just outside of the region. By default, the
+	// whole board is shown.
+	Region *bbox.BoundingBox
+
+	// CellSize is the distance between the lines of the board in images, in
+	// pixels. Defaults to DefaultCellSize.
+	CellSize int
+}
+
+func (o *DiagramOptions) numberFrom() int {
+	if o == nil {
+		return 0
+	}
+	return o.NumberFrom
+}
+
+func (o *DiagramOptions) markup() bool {
+	return o == nil || !o.NoMarkup
+}
+
+func (o *DiagramOptions) region() *bbox.BoundingBox {
+	if o == nil {
+		return nil
+	}
+	return o.Region
+}
+
+func (o *DiagramOptions) cellSize() int {
+	if o == nil || o.CellSize <= 0 {
+		return DefaultCellSize
+	}
+	return o.CellSize
+}
+
+// Diagram is a diagram of a region of a position, with numbered moves and
+// markup, which can be rendered as text or as an image. The points of the
+// region are indexed by [y][x], from the top-left of the region.
+type Diagram struct {
+	// Top and Left are the row and column of the board where the region
+	// starts, and BoardWidth and BoardHeight are the number of columns and
+	// rows of the board, so that the edges of the board can be drawn.
+	Top, Left, BoardWidth, BoardHeight int
+
+	// Stones contains the stone shown on each point.
+	Stones [][]color.Color
+
+	// Numbers contains the number printed on the stone on each point, or 0 for
+	// plain stones.
+	Numbers [][]int
+
+	// Marks and Labels contain the mark and the label on each point, which are
+	// empty if there's none.
+	Marks  [][]movetree.MarkType
+	Labels [][]string
+
+	// Notes are the numbered moves that can't be shown on the board (see
+	// Figure.Notes).
+	Notes []string
+
+	// cellSize is the distance between the lines of the board in images.
+	cellSize int
+}
+
+// BoardDiagram renders a diagram of the board.
+func BoardDiagram(b *board.Board, opts *DiagramOptions) (*Diagram, error) {
+	return newDiagram(b.FullBoardState(), nil, nil, opts)
+}
+
+// NodeDiagram renders a diagram of the position at node n of the movetree,
+// with the markup of the node. If DiagramOptions.NumberFrom is set, the moves
+// of the node's variation from that move on are numbered. As in figures,
+// stones that are captured after they're numbered are still shown.
+func NodeDiagram(mt *movetree.MoveTree, n *movetree.Node, opts *DiagramOptions) (*Diagram, error) {
+	var variation []*movetree.Node
+	for cur := n; cur != nil; cur = cur.Parent {
+		variation = append([]*movetree.Node{cur}, variation...)
+	}
+	from := opts.numberFrom()
+	if from > n.MoveNum() {
+		return nil, fmt.Errorf("%w: can't number from move %d at move %d", ErrRender, from, n.MoveNum())
+	}
+	start := n
+	if from > 0 {
+		start = variation[from-1]
+	}
+	b, err := mt.BoardAt(start)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRender, err)
+	}
+
+	stones := b.FullBoardState()
+	numbers := make([][]int, len(stones))
+	for y, row := range stones {
+		numbers[y] = make([]int, len(row))
+	}
+	var notes []string
+	if from > 0 {
+		label := func(num int) int { return num }
+		if notes, err = placeMoves(stones, numbers, variation[from:], label, true); err != nil {
+			return nil, err
+		}
+	}
+	var anns *movetree.NodeAnnotations
+	if opts.markup() {
+		a := n.Annotations()
+		anns = &a
+	}
+	d, err := newDiagram(stones, numbers, anns, opts)
+	if err != nil {
+		return nil, err
+	}
+	d.Notes = notes
+	return d, nil
+}
+
+// newDiagram creates a diagram of the region of the board, given the stones
+// and numbers on the whole board, and the annotations, which may be nil.
+func newDiagram(stones [][]color.Color, numbers [][]int, anns *movetree.NodeAnnotations, opts *DiagramOptions) (*Diagram, error) {
+	width, height := 0, len(stones)
+	if height > 0 {
+		width = len(stones[0])
+	}
+	top, left, bottom, right := 0, 0, height, width
+	if r := opts.region(); r != nil {
+		top, left, bottom, right = r.Top(), r.Left(), r.Bottom(), r.Right()
+		if top < 0 || left < 0 || bottom > height || right > width {
+			return nil, fmt.Errorf("%w: region %v-%v is off the %dx%d board", ErrRender, r.TopLeft(), r.BotRight(), width, height)
+		}
+	}
+
+	d := &Diagram{Top: top, Left: left, BoardWidth: width, BoardHeight: height, cellSize: opts.cellSize()}
+	for y := top; y < bottom; y++ {
+		d.Stones = append(d.Stones, append([]color.Color(nil), stones[y][left:right]...))
+		nums := make([]int, right-left)
+		if numbers != nil {
+			copy(nums, numbers[y][left:right])
+		}
+		d.Numbers = append(d.Numbers, nums)
+		d.Marks = append(d.Marks, make([]movetree.MarkType, right-left))
+		d.Labels = append(d.Labels, make([]string, right-left))
+	}
+	if anns != nil {
+		for _, m := range anns.Marks {
+			if d.inRegion(m.X, m.Y) {
+				d.Marks[m.Y-top][m.X-left] = m.Type
+			}
+		}
+		for _, l := range anns.Labels {
+			if d.inRegion(l.X, l.Y) {
+				d.Labels[l.Y-top][l.X-left] = l.Text
+			}
+		}
+	}
+	return d, nil
+}
+
+// inRegion indicates whether the point x, y of the board is in the region of
+// the diagram.
+func (d *Diagram) inRegion(x, y int) bool {
+	return y >= d.Top && y < d.Top+len(d.Stones) && x >= d.Left && x < d.Left+len(d.Stones[0])
+}
+
+// markLetters are the letters of the marks in text diagrams, for black stones,
+// white stones, and empty points, as on Sensei's Library.
+var markLetters = map[movetree.MarkType][3]string{
+	movetree.MarkCircle:   {"B", "W", "C"},
+	movetree.MarkSquare:   {"#", "@", "S"},
+	movetree.MarkTriangle: {"Y", "Q", "T"},
+	movetree.MarkX:        {"Z", "P", "M"},
+}
+
+// String returns a text diagram, with X for black stones, O for white stones,
+// and . for empty points, followed by the notes. Numbers and labels are
+// printed as they are, and marks are printed with the letters used by
+// Sensei's Library (ex: Y for a black stone with a triangle, or T for an
+// empty point with a triangle).
+func (d *Diagram) String() string {
+	return d.text(func(x, y int) string {
+		c := d.Stones[y][x]
+		if m := d.Marks[y][x]; m != "" {
+			letters := markLetters[m]
+			if c == color.Black {
+				return letters[0]
+			} else if c == color.White {
+				return letters[1]
+			}
+			return letters[2]
+		}
+		switch c {
+		case color.Black:
+			return "X"
+		case color.White:
+			return "O"
+		}
+		return "."
+	})
+}
+
+// Unicode is like String, but the points are drawn with the top layer of
+// their snapshot intersection (see snapshot.Intersection): marks are drawn
+// over the stones (ex: ▴), the stones are drawn as ● and ○, and the empty
+// points are drawn as the lines of the board (ex: ╋).
+func (d *Diagram) Unicode() string {
+	return d.text(func(x, y int) string {
+		return d.intersection(x, y).TopLayerUnicodeString()
+	})
+}
+
+// intersection returns the snapshot intersection of the point x, y of the
+// region, without its number or label.
+func (d *Diagram) intersection(x, y int) *snapshot.Intersection {
+	bx, by := x+d.Left, y+d.Top
+	return &snapshot.Intersection{
+		Point: point.New(bx, by),
+		Base:  symbol.BaseFromPosition(bx, by, d.BoardWidth, d.BoardHeight),
+		Stone: symbol.StoneFromColor(d.Stones[y][x]),
+		Mark:  symbol.MarkFromType(d.Marks[y][x]),
+	}
+}
+
+// text returns a text diagram, where pointText returns the text of the point
+// x, y of the region when it has no number or label.
+func (d *Diagram) text(pointText func(x, y int) string) string {
+	var sb strings.Builder
+	for y, row := range d.Stones {
+		for x := range row {
+			cell := d.Labels[y][x]
+			if d.Numbers[y][x] != 0 {
+				cell = strconv.Itoa(d.Numbers[y][x])
+			} else if cell == "" {
+				cell = pointText(x, y)
+			}
+			// Unlike fmt's padding, pad by characters rather than bytes, so the
+			// box-drawing characters line up.
+			sb.WriteString(strings.Repeat(" ", maxInt(0, 3-len([]rune(cell)))) + cell)
+		}
+		sb.WriteString("\n")
+	}
+	for _, note := range d.Notes {
+		sb.WriteString(note + "\n")
+	}
+	return sb.String()
+}
+
+// contrast returns the color for drawing over the point: white on black
+// stones, and black otherwise.
+func contrast(c color.Color) string {
+	if c == color.Black {
+		return "white"
+	}
+	return "black"
+}
+
+// SVG renders the diagram as an SVG document. Where the region doesn't reach
+// the edge of the board, the lines run to the edge of the image.
+func (d *Diagram) SVG() []byte {
+	cell := d.cellSize
+	rows, cols := len(d.Stones), 0
+	if rows > 0 {
+		cols = len(d.Stones[0])
+	}
+	width, height := (cols+1)*cell, (rows+1)*cell
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, width, height, width, height)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="#dcb35c"/>`, width, height)
+	x1, x2, y1, y2 := d.lineEnds(cols, rows, cell)
+	for y := 1; y <= rows; y++ {
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`, x1, y*cell, x2, y*cell)
+	}
+	for x := 1; x <= cols; x++ {
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`, x*cell, y1, x*cell, y2)
+	}
+
+	r := cell * 12 / 25
+	for y, row := range d.Stones {
+		for x, c := range row {
+			cx, cy := (x+1)*cell, (y+1)*cell
+			switch c {
+			case color.Black:
+				fmt.Fprintf(&sb, `<circle cx="%d" cy="%d" r="%d" fill="black" stroke="black"/>`, cx, cy, r)
+			case color.White:
+				fmt.Fprintf(&sb, `<circle cx="%d" cy="%d" r="%d" fill="white" stroke="black"/>`, cx, cy, r)
+			}
+
+			text := d.Labels[y][x]
+			if d.Numbers[y][x] != 0 {
+				text = strconv.Itoa(d.Numbers[y][x])
+			}
+			if text != "" {
+				if c == color.Empty {
+					// Hide the lines behind the text.
+					fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="#dcb35c"/>`, cx-cell/3, cy-cell/3, cell*2/3, cell*2/3)
+				}
+				fmt.Fprintf(&sb, `<text x="%d" y="%d" font-family="sans-serif" font-size="%d" text-anchor="middle" dominant-baseline="central" fill="%s">%s</text>`,
+					cx, cy, cell/2, contrast(c), html.EscapeString(text))
+				continue
+			}
+
+			stroke, m := contrast(c), cell/4
+			switch d.Marks[y][x] {
+			case movetree.MarkCircle:
+				fmt.Fprintf(&sb, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="%s" stroke-width="2"/>`, cx, cy, m, stroke)
+			case movetree.MarkSquare:
+				fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="%s" stroke-width="2"/>`, cx-m, cy-m, 2*m, 2*m, stroke)
+			case movetree.MarkTriangle:
+				fmt.Fprintf(&sb, `<polygon points="%d,%d %d,%d %d,%d" fill="none" stroke="%s" stroke-width="2"/>`, cx, cy-m, cx-m, cy+m, cx+m, cy+m, stroke)
+			case movetree.MarkX:
+				fmt.Fprintf(&sb, `<path d="M%d %dL%d %dM%d %dL%d %d" stroke="%s" stroke-width="2"/>`, cx-m, cy-m, cx+m, cy+m, cx-m, cy+m, cx+m, cy-m, stroke)
+			}
+		}
+	}
+	sb.WriteString("</svg>\n")
+	return []byte(sb.String())
+}
+
+// lineEnds returns where the lines of the board start and end in images: at
+// the first and last lines at the edges of the board, and at the edges of the
+// image elsewhere.
+func (d *Diagram) lineEnds(cols, rows, cell int) (x1, x2, y1, y2 int) {
+	x1, x2, y1, y2 = cell, cols*cell, cell, rows*cell
+	if d.Left > 0 {
+		x1 = 0
+	}
+	if d.Left+cols < d.BoardWidth {
+		x2 = (cols + 1) * cell
+	}
+	if d.Top > 0 {
+		y1 = 0
+	}
+	if d.Top+rows < d.BoardHeight {
+		y2 = (rows + 1) * cell
+	}
+	return x1, x2, y1, y2
+}
+
+// PNG renders the diagram as a PNG image. Since the standard library has no
+// fonts, the numbers and labels aren't drawn: numbered stones are drawn as
+// plain stones. Use SVG for diagrams with numbers or labels.
+func (d *Diagram) PNG() ([]byte, error) {
+	cell := d.cellSize
+	rows, cols := len(d.Stones), 0
+	if rows > 0 {
+		cols = len(d.Stones[0])
+	}
+	width, height := (cols+1)*cell, (rows+1)*cell
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, boardColor)
+		}
+	}
+	x1, x2, y1, y2 := d.lineEnds(cols, rows, cell)
+	for y := 1; y <= rows; y++ {
+		for x := x1; x <= x2; x++ {
+			img.Set(x, y*cell, blackColor)
+		}
+	}
+	for x := 1; x <= cols; x++ {
+		for y := y1; y <= y2; y++ {
+			img.Set(x*cell, y, blackColor)
+		}
+	}
+
+	// line draws a line between two points, relative to the center of the
+	// point at x, y.
+	line := func(x, y, dx1, dy1, dx2, dy2 int, c imgcolor.Color) {
+		cx, cy := (x+1)*cell, (y+1)*cell
+		steps := maxInt(absInt(dx2-dx1), absInt(dy2-dy1))
+		for i := 0; i <= steps; i++ {
+			px, py := dx1, dy1
+			if steps > 0 {
+				px, py = dx1+(dx2-dx1)*i/steps, dy1+(dy2-dy1)*i/steps
+			}
+			img.Set(cx+px, cy+py, c)
+		}
+	}
+	// disc fills a circle (or, if inner is positive, a ring) around the center
+	// of the point at x, y.
+	disc := func(x, y, r, inner int, c imgcolor.Color) {
+		cx, cy := (x+1)*cell, (y+1)*cell
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if dd := dx*dx + dy*dy; dd <= r*r && dd >= inner*inner {
+					img.Set(cx+dx, cy+dy, c)
+				}
+			}
+		}
+	}
+
+	r, m := cell*12/25, cell/4
+	for y, row := range d.Stones {
+		for x, c := range row {
+			switch c {
+			case color.Black:
+				disc(x, y, r, 0, blackColor)
+			case color.White:
+				disc(x, y, r, 0, blackColor)
+				disc(x, y, r-1, 0, whiteColor)
+			}
+			mark := blackColor
+			if c == color.Black {
+				mark = whiteColor
+			}
+			switch d.Marks[y][x] {
+			case movetree.MarkCircle:
+				disc(x, y, m, m-2, mark)
+			case movetree.MarkSquare:
+				line(x, y, -m, -m, m, -m, mark)
+				line(x, y, m, -m, m, m, mark)
+				line(x, y, m, m, -m, m, mark)
+				line(x, y, -m, m, -m, -m, mark)
+			case movetree.MarkTriangle:
+				line(x, y, 0, -m, -m, m, mark)
+				line(x, y, -m, m, m, m, mark)
+				line(x, y, m, m, 0, -m, mark)
+			case movetree.MarkX:
+				line(x, y, -m, -m, m, m, mark)
+				line(x, y, -m, m, m, -m, mark)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRender, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
@@ -0,0 +1,141 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/otrego/clamshell/go/bbox"
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestNodeDiagram(t *testing.T) {
+	corner, err := bbox.New(point.New(0, 0), point.New(4, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		desc    string
+		opts    *DiagramOptions
+		unicode bool
+		exp     []string
+		expErr  error
+	}{
+		{
+			desc: "markup",
+			exp: []string{
+				"  .  X  O  .  .",
+				"  X  X  .  .  .",
+				"  .  .  Q  a  .",
+				"  .  .  .  O  .",
+				"  .  .  .  .  Y",
+			},
+		},
+		{
+			// Stones captured during the numbered moves are still shown.
+			desc: "numbered moves",
+			opts: &DiagramOptions{NumberFrom: 4, NoMarkup: true},
+			exp: []string{
+				"  O  X  6  .  .",
+				"  5  7  .  .  .",
+				"  .  .  8  .  .",
+				"  .  .  .  4  .",
+				"  .  .  .  .  X",
+			},
+		},
+		{
+			desc:    "unicode corner",
+			opts:    &DiagramOptions{Region: corner},
+			unicode: true,
+			exp: []string{
+				"  ┏  ●  ○  ┳",
+				"  ●  ●  ╋  ╋",
+				"  ┣  ╋  ▴  a",
+			},
+		},
+		{
+			desc:   "numbered from after the node",
+			opts:   &DiagramOptions{NumberFrom: 9},
+			expErr: ErrRender,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse("(;GM[1]SZ[5];B[ee];W[aa];B[ba];W[dd];B[ab];W[ca];B[bb];W[cc]TR[cc][ee]LB[dc:a];B[ca])")
+			if err != nil {
+				t.Fatal(err)
+			}
+			n := g.Root
+			for i := 0; i < 8; i++ {
+				n = n.Next(0)
+			}
+			d, err := NodeDiagram(g, n, tc.opts)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("NodeDiagram() got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			got := d.String()
+			if tc.unicode {
+				got = d.Unicode()
+			}
+			if exp := strings.Join(tc.exp, "\n") + "\n"; got != exp {
+				t.Errorf("got diagram\n%s\nbut expected\n%s", got, exp)
+			}
+		})
+	}
+}
+
+func TestDiagramImages(t *testing.T) {
+	b := board.New(9)
+	if err := b.SetPlacements(move.List{move.New(color.Black, point.New(2, 2))}); err != nil {
+		t.Fatal(err)
+	}
+	region, err := bbox.New(point.New(0, 0), point.New(5, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := BoardDiagram(b, &DiagramOptions{Region: region, CellSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Stones[2][2] != color.Black {
+		t.Errorf("got stone %q at 2, 2, but expected %q", d.Stones[2][2], color.Black)
+	}
+
+	svg := d.SVG()
+	if !bytes.HasPrefix(svg, []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="60" height="60"`)) {
+		t.Errorf("SVG() got %q, but expected a 60x60 SVG", svg)
+	}
+	// The lines run to the edge of the image on the cropped sides.
+	if !bytes.Contains(svg, []byte(`<line x1="10" y1="10" x2="60" y2="10"`)) {
+		t.Errorf("SVG() got %q, but expected a line to the right edge", svg)
+	}
+
+	data, err := d.PNG()
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, h := img.Bounds().Dx(), img.Bounds().Dy(); w != 60 || h != 60 {
+		t.Errorf("PNG() got a %dx%d image, but expected 60x60", w, h)
+	}
+
+	big, err := bbox.New(point.New(5, 5), point.New(10, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := BoardDiagram(b, &DiagramOptions{Region: big}); !errors.Is(err, ErrRender) {
+		t.Errorf("BoardDiagram() with a region off the board got error %v, but expected %v", err, ErrRender)
+	}
+}
@@ -0,0 +1,169 @@
+// Package render renders go positions as diagrams, such as the numbered
+// figures printed in books.
+package render
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ErrRender indicates that a diagram could not be rendered.
+var ErrRender = errors.New("error rendering diagram")
+
+// MoveRange is an inclusive range of move numbers.
+type MoveRange struct {
+	Start, End int
+}
+
+// Options contains options for rendering figures. A nil *Options is valid and
+// means that the defaults are used.
+type Options struct {
+	// MoveRange, if set, is the range of main-line moves that are numbered.
+	// The figure shows the position at the end of the range. If nil, the range
+	// starts at the last figure (FG) of the main line, or else at the first
+	// move, and ends at the end of the main line.
+	MoveRange *MoveRange
+}
+
+// Figure is a diagram of a position, where the moves of a range are
+// numbered.
+type Figure struct {
+	// Stones contains the stone shown on each point, indexed by [y][x].
+	Stones [][]color.Color
+
+	// Numbers contains the number printed on the stone on each point, or 0 for
+	// plain stones. Indexed by [y][x].
+	Numbers [][]int
+
+	// Notes are the moves that can't be shown on the board, because they
+	// were played on a point where a stone is already shown (ex: "12 at 4"),
+	// or because they are passes.
+	Notes []string
+}
+
+// NewFigure renders a figure of the main line of the movetree. The stones
+// played before the range are shown as plain stones, and the stones played
+// in the range are numbered according to the print mode (PM): PM[1] (the
+// default) prints the move numbers, PM[2] prints the move numbers modulo 100,
+// and PM[0] prints no numbers.
+//
+// As is conventional, stones captured during the range are still shown.
+func NewFigure(mt *movetree.MoveTree, opts *Options) (*Figure, error) {
+	var mainLine []*movetree.Node
+	for n := mt.Root; n != nil; n = n.Next(0) {
+		mainLine = append(mainLine, n)
+	}
+	last := mainLine[len(mainLine)-1].MoveNum()
+
+	rng := MoveRange{Start: 1, End: last}
+	if opts != nil && opts.MoveRange != nil {
+		rng = *opts.MoveRange
+	} else {
+		for _, n := range mainLine {
+			if _, ok := n.SGFProperties["FG"]; ok && n.MoveNum() > 0 {
+				rng.Start = n.MoveNum()
+			}
+		}
+	}
+	if rng.Start < 1 || rng.End < rng.Start || rng.End > last {
+		return nil, fmt.Errorf("%w: invalid move range %d-%d for a game with %d moves", ErrRender, rng.Start, rng.End, last)
+	}
+
+	b, err := mt.BoardAt(mainLine[rng.Start-1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRender, err)
+	}
+	f := &Figure{Stones: b.FullBoardState()}
+	f.Numbers = make([][]int, len(f.Stones))
+	for y, row := range f.Stones {
+		f.Numbers[y] = make([]int, len(row))
+	}
+
+	pm := 1
+	for _, n := range mainLine[:rng.End+1] {
+		if v, ok := n.SGFProperties["PM"]; ok && len(v) == 1 {
+			if pm, err = strconv.Atoi(v[0]); err != nil || pm < 0 || pm > 2 {
+				return nil, fmt.Errorf("%w: invalid print mode PM[%s]", ErrRender, v[0])
+			}
+		}
+	}
+	label := func(num int) int {
+		if pm == 2 && num > 100 {
+			return (num-1)%100 + 1
+		}
+		return num
+	}
+
+	if f.Notes, err = placeMoves(f.Stones, f.Numbers, mainLine[rng.Start:rng.End+1], label, pm != 0); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// placeMoves shows the moves of the nodes on the stones, indexed by [y][x].
+// If numbered, the moves are numbered in numbers, with the numbers from label.
+// Returns the notes for the moves that can't be shown.
+func placeMoves(stones [][]color.Color, numbers [][]int, nodes []*movetree.Node, label func(int) int, numbered bool) ([]string, error) {
+	var notes []string
+	for _, n := range nodes {
+		m := n.Move
+		if m == nil || m.Color() == color.Empty {
+			continue
+		}
+		num := label(n.MoveNum())
+		if m.IsPass() {
+			notes = append(notes, fmt.Sprintf("%d: pass", num))
+			continue
+		}
+		x, y := m.Point().X(), m.Point().Y()
+		if y >= len(stones) || x >= len(stones[y]) {
+			return nil, fmt.Errorf("%w: move %d at %v is off the board", ErrRender, n.MoveNum(), m.Point())
+		}
+		if stones[y][x] != color.Empty {
+			at, err := m.Point().ToSGF()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrRender, err)
+			}
+			if numbers[y][x] != 0 {
+				at = strconv.Itoa(numbers[y][x])
+			}
+			notes = append(notes, fmt.Sprintf("%d at %s", num, at))
+			continue
+		}
+		stones[y][x] = m.Color()
+		if numbered {
+			numbers[y][x] = num
+		}
+	}
+	return notes, nil
+}
+
+// String returns a text diagram of the figure, with X for black stones, O for
+// white stones, and . for empty points, followed by the notes.
+func (f *Figure) String() string {
+	var sb strings.Builder
+	for y, row := range f.Stones {
+		for x, c := range row {
+			cell := "."
+			switch {
+			case f.Numbers[y][x] != 0:
+				cell = strconv.Itoa(f.Numbers[y][x])
+			case c == color.Black:
+				cell = "X"
+			case c == color.White:
+				cell = "O"
+			}
+			sb.WriteString(fmt.Sprintf("%3s", cell))
+		}
+		sb.WriteString("\n")
+	}
+	for _, note := range f.Notes {
+		sb.WriteString(note + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,106 @@
+package render
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+// tenMoves is a 10-move game on a 9x9 board. Move 5 captures move 2, and move
+// 9 is played on the captured point.
+const tenMoves = "(;GM[1]SZ[9];B[ee];W[aa];B[ba];W[dd];B[ab];W[ff]%s;B[gg];W[cc];B[aa];W[hh])"
+
+func TestNewFigure(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		sgf    string
+		opts   *Options
+		exp    []string
+		expErr error
+	}{
+		{
+			desc: "all moves",
+			sgf:  strings.Replace(tenMoves, "%s", "", 1),
+			exp: []string{
+				"  2  3  .  .  .  .  .  .  .",
+				"  5  .  .  .  .  .  .  .  .",
+				"  .  .  8  .  .  .  .  .  .",
+				"  .  .  .  4  .  .  .  .  .",
+				"  .  .  .  .  1  .  .  .  .",
+				"  .  .  .  .  .  6  .  .  .",
+				"  .  .  .  .  .  .  7  .  .",
+				"  .  .  .  .  .  .  . 10  .",
+				"  .  .  .  .  .  .  .  .  .",
+				"9 at 2",
+			},
+		},
+		{
+			desc: "move range",
+			sgf:  strings.Replace(tenMoves, "%s", "", 1),
+			opts: &Options{MoveRange: &MoveRange{Start: 5, End: 9}},
+			exp: []string{
+				"  O  X  .  .  .  .  .  .  .",
+				"  5  .  .  .  .  .  .  .  .",
+				"  .  .  8  .  .  .  .  .  .",
+				"  .  .  .  O  .  .  .  .  .",
+				"  .  .  .  .  X  .  .  .  .",
+				"  .  .  .  .  .  6  .  .  .",
+				"  .  .  .  .  .  .  7  .  .",
+				"  .  .  .  .  .  .  .  .  .",
+				"  .  .  .  .  .  .  .  .  .",
+				"9 at aa",
+			},
+		},
+		{
+			desc: "figure starts at FG",
+			sgf:  strings.Replace(tenMoves, "%s", "FG[]", 1),
+			exp: []string{
+				"  9  X  .  .  .  .  .  .  .",
+				"  X  .  .  .  .  .  .  .  .",
+				"  .  .  8  .  .  .  .  .  .",
+				"  .  .  .  O  .  .  .  .  .",
+				"  .  .  .  .  X  .  .  .  .",
+				"  .  .  .  .  .  6  .  .  .",
+				"  .  .  .  .  .  .  7  .  .",
+				"  .  .  .  .  .  .  . 10  .",
+				"  .  .  .  .  .  .  .  .  .",
+			},
+		},
+		{
+			desc: "no numbers",
+			sgf:  "(;GM[1]SZ[3]PM[0];B[aa];W[cc])",
+			exp: []string{
+				"  X  .  .",
+				"  .  .  .",
+				"  .  .  O",
+			},
+		},
+		{
+			desc:   "range past the end",
+			sgf:    strings.Replace(tenMoves, "%s", "", 1),
+			opts:   &Options{MoveRange: &MoveRange{Start: 5, End: 11}},
+			expErr: ErrRender,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := NewFigure(g, tc.opts)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			exp := strings.Join(tc.exp, "\n") + "\n"
+			if got := f.String(); got != exp {
+				t.Errorf("got figure\n%s\nbut expected\n%s", got, exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	imgcolor "image/color"
+	"image/png"
+	"strings"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// ImageFormat is the format of a rendered board image.
+type ImageFormat string
+
+const (
+	// SVG renders boards as SVG documents.
+	SVG ImageFormat = "svg"
+
+	// PNG renders boards as PNG images.
+	PNG ImageFormat = "png"
+)
+
+// DefaultCellSize is the default distance between the lines of a rendered
+// board, in pixels.
+const DefaultCellSize = 24
+
+// ReplayOptions contains options for rendering replay frames. A nil
+// *ReplayOptions is valid and means that the defaults are used.
+type ReplayOptions struct {
+	// Format is the image format of the frames. Defaults to SVG.
+	Format ImageFormat
+
+	// Every, if greater than 1, renders only every Nth position of the main
+	// line (the start position, then the positions after moves N, 2N, ...).
+	// The final position is always rendered.
+	Every int
+
+	// CellSize is the distance between the lines of the board, in pixels.
+	// Defaults to DefaultCellSize.
+	CellSize int
+}
+
+func (o *ReplayOptions) format() ImageFormat {
+	if o == nil || o.Format == "" {
+		return SVG
+	}
+	return o.Format
+}
+
+func (o *ReplayOptions) every() int {
+	if o == nil || o.Every < 1 {
+		return 1
+	}
+	return o.Every
+}
+
+func (o *ReplayOptions) cellSize() int {
+	if o == nil || o.CellSize <= 0 {
+		return DefaultCellSize
+	}
+	return o.CellSize
+}
+
+// ReplayFrames renders an image of each position of the main line, starting
+// with the root, for assembling into an animation of the game. The last move
+// of each position is marked. For a game of n moves, there are n+1 frames,
+// unless ReplayOptions.Every is set.
+//
+// The positions are computed incrementally with MainLineBoards, so rendering
+// a whole game doesn't replay it once per frame.
+func ReplayFrames(mt *movetree.MoveTree, opts *ReplayOptions) ([][]byte, error) {
+	boards, err := mt.MainLineBoards()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRender, err)
+	}
+	format := opts.format()
+	if format != SVG && format != PNG {
+		return nil, fmt.Errorf("%w: unknown image format %q", ErrRender, format)
+	}
+
+	var frames [][]byte
+	n := mt.Root
+	for i, b := range boards {
+		if i > 0 {
+			n = n.Next(0)
+		}
+		if i%opts.every() != 0 && i != len(boards)-1 {
+			continue
+		}
+		var last *point.Point
+		if n.Move != nil && !n.Move.IsPass() && n.Move.Color() != color.Empty {
+			last = n.Move.Point()
+		}
+		var frame []byte
+		if format == PNG {
+			frame, err = boardPNG(b, last, opts.cellSize())
+		} else {
+			frame = boardSVG(b, last, opts.cellSize())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: at move %d: %v", ErrRender, n.MoveNum(), err)
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+// boardSVG renders the board as an SVG document, with a marker on the last
+// move, if it's non-nil.
+func boardSVG(b *board.Board, last *point.Point, cell int) []byte {
+	cols, rows := b.Width(), b.Height()
+	width, height := (cols+1)*cell, (rows+1)*cell
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, width, height, width, height)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="#dcb35c"/>`, width, height)
+	for i := 1; i <= rows; i++ {
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`, cell, i*cell, cols*cell, i*cell)
+	}
+	for i := 1; i <= cols; i++ {
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`, i*cell, cell, i*cell, rows*cell)
+	}
+	stones := b.FullBoardState()
+	for y, row := range stones {
+		for x, c := range row {
+			if c == color.Empty {
+				continue
+			}
+			fill := "black"
+			if c == color.White {
+				fill = "white"
+			}
+			fmt.Fprintf(&sb, `<circle cx="%d" cy="%d" r="%d" fill="%s" stroke="black"/>`, (x+1)*cell, (y+1)*cell, cell*12/25, fill)
+		}
+	}
+	if last != nil {
+		stroke := "white"
+		if stones[last.Y()][last.X()] == color.White {
+			stroke = "black"
+		}
+		fmt.Fprintf(&sb, `<circle class="last-move" cx="%d" cy="%d" r="%d" fill="none" stroke="%s" stroke-width="2"/>`, (last.X()+1)*cell, (last.Y()+1)*cell, cell/4, stroke)
+	}
+	sb.WriteString("</svg>\n")
+	return []byte(sb.String())
+}
+
+var (
+	boardColor = imgcolor.RGBA{0xdc, 0xb3, 0x5c, 0xff}
+	blackColor = imgcolor.RGBA{0, 0, 0, 0xff}
+	whiteColor = imgcolor.RGBA{0xff, 0xff, 0xff, 0xff}
+)
+
+// boardPNG renders the board as a PNG image, with a marker on the last move,
+// if it's non-nil.
+func boardPNG(b *board.Board, last *point.Point, cell int) ([]byte, error) {
+	cols, rows := b.Width(), b.Height()
+	width, height := (cols+1)*cell, (rows+1)*cell
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, boardColor)
+		}
+	}
+	for i := 1; i <= rows; i++ {
+		for j := cell; j <= cols*cell; j++ {
+			img.Set(j, i*cell, blackColor)
+		}
+	}
+	for i := 1; i <= cols; i++ {
+		for j := cell; j <= rows*cell; j++ {
+			img.Set(i*cell, j, blackColor)
+		}
+	}
+	// disc fills a circle (or, if inner is positive, a ring) around the
+	// center of the point at x, y.
+	disc := func(x, y, r, inner int, c imgcolor.Color) {
+		cx, cy := (x+1)*cell, (y+1)*cell
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if d := dx*dx + dy*dy; d <= r*r && d >= inner*inner {
+					img.Set(cx+dx, cy+dy, c)
+				}
+			}
+		}
+	}
+	stones := b.FullBoardState()
+	for y, row := range stones {
+		for x, c := range row {
+			switch c {
+			case color.Black:
+				disc(x, y, cell*12/25, 0, blackColor)
+			case color.White:
+				disc(x, y, cell*12/25, 0, blackColor)
+				disc(x, y, cell*12/25-1, 0, whiteColor)
+			}
+		}
+	}
+	if last != nil {
+		marker := whiteColor
+		if stones[last.Y()][last.X()] == color.White {
+			marker = blackColor
+		}
+		disc(last.X(), last.Y(), cell/4, cell/4-2, marker)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
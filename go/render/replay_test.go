@@ -0,0 +1,86 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestReplayFrames(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		sgf       string
+		opts      *ReplayOptions
+		expFrames int
+	}{
+		{
+			desc:      "every move",
+			sgf:       strings.Replace(tenMoves, "%s", "", 1),
+			expFrames: 11,
+		},
+		{
+			desc:      "every third move, with the final position",
+			sgf:       strings.Replace(tenMoves, "%s", "", 1),
+			opts:      &ReplayOptions{Every: 3},
+			expFrames: 5,
+		},
+		{
+			desc:      "png",
+			sgf:       "(;GM[1]SZ[5];B[cc];W[];B[dd])",
+			opts:      &ReplayOptions{Format: PNG, CellSize: 10},
+			expFrames: 4,
+		},
+		{
+			desc:      "no moves",
+			sgf:       "(;GM[1]SZ[9]AB[ee])",
+			expFrames: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			frames, err := ReplayFrames(g, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(frames) != tc.expFrames {
+				t.Fatalf("ReplayFrames() got %d frames, but expected %d", len(frames), tc.expFrames)
+			}
+			for i, f := range frames {
+				if tc.opts.format() == PNG {
+					img, err := png.Decode(bytes.NewReader(f))
+					if err != nil {
+						t.Fatalf("frame %d: %v", i, err)
+					}
+					if w := img.Bounds().Dx(); w != 60 {
+						t.Errorf("frame %d has width %d, but expected 60", i, w)
+					}
+					continue
+				}
+				if !bytes.HasPrefix(f, []byte("<svg")) {
+					t.Errorf("frame %d isn't an SVG: %q", i, f)
+				}
+				if marked := bytes.Contains(f, []byte("last-move")); marked != (i > 0) {
+					t.Errorf("frame %d: got last-move marker %v, but expected %v", i, marked, i > 0)
+				}
+			}
+		})
+	}
+}
+
+func TestReplayFrames_Error(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[5];B[cc])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReplayFrames(g, &ReplayOptions{Format: "gif"}); !errors.Is(err, ErrRender) {
+		t.Errorf("ReplayFrames() with an unknown format got error %v, but expected %v", err, ErrRender)
+	}
+}
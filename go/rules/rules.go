@@ -0,0 +1,91 @@
+// Package rules contains the rulesets used for playing and scoring go games.
+package rules
+
+import "strings"
+
+// Ruleset is a go ruleset. The values are the SGF names of the rulesets, as
+// they appear in the RU property.
+type Ruleset string
+
+const (
+	// Unspecified indicates that no ruleset was given.
+	Unspecified Ruleset = ""
+	// Japanese rules use territory scoring.
+	Japanese Ruleset = "Japanese"
+	// Korean rules are very similar to Japanese rules and use territory
+	// scoring.
+	Korean Ruleset = "Korean"
+	// Chinese rules use area scoring.
+	Chinese Ruleset = "Chinese"
+	// AGA rules (American Go Association) use area scoring.
+	AGA Ruleset = "AGA"
+	// Ing rules (Goe) use area scoring.
+	Ing Ruleset = "GOE"
+	// NewZealand rules use area scoring.
+	NewZealand Ruleset = "NZ"
+)
+
+// aliases maps a lower-cased rule name to its ruleset.
+var aliases = map[string]Ruleset{
+	"japanese":    Japanese,
+	"jp":          Japanese,
+	"korean":      Korean,
+	"chinese":     Chinese,
+	"cn":          Chinese,
+	"aga":         AGA,
+	"goe":         Ing,
+	"ing":         Ing,
+	"nz":          NewZealand,
+	"new zealand": NewZealand,
+	"new-zealand": NewZealand,
+}
+
+// Parse converts a rule name (typically from the RU property) into a Ruleset.
+// Matching is case-insensitive. Unknown names are returned as-is.
+func Parse(name string) Ruleset {
+	if rs, ok := aliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return rs
+	}
+	return Ruleset(name)
+}
+
+// Scoring is a scoring system.
+type Scoring string
+
+const (
+	// AreaScoring counts stones plus surrounded empty points.
+	AreaScoring Scoring = "Area"
+	// TerritoryScoring counts surrounded empty points plus prisoners.
+	TerritoryScoring Scoring = "Territory"
+)
+
+// Scoring returns the scoring system used by this ruleset. Unknown or
+// unspecified rulesets default to area scoring, which is the scoring used by
+// Tromp-Taylor rules.
+func (r Ruleset) Scoring() Scoring {
+	switch r {
+	case Japanese, Korean:
+		return TerritoryScoring
+	default:
+		return AreaScoring
+	}
+}
+
+// Superko indicates whether the ruleset forbids moves that repeat an earlier
+// board position (positional superko), rather than only the immediate
+// recapture of a ko. Unknown or unspecified rulesets forbid repetitions, as
+// Tromp-Taylor rules do.
+func (r Ruleset) Superko() bool {
+	switch r {
+	case Japanese, Korean:
+		return false
+	default:
+		return true
+	}
+}
+
+// AllowsSuicide indicates whether the ruleset allows moves that capture their
+// own group, which New Zealand and Ing rules do.
+func (r Ruleset) AllowsSuicide() bool {
+	return r == NewZealand || r == Ing
+}
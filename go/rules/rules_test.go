@@ -0,0 +1,68 @@
+package rules
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		in  string
+		exp Ruleset
+	}{
+		{in: "Japanese", exp: Japanese},
+		{in: "japanese", exp: Japanese},
+		{in: "Chinese", exp: Chinese},
+		{in: "AGA", exp: AGA},
+		{in: "GOE", exp: Ing},
+		{in: "Ing", exp: Ing},
+		{in: "NZ", exp: NewZealand},
+		{in: "Korean", exp: Korean},
+		{in: "", exp: Unspecified},
+		{in: "Zork", exp: Ruleset("Zork")},
+	}
+	for _, tc := range testCases {
+		if got := Parse(tc.in); got != tc.exp {
+			t.Errorf("Parse(%q)=%q, but expected %q", tc.in, got, tc.exp)
+		}
+	}
+}
+
+func TestScoring(t *testing.T) {
+	testCases := []struct {
+		rs  Ruleset
+		exp Scoring
+	}{
+		{rs: Japanese, exp: TerritoryScoring},
+		{rs: Korean, exp: TerritoryScoring},
+		{rs: Chinese, exp: AreaScoring},
+		{rs: AGA, exp: AreaScoring},
+		{rs: Unspecified, exp: AreaScoring},
+	}
+	for _, tc := range testCases {
+		if got := tc.rs.Scoring(); got != tc.exp {
+			t.Errorf("%q.Scoring()=%q, but expected %q", tc.rs, got, tc.exp)
+		}
+	}
+}
+
+func TestKoAndSuicide(t *testing.T) {
+	testCases := []struct {
+		rs         Ruleset
+		expSuperko bool
+		expSuicide bool
+	}{
+		{rs: Japanese},
+		{rs: Korean},
+		{rs: Chinese, expSuperko: true},
+		{rs: AGA, expSuperko: true},
+		{rs: NewZealand, expSuperko: true, expSuicide: true},
+		{rs: Ing, expSuperko: true, expSuicide: true},
+		{rs: Unspecified, expSuperko: true},
+	}
+	for _, tc := range testCases {
+		if got := tc.rs.Superko(); got != tc.expSuperko {
+			t.Errorf("%q.Superko()=%v, but expected %v", tc.rs, got, tc.expSuperko)
+		}
+		if got := tc.rs.AllowsSuicide(); got != tc.expSuicide {
+			t.Errorf("%q.AllowsSuicide()=%v, but expected %v", tc.rs, got, tc.expSuicide)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package scoring
+
+import (
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+)
+
+// bigEye is the size of an eye that's considered to have room for two eyes,
+// for estimating dead stones.
+const bigEye = 7
+
+// deadRadius is the distance (in steps between neighboring points) within
+// which stones count as being near a chain, for estimating dead stones.
+const deadRadius = 3
+
+// chain is a connected group of stones of one color.
+type chain struct {
+	color  color.Color
+	points []*point.Point
+}
+
+// EstimateDead estimates the dead stones of a finished position, for when they
+// weren't marked by the players. It's a heuristic: a chain is considered dead
+// when it has neither two eyes (empty regions bordered only by its color) nor
+// a big eye, and the opponent has more stones than the chain's player near
+// the chain. Chains are removed one at a time, starting with the most
+// outnumbered, since removing a dead chain can turn the area around it into an
+// eye of the chains surrounding it. Seki isn't recognized, so chains in seki
+// may be estimated dead.
+//
+// The points of the dead stones are returned sorted, as by move.PointSet.
+func EstimateDead(b *board.Board) []*point.Point {
+	grid := b.FullBoardState()
+	chains := findChains(grid)
+	owner := make(map[point.Point]*chain)
+	for _, c := range chains {
+		for _, pt := range c.points {
+			owner[*pt] = c
+		}
+	}
+	dead := make(map[*chain]bool)
+	for {
+		var weakest *chain
+		var weakestRatio float64
+		alive := eyeChains(grid, owner, dead)
+		for _, c := range chains {
+			if dead[c] || alive[c] {
+				continue
+			}
+			own, opp := nearbyStones(grid, c, owner, dead)
+			if ratio := float64(opp) / float64(own); opp > own && ratio > weakestRatio {
+				weakest, weakestRatio = c, ratio
+			}
+		}
+		if weakest == nil {
+			break
+		}
+		dead[weakest] = true
+	}
+
+	if len(dead) == 0 {
+		return nil
+	}
+	dp := &move.PointSet{}
+	for c := range dead {
+		for _, pt := range c.points {
+			dp.Add(pt)
+		}
+	}
+	return dp.Sorted()
+}
+
+// findChains partitions the stones of the board into chains.
+func findChains(grid [][]color.Color) []*chain {
+	height, width := len(grid), len(grid[0])
+	seen := make(map[point.Point]bool)
+	var chains []*chain
+	for y := range grid {
+		for x, col := range grid[y] {
+			if col == color.Empty || seen[*point.New(x, y)] {
+				continue
+			}
+			c := &chain{color: col}
+			stack := []*point.Point{point.New(x, y)}
+			seen[*stack[0]] = true
+			for len(stack) > 0 {
+				pt := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				c.points = append(c.points, pt)
+				for _, nb := range board.Neighbors(pt, width, height) {
+					if grid[nb.Y()][nb.X()] == col && !seen[*nb] {
+						seen[*nb] = true
+						stack = append(stack, nb)
+					}
+				}
+			}
+			chains = append(chains, c)
+		}
+	}
+	return chains
+}
+
+// eyeChains returns the living chains that have two eyes, or an eye big
+// enough for two. An eye is a connected region of empty points and dead
+// stones that only borders living stones of the chain's color.
+func eyeChains(grid [][]color.Color, owner map[point.Point]*chain, dead map[*chain]bool) map[*chain]bool {
+	height, width := len(grid), len(grid[0])
+	open := func(pt *point.Point) bool {
+		c := owner[*pt]
+		return c == nil || dead[c]
+	}
+
+	eyes := make(map[*chain]int)
+	seen := make(map[point.Point]bool)
+	for y := range grid {
+		for x := range grid[y] {
+			start := point.New(x, y)
+			if !open(start) || seen[*start] {
+				continue
+			}
+			// Flood the region, collecting the living chains that border it.
+			borders := make(map[*chain]bool)
+			colors := make(map[color.Color]bool)
+			stack := []*point.Point{start}
+			seen[*start] = true
+			size := 0
+			for len(stack) > 0 {
+				pt := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				size++
+				for _, nb := range board.Neighbors(pt, width, height) {
+					if !open(nb) {
+						borders[owner[*nb]] = true
+						colors[owner[*nb].color] = true
+					} else if !seen[*nb] {
+						seen[*nb] = true
+						stack = append(stack, nb)
+					}
+				}
+			}
+			if len(colors) != 1 {
+				continue
+			}
+			for c := range borders {
+				if size >= bigEye {
+					eyes[c] += 2
+				} else {
+					eyes[c]++
+				}
+			}
+		}
+	}
+
+	out := make(map[*chain]bool)
+	for c, n := range eyes {
+		out[c] = n >= 2
+	}
+	return out
+}
+
+// nearbyStones counts the living stones within deadRadius of chain c, for the
+// chain's color (including the chain itself) and for the opponent. The
+// distance is measured along empty points, dead stones, and stones of the
+// chain's color, so that stones on the other side of an opponent's wall
+// aren't counted.
+func nearbyStones(grid [][]color.Color, c *chain, owner map[point.Point]*chain, dead map[*chain]bool) (own, opp int) {
+	height, width := len(grid), len(grid[0])
+	dist := make(map[point.Point]int)
+	var queue []*point.Point
+	for _, pt := range c.points {
+		dist[*pt] = 0
+		queue = append(queue, pt)
+	}
+	for len(queue) > 0 {
+		pt := queue[0]
+		queue = queue[1:]
+		if o := owner[*pt]; o != nil && !dead[o] {
+			if o.color != c.color {
+				// The opponent's stones are counted, but not passed through.
+				opp++
+				continue
+			}
+			own++
+		}
+		if dist[*pt] == deadRadius {
+			continue
+		}
+		for _, nb := range board.Neighbors(pt, width, height) {
+			if _, ok := dist[*nb]; !ok {
+				dist[*nb] = dist[*pt] + 1
+				queue = append(queue, nb)
+			}
+		}
+	}
+	return own, opp
+}
@@ -0,0 +1,69 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/point"
+)
+
+func TestEstimateDead(t *testing.T) {
+	testCases := []struct {
+		desc string
+		rows []string
+		exp  []*point.Point
+	}{
+		{
+			desc: "no dead stones",
+			rows: []string{
+				"..BW.",
+				"..BW.",
+				"..BW.",
+				"..BW.",
+				"..BW."},
+		},
+		{
+			desc: "dead stone in territory",
+			rows: []string{
+				"..BW...",
+				"..BW.B.",
+				"..BW...",
+				"..BW...",
+				"..BW...",
+				"..BW...",
+				"..BW..."},
+			exp: []*point.Point{point.New(5, 1)},
+		},
+		{
+			desc: "dead group",
+			rows: []string{
+				".WB.B..",
+				"WW.B...",
+				"..B....",
+				"BB.....",
+				".......",
+				".......",
+				"......."},
+			exp: []*point.Point{point.New(0, 1), point.New(1, 0), point.New(1, 1)},
+		},
+		{
+			desc: "living group with two eyes",
+			rows: []string{
+				".W.WB..",
+				"WWWWB..",
+				"BBBB...",
+				".......",
+				".......",
+				".......",
+				"......."},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := EstimateDead(makeBoard(t, tc.rows...))
+			if diff := cmp.Diff(tc.exp, got, cmp.AllowUnexported(point.Point{})); diff != "" {
+				t.Errorf("EstimateDead got diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
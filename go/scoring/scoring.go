@@ -0,0 +1,176 @@
+// Package scoring computes the score of finished go positions.
+package scoring
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/rules"
+)
+
+// ErrScoring indicates that a position could not be scored.
+var ErrScoring = errors.New("error scoring position")
+
+// Position is a finished position that can be scored.
+type Position struct {
+	// Board is the final board position.
+	Board *board.Board
+
+	// Dead contains the points of stones that are dead. These are removed
+	// before counting and, under territory scoring, count as prisoners.
+	// Duplicate points are ignored.
+	Dead []*point.Point
+
+	// Captures contains the number of stones captured by each color during play
+	// (i.e., the prisoners held by that color). Only used for territory scoring.
+	Captures map[color.Color]int
+
+	// Passes contains the number of passes made by each color during play.
+	// Only used for AGA scoring.
+	Passes map[color.Color]int
+
+	// Komi is the compensation added to white's score.
+	Komi float64
+}
+
+// Score is the result of scoring a position.
+type Score struct {
+	// Black is black's total score.
+	Black float64
+	// White is white's total score, including komi.
+	White float64
+}
+
+// Winner returns the color of the winner, or color.Empty if the game was a
+// draw.
+func (s *Score) Winner() color.Color {
+	if s.Black > s.White {
+		return color.Black
+	} else if s.White > s.Black {
+		return color.White
+	}
+	return color.Empty
+}
+
+// Margin returns the winning margin, which is always non-negative. To record
+// the score as the result of a game (RE), see movetree.MoveTree.ScoreResult.
+func (s *Score) Margin() float64 {
+	if s.Black > s.White {
+		return s.Black - s.White
+	}
+	return s.White - s.Black
+}
+
+// String returns a string representation of the score.
+func (s *Score) String() string {
+	return fmt.Sprintf("{B:%v, W:%v}", s.Black, s.White)
+}
+
+// Compute scores the position with the scoring system of the provided ruleset.
+// AGA rules are scored with AGA.
+func Compute(rs rules.Ruleset, p *Position) (*Score, error) {
+	if rs == rules.AGA {
+		return AGA(p)
+	}
+	if rs.Scoring() == rules.TerritoryScoring {
+		return Territory(p)
+	}
+	return Area(p)
+}
+
+// Area scores a position with area scoring: each player gets a point for each
+// of their living stones and for each empty point surrounded by only their
+// stones.
+func Area(p *Position) (*Score, error) {
+	c, err := count(p)
+	if err != nil {
+		return nil, err
+	}
+	return &Score{
+		Black: float64(c.stones[color.Black] + c.territory[color.Black]),
+		White: float64(c.stones[color.White]+c.territory[color.White]) + p.Komi,
+	}, nil
+}
+
+// AGA scores a position with the AGA variant of area scoring, where each pass
+// hands the opponent a prisoner (a pass stone): each player gets their area
+// score (see Area), plus a point for each pass of their opponent.
+func AGA(p *Position) (*Score, error) {
+	s, err := Area(p)
+	if err != nil {
+		return nil, err
+	}
+	s.Black += float64(p.Passes[color.White])
+	s.White += float64(p.Passes[color.Black])
+	return s, nil
+}
+
+// Territory scores a position with territory scoring: each player gets a point
+// for each empty point surrounded by only their stones and for each prisoner,
+// which includes the dead stones left on the board.
+func Territory(p *Position) (*Score, error) {
+	c, err := count(p)
+	if err != nil {
+		return nil, err
+	}
+	blackPrisoners := p.Captures[color.Black] + c.dead[color.White]
+	whitePrisoners := p.Captures[color.White] + c.dead[color.Black]
+	return &Score{
+		Black: float64(c.territory[color.Black] + blackPrisoners),
+		White: float64(c.territory[color.White]+whitePrisoners) + p.Komi,
+	}, nil
+}
+
+// counts contains the raw counts for a position.
+type counts struct {
+	// stones are the living stones for each color.
+	stones map[color.Color]int
+	// dead are the dead stones for each color.
+	dead map[color.Color]int
+	// territory are the empty points surrounded by each color.
+	territory map[color.Color]int
+}
+
+// count removes the dead stones and counts the living stones and territory.
+func count(p *Position) (*counts, error) {
+	if p.Board == nil {
+		return nil, fmt.Errorf("%w: no board provided", ErrScoring)
+	}
+	b := p.Board.Clone()
+	c := &counts{
+		stones:    make(map[color.Color]int),
+		dead:      make(map[color.Color]int),
+		territory: make(map[color.Color]int),
+	}
+
+	grid := b.FullBoardState()
+	var removed move.List
+	for _, pt := range move.NewPointSet(p.Dead...).Sorted() {
+		if pt.Y() < 0 || pt.Y() >= len(grid) || pt.X() < 0 || pt.X() >= len(grid[pt.Y()]) {
+			return nil, fmt.Errorf("%w: dead stone %v is off the board", ErrScoring, pt)
+		}
+		col := grid[pt.Y()][pt.X()]
+		if col == color.Empty {
+			return nil, fmt.Errorf("%w: dead stone %v is on an empty point", ErrScoring, pt)
+		}
+		c.dead[col]++
+		removed = append(removed, move.New(color.Empty, pt))
+	}
+	if err := b.SetPlacements(removed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScoring, err)
+	}
+
+	for _, m := range b.StoneState() {
+		c.stones[m.Color()]++
+	}
+	for _, r := range b.Regions() {
+		if r.Owner != color.Empty {
+			c.territory[r.Owner] += len(r.Points)
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,206 @@
+package scoring
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/otrego/clamshell/go/board"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/rules"
+)
+
+// makeBoard creates a board from rows of B, W, and . characters.
+func makeBoard(t *testing.T, rows ...string) *board.Board {
+	t.Helper()
+	b := board.New(len(rows))
+	var ml move.List
+	for y, row := range rows {
+		for x, c := range row {
+			switch c {
+			case 'B':
+				ml = append(ml, move.New(color.Black, point.New(x, y)))
+			case 'W':
+				ml = append(ml, move.New(color.White, point.New(x, y)))
+			}
+		}
+	}
+	if err := b.SetPlacements(ml); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestCompute(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		rs     rules.Ruleset
+		pos    func(t *testing.T) *Position
+		exp    *Score
+		expErr error
+	}{
+		{
+			desc: "area: split board",
+			rs:   rules.Chinese,
+			pos: func(t *testing.T) *Position {
+				return &Position{
+					Board: makeBoard(t,
+						"..BW.",
+						"..BW.",
+						"..BW.",
+						"..BW.",
+						"..BW."),
+					Komi: 0.5,
+				}
+			},
+			exp: &Score{Black: 15, White: 10.5},
+		},
+		{
+			desc: "territory: split board with prisoners",
+			rs:   rules.Japanese,
+			pos: func(t *testing.T) *Position {
+				return &Position{
+					Board: makeBoard(t,
+						"..BW.",
+						"..BW.",
+						"..BW.",
+						"..BW.",
+						"..BW."),
+					Captures: map[color.Color]int{color.White: 2},
+					Komi:     0.5,
+				}
+			},
+			exp: &Score{Black: 10, White: 7.5},
+		},
+		{
+			desc: "aga: pass stones",
+			rs:   rules.AGA,
+			pos: func(t *testing.T) *Position {
+				return &Position{
+					Board: makeBoard(t,
+						"..BW.",
+						"..BW.",
+						"..BW.",
+						"..BW.",
+						"..BW."),
+					Captures: map[color.Color]int{color.White: 2},
+					Passes:   map[color.Color]int{color.Black: 1},
+					Komi:     0.5,
+				}
+			},
+			// Area scoring gives B:15, W:10.5; black's pass gives white a
+			// point, and the captures don't count.
+			exp: &Score{Black: 15, White: 11.5},
+		},
+		{
+			desc: "dame is neutral",
+			rs:   rules.Chinese,
+			pos: func(t *testing.T) *Position {
+				return &Position{
+					Board: makeBoard(t,
+						"..B.W",
+						"..B.W",
+						"..B.W",
+						"..B.W",
+						"..B.W"),
+				}
+			},
+			exp: &Score{Black: 15, White: 5},
+		},
+		{
+			desc: "territory: dead stones are prisoners",
+			rs:   rules.Japanese,
+			pos: func(t *testing.T) *Position {
+				return &Position{
+					Board: makeBoard(t,
+						".WBW.",
+						"..BW.",
+						"..BW.",
+						"..BW.",
+						"..BW."),
+					Dead: []*point.Point{point.New(1, 0)},
+				}
+			},
+			exp: &Score{Black: 11, White: 5},
+		},
+		{
+			desc: "area: dead stones are removed",
+			rs:   rules.Chinese,
+			pos: func(t *testing.T) *Position {
+				return &Position{
+					Board: makeBoard(t,
+						".WBW.",
+						"..BW.",
+						"..BW.",
+						"..BW.",
+						"..BW."),
+					Dead: []*point.Point{point.New(1, 0)},
+				}
+			},
+			exp: &Score{Black: 15, White: 10},
+		},
+		{
+			desc: "duplicate dead stones are counted once",
+			rs:   rules.Japanese,
+			pos: func(t *testing.T) *Position {
+				return &Position{
+					Board: makeBoard(t,
+						".WBW.",
+						"..BW.",
+						"..BW.",
+						"..BW.",
+						"..BW."),
+					Dead: []*point.Point{point.New(1, 0), point.New(1, 0)},
+				}
+			},
+			exp: &Score{Black: 11, White: 5},
+		},
+		{
+			desc: "dead stone on empty point",
+			rs:   rules.Chinese,
+			pos: func(t *testing.T) *Position {
+				return &Position{
+					Board: makeBoard(t, "...", "...", "..."),
+					Dead:  []*point.Point{point.New(1, 1)},
+				}
+			},
+			expErr: ErrScoring,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := Compute(tc.rs, tc.pos(t))
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if *got != *tc.exp {
+				t.Errorf("Compute(%q)=%v, but expected %v", tc.rs, got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestScoreWinner(t *testing.T) {
+	s := &Score{Black: 10, White: 6.5}
+	if got := s.Winner(); got != color.Black {
+		t.Errorf("Winner()=%v, but expected %v", got, color.Black)
+	}
+	if got := s.Margin(); got != 3.5 {
+		t.Errorf("Margin()=%v, but expected %v", got, 3.5)
+	}
+	white := &Score{Black: 10, White: 22.5}
+	if got := white.Winner(); got != color.White {
+		t.Errorf("Winner()=%v, but expected %v", got, color.White)
+	}
+	if got := white.Margin(); got != 12.5 {
+		t.Errorf("Margin()=%v, but expected %v", got, 12.5)
+	}
+	draw := &Score{Black: 6, White: 6}
+	if got := draw.Winner(); got != color.Empty {
+		t.Errorf("Winner()=%v, but expected a draw", got)
+	}
+}
@@ -0,0 +1,62 @@
+package sgf
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// NamedTree is a movetree parsed from a file in an archive.
+type NamedTree struct {
+	// Name is the path of the file within the archive.
+	Name string
+
+	// Tree is the parsed movetree. Nil if there was an error.
+	Tree *movetree.MoveTree
+
+	// Err is the error reading or parsing the file, if any.
+	Err error
+}
+
+// ParseArchive parses each .sgf file in a zip archive, including the files in
+// nested directories. Other files are skipped. The trees are returned in the
+// order of the archive's entries.
+//
+// An error in one file doesn't stop the others from being parsed; instead,
+// it's recorded in the file's NamedTree. An error is only returned if the
+// archive itself can't be read. The files may be in any charset (see
+// FromBytes).
+func ParseArchive(r io.ReaderAt, size int64) ([]NamedTree, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading archive: %v", ErrParse, err)
+	}
+	var trees []NamedTree
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".sgf") {
+			continue
+		}
+		nt := NamedTree{Name: f.Name}
+		nt.Tree, nt.Err = parseArchiveFile(f)
+		trees = append(trees, nt)
+	}
+	return trees, nil
+}
+
+// parseArchiveFile parses a file in a zip archive.
+func parseArchiveFile(f *zip.File) (*movetree.MoveTree, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBytes(data)
+}
@@ -0,0 +1,67 @@
+package sgf_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestParseArchive(t *testing.T) {
+	files := []struct {
+		name, content string
+	}{
+		{"game1.sgf", "(;GM[1]SZ[9];B[ee])"},
+		{"README.txt", "Some games"},
+		{"2016/", ""},
+		{"2016/game2.SGF", "(;GM[1]SZ[13];B[gg];W[cc])"},
+		{"2016/bad.sgf", "(;GM[1]SZ[9];B[ee]"},
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(f.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	trees, err := sgf.ParseArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, nt := range trees {
+		names = append(names, nt.Name)
+	}
+	expNames := []string{"game1.sgf", "2016/game2.SGF", "2016/bad.sgf"}
+	if !cmp.Equal(names, expNames) {
+		t.Fatalf("got files %v, but expected %v", names, expNames)
+	}
+	for _, nt := range trees[:2] {
+		if nt.Err != nil || nt.Tree == nil {
+			t.Errorf("file %s: got tree %v, and error %v, but expected a tree", nt.Name, nt.Tree, nt.Err)
+		}
+	}
+	if got := trees[1].Tree.Root.GameInfo.Size; got != 13 {
+		t.Errorf("file %s: got size %d, but expected 13", trees[1].Name, got)
+	}
+	if trees[2].Err == nil || trees[2].Tree != nil {
+		t.Errorf("file %s: got tree %v, and error %v, but expected an error", trees[2].Name, trees[2].Tree, trees[2].Err)
+	}
+}
+
+func TestParseArchive_NotAZip(t *testing.T) {
+	data := []byte("(;GM[1])")
+	if _, err := sgf.ParseArchive(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Errorf("got no error parsing a non-zip archive")
+	}
+}
@@ -0,0 +1,138 @@
+package sgf
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// ErrCharset indicates that the SGF could not be decoded to UTF-8.
+var ErrCharset = errors.New("error decoding SGF charset")
+
+// Charsets that can be detected by detectEncoding.
+const (
+	charsetUTF8     = "UTF-8"
+	charsetShiftJIS = "Shift_JIS"
+	charsetGB2312   = "GB2312"
+	charsetLatin1   = "ISO-8859-1"
+)
+
+// caRegexp finds the charset property CA. Since the charset names are ASCII,
+// this works for all ASCII-compatible charsets.
+var caRegexp = regexp.MustCompile(`(?:^|[^A-Z])CA\s*\[([^\]]*)\]`)
+
+// recordedCharset returns the charset recorded in the CA property of the SGF,
+// or the empty string if there's no CA property.
+func recordedCharset(data []byte) string {
+	m := caRegexp.FindSubmatch(data)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(string(m[1]))
+}
+
+// decode transcodes the SGF from the charset to UTF-8.
+func decode(data []byte, charset string) (string, error) {
+	if strings.EqualFold(charset, charsetUTF8) || strings.EqualFold(charset, "UTF8") {
+		return string(data), nil
+	}
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return "", fmt.Errorf("%w: unknown charset %q", ErrCharset, charset)
+	}
+	out, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", fmt.Errorf("%w: decoding as %s: %v", ErrCharset, charset, err)
+	}
+	return string(out), nil
+}
+
+// transcode transcodes the SGF from UTF-8 to the charset. It's an error if the
+// SGF contains characters that the charset can't represent.
+func transcode(s string, charset string) ([]byte, error) {
+	if strings.EqualFold(charset, charsetUTF8) || strings.EqualFold(charset, "UTF8") {
+		return []byte(s), nil
+	}
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown charset %q", ErrCharset, charset)
+	}
+	out, err := enc.NewEncoder().Bytes([]byte(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: encoding as %s: %v", ErrCharset, charset, err)
+	}
+	return out, nil
+}
+
+// detectEncoding guesses the charset of an SGF without a CA property, from
+// its byte patterns. The guess is conservative: valid UTF-8 is always taken
+// to be UTF-8, and Latin-1, which any bytes are valid in, is the fallback.
+//
+// Shift_JIS overlaps with both GB2312 (EUC-CN) and Latin-1: most GB2312 text
+// is also valid Shift_JIS, as are many accented Latin-1 letters followed by
+// ASCII. However, the Shift_JIS lead bytes 0x81-0x9F are the lead bytes of the
+// kana and the common kanji, and can't occur in GB2312 or in Latin-1 text, so
+// only text containing them is taken to be Shift_JIS.
+func detectEncoding(data []byte) string {
+	if utf8.Valid(data) {
+		return charsetUTF8
+	}
+	if validShiftJIS(data) {
+		return charsetShiftJIS
+	}
+	if validEUC(data) {
+		return charsetGB2312
+	}
+	return charsetLatin1
+}
+
+// validShiftJIS indicates whether the data is valid Shift_JIS that contains a
+// double-byte character with a lead byte between 0x81 and 0x9F.
+func validShiftJIS(data []byte) bool {
+	lowLead := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c < 0x80, c >= 0xA1 && c <= 0xDF:
+			// ASCII or half-width katakana.
+		case c >= 0x81 && c <= 0x9F, c >= 0xE0 && c <= 0xFC:
+			if i+1 >= len(data) {
+				return false
+			}
+			t := data[i+1]
+			if t < 0x40 || t == 0x7F || t > 0xFC {
+				return false
+			}
+			if c <= 0x9F {
+				lowLead = true
+			}
+			i++
+		default:
+			return false
+		}
+	}
+	return lowLead
+}
+
+// validEUC indicates whether the data is valid EUC-CN (GB2312), where each
+// non-ASCII character is a pair of bytes between 0xA1 and 0xFE.
+func validEUC(data []byte) bool {
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c < 0x80 {
+			continue
+		}
+		if c < 0xA1 || c > 0xFE || i+1 >= len(data) {
+			return false
+		}
+		if t := data[i+1]; t < 0xA1 || t > 0xFE {
+			return false
+		}
+		i++
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+package sgf
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func encode(t *testing.T, enc encoding.Encoding, s string) []byte {
+	t.Helper()
+	out, err := enc.NewEncoder().Bytes([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestDetectEncoding(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data func(t *testing.T) []byte
+		exp  string
+	}{
+		{
+			desc: "ascii",
+			data: func(t *testing.T) []byte { return []byte("(;GM[1]PB[Honinbo])") },
+			exp:  charsetUTF8,
+		},
+		{
+			desc: "utf-8",
+			data: func(t *testing.T) []byte { return []byte("(;GM[1]PB[本因坊秀策])") },
+			exp:  charsetUTF8,
+		},
+		{
+			desc: "shift_jis",
+			data: func(t *testing.T) []byte {
+				return encode(t, japanese.ShiftJIS, "(;GM[1]PB[本因坊秀策]C[こんにちは])")
+			},
+			exp: charsetShiftJIS,
+		},
+		{
+			desc: "gb2312",
+			data: func(t *testing.T) []byte {
+				return encode(t, simplifiedchinese.GBK, "(;GM[1]PB[聂卫平]C[围棋])")
+			},
+			exp: charsetGB2312,
+		},
+		{
+			desc: "latin-1",
+			data: func(t *testing.T) []byte {
+				return encode(t, charmap.ISO8859_1, "(;GM[1]C[Café])")
+			},
+			exp: charsetLatin1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := detectEncoding(tc.data(t)); got != tc.exp {
+				t.Errorf("detectEncoding()=%q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestSerializeCharset(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		charset string
+		enc     encoding.Encoding
+		expErr  error
+	}{
+		{desc: "shift_jis", charset: "Shift_JIS", enc: japanese.ShiftJIS},
+		{desc: "euc-kr", charset: "EUC-KR", enc: korean.EUCKR},
+		{desc: "utf-8", charset: "UTF-8", enc: encoding.Nop},
+		{desc: "unrepresentable", charset: "ISO-8859-1", expErr: ErrCharset},
+		{desc: "unknown", charset: "Klingon", expErr: ErrCharset},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := Parse("(;GM[1]PB[이창호]PW[本因坊秀策])")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.charset == "Shift_JIS" {
+				// Shift_JIS has no hangul.
+				g.Root.GameInfo.BlackPlayer = "秀哉"
+			}
+			got, err := SerializeCharset(g, nil, tc.charset)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			exp := "(;FF[4]GM[1]CA[" + tc.charset + "]SZ[19]PB[" + g.Root.GameInfo.BlackPlayer + "]PW[本因坊秀策])"
+			if string(got) != string(encode(t, tc.enc, exp)) {
+				t.Errorf("SerializeCharset()=%q, but expected %q encoded as %s", got, exp, tc.charset)
+			}
+			if g.Root.SGFProperties["CA"][0] != charsetUTF8 {
+				t.Errorf("the movetree's charset was changed to %v", g.Root.SGFProperties["CA"])
+			}
+
+			// Parsing the encoded SGF gives back the UTF-8 text.
+			rt, err := ParseBytes(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rt.Root.GameInfo.WhitePlayer != "本因坊秀策" {
+				t.Errorf("after a round trip, got white player %q", rt.Root.GameInfo.WhitePlayer)
+			}
+		})
+	}
+}
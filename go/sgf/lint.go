@@ -0,0 +1,56 @@
+package sgf
+
+import (
+	"errors"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+)
+
+// Lint parses an SGF leniently and reports all of its issues in one pass: the
+// problems that were recovered from while parsing (such as duplicate
+// properties), followed by the issues found by movetree.Lint. Problems that are
+// both recovered from and found in the movetree, such as a root property on a
+// move, are only reported once. An error is only returned if the SGF can't be
+// parsed at all.
+func Lint(s string) ([]movetree.LintIssue, error) {
+	p := FromString(s).WithOptions(&prop.ParseOptions{Lenient: true})
+	g, err := p.Parse()
+	if err != nil {
+		return nil, err
+	}
+	type key struct {
+		code movetree.LintCode
+		path string
+		prop string
+	}
+	found := make(map[key]bool)
+	var issues []movetree.LintIssue
+	for _, w := range p.Warnings() {
+		issue := movetree.LintIssue{
+			Code:     movetree.LintMalformed,
+			Severity: movetree.SeverityWarning,
+			Path:     w.Path,
+			Msg:      w.Err.Error(),
+		}
+		var pw *prop.Warning
+		if errors.As(w.Err, &pw) {
+			issue.Prop = pw.Prop
+			issue.Msg = pw.Msg
+			if pw.Code != "" {
+				issue.Code = pw.Code
+				issue.Severity = movetree.SeverityError
+			}
+		}
+		found[key{issue.Code, issue.Path.String(), issue.Prop}] = true
+		issues = append(issues, issue)
+	}
+	for _, issue := range g.Lint() {
+		// Some problems, such as misplaced root properties, are both recovered
+		// from while parsing and found in the movetree.
+		if !found[key{issue.Code, issue.Path.String(), issue.Prop}] {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
@@ -0,0 +1,81 @@
+package sgf_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestLint(t *testing.T) {
+	type issue struct {
+		Code movetree.LintCode
+		Path string
+		Prop string
+	}
+	testCases := []struct {
+		desc string
+		sgf  string
+		exp  []issue
+	}{
+		{
+			desc: "clean",
+			sgf:  "(;GM[1]SZ[9]HA[2]AB[cc][gg];W[ee])",
+		},
+		{
+			desc: "three defects",
+			sgf:  "(;GM[1]SZ[9]XX[foo];B[ee]W[dd];W[cc]C[a]C[b])",
+			exp: []issue{
+				{Code: movetree.LintTwoMoves, Path: "-0", Prop: "W"},
+				{Code: movetree.LintDuplicateProp, Path: "-0x2", Prop: "C"},
+				{Code: movetree.LintUnknownProp, Path: "-", Prop: "XX"},
+			},
+		},
+		{
+			desc: "scope, off-board, and handicap",
+			sgf:  "(;GM[1]SZ[9]HA[3]AB[cc][gg](;B[jj])(;W[ee]PB[Honinbo]))",
+			exp: []issue{
+				{Code: movetree.LintHandicap, Path: "-", Prop: "HA"},
+				{Code: movetree.LintOffBoard, Path: "-0", Prop: "B"},
+				{Code: movetree.LintScope, Path: "-1", Prop: "PB"},
+			},
+		},
+		{
+			desc: "root property on a move, reported once",
+			sgf:  "(;GM[1];B[aa]SZ[9])",
+			exp: []issue{
+				{Code: movetree.LintScope, Path: "-0", Prop: "SZ"},
+			},
+		},
+		{
+			desc: "point labeled twice",
+			sgf:  "(;GM[1]SZ[9]LB[aa:x][aa:y];B[ee])",
+			exp: []issue{
+				{Code: movetree.LintDuplicateProp, Path: "-", Prop: "LB"},
+			},
+		},
+		{
+			desc: "malformed result",
+			sgf:  "(;GM[1]SZ[9]RE[foo];B[ee])",
+			exp: []issue{
+				{Code: movetree.LintMalformed, Path: "-", Prop: "RE"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			issues, err := sgf.Lint(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []issue
+			for _, li := range issues {
+				got = append(got, issue{Code: li.Code, Path: li.Path.CompactString(), Prop: li.Prop})
+			}
+			if !cmp.Equal(got, tc.exp) {
+				t.Errorf("Lint()=%v, but expected %v. Diff=%s", issues, tc.exp, cmp.Diff(got, tc.exp))
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package sgf
+
+import (
+	"io"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+)
+
+// DefaultApplication is the application stamped into the AP property by
+// WriteNormalized.
+var DefaultApplication = movetree.Application{Name: "clamshell"}
+
+// NormalizedOptions are options for WriteNormalized. A nil *NormalizedOptions
+// uses the defaults.
+type NormalizedOptions struct {
+	// Application is the application stamped into the AP property. Defaults to
+	// DefaultApplication.
+	Application *movetree.Application
+
+	// Normalize are the options for normalizing the structure of the movetree
+	// (see movetree.NormalizeWithOptions).
+	Normalize *movetree.NormalizeOptions
+
+	// Serialize are the options for writing the SGF. Point lists are always
+	// compressed.
+	Serialize *prop.SerializeOptions
+}
+
+// WriteNormalized writes a canonical SGF for the movetree, so that SGFs of the
+// same game are written identically, however they were formatted. It's a
+// single entry point for the finer-grained options:
+//
+//   - The structure is normalized (see movetree.NormalizeWithOptions).
+//   - The file format is FF[4], so legacy properties are written in their
+//     FF[4] form, and the charset is UTF-8 (CA[UTF-8]).
+//   - The application is stamped into AP.
+//   - Point lists are compressed (see prop.SerializeOptions).
+//
+// The properties are always written in the same order. The movetree itself is
+// left unmodified.
+func WriteNormalized(w io.Writer, g *movetree.MoveTree, opts *NormalizedOptions) error {
+	if opts == nil {
+		opts = &NormalizedOptions{}
+	}
+	g = g.Clone()
+	g.NormalizeWithOptions(opts.Normalize)
+
+	root := g.Root
+	root.SGFProperties["GM"] = []string{"1"}
+	root.SGFProperties["FF"] = []string{"4"}
+	root.SGFProperties["CA"] = []string{charsetUTF8}
+	delete(root.SGFProperties, "AP")
+	if root.GameInfo == nil {
+		root.GameInfo = &movetree.GameInfo{}
+	}
+	app := DefaultApplication
+	if opts.Application != nil {
+		app = *opts.Application
+	}
+	root.GameInfo.Application = &app
+
+	sopts := &prop.SerializeOptions{}
+	if opts.Serialize != nil {
+		*sopts = *opts.Serialize
+	}
+	sopts.CompressPointLists = true
+	s, err := SerializeWithOptions(g, sopts)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, s)
+	return err
+}
@@ -0,0 +1,53 @@
+package sgf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestWriteNormalized(t *testing.T) {
+	inputs := []string{
+		"(;GM[1]FF[4]CA[UTF-8]AP[CGoban:3]SZ[9]PB[Black]PW[White]KM[6.5]AB[aa][ab][ba][bb]\n;W[ee]MA[cc];B[dd];W[])",
+		"(;FF[3]GM[1]\r\nSZ[9]KM[6.5]PW[White]PB[Black]AP[SmartGo:1]\r\nAB[bb][ba][ab][aa];W[ee]M[cc];B[dd])",
+		"(;CA[UTF-8]SZ[9]PB[Black]PW[White]KM[6.5]AB[aa:bb];W[ee]MA[cc]\n;B[dd]\n)",
+	}
+	exp := "(;FF[4]GM[1]CA[UTF-8]AP[clamshell]SZ[9]PB[Black]PW[White]KM[6.5]AB[aa:bb];W[ee]MA[cc];B[dd])"
+	for _, in := range inputs {
+		g, err := sgf.ParseBytes([]byte(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		orig, err := sgf.Serialize(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := sgf.WriteNormalized(&buf, g, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != exp {
+			t.Errorf("WriteNormalized(%q)=%q, but expected %q", in, got, exp)
+		}
+		if after, err := sgf.Serialize(g); err != nil || after != orig {
+			t.Errorf("WriteNormalized(%q) modified the movetree to %q, but expected %q", in, after, orig)
+		}
+	}
+}
+
+func TestWriteNormalized_Application(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]AP[CGoban:3];B[aa])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	opts := &sgf.NormalizedOptions{Application: &movetree.Application{Name: "archiver", Version: "2.1"}}
+	if err := sgf.WriteNormalized(&buf, g, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := buf.String(), "(;FF[4]GM[1]CA[UTF-8]AP[archiver:2.1]SZ[19];B[aa])"; got != exp {
+		t.Errorf("WriteNormalized()=%q, but expected %q", got, exp)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"unicode"
 
@@ -13,14 +14,80 @@ import (
 
 var ErrParse = errors.New("error parsing SGF")
 
+// ErrTruncated indicates that the SGF ended before all of its variations were
+// closed. When parsing leniently, the variations are closed, with a warning.
+var ErrTruncated = errors.New("SGF ended with unclosed variations")
+
+// ErrLimitExceeded indicates that the SGF exceeded one of the parsing limits
+// set by the options (see prop.ParseOptions.MaxDepth).
+var ErrLimitExceeded = errors.New("SGF exceeded a parsing limit")
+
+// ErrSyntax indicates malformed SGF syntax that was recovered from, which is
+// only done when parsing leniently. The recoveries are:
+//
+//   - A byte order mark, or other text, before the first variation is skipped.
+//   - A node that's missing its semicolon at the start of a variation (ex:
+//     "(B[aa]") is started anyway.
+//   - A property name with lowercase letters, as written by FF[1-3] files, is
+//     converted to its uppercase letters (ex: AddBlack becomes AB), or else is
+//     uppercased (ex: b becomes B).
+//   - An unescaped ']' in a comment (C or GC) or a node name (N) is kept as
+//     text, when it isn't followed by something that can follow a property
+//     value (ex: "C[see [1] below]").
+//   - Other stray characters between properties are skipped.
+var ErrSyntax = errors.New("malformed SGF syntax")
+
+// textProps are the properties whose values might contain an unescaped ']'.
+var textProps = map[string]bool{"C": true, "GC": true, "N": true}
+
 // Parse is a convenience helper to parse sgf strings.
 func Parse(s string) (*movetree.MoveTree, error) {
 	return FromString(s).Parse()
 }
 
+// ParseBytes is a convenience helper to parse SGFs in any charset.
+func ParseBytes(data []byte) (*movetree.MoveTree, error) {
+	return FromBytes(data).Parse()
+}
+
 // Parser parses SGFs into MoveTree objects.
 type Parser struct {
-	rdr io.RuneReader
+	rdr  io.RuneReader
+	opts *prop.ParseOptions
+
+	// data is the raw SGF, when the parser was created with FromBytes. It's
+	// decoded to UTF-8 when parsing.
+	data []byte
+
+	// warnings from the last parse.
+	warnings []*Warning
+}
+
+// Warning is a problem with the SGF that was recovered from, which is only
+// done when parsing leniently.
+type Warning struct {
+	// Line and Column indicate where the problem was found.
+	Line, Column int
+
+	// Offset is the byte offset in the (UTF-8) SGF where the problem was found.
+	Offset int
+
+	// Path is the path to the node where the problem was found.
+	Path movetree.Path
+
+	// Err describes the problem. For problems with properties, Err is a
+	// *prop.Warning.
+	Err error
+}
+
+// Error returns the warning message.
+func (w *Warning) Error() string {
+	return fmt.Sprintf("at line %v, column %v: %v", w.Line, w.Column, w.Err)
+}
+
+// Unwrap returns the underlying problem.
+func (w *Warning) Unwrap() error {
+	return w.Err
 }
 
 // FromString creates a parser from a string.
@@ -35,6 +102,28 @@ func FromReader(r *strings.Reader) *Parser {
 	}
 }
 
+// FromBytes creates a parser from a raw SGF, which might not be UTF-8. The SGF
+// is decoded from the charset given by the options, or else the charset in
+// the CA property, or else a charset guessed from the bytes. Since the parsed
+// text is UTF-8, the root's CA property is set to UTF-8.
+func FromBytes(data []byte) *Parser {
+	return &Parser{
+		data: data,
+	}
+}
+
+// WithOptions sets the options used for parsing properties.
+func (p *Parser) WithOptions(opts *prop.ParseOptions) *Parser {
+	p.opts = opts
+	return p
+}
+
+// Warnings returns the problems that were recovered from during the last
+// parse.
+func (p *Parser) Warnings() []*Warning {
+	return p.warnings
+}
+
 // stateData contains the current parser state.
 type stateData struct {
 	idx, row, col int
@@ -42,16 +131,53 @@ type stateData struct {
 	prevchar      rune
 	curstate      parseState
 
+	// input is the SGF, and offsets are the byte offsets of its characters
+	// and of its end. pos is the position of the current character.
+	input   []rune
+	offsets []int
+	pos     int
+
+	// lenient indicates whether malformed syntax should be recovered from.
+	lenient bool
+
+	// skipped is one past the position of the last stray character that was
+	// skipped, or 0 if none were, so that a run of them is only warned about
+	// once.
+	skipped int
+
+	// newBranch indicates that a variation was just started, and its first
+	// node is expected.
+	newBranch bool
+
 	// tmp buffer for holding an escape char '\' during property data.
 	holdChar rune
 	buf      strings.Builder
 
 	branches []*movetree.Node
 	curnode  *movetree.Node
+
+	// nodes is the number of nodes so far.
+	nodes int
+
+	// The parsing limits, which are negative if there's no limit.
+	maxDepth, maxValueBytes, maxNodes int
 }
 
-func (sd *stateData) addBranch(n *movetree.Node) {
+func (sd *stateData) addBranch(n *movetree.Node) error {
+	if sd.maxDepth >= 0 && len(sd.branches) >= sd.maxDepth {
+		return sd.propError(fmt.Errorf("%w: variations are nested more than %d deep", ErrLimitExceeded, sd.maxDepth))
+	}
 	sd.branches = append(sd.branches, n)
+	return nil
+}
+
+// addNode counts a new node.
+func (sd *stateData) addNode() error {
+	if sd.maxNodes >= 0 && sd.nodes >= sd.maxNodes {
+		return sd.propError(fmt.Errorf("%w: more than %d nodes", ErrLimitExceeded, sd.maxNodes))
+	}
+	sd.nodes++
+	return nil
 }
 
 func (sd *stateData) popBranch() (*movetree.Node, error) {
@@ -63,6 +189,88 @@ func (sd *stateData) popBranch() (*movetree.Node, error) {
 	return parent, nil
 }
 
+// offset returns the byte offset of the current character.
+func (sd *stateData) offset() int {
+	if sd.pos >= len(sd.offsets) {
+		return 0
+	}
+	return sd.offsets[sd.pos]
+}
+
+// warn adds a warning for malformed syntax at the current character, which was
+// recovered from.
+func (sd *stateData) warn(pbuf *propBuffer, msg string) {
+	path := movetree.Path{}
+	if sd.curnode != nil {
+		path = movetree.PathTo(sd.curnode)
+	}
+	pbuf.warnings = append(pbuf.warnings, &Warning{
+		Line:   sd.row,
+		Column: sd.col,
+		Offset: sd.offset(),
+		Path:   path,
+		Err:    fmt.Errorf("%w: %s", ErrSyntax, msg),
+	})
+}
+
+// skip skips a stray character, warning about the first of a run of them
+// (which may contain whitespace).
+func (sd *stateData) skip(pbuf *propBuffer, where string) {
+	if sd.skipped == 0 || strings.TrimSpace(string(sd.input[sd.skipped:sd.pos])) != "" {
+		sd.warn(pbuf, fmt.Sprintf("skipped stray character %q %s", sd.curchar, where))
+	}
+	sd.skipped = sd.pos + 1
+}
+
+// valueEnds indicates whether the current ']' ends a property value, rather
+// than being an unescaped ']' in the value, by looking ahead for something
+// that can follow a property value: another value, a property, a node, a
+// variation, or the end of the SGF.
+func (sd *stateData) valueEnds() bool {
+	i := sd.pos + 1
+	for i < len(sd.input) && unicode.IsSpace(sd.input[i]) {
+		i++
+	}
+	if i == len(sd.input) {
+		return true
+	}
+	switch sd.input[i] {
+	case lbrace, lparen, rparen, scolon:
+		return true
+	}
+	if !unicode.IsLetter(sd.input[i]) {
+		return false
+	}
+	for i < len(sd.input) && unicode.IsLetter(sd.input[i]) {
+		i++
+	}
+	for i < len(sd.input) && unicode.IsSpace(sd.input[i]) {
+		i++
+	}
+	return i < len(sd.input) && sd.input[i] == lbrace
+}
+
+// propName returns the property name in the buffer. When parsing leniently,
+// a name with lowercase letters is converted to its uppercase letters, or else
+// is uppercased.
+func (sd *stateData) propName(pbuf *propBuffer) string {
+	name := sd.flushBuf()
+	if !sd.lenient || strings.ToUpper(name) == name {
+		return name
+	}
+	upper := strings.Map(func(r rune) rune {
+		if unicode.IsUpper(r) {
+			return r
+		}
+		return -1
+	}, name)
+	if upper == "" {
+		upper = strings.ToUpper(name)
+	}
+	sd.warn(pbuf, fmt.Sprintf("converted property %s to %s", name, upper))
+	return upper
+}
+
 func (sd *stateData) addToBuf(c rune) {
 	sd.buf.WriteRune(c)
 }
@@ -73,6 +281,53 @@ func (sd *stateData) flushBuf() string {
 	return o
 }
 
+// ParseError is an error converting the properties of a node while parsing,
+// or an exceeded parsing limit. It matches ErrParse with errors.Is, and unwraps
+// to the error from the converter (ex: prop.ErrInvalidBoardSize) or to
+// ErrLimitExceeded, so callers can decide how to handle each kind of error.
+type ParseError struct {
+	// Line and Column indicate where the error was found.
+	Line, Column int
+
+	// Offset is the byte offset in the (UTF-8) SGF where the error was found.
+	Offset int
+
+	// Err is the error from converting the properties, or an error wrapping
+	// ErrLimitExceeded.
+	Err error
+
+	// msg is the full error message, with the parser state.
+	msg string
+}
+
+// Error returns the error message.
+func (e *ParseError) Error() string {
+	return e.msg
+}
+
+// Is indicates whether the target is ErrParse.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// Unwrap returns the error from converting the properties, or the exceeded
+// limit.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// propError creates a parsing error for an error converting properties, or for
+// an exceeded limit.
+func (sd *stateData) propError(err error) error {
+	return &ParseError{
+		Line:   sd.row,
+		Column: sd.col,
+		Offset: sd.offset(),
+		Err:    err,
+		msg:    sd.parseError(err.Error()).Error(),
+	}
+}
+
 // parseError creates a parsing error, which fives index, line, col and
 // charector context
 func (sd *stateData) parseError(msg string) error {
@@ -85,21 +340,96 @@ func (sd *stateData) parseError(msg string) error {
 type propBuffer struct {
 	prop     string
 	propdata []string
+
+	// row, col, and offset are the location of the property.
+	row, col, offset int
+
+	// pending contains the complete properties of the current node. The
+	// properties of a node are processed together, once the node ends, so that
+	// they can be processed in dependency order.
+	pending []*pendingProp
+
+	opts     *prop.ParseOptions
+	warnings []*Warning
 }
 
-func (b *propBuffer) flush(n *movetree.Node) error {
+// pendingProp is a property that has yet to be processed.
+type pendingProp struct {
+	prop             string
+	propdata         []string
+	row, col, offset int
+}
+
+// endProp ends the current property, adding it to the pending properties.
+func (b *propBuffer) endProp() {
 	if b.prop != "" && len(b.propdata) != 0 {
-		if err := prop.ProcessPropertyData(n, b.prop, b.propdata); err != nil {
-			return err
-		}
+		b.pending = append(b.pending, &pendingProp{
+			prop:     b.prop,
+			propdata: b.propdata,
+			row:      b.row,
+			col:      b.col,
+			offset:   b.offset,
+		})
 	}
 	b.prop = ""
 	b.propdata = []string{}
-	return nil
+}
+
+// flush processes the pending properties for node n.
+func (b *propBuffer) flush(n *movetree.Node) error {
+	b.endProp()
+	pending := b.pending
+	b.pending = nil
+	sort.SliceStable(pending, func(i, j int) bool {
+		return prop.ProcessOrder(pending[i].prop) < prop.ProcessOrder(pending[j].prop)
+	})
+	seen := make(map[string]bool)
+	for _, p := range pending {
+		// A duplicate property is converted as when parsing strictly (ex: the
+		// points of AB[aa]AB[bb] are merged, and the last komi of
+		// KM[6.5]KM[7] is kept), and is only reported when parsing leniently.
+		dup := seen[p.prop]
+		seen[p.prop] = true
+		err := prop.ProcessPropertyDataWithOptions(n, p.prop, p.propdata, b.opts)
+		var warn *prop.Warning
+		if errors.As(err, &warn) {
+			if dup && warn.Code == "" {
+				// The duplicate can't be converted (ex: a second comment), so
+				// it's kept raw, which is reported as a duplicate.
+				warn.Code = movetree.LintDuplicateProp
+			}
+			b.addWarning(n, p, warn)
+		} else if err != nil {
+			return err
+		} else if dup && b.opts != nil && b.opts.Lenient {
+			b.addWarning(n, p, &prop.Warning{
+				Prop: p.prop,
+				Msg:  fmt.Sprintf("duplicate property %s%v", p.prop, p.propdata),
+				Code: movetree.LintDuplicateProp,
+			})
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	return prop.ExtractAnalysis(n, b.opts)
+}
+
+// addWarning adds a warning for property p of node n.
+func (b *propBuffer) addWarning(n *movetree.Node, p *pendingProp, warn *prop.Warning) {
+	b.warnings = append(b.warnings, &Warning{
+		Line:   p.row,
+		Column: p.col,
+		Offset: p.offset,
+		Path:   movetree.PathTo(n),
+		Err:    warn,
+	})
 }
 
 func (b *propBuffer) addToData(s string) {
-	b.propdata = append(b.propdata, s)
+	// Normalize CRLF line endings, so that SGFs written on Windows have the
+	// same property values.
+	b.propdata = append(b.propdata, strings.ReplaceAll(s, "\r\n", "\n"))
 }
 
 // special chars, used to delimit sections of the SGF.
@@ -142,9 +472,31 @@ func (p parseState) String() string {
 // Parse parses a movetree into a tree of moves, return a movetree or a parsing
 // error.
 func (p *Parser) Parse() (*movetree.MoveTree, error) {
+	charset := ""
+	if p.data != nil {
+		charset = p.opts.CharsetOverride()
+		if charset == "" {
+			charset = recordedCharset(p.data)
+		}
+		if charset == "" {
+			charset = detectEncoding(p.data)
+		}
+		s, err := decode(p.data, charset)
+		if err != nil {
+			return nil, err
+		}
+		p.rdr = strings.NewReader(s)
+	}
+
 	g := movetree.New()
-	stateData := &stateData{}
-	pbuf := &propBuffer{}
+	stateData := &stateData{
+		maxDepth:      p.opts.DepthLimit(),
+		maxValueBytes: p.opts.ValueBytesLimit(),
+		maxNodes:      p.opts.NodeLimit(),
+		lenient:       p.opts != nil && p.opts.Lenient,
+	}
+	pbuf := &propBuffer{opts: p.opts}
+	p.warnings = nil
 
 	// the parser uses a finite state machine to perform parsing, having the
 	// following states & actions
@@ -159,9 +511,25 @@ func (p *Parser) Parse() (*movetree.MoveTree, error) {
 	//               |    --------------'['-------
 	//               V
 	//              END
-	var c rune
+	offset := 0
+	for {
+		c, size, err := p.rdr.ReadRune()
+		if err != nil {
+			// We should **always** end with an EOF error
+			if !errors.Is(err, io.EOF) {
+				return nil, stateData.parseError(fmt.Sprintf("expected to end on EOF; got %v", err))
+			}
+			break
+		}
+		stateData.input = append(stateData.input, c)
+		stateData.offsets = append(stateData.offsets, offset)
+		offset += size
+	}
+	stateData.offsets = append(stateData.offsets, offset)
+
 	var err error
-	for c, _, err = p.rdr.ReadRune(); err == nil; c, _, err = p.rdr.ReadRune() {
+	for i, c := range stateData.input {
+		stateData.pos = i
 		stateData.idx++
 		stateData.col++
 		stateData.curchar = c
@@ -198,16 +566,47 @@ func (p *Parser) Parse() (*movetree.MoveTree, error) {
 		stateData.prevchar = c
 	}
 
-	// We should **always** end with an EOF error
-	if err == nil || !errors.Is(err, io.EOF) {
-		return nil, stateData.parseError(fmt.Sprintf("expected to end on EOF; got %v", err))
-	} else if len(stateData.branches) != 0 {
+	stateData.pos = len(stateData.input)
+	if len(stateData.branches) != 0 && !stateData.lenient {
 		return nil, stateData.parseError("expected to end on root branch, but ended in nested condition")
+	} else if len(stateData.branches) != 0 {
+		if err := closeBranches(stateData, pbuf); err != nil {
+			return nil, err
+		}
 	}
 
+	if charset != "" {
+		g.Root.SGFProperties["CA"] = []string{charsetUTF8}
+	}
+	p.warnings = pbuf.warnings
 	return g, nil
 }
 
+// closeBranches closes the variations that are still open at the end of a
+// truncated SGF, adding a warning. A property that was cut off is dropped,
+// but the other properties of the last node are kept.
+func closeBranches(stateData *stateData, pbuf *propBuffer) error {
+	if stateData.curstate == propertyState || stateData.curstate == propDataState {
+		stateData.flushBuf()
+	}
+	path := movetree.Path{}
+	if stateData.curnode != nil {
+		if err := pbuf.flush(stateData.curnode); err != nil {
+			return stateData.propError(err)
+		}
+		path = movetree.PathTo(stateData.curnode)
+	}
+	pbuf.warnings = append(pbuf.warnings, &Warning{
+		Line:   stateData.row,
+		Column: stateData.col,
+		Offset: stateData.offset(),
+		Path:   path,
+		Err:    fmt.Errorf("%w: closed %d variations", ErrTruncated, len(stateData.branches)),
+	})
+	stateData.branches = nil
+	return nil
+}
+
 // handleBeginning handles the beginning state, initializing the first (root)
 // node.
 //
@@ -220,16 +619,31 @@ func handleBeginning(stateData *stateData, pbuf *propBuffer, g *movetree.MoveTre
 	} else if stateData.curchar == lparen {
 		// (;AW[aw][bw]
 		// ^
-		stateData.branches = append(stateData.branches, g.Root)
-		return nil
+		return stateData.addBranch(g.Root)
 	} else if stateData.curchar == scolon {
 		// (;AW[aw][bw]
 		//  ^
 		stateData.curstate = betweenState
 		stateData.curnode = g.Root
+		return stateData.addNode()
+	} else if !stateData.lenient {
+		return stateData.parseError("unexpected char")
+	} else if len(stateData.branches) > 0 && unicode.IsLetter(stateData.curchar) {
+		// (AW[aw][bw]
+		//  ^
+		stateData.curstate = betweenState
+		stateData.curnode = g.Root
+		stateData.warn(pbuf, "started the root node, which was missing its semicolon")
+		if err := stateData.addNode(); err != nil {
+			return err
+		}
+		return handleBetween(stateData, pbuf)
+	} else if stateData.curchar == '\ufeff' && stateData.pos == 0 {
+		stateData.warn(pbuf, "skipped byte order mark")
 		return nil
 	}
-	return stateData.parseError("unexpected char")
+	stateData.skip(pbuf, "before the first node")
+	return nil
 }
 
 // handleBetween handles the between state, transitioning to one of several
@@ -246,12 +660,18 @@ func handleBetween(stateData *stateData, pbuf *propBuffer) error {
 	if unicode.IsSpace(stateData.curchar) {
 		// We can safely ignore whitespace here.
 		return nil
-	} else if unicode.IsUpper(stateData.curchar) {
+	} else if unicode.IsUpper(stateData.curchar) || stateData.lenient && unicode.IsLetter(stateData.curchar) {
 		// AW[aw][bw]
 		// ^
-		if err := pbuf.flush(stateData.curnode); err != nil {
-			return stateData.parseError(err.Error())
+		if stateData.lenient && stateData.newBranch {
+			// (;B[aa](W[ab])
+			//         ^
+			if err := startNode(stateData, pbuf); err != nil {
+				return err
+			}
+			stateData.warn(pbuf, "started a node that was missing its semicolon")
 		}
+		pbuf.endProp()
 		stateData.addToBuf(stateData.curchar)
 		stateData.curstate = propertyState
 		return nil
@@ -263,30 +683,21 @@ func handleBetween(stateData *stateData, pbuf *propBuffer) error {
 	} else if stateData.curchar == lparen {
 		// AW[aw][bw] (;B[ab]
 		//            ^
-		pbuf.flush(stateData.curnode)
 		if err := pbuf.flush(stateData.curnode); err != nil {
-			return stateData.parseError(err.Error())
+			return stateData.propError(err)
 		}
-		stateData.addBranch(stateData.curnode)
-		return nil
+		stateData.newBranch = true
+		return stateData.addBranch(stateData.curnode)
 	} else if stateData.curchar == scolon {
 		// AW[aw][bw] (;B[ab];W[ac])
 		//             ^     ^
-		pbuf.flush(stateData.curnode)
-		if err := pbuf.flush(stateData.curnode); err != nil {
-			return stateData.parseError(err.Error())
-		}
-		cn := stateData.curnode
-		stateData.curnode = movetree.NewNode()
-		cn.AddChild(stateData.curnode)
-		stateData.curnode.Parent = cn
-		return nil
+		return startNode(stateData, pbuf)
 	} else if stateData.curchar == rparen {
 		// AW[aw][bw] (;B[ab])
 		//                   ^
-		pbuf.flush(stateData.curnode)
+		stateData.newBranch = false
 		if err := pbuf.flush(stateData.curnode); err != nil {
-			return stateData.parseError(err.Error())
+			return stateData.propError(err)
 		}
 		cn, err := stateData.popBranch()
 		if err != nil {
@@ -294,18 +705,40 @@ func handleBetween(stateData *stateData, pbuf *propBuffer) error {
 		}
 		stateData.curnode = cn
 		return nil
+	} else if stateData.lenient {
+		// B[ab]] ;W[ac]
+		//      ^
+		stateData.skip(pbuf, "between properties")
+		return nil
 	}
 	return stateData.parseError("unexpected character between")
 }
 
+// startNode starts a new node, as a child of the current node.
+func startNode(stateData *stateData, pbuf *propBuffer) error {
+	stateData.newBranch = false
+	if err := pbuf.flush(stateData.curnode); err != nil {
+		return stateData.propError(err)
+	}
+	if err := stateData.addNode(); err != nil {
+		return err
+	}
+	cn := stateData.curnode
+	stateData.curnode = movetree.NewNode()
+	cn.AddChild(stateData.curnode)
+	stateData.curnode.Parent = cn
+	return nil
+}
+
 // handleProperty is a simple state for handling parsing property
 // keys (AW, C, etc)
 //
 // Transitions:
 //
 //     property => propData
+//     property => between  ex: AW [aw]
 func handleProperty(stateData *stateData, pbuf *propBuffer) error {
-	if unicode.IsUpper(stateData.curchar) {
+	if unicode.IsUpper(stateData.curchar) || stateData.lenient && unicode.IsLetter(stateData.curchar) {
 		// AW[aw][bw]
 		//  ^
 		stateData.addToBuf(stateData.curchar)
@@ -313,10 +746,25 @@ func handleProperty(stateData *stateData, pbuf *propBuffer) error {
 	} else if stateData.curchar == lbrace {
 		// AW[aw][bw]
 		//   ^
-		prop := stateData.flushBuf()
-		pbuf.prop = prop
+		pbuf.prop = stateData.propName(pbuf)
+		pbuf.row, pbuf.col, pbuf.offset = stateData.row, stateData.col, stateData.offset()
 		stateData.curstate = propDataState
 		return nil
+	} else if unicode.IsSpace(stateData.curchar) {
+		// AW [aw][bw]
+		//   ^
+		// Whitespace is allowed before the values, which are handled like the
+		// following values of the property.
+		pbuf.prop = stateData.propName(pbuf)
+		pbuf.row, pbuf.col, pbuf.offset = stateData.row, stateData.col, stateData.offset()
+		stateData.curstate = betweenState
+		return nil
+	} else if stateData.lenient {
+		// AW;B[ab]
+		//   ^
+		stateData.warn(pbuf, fmt.Sprintf("dropped property %s, which has no values", stateData.flushBuf()))
+		stateData.curstate = betweenState
+		return handleBetween(stateData, pbuf)
 	}
 	return stateData.parseError("unexpected character during property parsing")
 }
@@ -329,6 +777,9 @@ func handleProperty(stateData *stateData, pbuf *propBuffer) error {
 //     propData => propData
 //     propData => between
 func handlePropData(stateData *stateData, pbuf *propBuffer) error {
+	if max := stateData.maxValueBytes; max >= 0 && stateData.buf.Len() > max {
+		return stateData.propError(fmt.Errorf("%w: property value of more than %d bytes", ErrLimitExceeded, max))
+	}
 	if stateData.curchar == rbrace && stateData.holdChar == backslash {
 		// C[foo 1[k\] bar]
 		//           ^
@@ -350,6 +801,12 @@ func handlePropData(stateData *stateData, pbuf *propBuffer) error {
 		// Ignore for now, wait for next character
 		stateData.holdChar = stateData.curchar
 		return nil
+	} else if stateData.curchar == rbrace && stateData.lenient && textProps[pbuf.prop] && !stateData.valueEnds() {
+		// C[see [1] below]
+		//          ^
+		stateData.warn(pbuf, fmt.Sprintf("kept unescaped ']' in the value of %s", pbuf.prop))
+		stateData.addToBuf(stateData.curchar)
+		return nil
 	} else if stateData.curchar == rbrace {
 		// C[foo 1[k\] bar]
 		//                ^
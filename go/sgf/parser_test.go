@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -11,7 +12,9 @@ import (
 	"github.com/otrego/clamshell/go/move"
 	"github.com/otrego/clamshell/go/movetree"
 	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/prop"
 	"github.com/otrego/clamshell/go/sgf"
+	"golang.org/x/text/encoding/japanese"
 )
 
 type propmap map[string][]string
@@ -158,8 +161,6 @@ SQ[ra][rb][rc]
 			pathToProps: map[string]propmap{
 				"-": propmap{
 					"GM": []string{"1"},
-					"SQ": []string{"ra", "rb", "rc"},
-					"PW": []string{"White"},
 				},
 			},
 			pathToNodeCheck: map[string]nodeCheck{
@@ -168,6 +169,21 @@ SQ[ra][rb][rc]
 					if n.GameInfo.Size != expSize {
 						return fmt.Errorf("incorrect size; got %v, but wanted %v", n.GameInfo.Size, expSize)
 					}
+					if got := len(n.Marks); got != 12 {
+						return fmt.Errorf("got %d marks, but wanted 12", got)
+					}
+					if got := n.Marks[*point.New(17, 1)]; got != movetree.MarkSquare {
+						return fmt.Errorf("got mark %q at rb, but wanted %q", got, movetree.MarkSquare)
+					}
+					if got := len(n.Labels); got != 39 {
+						return fmt.Errorf("got %d labels, but wanted 39", got)
+					}
+					if got := n.Labels[*point.New(15, 6)]; got != "100" {
+						return fmt.Errorf("got label %q at pg, but wanted %q", got, "100")
+					}
+					if n.GameInfo.WhitePlayer != "White" {
+						return fmt.Errorf("incorrect white player; got %q, but wanted %q", n.GameInfo.WhitePlayer, "White")
+					}
 					return nil
 				},
 			},
@@ -367,6 +383,54 @@ func TestPropertyPostProcessing(t *testing.T) {
 				move.New(color.White, point.New(1, 2)),
 			},
 		},
+		{
+			desc: "game info on a non-root node is kept raw",
+			sgf:  "(;GM[1];B[aa]PB[Honinbo]DT[1846-09-11]RE[B+2])",
+			path: "0",
+			getter: func(n *movetree.Node) interface{} {
+				return []interface{}{n.GameInfo, n.SGFProperties}
+			},
+			want: []interface{}{(*movetree.GameInfo)(nil), map[string][]string{
+				"PB": {"Honinbo"},
+				"DT": {"1846-09-11"},
+				"RE": {"B+2"},
+			}},
+		},
+		{
+			desc: "FF[3] mark before the move",
+			sgf:  "(;FF[3]GM[1];M[cc][dd]B[cc])",
+			path: "0",
+			getter: func(n *movetree.Node) interface{} {
+				return n.Marks
+			},
+			want: map[point.Point]movetree.MarkType{
+				*point.New(2, 2): movetree.MarkTriangle,
+				*point.New(3, 3): movetree.MarkX,
+			},
+		},
+		{
+			desc: "FF[3] mark after the move",
+			sgf:  "(;FF[3]GM[1];B[cc]M[cc][dd])",
+			path: "0",
+			getter: func(n *movetree.Node) interface{} {
+				return n.Marks
+			},
+			want: map[point.Point]movetree.MarkType{
+				*point.New(2, 2): movetree.MarkTriangle,
+				*point.New(3, 3): movetree.MarkX,
+			},
+		},
+		{
+			desc: "FF[3] mark before the placements",
+			sgf:  "(;FF[3]GM[1];M[cc]AW[cc])",
+			path: "0",
+			getter: func(n *movetree.Node) interface{} {
+				return n.Marks
+			},
+			want: map[point.Point]movetree.MarkType{
+				*point.New(2, 2): movetree.MarkTriangle,
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -387,3 +451,711 @@ func TestPropertyPostProcessing(t *testing.T) {
 		})
 	}
 }
+
+func TestParse_Lenient(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		sgf         string
+		opts        *prop.ParseOptions
+		expWarnings int
+		expErr      error
+	}{
+		{
+			desc: "well-formed, strict",
+			sgf:  "(;GM[1]AP[CGoban:3])",
+		},
+		{
+			desc:   "malformed, strict",
+			sgf:    "(;GM[1]AP[:1])",
+			expErr: sgf.ErrParse,
+		},
+		{
+			desc:        "malformed, lenient",
+			sgf:         "(;GM[1]\nAP[:1])",
+			opts:        &prop.ParseOptions{Lenient: true},
+			expWarnings: 1,
+		},
+		{
+			desc:   "whitespace around a point, strict",
+			sgf:    "(;GM[1];B[ qd ])",
+			expErr: sgf.ErrParse,
+		},
+		{
+			desc:        "whitespace around a point, lenient",
+			sgf:         "(;GM[1]\n;B[ qd ]AB[aa][ bb])",
+			opts:        &prop.ParseOptions{Lenient: true},
+			expWarnings: 2,
+		},
+		{
+			desc:   "comment with several values, strict",
+			sgf:    "(;GM[1]C[a][b])",
+			expErr: sgf.ErrParse,
+		},
+		{
+			desc:        "comment with several values, lenient",
+			sgf:         "(;GM[1]\nC[a][b])",
+			opts:        &prop.ParseOptions{Lenient: true},
+			expWarnings: 1,
+		},
+		{
+			desc:        "uppercase points, lenient",
+			sgf:         "(;GM[1]\nAB[CC]SZ[9];B[GG])",
+			opts:        &prop.ParseOptions{Lenient: true},
+			expWarnings: 2,
+		},
+		{
+			desc:   "root property on a non-root node, strict",
+			sgf:    "(;GM[1];B[aa]SZ[9])",
+			expErr: prop.ErrScope,
+		},
+		{
+			desc:        "root property on a non-root node, lenient",
+			sgf:         "(;GM[1]\n;B[aa]SZ[9])",
+			opts:        &prop.ParseOptions{Lenient: true},
+			expWarnings: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := sgf.FromString(tc.sgf).WithOptions(tc.opts)
+			_, err := p.Parse()
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got err %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			warnings := p.Warnings()
+			if len(warnings) != tc.expWarnings {
+				t.Fatalf("got warnings %v, but expected %d warnings", warnings, tc.expWarnings)
+			}
+			for _, w := range warnings {
+				var pw *prop.Warning
+				if !errors.As(w, &pw) {
+					t.Errorf("got warning %v, but expected it to wrap a *prop.Warning", w)
+				}
+				if w.Line != 1 {
+					t.Errorf("got warning on line %d, but expected line 1", w.Line)
+				}
+			}
+		})
+	}
+}
+
+func TestParse_LenientMalformedValues(t *testing.T) {
+	// Each malformed property fails a strict parse, but is recovered from
+	// with a single warning when parsing leniently.
+	testCases := []string{
+		"(;GM[1]KM[])",
+		"(;GM[1]KM[abc])",
+		"(;GM[1]SZ[])",
+		"(;GM[1]SZ[0])",
+		"(;GM[1]SZ[99])",
+		"(;GM[1]PL[X])",
+		"(;GM[1];B[x])",
+		"(;GM[1]LB[aa])",
+		"(;GM[1];B[aa]BM[3])",
+		"(;GM[1]V[x])",
+		"(;GM[1]HO[5])",
+		"(;GM[1]GB[7])",
+		"(;GM[1]RU[a][b])",
+		"(;GM[1]AB[aa:])",
+		"(;GM[1]CR[zz:aa])",
+	}
+	for _, in := range testCases {
+		t.Run(in, func(t *testing.T) {
+			if _, err := sgf.Parse(in); err == nil {
+				t.Errorf("got no error parsing strictly, but expected one")
+			}
+			p := sgf.FromString(in).WithOptions(&prop.ParseOptions{Lenient: true})
+			if _, err := p.Parse(); err != nil {
+				t.Fatalf("got error %v parsing leniently, but expected none", err)
+			}
+			if warnings := p.Warnings(); len(warnings) != 1 {
+				t.Errorf("got warnings %v, but expected 1 warning", warnings)
+			}
+		})
+	}
+}
+
+func TestParse_LenientDuplicates(t *testing.T) {
+	// Duplicate properties are converted as when parsing strictly, and are
+	// reported with a warning.
+	testCases := []struct {
+		desc string
+		sgf  string
+		exp  string
+	}{
+		{
+			desc: "point list",
+			sgf:  "(;GM[1]SZ[9]AB[aa]AB[bb])",
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9]AB[aa][bb])",
+		},
+		{
+			desc: "single value",
+			sgf:  "(;GM[1]SZ[9]KM[6.5]KM[7])",
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9]KM[7.0])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			strict, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := sgf.FromString(tc.sgf).WithOptions(&prop.ParseOptions{Lenient: true})
+			lenient, err := p.Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, mt := range []*movetree.MoveTree{strict, lenient} {
+				if got, err := sgf.Serialize(mt); err != nil || got != tc.exp {
+					t.Errorf("got %q (error %v), but expected %q", got, err, tc.exp)
+				}
+			}
+			warnings := p.Warnings()
+			if len(warnings) != 1 {
+				t.Fatalf("got warnings %v, but expected 1 warning", warnings)
+			}
+			var pw *prop.Warning
+			if !errors.As(warnings[0], &pw) || pw.Code != movetree.LintDuplicateProp {
+				t.Errorf("got warning %v, but expected a duplicate property", warnings[0])
+			}
+		})
+	}
+}
+
+func TestParse_Truncated(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		sgf    string
+		opts   *prop.ParseOptions
+		exp    string
+		expErr error
+	}{
+		{
+			desc:   "missing closing parens, strict",
+			sgf:    "(;GM[1];B[aa](;W[bb];B[cc]",
+			expErr: sgf.ErrParse,
+		},
+		{
+			desc: "missing closing parens, lenient",
+			sgf:  "(;GM[1];B[aa](;W[bb];B[cc])(;W[cc];B[bb]",
+			opts: &prop.ParseOptions{Lenient: true},
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19];B[aa](;W[bb];B[cc])(;W[cc];B[bb]))",
+		},
+		{
+			desc: "cut off in a property value",
+			sgf:  "(;GM[1];B[aa]C[nice move];W[bb]C[a com",
+			opts: &prop.ParseOptions{Lenient: true},
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19];B[aa]C[nice move];W[bb])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := sgf.FromString(tc.sgf).WithOptions(tc.opts)
+			g, err := p.Parse()
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got err %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			warnings := p.Warnings()
+			if len(warnings) != 1 || !errors.Is(warnings[0], sgf.ErrTruncated) {
+				t.Errorf("got warnings %v, but expected one %v warning", warnings, sgf.ErrTruncated)
+			}
+			var check func(n *movetree.Node)
+			check = func(n *movetree.Node) {
+				for _, c := range n.Children {
+					if c.Parent != n {
+						t.Errorf("node %v has parent %v, but expected %v", c, c.Parent, n)
+					}
+					check(c)
+				}
+			}
+			check(g.Root)
+			got, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.exp {
+				t.Errorf("Serialize()=%q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestParse_Syntax(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		sgf         string
+		exp         string
+		expWarnings int
+		expOffset   int
+		expPath     string
+	}{
+		{
+			desc:        "byte order mark",
+			sgf:         "\ufeff(;GM[1];B[aa])",
+			exp:         "(;FF[4]GM[1]CA[UTF-8]SZ[19];B[aa])",
+			expWarnings: 1,
+			expOffset:   0,
+			expPath:     "-",
+		},
+		{
+			desc:        "text before the first variation",
+			sgf:         "Subject: my game\n(;GM[1];B[aa])",
+			exp:         "(;FF[4]GM[1]CA[UTF-8]SZ[19];B[aa])",
+			expWarnings: 1,
+			expOffset:   0,
+			expPath:     "-",
+		},
+		{
+			desc:        "missing root semicolon",
+			sgf:         "(GM[1];B[aa])",
+			exp:         "(;FF[4]GM[1]CA[UTF-8]SZ[19];B[aa])",
+			expWarnings: 1,
+			expOffset:   1,
+			expPath:     "-",
+		},
+		{
+			desc:        "missing semicolon in a variation",
+			sgf:         "(;GM[1];B[aa](W[bb])(;W[cc]))",
+			exp:         "(;FF[4]GM[1]CA[UTF-8]SZ[19];B[aa](;W[bb])(;W[cc]))",
+			expWarnings: 1,
+			expOffset:   14,
+			expPath:     "-0x2",
+		},
+		{
+			desc:        "lowercase property names",
+			sgf:         "(;GaMe[1]sz[9];AddBlack[aa]AddWhite[bb];b[cc])",
+			exp:         "(;FF[4]GM[1]CA[UTF-8]SZ[9];AB[aa]AW[bb];B[cc])",
+			expWarnings: 5,
+			expOffset:   6,
+			expPath:     "-",
+		},
+		{
+			desc:        "unescaped bracket in a comment",
+			sgf:         "(;GM[1]C[see [1] below];B[aa])",
+			exp:         "(;FF[4]GM[1]CA[UTF-8]SZ[19]C[see [1\\] below];B[aa])",
+			expWarnings: 1,
+			expOffset:   15,
+			expPath:     "-",
+		},
+		{
+			desc:        "stray characters",
+			sgf:         "(;GM[1]C[囲碁];B[aa]]] ;W[bb])",
+			exp:         "(;FF[4]GM[1]CA[UTF-8]SZ[19]C[囲碁];B[aa];W[bb])",
+			expWarnings: 1,
+			expOffset:   22,
+			expPath:     "-0",
+		},
+		{
+			desc:        "property without values",
+			sgf:         "(;GM[1];B[aa]XY;W[bb])",
+			exp:         "(;FF[4]GM[1]CA[UTF-8]SZ[19];B[aa];W[bb])",
+			expWarnings: 1,
+			expOffset:   15,
+			expPath:     "-0",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := sgf.Parse(tc.sgf); !errors.Is(err, sgf.ErrParse) {
+				t.Errorf("got err %v when parsing strictly, but expected %v", err, sgf.ErrParse)
+			}
+
+			p := sgf.FromString(tc.sgf).WithOptions(&prop.ParseOptions{Lenient: true})
+			g, err := p.Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+			warnings := p.Warnings()
+			if len(warnings) != tc.expWarnings {
+				t.Fatalf("got warnings %v, but expected %d warnings", warnings, tc.expWarnings)
+			}
+			for _, w := range warnings {
+				if !errors.Is(w, sgf.ErrSyntax) {
+					t.Errorf("got warning %v, but expected %v", w, sgf.ErrSyntax)
+				}
+			}
+			if w := warnings[0]; w.Offset != tc.expOffset || w.Path.CompactString() != tc.expPath {
+				t.Errorf("got warning at offset %d, path %v, but expected offset %d, path %v",
+					w.Offset, w.Path.CompactString(), tc.expOffset, tc.expPath)
+			}
+			got, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.exp {
+				t.Errorf("Serialize()=%q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestParse_Limits(t *testing.T) {
+	nested := func(depth int) string {
+		return strings.Repeat("(;B[aa]", depth) + strings.Repeat(")", depth)
+	}
+	testCases := []struct {
+		desc   string
+		sgf    string
+		opts   *prop.ParseOptions
+		expErr error
+	}{
+		{
+			desc:   "100k-deep nesting, default limits",
+			sgf:    nested(100000),
+			expErr: sgf.ErrLimitExceeded,
+		},
+		{
+			desc: "deep nesting within the limit",
+			sgf:  nested(100),
+			opts: &prop.ParseOptions{MaxDepth: 100},
+		},
+		{
+			desc:   "deep nesting over the limit",
+			sgf:    nested(101),
+			opts:   &prop.ParseOptions{MaxDepth: 100},
+			expErr: sgf.ErrLimitExceeded,
+		},
+		{
+			desc: "100k-deep nesting, no limit",
+			sgf:  nested(100000),
+			opts: &prop.ParseOptions{MaxDepth: -1},
+		},
+		{
+			desc: "value within the limit",
+			sgf:  "(;GM[1]C[" + strings.Repeat("x", 10) + "])",
+			opts: &prop.ParseOptions{MaxValueBytes: 10},
+		},
+		{
+			desc:   "value over the limit",
+			sgf:    "(;GM[1]C[" + strings.Repeat("x", 11) + "])",
+			opts:   &prop.ParseOptions{MaxValueBytes: 10},
+			expErr: sgf.ErrLimitExceeded,
+		},
+		{
+			desc:   "value over the limit, lenient",
+			sgf:    "(;GM[1]C[" + strings.Repeat("x", 11) + "])",
+			opts:   &prop.ParseOptions{MaxValueBytes: 10, Lenient: true},
+			expErr: sgf.ErrLimitExceeded,
+		},
+		{
+			desc: "nodes within the limit",
+			sgf:  "(;GM[1];B[aa];W[bb])",
+			opts: &prop.ParseOptions{MaxNodes: 3},
+		},
+		{
+			desc:   "nodes over the limit",
+			sgf:    "(;GM[1];B[aa];W[bb](;B[cc])(;B[dd]))",
+			opts:   &prop.ParseOptions{MaxNodes: 4},
+			expErr: sgf.ErrLimitExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := sgf.FromString(tc.sgf).WithOptions(tc.opts).Parse()
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got err %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil && !errors.Is(err, sgf.ErrParse) {
+				t.Errorf("got err %v, but expected it to also be %v", err, sgf.ErrParse)
+			}
+		})
+	}
+}
+
+func TestParse_RejectUnknown(t *testing.T) {
+	const s = "(;GM[1]SZ[9];B[aa]XX[custom])"
+	testCases := []struct {
+		desc   string
+		opts   *prop.ParseOptions
+		expErr error
+	}{
+		{
+			desc: "permissive by default",
+		},
+		{
+			desc:   "rejected",
+			opts:   &prop.ParseOptions{RejectUnknown: true},
+			expErr: prop.ErrUnknownProp,
+		},
+		{
+			desc:   "rejected when lenient",
+			opts:   &prop.ParseOptions{RejectUnknown: true, Lenient: true},
+			expErr: prop.ErrUnknownProp,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.FromString(s).WithOptions(tc.opts).Parse()
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got err %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), "XX[custom] at {move:1") {
+					t.Errorf("got err %v, but expected it to report the property and the node", err)
+				}
+				return
+			}
+			if got := g.Root.Next(0).SGFProperties["XX"]; len(got) != 1 || got[0] != "custom" {
+				t.Errorf("got XX=%v, but expected it to be kept", got)
+			}
+		})
+	}
+}
+
+func TestParse_Whitespace(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		sgf     string
+		compact string
+	}{
+		{
+			desc:    "between value blocks",
+			sgf:     "(;GM[1]AB[aa]\n[bb]  [cc])",
+			compact: "(;GM[1]AB[aa][bb][cc])",
+		},
+		{
+			desc:    "before the first value block",
+			sgf:     "(;GM[1]AB [aa]\n[bb];W\n\t[cc])",
+			compact: "(;GM[1]AB[aa][bb];W[cc])",
+		},
+		{
+			desc:    "newlines after semicolons and between properties",
+			sgf:     "(\n;\nGM [1]\nSZ [9]\n;\nB [ee]\nC [nice  move ]\n(\n;\nW [cc]\n)\n(\n;\nW [gg]\n)\n)",
+			compact: "(;GM[1]SZ[9];B[ee]C[nice  move ](;W[cc])(;W[gg]))",
+		},
+		{
+			desc:    "CRLF line endings",
+			sgf:     "(;GM[1]\r\nAB\r\n[aa]\r\n[bb]\r\n;B [cc])",
+			compact: "(;GM[1]AB[aa][bb];B[cc])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exp, err := sgf.Parse(tc.compact)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expSGF, err := sgf.Serialize(exp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != expSGF {
+				t.Errorf("Parse(%q) serialized to %q, but expected %q", tc.sgf, got, expSGF)
+			}
+		})
+	}
+}
+
+func TestParse_KomiRules(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		sgf     string
+		expKomi float64
+		expErr  error
+	}{
+		{
+			desc:    "quarter komi, Ing rules",
+			sgf:     "(;GM[1]RU[GOE]KM[7.75])",
+			expKomi: 7.75,
+		},
+		{
+			desc:    "quarter komi before Ing rules",
+			sgf:     "(;GM[1]KM[7.75]RU[GOE])",
+			expKomi: 7.75,
+		},
+		{
+			desc:   "quarter komi, Japanese rules",
+			sgf:    "(;GM[1]KM[7.75]RU[Japanese])",
+			expErr: sgf.ErrParse,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.FromString(tc.sgf).WithOptions(&prop.ParseOptions{StrictKomi: true}).Parse()
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got err %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := *g.Root.GameInfo.Komi; got != tc.expKomi {
+				t.Errorf("got komi %v, but expected %v", got, tc.expKomi)
+			}
+		})
+	}
+}
+
+func TestParse_Result(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		sgf       string
+		expResult *movetree.Result
+	}{
+		{
+			desc:      "void",
+			sgf:       "(;GM[1]RE[Void])",
+			expResult: &movetree.Result{Reason: movetree.ReasonVoid},
+		},
+		{
+			desc:      "unknown",
+			sgf:       "(;GM[1]RE[?])",
+			expResult: &movetree.Result{Reason: movetree.ReasonUnknown},
+		},
+		{
+			desc: "no result",
+			sgf:  "(;GM[1])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got *movetree.Result
+			if g.Root.GameInfo != nil {
+				got = g.Root.GameInfo.Result
+			}
+			if !cmp.Equal(got, tc.expResult) {
+				t.Errorf("got result %v, but expected %v", got, tc.expResult)
+			}
+		})
+	}
+}
+
+func TestParseBytes_Charset(t *testing.T) {
+	sjis, err := japanese.ShiftJIS.NewEncoder().String("(;GM[1]PB[本因坊秀策]C[黒番])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		desc       string
+		data       []byte
+		opts       *prop.ParseOptions
+		expPlayer  string
+		expComment string
+		expErr     error
+	}{
+		{
+			desc:       "shift_jis, without CA",
+			data:       []byte(sjis),
+			expPlayer:  "本因坊秀策",
+			expComment: "黒番",
+		},
+		{
+			desc:       "shift_jis, with CA",
+			data:       []byte(strings.Replace(sjis, "GM[1]", "GM[1]CA[Shift_JIS]", 1)),
+			expPlayer:  "本因坊秀策",
+			expComment: "黒番",
+		},
+		{
+			desc:       "utf-8",
+			data:       []byte("(;GM[1]PB[本因坊秀策]C[黒番])"),
+			expPlayer:  "本因坊秀策",
+			expComment: "黒番",
+		},
+		{
+			desc:       "charset override",
+			data:       []byte("(;GM[1]CA[UTF-8]PB[Jos\xe9]C[Ol\xe9])"),
+			opts:       &prop.ParseOptions{Charset: "ISO-8859-1"},
+			expPlayer:  "José",
+			expComment: "Olé",
+		},
+		{
+			desc:   "unknown charset",
+			data:   []byte("(;GM[1]CA[Zork]C[hi])"),
+			expErr: sgf.ErrCharset,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.FromBytes(tc.data).WithOptions(tc.opts).Parse()
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got err %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := g.Root.GameInfo.BlackPlayer; got != tc.expPlayer {
+				t.Errorf("got black player %q, but expected %q", got, tc.expPlayer)
+			}
+			if got := g.Root.Comment; got != tc.expComment {
+				t.Errorf("got comment %q, but expected %q", got, tc.expComment)
+			}
+			if got := g.Root.SGFProperties["CA"]; !cmp.Equal(got, []string{"UTF-8"}) {
+				t.Errorf("got CA %v, but expected [UTF-8]", got)
+			}
+		})
+	}
+}
+
+func TestParse_Analysis(t *testing.T) {
+	g, err := sgf.FromString("(;GM[1];B[pd]C[winrate: 0.48\nscoreLead: -0.7];W[dd])").
+		WithOptions(&prop.ParseOptions{ExtractAnalysis: true}).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	winRate, scoreLead := 0.48, -0.7
+	exp := &movetree.Analysis{WinRate: &winRate, ScoreLead: &scoreLead}
+	if got := g.Root.Next(0).Analysis; !cmp.Equal(got, exp) {
+		t.Errorf("got analysis %v, but expected %v", got, exp)
+	}
+	if got := g.Root.Next(0).Next(0).Analysis; got != nil {
+		t.Errorf("got analysis %v for a node without analysis, but expected nil", got)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		sgf    string
+		expErr error
+	}{
+		{
+			desc:   "bad size",
+			sgf:    "(;GM[1]SZ[30])",
+			expErr: prop.ErrInvalidBoardSize,
+		},
+		{
+			desc:   "bad komi",
+			sgf:    "(;GM[1]KM[six])",
+			expErr: prop.ErrBadKomi,
+		},
+		{
+			desc:   "duplicate move",
+			sgf:    "(;GM[1];B[aa]W[bb])",
+			expErr: prop.ErrDuplicateMove,
+		},
+		{
+			desc:   "root property on a non-root node",
+			sgf:    "(;GM[1];B[aa]SZ[9])",
+			expErr: prop.ErrScope,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := sgf.Parse(tc.sgf)
+			if !errors.Is(err, tc.expErr) || !errors.Is(err, sgf.ErrParse) {
+				t.Fatalf("got error %v, but expected %v and %v", err, tc.expErr, sgf.ErrParse)
+			}
+			var pe *sgf.ParseError
+			if !errors.As(err, &pe) {
+				t.Errorf("got error %v, but expected a *sgf.ParseError", err)
+			}
+		})
+	}
+}
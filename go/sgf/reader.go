@@ -0,0 +1,186 @@
+package sgf
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
+)
+
+// ParseReader parses a single game from the reader, which is useful when the
+// SGF is embedded in a larger stream or protocol. The reader is read up to the
+// paren that closes the game, so any data after the game is left in the
+// reader. Parens within property values are skipped. As with FromBytes, the
+// game may be in any charset.
+//
+// If the reader contains only whitespace, io.EOF is returned, so games can be
+// read until the end of the stream.
+func ParseReader(r io.Reader) (*movetree.MoveTree, error) {
+	data, err := readGame(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBytes(data)
+}
+
+// Decoder reads the games of a collection from a stream one at a time, so
+// that large collections (ex: thousands of concatenated games) can be
+// processed without holding the whole collection in memory.
+type Decoder struct {
+	r    *bufio.Reader
+	opts *prop.ParseOptions
+
+	// games is the number of games read so far.
+	games int
+
+	// warnings from the last game.
+	warnings []*Warning
+}
+
+// NewDecoder creates a decoder that reads games from r. The decoder buffers
+// its reads, so it may read past the last game.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// WithOptions sets the options used for parsing properties.
+func (d *Decoder) WithOptions(opts *prop.ParseOptions) *Decoder {
+	d.opts = opts
+	return d
+}
+
+// Next parses the next game of the stream, returning io.EOF once there are no
+// more games. As with FromBytes, each game may be in any charset.
+//
+// If a game can't be parsed, the error is returned and the rest of the stream
+// is kept, so that Next can be called again to skip to the following game.
+// Errors from reading the stream, or from a game cut off by the end of the
+// stream, aren't recoverable.
+func (d *Decoder) Next() (*movetree.MoveTree, error) {
+	d.warnings = nil
+	data, err := readGame(d.r)
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, fmt.Errorf("game %d: %w", d.games+1, err)
+	}
+	d.games++
+	p := FromBytes(data).WithOptions(d.opts)
+	g, err := p.Parse()
+	d.warnings = p.Warnings()
+	if err != nil {
+		return nil, fmt.Errorf("game %d: %w", d.games, err)
+	}
+	return g, nil
+}
+
+// NextBytes is like Next, but returns the raw bytes of the next game without
+// parsing it, so that the games can be parsed elsewhere (ex: concurrently).
+// Reading errors aren't recoverable.
+func (d *Decoder) NextBytes() ([]byte, error) {
+	d.warnings = nil
+	data, err := readGame(d.r)
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, fmt.Errorf("game %d: %w", d.games+1, err)
+	}
+	d.games++
+	return data, nil
+}
+
+// Warnings returns the problems that were recovered from while parsing the
+// last game.
+func (d *Decoder) Warnings() []*Warning {
+	return d.warnings
+}
+
+// SplitGames splits a collection of games into the raw bytes of each game,
+// without parsing them, so that the games can be indexed or stored cheaply and
+// parsed on demand (ex: with ParseBytes). As with ParseReader, parens within
+// property values are skipped. The returned slices share memory with data.
+func SplitGames(data []byte) ([][]byte, error) {
+	r := bytes.NewReader(data)
+	var games [][]byte
+	for {
+		game, err := readGame(r)
+		if errors.Is(err, io.EOF) {
+			return games, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("game %d: %w", len(games)+1, err)
+		}
+		end := len(data) - r.Len()
+		games = append(games, data[end-len(game):end:end])
+	}
+}
+
+// readGame reads the bytes of a single game from the reader. Bytes are read
+// one at a time, so that nothing after the game is consumed.
+func readGame(r io.Reader) ([]byte, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &byteReader{r: r}
+	}
+	var data []byte
+	depth := 0
+	inValue, escaped := false, false
+	for {
+		c, err := br.ReadByte()
+		if errors.Is(err, io.EOF) {
+			if len(data) == 0 {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("%w: unexpected end of input before the end of the game", ErrParse)
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrParse, err)
+		}
+		ch := rune(c)
+		if len(data) == 0 && unicode.IsSpace(ch) {
+			// Skip any whitespace before the game.
+			continue
+		}
+		data = append(data, c)
+
+		switch {
+		case escaped:
+			escaped = false
+		case inValue && ch == backslash:
+			escaped = true
+		case inValue:
+			inValue = ch != rbrace
+		case ch == lbrace:
+			inValue = true
+		case ch == lparen:
+			depth++
+		case ch == rparen:
+			depth--
+			if depth <= 0 {
+				return data, nil
+			}
+		}
+	}
+}
+
+// byteReader reads single bytes from a reader without buffering.
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+// ReadByte reads the next byte.
+func (b *byteReader) ReadByte() (byte, error) {
+	for {
+		n, err := b.r.Read(b.buf[:])
+		if n == 1 {
+			return b.buf[0], nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
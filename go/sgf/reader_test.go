@@ -0,0 +1,135 @@
+package sgf_test
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+func TestParseReader(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		in      string
+		expSGF  string
+		expRest string
+	}{
+		{
+			desc:    "trailing data",
+			in:      "  (;GM[1]SZ[9](;B[ee])(;B[cc]))OK more data",
+			expSGF:  "(;FF[4]GM[1]CA[UTF-8]SZ[9](;B[ee])(;B[cc]))",
+			expRest: "OK more data",
+		},
+		{
+			desc:    "parens in comments",
+			in:      "(;GM[1]C[a smile :) \\] (still a comment)];B[ee])(;GM[1])",
+			expSGF:  "(;FF[4]GM[1]CA[UTF-8]SZ[19]C[a smile :) \\] (still a comment)];B[ee])",
+			expRest: "(;GM[1])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			for _, r := range []io.Reader{
+				strings.NewReader(tc.in),
+				// A reader that doesn't implement io.ByteReader.
+				io.LimitReader(strings.NewReader(tc.in), int64(len(tc.in))),
+			} {
+				g, err := sgf.ParseReader(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := sgf.Serialize(g)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tc.expSGF {
+					t.Errorf("got SGF %q, but expected %q", got, tc.expSGF)
+				}
+				rest, err := io.ReadAll(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(rest) != tc.expRest {
+					t.Errorf("got remaining data %q, but expected %q", rest, tc.expRest)
+				}
+			}
+		})
+	}
+}
+
+func TestParseReader_Errors(t *testing.T) {
+	if _, err := sgf.ParseReader(strings.NewReader(" \n")); !errors.Is(err, io.EOF) {
+		t.Errorf("got error %v, but expected %v", err, io.EOF)
+	}
+	if _, err := sgf.ParseReader(strings.NewReader("(;GM[1];B[ee]")); !errors.Is(err, sgf.ErrParse) {
+		t.Errorf("got error %v, but expected %v", err, sgf.ErrParse)
+	}
+}
+
+func TestSplitGames(t *testing.T) {
+	games := []string{
+		"(;GM[1]SZ[9];B[ee];W[cc])",
+		"(;GM[1]SZ[9]C[a smile :) and (parens\\]];B[ee](;W[cc])(;W[gg]))",
+		"(;GM[1]SZ[13]PB[Black (amateur)])",
+	}
+	got, err := sgf.SplitGames([]byte(strings.Join(games, "\n\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(games) {
+		t.Fatalf("SplitGames() got %d games, but expected %d", len(got), len(games))
+	}
+	for i, data := range got {
+		if string(data) != games[i] {
+			t.Errorf("game %d=%q, but expected %q", i, data, games[i])
+		}
+		if _, err := sgf.ParseBytes(data); err != nil {
+			t.Errorf("game %d couldn't be parsed: %v", i, err)
+		}
+	}
+
+	if got, err := sgf.SplitGames([]byte(" \n")); err != nil || len(got) != 0 {
+		t.Errorf("SplitGames() for whitespace=%q, %v, but expected no games", got, err)
+	}
+	if _, err := sgf.SplitGames([]byte(games[0] + "(;GM[1];B[ee]")); !errors.Is(err, sgf.ErrParse) {
+		t.Errorf("SplitGames() for a truncated game got error %v, but expected %v", err, sgf.ErrParse)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	in := "(;GM[1]SZ[9];B[ee];W[cc])\n" +
+		"(;GM[1]SZ[9]C[a smile :) and (parens\\]];B[ee](;W[cc])(;W[gg]))" +
+		"(;GM[1]SZ[9];B[zzz])\n" +
+		"(;GM[1]SZ[13]PB[Black (amateur)])\n"
+	d := sgf.NewDecoder(io.LimitReader(strings.NewReader(in), int64(len(in))))
+
+	var sizes []int
+	var errs []error
+	for {
+		g, err := d.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sizes = append(sizes, g.Root.GameInfo.Size)
+	}
+	if exp := []int{9, 9, 13}; !reflect.DeepEqual(sizes, exp) {
+		t.Errorf("decoded games with sizes %v, but expected %v", sizes, exp)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], sgf.ErrParse) || !strings.HasPrefix(errs[0].Error(), "game 3:") {
+		t.Errorf("got errors %v, but expected a parse error for game 3", errs)
+	}
+
+	d = sgf.NewDecoder(strings.NewReader("(;GM[1])(;GM[1];B[ee]"))
+	if _, err := d.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Next(); !errors.Is(err, sgf.ErrParse) {
+		t.Errorf("got error %v for a truncated game, but expected %v", err, sgf.ErrParse)
+	}
+}
@@ -2,50 +2,148 @@ package sgf
 
 import (
 	"strings"
+	"unicode/utf8"
 
 	"github.com/otrego/clamshell/go/movetree"
 	"github.com/otrego/clamshell/go/prop"
 )
 
-// Serialize converts a Game into SGF format.
-// Calls serializeHelper.
+// Serialize converts a Game into SGF format, using the default serialization
+// options.
 func Serialize(g *movetree.MoveTree) (string, error) {
-	s, err := serializeHelper(g.Root)
-	if err != nil {
+	return SerializeWithOptions(g, nil)
+}
+
+// SerializeWithOptions converts a Game into SGF format. The options also
+// control the layout of the SGF: by default, the SGF is written compactly,
+// without newlines between nodes or variations, and lines aren't wrapped.
+func SerializeWithOptions(g *movetree.MoveTree, opts *prop.SerializeOptions) (string, error) {
+	w := &writer{opts: opts}
+	if opts != nil {
+		w.nodePerLine, w.indent, w.width = opts.NodePerLine, opts.Indent, opts.MaxLineWidth
+		w.variationPerLine = opts.VariationPerLine || opts.NodePerLine || opts.Indent != ""
+	}
+	w.write("(")
+	if err := w.serialize(g.Root, 0); err != nil {
 		return "", err
 	}
-	return "(" + s + ")", nil
+	w.write(")")
+	return w.sb.String(), nil
+}
+
+// SerializeCharset is like SerializeWithOptions, but encodes the SGF in the
+// given charset (ex: Shift_JIS, GB2312, or EUC-KR) rather than UTF-8, for
+// tools that can't read UTF-8. The charset is recorded in the CA property of
+// the root, and the movetree is left unmodified. It's an error if the text of
+// the movetree can't be represented in the charset.
+func SerializeCharset(g *movetree.MoveTree, opts *prop.SerializeOptions, charset string) ([]byte, error) {
+	g = g.Clone()
+	g.Root.SGFProperties["CA"] = []string{charset}
+	s, err := SerializeWithOptions(g, opts)
+	if err != nil {
+		return nil, err
+	}
+	return transcode(s, charset)
 }
 
-// serializeHelper is a recursive DFS searching all
-// descendant nodes of n.
-func serializeHelper(n *movetree.Node) (string, error) {
-	var sb strings.Builder
-	s, err := writeNode(n)
+// SerializePath converts only the line of the movetree that's reached by
+// following path tp from the root into SGF format, as a linear SGF. See
+// MoveTree.Line.
+func SerializePath(g *movetree.MoveTree, tp movetree.Path) (string, error) {
+	line, err := g.Line(tp)
 	if err != nil {
-		return "", nil
+		return "", err
+	}
+	return Serialize(line)
+}
+
+// writer writes an SGF, keeping track of the layout of the current line.
+type writer struct {
+	sb   strings.Builder
+	opts *prop.SerializeOptions
+
+	nodePerLine      bool
+	variationPerLine bool
+	indent           string
+	width            int
+
+	// col is the width of the current line, and start is its width after the
+	// indentation.
+	col, start int
+}
+
+// write writes s, which may contain newlines.
+func (w *writer) write(s string) {
+	w.sb.WriteString(s)
+	if i := strings.LastIndex(s, "\n"); i >= 0 {
+		w.col, w.start = utf8.RuneCountInString(s[i+1:]), 0
+		return
 	}
-	sb.WriteString(s)
+	w.col += utf8.RuneCountInString(s)
+}
+
+// newline starts a new line, indented for a variation nested depth deep.
+func (w *writer) newline(depth int) {
+	w.write(w.opts.Newline() + strings.Repeat(w.indent, depth))
+	w.start = w.col
+}
 
+// serialize is a recursive DFS writing node n, which is in a variation nested
+// depth deep, and all of its descendants.
+func (w *writer) serialize(n *movetree.Node, depth int) error {
+	if err := w.writeNode(n, depth); err != nil {
+		return err
+	}
 	for _, child := range n.Children {
-		s, err := serializeHelper(child)
-		if err != nil {
-			return "", err
-		}
 		if len(n.Children) > 1 {
-			sb.WriteString("(" + s + ")")
-		} else {
-			sb.WriteString(s)
+			if w.variationPerLine {
+				w.newline(depth + 1)
+			}
+			w.write("(")
+			if err := w.serialize(child, depth+1); err != nil {
+				return err
+			}
+			w.write(")")
+			continue
+		}
+		if w.nodePerLine {
+			w.newline(depth)
+		}
+		if err := w.serialize(child, depth); err != nil {
+			return err
 		}
 	}
-	return sb.String(), nil
+	return nil
 }
 
-// writeNode writes a node in SGF format
-func writeNode(n *movetree.Node) (string, error) {
-	s, err := prop.ConvertNode(n)
+// writeNode writes a node in SGF format, wrapping the line between its
+// properties if it gets too wide.
+func (w *writer) writeNode(n *movetree.Node, depth int) error {
+	props, err := prop.ConvertNodeProps(n, w.opts)
 	if err != nil {
-		return s, err
+		return err
+	}
+	for i, p := range props {
+		if i == 0 {
+			// The node starts on the line of its first property.
+			p = ";" + p
+		}
+		w.wrap(p, depth)
+		w.write(p)
+	}
+	if len(props) == 0 {
+		w.wrap(";", depth)
+		w.write(";")
+	}
+	return nil
+}
+
+// wrap starts a new line if s doesn't fit on the current line.
+func (w *writer) wrap(s string, depth int) {
+	if i := strings.Index(s, "\n"); i >= 0 {
+		s = s[:i]
+	}
+	if w.width > 0 && w.col > w.start && w.col+utf8.RuneCountInString(s) > w.width {
+		w.newline(depth)
 	}
-	return ";" + s, nil
 }
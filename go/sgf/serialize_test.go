@@ -1,11 +1,15 @@
 package sgf_test
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/otrego/clamshell/go/color"
 	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/prop"
 	"github.com/otrego/clamshell/go/sgf"
 )
 
@@ -26,6 +30,14 @@ func TestSerialize(t *testing.T) {
 			desc: "black & white placements",
 			sgf:  "(;GM[1];AB[ab][ac]AW[bb][bc])",
 		},
+		{
+			desc: "markup",
+			sgf:  "(;GM[1];B[cc]CR[aa]MA[bb]SQ[dd]TR[ee]LB[ff:A]AR[aa:cc][dd:bb]LN[bb:dd])",
+		},
+		{
+			desc: "node annotations",
+			sgf:  "(;GM[1];B[cc]TE[1]N[Tesuji]C[The key move]GB[2]HO[1]V[4.5];W[dd]N[Joseki \\[a\\]]UC[1])",
+		},
 		{
 			desc: "complex problem",
 			sgf: `
@@ -113,3 +125,539 @@ SQ[ra][rb][rc]
 		})
 	}
 }
+
+func TestSerialize_LegacyMarkup(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		ff   string
+		exp  string
+	}{
+		{
+			desc: "FF[3] output keeps legacy properties",
+			sgf:  "(;GM[1]FF[3];B[cc]M[cc][dd]L[aa][bb])",
+			ff:   "3",
+			exp:  ";B[cc]M[cc][dd]L[aa][bb])",
+		},
+		{
+			desc: "FF[4] output uses modern properties",
+			sgf:  "(;GM[1]FF[3];B[cc]M[cc][dd]L[aa][bb])",
+			ff:   "4",
+			exp:  ";B[cc]MA[dd]TR[cc]LB[aa:A][bb:B])",
+		},
+		{
+			desc: "FF[3] output writes passes as tt",
+			sgf:  "(;GM[1]FF[3];B[cc];W[tt])",
+			ff:   "3",
+			exp:  ";B[cc];W[tt])",
+		},
+		{
+			desc: "FF[4] output writes passes as empty",
+			sgf:  "(;GM[1]FF[3];B[cc];W[tt])",
+			ff:   "4",
+			exp:  ";B[cc];W[])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g.Root.SGFProperties["FF"] = []string{tc.ff}
+			got, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasSuffix(got, tc.exp) {
+				t.Errorf("Serialize()=%q, but expected it to end with %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestSerialize_Filter(t *testing.T) {
+	in := `(;GM[1]FF[4]SZ[19]PB[Zork]PW[Bork]
+;B[pd]BL[299.5]OB[5]C[A good start.]
+;W[dp]WL[298.1]OW[4]
+;B[pp]BL[290.3]OB[4]C[Solid.])`
+
+	g, err := sgf.Parse(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := sgf.SerializeWithOptions(g, &prop.SerializeOptions{
+		Exclude: []prop.Prop{"BL", "WL", "OB", "OW"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"BL[", "WL[", "OB[", "OW["} {
+		if strings.Contains(got, p) {
+			t.Errorf("Serialize()=%q, but expected no %s properties", got, p)
+		}
+	}
+	exp := ";B[pd]C[A good start.];W[dp];B[pp]C[Solid.])"
+	if !strings.HasSuffix(got, exp) {
+		t.Errorf("Serialize()=%q, but expected it to end with %q", got, exp)
+	}
+
+	// The movetree itself is unchanged.
+	if bl := g.Root.Next(0).TimeLeft; bl[color.Black] != 299.5 {
+		t.Errorf("after serializing, got time left %v, but expected the movetree to be unchanged", bl)
+	}
+
+	_, err = sgf.SerializeWithOptions(g, &prop.SerializeOptions{
+		Include: []prop.Prop{"B", "W"},
+		Exclude: []prop.Prop{"C"},
+	})
+	if !errors.Is(err, prop.ErrSerializeOptions) {
+		t.Errorf("got error %v, but expected %v", err, prop.ErrSerializeOptions)
+	}
+}
+
+func TestSerialize_MoveAnnotation(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1];B[aa]BM[];W[bb]TE[2])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm := g.Root.Next(0).MoveAnnotation
+	if bm == nil || bm.Type != movetree.BadMove || bm.Emphasis != 1 {
+		t.Errorf("got move annotation %+v, but expected a bad move with emphasis 1", bm)
+	}
+	got, err := sgf.Serialize(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := ";B[aa]BM[1];W[bb]TE[2])"
+	if !strings.HasSuffix(got, exp) {
+		t.Errorf("Serialize()=%q, but expected it to end with %q", got, exp)
+	}
+}
+
+func TestSerialize_LineEnding(t *testing.T) {
+	in := "(;GM[1]C[Two\nlines](;B[aa]C[Variation\r\none])(;B[bb]))"
+	testCases := []struct {
+		desc             string
+		lineEnding       string
+		variationPerLine bool
+		exp              string
+		expErr           error
+	}{
+		{
+			desc: "default",
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19]C[Two\nlines](;B[aa]C[Variation\none])(;B[bb]))",
+		},
+		{
+			desc:       "LF",
+			lineEnding: prop.LF,
+			exp:        "(;FF[4]GM[1]CA[UTF-8]SZ[19]C[Two\nlines](;B[aa]C[Variation\none])(;B[bb]))",
+		},
+		{
+			desc:       "CRLF",
+			lineEnding: prop.CRLF,
+			exp:        "(;FF[4]GM[1]CA[UTF-8]SZ[19]C[Two\r\nlines](;B[aa]C[Variation\r\none])(;B[bb]))",
+		},
+		{
+			desc:             "CRLF, variation per line",
+			lineEnding:       prop.CRLF,
+			variationPerLine: true,
+			exp:              "(;FF[4]GM[1]CA[UTF-8]SZ[19]C[Two\r\nlines]\r\n(;B[aa]C[Variation\r\none])\r\n(;B[bb]))",
+		},
+		{
+			desc:       "invalid",
+			lineEnding: "\r",
+			expErr:     prop.ErrSerializeOptions,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := sgf.SerializeWithOptions(g, &prop.SerializeOptions{LineEnding: tc.lineEnding, VariationPerLine: tc.variationPerLine})
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tc.exp {
+				t.Errorf("SerializeWithOptions()=%q, but expected %q", got, tc.exp)
+			}
+
+			// The round trip gives back the same movetree.
+			rt, err := sgf.Parse(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := sgf.Serialize(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotRT, err := sgf.Serialize(rt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotRT != want {
+				t.Errorf("after a round trip, got %q, but expected %q", gotRT, want)
+			}
+			if c := rt.Root.Next(0).Comment; c != "Variation\none" {
+				t.Errorf("after a round trip, got comment %q, but expected %q", c, "Variation\none")
+			}
+		})
+	}
+}
+
+func TestSerialize_Layout(t *testing.T) {
+	in := "(;GM[1]SZ[9]PB[Black]PW[White];B[ee];W[cc](;B[gg]C[Two\nlines];W[gc])(;B[cg]))"
+	testCases := []struct {
+		desc   string
+		opts   *prop.SerializeOptions
+		exp    string
+		expErr error
+	}{
+		{
+			desc: "default",
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9]PB[Black]PW[White];B[ee];W[cc](;B[gg]C[Two\nlines];W[gc])(;B[cg]))",
+		},
+		{
+			desc: "variation per line",
+			opts: &prop.SerializeOptions{VariationPerLine: true},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9]PB[Black]PW[White];B[ee];W[cc]\n" +
+				"(;B[gg]C[Two\nlines];W[gc])\n" +
+				"(;B[cg]))",
+		},
+		{
+			desc: "node per line",
+			opts: &prop.SerializeOptions{NodePerLine: true},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9]PB[Black]PW[White]\n" +
+				";B[ee]\n" +
+				";W[cc]\n" +
+				"(;B[gg]C[Two\nlines]\n" +
+				";W[gc])\n" +
+				"(;B[cg]))",
+		},
+		{
+			desc: "indented",
+			opts: &prop.SerializeOptions{NodePerLine: true, Indent: "  "},
+			exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9]PB[Black]PW[White]\n" +
+				";B[ee]\n" +
+				";W[cc]\n" +
+				"  (;B[gg]C[Two\nlines]\n" +
+				"  ;W[gc])\n" +
+				"  (;B[cg]))",
+		},
+		{
+			desc: "wrapped",
+			opts: &prop.SerializeOptions{MaxLineWidth: 20, Indent: "\t"},
+			exp: "(;FF[4]GM[1]\n" +
+				"CA[UTF-8]SZ[9]\n" +
+				"PB[Black]PW[White]\n" +
+				";B[ee];W[cc]\n" +
+				"\t(;B[gg]C[Two\nlines];W[gc])\n" +
+				"\t(;B[cg]))",
+		},
+		{
+			desc:   "invalid indent",
+			opts:   &prop.SerializeOptions{Indent: "--"},
+			expErr: prop.ErrSerializeOptions,
+		},
+		{
+			desc:   "invalid width",
+			opts:   &prop.SerializeOptions{MaxLineWidth: -1},
+			expErr: prop.ErrSerializeOptions,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := sgf.SerializeWithOptions(g, tc.opts)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("got error %v, but expected %v", err, tc.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tc.exp {
+				t.Errorf("SerializeWithOptions()=%q, but expected %q", got, tc.exp)
+			}
+
+			// The layout doesn't change the movetree.
+			rt, err := sgf.Parse(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotRT, err := sgf.Serialize(rt); err != nil || gotRT != testCases[0].exp {
+				t.Errorf("after a round trip, got %q, %v, but expected %q", gotRT, err, testCases[0].exp)
+			}
+		})
+	}
+}
+
+func TestSerialize_FileProps(t *testing.T) {
+	g := movetree.New()
+	for _, p := range []string{"FF", "GM", "CA"} {
+		delete(g.Root.SGFProperties, p)
+	}
+	g.Root.SGFProperties["FF"] = []string{"3"}
+	testCases := []struct {
+		desc string
+		opts *prop.SerializeOptions
+		exp  string
+	}{
+		{
+			desc: "default",
+			exp:  "(;FF[3]SZ[19])",
+		},
+		{
+			desc: "missing file props",
+			opts: &prop.SerializeOptions{FileProps: true},
+			exp:  "(;FF[3]GM[1]CA[UTF-8]SZ[19])",
+		},
+		{
+			desc: "excluded file props",
+			opts: &prop.SerializeOptions{FileProps: true, Exclude: []prop.Prop{"FF", "GM", "CA"}},
+			exp:  "(;SZ[19])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := sgf.SerializeWithOptions(g, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.exp {
+				t.Errorf("SerializeWithOptions()=%q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestSerialize_PointListRectangles(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[19];B[pd];W[dp]TB[aa:jj]CR[aa:ab][ab:bb])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := g.Root.Next(0).Next(0)
+	if got := len(n.SGFProperties["TB"]); got != 100 {
+		t.Errorf("got %d TB points, but expected the quadrant to expand to 100", got)
+	}
+	if got := len(n.Marks); got != 3 {
+		t.Errorf("got %d CR points, but expected overlaps to be deduplicated to 3: %v", got, n.Marks)
+	}
+
+	compressed, err := sgf.SerializeWithOptions(g, &prop.SerializeOptions{CompressPointLists: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(compressed, "TB[aa:jj]") {
+		t.Errorf("got %q, but expected the territory to be recompressed as TB[aa:jj]", compressed)
+	}
+
+	for _, opts := range []*prop.SerializeOptions{nil, {CompressPointLists: true}} {
+		s, err := sgf.SerializeWithOptions(g, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rt, err := sgf.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := rt.Root.Next(0).Next(0)
+		if !reflect.DeepEqual(got.SGFProperties["TB"], n.SGFProperties["TB"]) || !reflect.DeepEqual(got.Marks, n.Marks) {
+			t.Errorf("after a round trip with options %+v, got %v and %v, but expected %v and %v", opts, got.SGFProperties, got.Marks, n.SGFProperties, n.Marks)
+		}
+	}
+}
+
+func TestSerialize_ClearedInheritance(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[9]VW[aa:cc]DD[bb];B[aa]VW[]DD[];W[bb])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A node can also clear a view without any values.
+	g.Root.Next(0).Next(0).SGFProperties["VW"] = nil
+
+	for _, opts := range []*prop.SerializeOptions{nil, {CompressPointLists: true}} {
+		s, err := sgf.SerializeWithOptions(g, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := ";B[aa]DD[]VW[];W[bb]VW[])"; !strings.HasSuffix(s, exp) {
+			t.Errorf("SerializeWithOptions(%+v)=%q, but expected it to end with %q", opts, s, exp)
+		}
+		rt, err := sgf.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range []prop.Prop{"VW", "DD"} {
+			values, ok := prop.Inherited(rt.Root.Next(0).Next(0), p)
+			if !ok || !reflect.DeepEqual(values, []string{""}) {
+				t.Errorf("after a round trip with options %+v, Inherited(%s)=%v, %v, but expected it to be cleared", opts, p, values, ok)
+			}
+		}
+	}
+}
+
+func TestSerialize_InferHandicap(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sgf  string
+		opts *prop.SerializeOptions
+		exp  string
+	}{
+		{
+			desc: "nine star points",
+			sgf:  "(;GM[1]SZ[19]AB[dd][jd][pd][dj][jj][pj][dp][jp][pp];W[qf])",
+			opts: &prop.SerializeOptions{InferHandicap: true},
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19]HA[9]AB[dd][dj][dp][jd][jj][jp][pd][pj][pp];W[qf])",
+		},
+		{
+			desc: "nine star points, without the option",
+			sgf:  "(;GM[1]SZ[19]AB[dd][jd][pd][dj][jj][pj][dp][jp][pp];W[qf])",
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19]AB[dd][dj][dp][jd][jj][jp][pd][pj][pp];W[qf])",
+		},
+		{
+			desc: "existing handicap is kept",
+			sgf:  "(;GM[1]SZ[19]HA[8]AB[dd][jd][pd][dj][jj][pj][dp][jp][pp];W[qf])",
+			opts: &prop.SerializeOptions{InferHandicap: true},
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19]HA[8]AB[dd][dj][dp][jd][jj][jp][pd][pj][pp];W[qf])",
+		},
+		{
+			desc: "not a standard placement",
+			sgf:  "(;GM[1]SZ[19]AB[dd][pp];W[qf])",
+			opts: &prop.SerializeOptions{InferHandicap: true},
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[19]AB[dd][pp];W[qf])",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := sgf.SerializeWithOptions(g, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.exp {
+				t.Errorf("SerializeWithOptions()=%q, but expected %q", got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestSerializePath(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[9]C[review];B[ee]C[opening](;W[cc];B[gg])(;W[gc]C[question](;B[gg];W[cg])(;B[cg]))(;W[dd]))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		desc   string
+		path   movetree.Path
+		exp    string
+		expErr error
+	}{
+		{
+			desc: "line with shared annotations",
+			path: movetree.Path{0, 1, 0},
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9]C[review];B[ee]C[opening];W[gc]C[question];B[gg])",
+		},
+		{
+			desc: "root only",
+			path: movetree.Path{},
+			exp:  "(;FF[4]GM[1]CA[UTF-8]SZ[9]C[review])",
+		},
+		{
+			desc:   "missing variation",
+			path:   movetree.Path{0, 3},
+			expErr: movetree.ErrApplyTreepath,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := sgf.SerializePath(g, tc.path)
+			if !errors.Is(err, tc.expErr) {
+				t.Fatalf("SerializePath(%v) got error %v, but expected %v", tc.path, err, tc.expErr)
+			}
+			if got != tc.exp {
+				t.Errorf("SerializePath(%v)=%q, but expected %q", tc.path, got, tc.exp)
+			}
+		})
+	}
+}
+
+func TestSerialize_RectangularBoard(t *testing.T) {
+	testCases := []struct {
+		in, exp string
+	}{
+		{in: "(;GM[1]SZ[19:9];B[si];W[aa])", exp: "(;FF[4]GM[1]CA[UTF-8]SZ[19:9];B[si];W[aa])"},
+		{in: "(;GM[1]SZ[5:13];B[em])", exp: "(;FF[4]GM[1]CA[UTF-8]SZ[5:13];B[em])"},
+		{in: "(;GM[1]SZ[19])", exp: "(;FF[4]GM[1]CA[UTF-8]SZ[19])"},
+		{in: "(;GM[1]SZ[9:9])", exp: "(;FF[4]GM[1]CA[UTF-8]SZ[9])"},
+	}
+	for _, tc := range testCases {
+		g, err := sgf.Parse(tc.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.in, err)
+		}
+		got, err := sgf.Serialize(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.exp {
+			t.Errorf("Serialize(Parse(%q))=%q, but expected %q", tc.in, got, tc.exp)
+		}
+	}
+}
+
+func TestSerialize_CommentRoundTrip(t *testing.T) {
+	for _, in := range []string{
+		"(;FF[4]GM[1]CA[UTF-8]SZ[19]C[a [bracket\\] and a \\\\ backslash])",
+		"(;FF[4]GM[1]CA[UTF-8]SZ[19];B[aa]C[first line\nsoft \\\nbreak: kept])",
+	} {
+		g, err := sgf.Parse(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := sgf.Serialize(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != in {
+			t.Errorf("Serialize(Parse(%q))=%q, but expected it unchanged", in, got)
+		}
+	}
+	g, err := sgf.Parse("(;GM[1]C[a [bracket\\]])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "a [bracket]"; g.Root.Comment != exp {
+		t.Errorf("got comment %q, but expected %q", g.Root.Comment, exp)
+	}
+}
+
+func TestSerialize_UnknownProperties(t *testing.T) {
+	const in = "(;GM[1]SZ[9]GC[game \\] comment]FOO[bar][baz]ZZ[a\\\\b];B[aa]XY[x\\]y]C[note])"
+	g, err := sgf.Parse(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Root.SGFProperties["FOO"]; !reflect.DeepEqual(got, []string{"bar", "baz"}) {
+		t.Errorf("got FOO=%v, but expected [bar baz]", got)
+	}
+	got, err := sgf.Serialize(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Unknown properties are written after the known ones, sorted.
+	exp := "(;FF[4]GM[1]CA[UTF-8]SZ[9]GC[game \\] comment]FOO[bar][baz]ZZ[a\\\\b];B[aa]C[note]XY[x\\]y])"
+	if got != exp {
+		t.Errorf("Serialize()=%q, but expected %q", got, exp)
+	}
+}
@@ -11,6 +11,9 @@ import (
 	"github.com/otrego/clamshell/go/movetree"
 )
 
+// ErrAnalysis indicates that katago's analysis couldn't be added to a game.
+var ErrAnalysis = errors.New("error adding analysis")
+
 // AnalysisResult represents the result of an analysis from katago.
 //
 // For more details, see: https://github.com/lightvector/KataGo/blob/master/docs/Analysis_Engine.md
@@ -191,4 +194,7 @@ type RootInfo struct {
 	ScoreSelfPlay float64 `json:"scoreSelfPlay"`
 	Utility       float64 `json:"utility"`
 	Visits        int     `json:"visits"`
+
+	// CurrentPlayer is the player to move, B or W.
+	CurrentPlayer string `json:"currentPlayer"`
 }
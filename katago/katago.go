@@ -1,4 +1,5 @@
-// Package katago provides wrappers for analyzing games with katago.
+// Package katago provides wrappers for analyzing games with katago, and for
+// annotating games with the analysis.
 package katago
 
 import (
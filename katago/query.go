@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/otrego/clamshell/go/move"
@@ -125,6 +126,36 @@ func (gc *movetreeConverter) point(pt *point.Point) string {
 	return fmt.Sprintf("%c%d", val, pt.Y()+1)
 }
 
+// parsePoint converts a GTP point from katago back to a point on a width x
+// height board, as the inverse of point. Since point numbers the rows from
+// the top, katago analyzes the game reflected top-to-bottom, which doesn't
+// change the analysis, and its points are reflected back. Passes are returned
+// as nil points.
+func parsePoint(v string, width, height int) (*point.Point, error) {
+	if strings.EqualFold(v, "pass") {
+		return nil, nil
+	}
+	if len(v) < 2 {
+		return nil, fmt.Errorf("malformed katago point %q: %w", v, ErrAnalysis)
+	}
+	col := rune(v[0])
+	if col == 'I' || col < 'A' || col > 'Z' {
+		return nil, fmt.Errorf("malformed katago point %q: %w", v, ErrAnalysis)
+	}
+	if col > 'I' {
+		col--
+	}
+	row, err := strconv.Atoi(v[1:])
+	if err != nil {
+		return nil, fmt.Errorf("malformed katago point %q: %w", v, ErrAnalysis)
+	}
+	x, y := int(col-'A'), row-1
+	if x >= width || y < 0 || y >= height {
+		return nil, fmt.Errorf("katago point %q is not on the %dx%d board: %w", v, width, height, ErrAnalysis)
+	}
+	return point.New(x, y), nil
+}
+
 // move converts from a movetree-move to a move-array with a GTP Point. This is a
 // format peculiar to Katago.
 func (gc *movetreeConverter) move(mv *move.Move) Move {
@@ -194,14 +225,20 @@ func (gc *movetreeConverter) komi() (*float64, error) {
 	return nil, nil
 }
 
-// boardSize gets the size of the go board. Only sizes ups to 25 are allowed,
-// but should typically be 19, 13, or 9.
-func (gc *movetreeConverter) boardSize() int {
-	sz := gc.g.Root.GameInfo.Size
-	if sz == 0 {
-		sz = 19
+// boardSize gets the width and height of the go board, which are the same
+// unless the board is rectangular. Only sizes ups to 25 are allowed, but should
+// typically be 19, 13, or 9.
+func (gc *movetreeConverter) boardSize() (int, int) {
+	return boardDimensions(gc.g)
+}
+
+// boardDimensions gets the width and height of the board of the movetree,
+// defaulting to 19x19.
+func boardDimensions(g *movetree.MoveTree) (int, int) {
+	if gi := g.Root.GameInfo; gi != nil && gi.Size != 0 {
+		return gi.Dimensions()
 	}
-	return sz
+	return 19, 19
 }
 
 // analyzeMainBranch analyzes the main branch of the movetree.
@@ -291,10 +328,7 @@ func AnalysisQueryFromGame(g *movetree.MoveTree, inOpts *QueryOptions) (*Query,
 	}
 	q.Komi = km
 
-	sz := gc.boardSize()
-
-	q.BoardYSize = sz
-	q.BoardXSize = sz
+	q.BoardXSize, q.BoardYSize = gc.boardSize()
 	q.OverrideSettings["analysisPVLen"] = strconv.Itoa(*opts.AnalysisDepth)
 	q.AnalyzeTurns = gc.analyzeMainBranch(*opts.StartFrom, *opts.MaxMoves)
 
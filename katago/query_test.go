@@ -98,6 +98,20 @@ func TestCreateAnalysis(t *testing.T) {
 				return q
 			}(),
 		},
+		{
+			desc: "rectangular board",
+			sgf:  "(;GM[1]SZ[19:9];B[ab])",
+			expQuery: func() *Query {
+				q := defaultQuery()
+				q.Moves = []Move{
+					Move{"B", "A2"},
+				}
+				q.BoardXSize = 19
+				q.BoardYSize = 9
+				q.AnalyzeTurns = []int{1}
+				return q
+			}(),
+		},
 		{
 			desc: "Analyze some moves: Max moves",
 			sgf:  "(;GM[1];B[aa];W[bb];B[cc];W[dd])",
@@ -0,0 +1,168 @@
+package katago
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+)
+
+// ReviewOptions contains options for reviewing a game with Review, or for
+// annotating a game with its analysis.
+type ReviewOptions struct {
+	// Query contains the options for the analysis query. If not specified, the
+	// defaults of AnalysisQueryFromGame are used.
+	Query *QueryOptions
+
+	// Comment indicates that the win rate and the score lead are appended to
+	// the comment of each analyzed node, in the format that the prop
+	// package's KataGo extractor reads back (ex: "winrate: 0.553 scoreLead:
+	// 2.4").
+	Comment bool
+
+	// Variations is the number of best moves at each position whose principal
+	// variations are added as variations, after the existing children. A best
+	// move that's already a child isn't added again.
+	Variations int
+
+	// MaxVariationLength is the maximum number of moves of an added variation.
+	// If 0, the whole principal variation is added.
+	MaxVariationLength int
+}
+
+// Review analyzes the main line of the game with katago, and annotates the
+// game with the analysis (see AnalysisList.Annotate).
+func Review(an *Analyzer, g *movetree.MoveTree, opts *ReviewOptions) error {
+	if opts == nil {
+		opts = &ReviewOptions{}
+	}
+	q, err := AnalysisQueryFromGame(g, opts.Query)
+	if err != nil {
+		return err
+	}
+	// Annotations are from black's point of view, whoever is to play.
+	q.OverrideSettings["reportAnalysisWinratesAs"] = "BLACK"
+	al, err := an.AnalyzeGame(q)
+	if err != nil {
+		return err
+	}
+	return al.Annotate(g, opts)
+}
+
+// Annotate records the analysis on the main line of the game, matching each
+// result to the node with its turn number: the win rate and the score lead of
+// the position are recorded as the analysis of the node (see
+// movetree.Node.Analysis). Depending on the options, they're also appended to
+// the comment of the node, and the best moves are added as variations. The win
+// rates and score leads should be black's, as requested by Review.
+func (al AnalysisList) Annotate(g *movetree.MoveTree, opts *ReviewOptions) error {
+	if opts == nil {
+		opts = &ReviewOptions{}
+	}
+	turns := make(map[int]*AnalysisResult)
+	for _, res := range al {
+		turns[res.TurnNumber] = res
+	}
+	width, height := boardDimensions(g)
+
+	for n := g.Root; n != nil; n = n.Next(0) {
+		res, ok := turns[n.MoveNum()]
+		if !ok {
+			continue
+		}
+		delete(turns, n.MoveNum())
+		if res.RootInfo == nil {
+			return fmt.Errorf("no root info for turn %d: %w", res.TurnNumber, ErrAnalysis)
+		}
+		winRate, scoreLead := res.RootInfo.Winrate, res.RootInfo.ScoreLead
+		n.Analysis = &movetree.Analysis{WinRate: &winRate, ScoreLead: &scoreLead}
+		if opts.Comment {
+			if n.Comment != "" {
+				n.Comment += "\n\n"
+			}
+			n.Comment += fmt.Sprintf("winrate: %.3f scoreLead: %.1f", winRate, scoreLead)
+		}
+		if err := addVariations(n, res, width, height, opts); err != nil {
+			return err
+		}
+	}
+
+	for _, res := range al {
+		if _, ok := turns[res.TurnNumber]; ok {
+			return fmt.Errorf("turn %d is not on the main line: %w", res.TurnNumber, ErrAnalysis)
+		}
+	}
+	return nil
+}
+
+// addVariations adds the principal variations of the best moves of the
+// analysis as variations of node n.
+func addVariations(n *movetree.Node, res *AnalysisResult, width, height int, opts *ReviewOptions) error {
+	infos := append([]*MoveInfo{}, res.MoveInfos...)
+	sort.SliceStable(infos, func(i, j int) bool { return infos[i].Order < infos[j].Order })
+	if len(infos) > opts.Variations {
+		infos = infos[:opts.Variations]
+	}
+	toPlay := color.Color(res.RootInfo.CurrentPlayer)
+	if toPlay == color.Empty {
+		toPlay = color.Black
+		if n.Move != nil {
+			toPlay = n.Move.Color().Opposite()
+		}
+	}
+
+	for _, info := range infos {
+		first, err := parseMove(toPlay, info.Move, width, height)
+		if err != nil {
+			return err
+		}
+		played := false
+		for _, c := range n.Children {
+			if c.Move != nil && c.Move.Equal(first) {
+				played = true
+			}
+		}
+		if played {
+			continue
+		}
+
+		pv := info.PV
+		if len(pv) == 0 {
+			pv = []string{info.Move}
+		}
+		if max := opts.MaxVariationLength; max > 0 && len(pv) > max {
+			pv = pv[:max]
+		}
+		cur, c := n, toPlay
+		for i, v := range pv {
+			m, err := parseMove(c, v, width, height)
+			if err != nil {
+				return err
+			}
+			child := movetree.NewNode()
+			child.Move = m
+			if i == 0 {
+				winRate, scoreLead := info.Winrate, info.ScoreLead
+				child.Analysis = &movetree.Analysis{WinRate: &winRate, ScoreLead: &scoreLead}
+			}
+			child.Parent = cur
+			cur.AddChild(child)
+			cur, c = child, c.Opposite()
+		}
+	}
+	return nil
+}
+
+// parseMove converts a katago move of color c to a move (see parsePoint).
+func parseMove(c color.Color, v string, width, height int) (*move.Move, error) {
+	pt, err := parsePoint(v, width, height)
+	if err != nil {
+		return nil, err
+	}
+	if pt == nil {
+		return move.NewPass(c), nil
+	}
+	return move.New(c, pt), nil
+}
@@ -0,0 +1,149 @@
+package katago
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/move"
+	"github.com/otrego/clamshell/go/movetree"
+	"github.com/otrego/clamshell/go/point"
+	"github.com/otrego/clamshell/go/prop"
+	"github.com/otrego/clamshell/go/sgf"
+)
+
+// fakeAnalyzer creates an analyzer without a katago process, which answers
+// one query with the output returned by respond.
+func fakeAnalyzer(t *testing.T, respond func(q *Query) []string) *Analyzer {
+	an := New("model.bin.gz", "analysis.cfg", 1)
+	go an.resultCollector()
+	go func() {
+		q := &Query{}
+		if err := json.Unmarshal([]byte(<-an.stdinWrite), q); err != nil {
+			t.Error(err)
+			return
+		}
+		for _, out := range respond(q) {
+			an.katagoOutput <- out
+		}
+	}()
+	t.Cleanup(func() { an.collectorQuit <- 1 })
+	return an
+}
+
+func TestReview(t *testing.T) {
+	g, err := sgf.Parse("(;GM[1]SZ[9];B[ee];W[cc]C[Hmm.])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	an := fakeAnalyzer(t, func(q *Query) []string {
+		if got := q.OverrideSettings["reportAnalysisWinratesAs"]; got != "BLACK" {
+			t.Errorf("got reportAnalysisWinratesAs %v, but expected BLACK", got)
+		}
+		var out []string
+		// The turns are answered in reverse, as katago may answer in any order.
+		for i := len(q.AnalyzeTurns) - 1; i >= 0; i-- {
+			turn := q.AnalyzeTurns[i]
+			player := "B"
+			if turn%2 == 1 {
+				player = "W"
+			}
+			out = append(out, fmt.Sprintf(`{"id":%q,"turnNumber":%d,`+
+				`"rootInfo":{"winrate":0.%d,"scoreLead":%d.5,"currentPlayer":%q},`+
+				`"moveInfos":[{"move":"C3","order":0,"winrate":0.4,"pv":["C3"]},`+
+				`{"move":"G7","order":1,"winrate":0.3,"scoreLead":-1.5,"pv":["G7","C7","C3"]}]}`,
+				q.ID, turn, turn+4, turn, player))
+		}
+		return out
+	})
+
+	err = Review(an, g, &ReviewOptions{Comment: true, Variations: 2, MaxVariationLength: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analysis := func(winRate, scoreLead float64) *movetree.Analysis {
+		return &movetree.Analysis{WinRate: &winRate, ScoreLead: &scoreLead}
+	}
+	nodes := []*movetree.Node{g.Root.Next(0), g.Root.Next(0).Next(0)}
+	for i, exp := range []*movetree.Analysis{analysis(0.5, 1.5), analysis(0.6, 2.5)} {
+		if diff := cmp.Diff(exp, nodes[i].Analysis); diff != "" {
+			t.Errorf("analysis of move %d got diff (-want +got):\n%s", i+1, diff)
+		}
+	}
+	if g.Root.Analysis != nil {
+		t.Errorf("got analysis %v for the root, which wasn't analyzed", g.Root.Analysis)
+	}
+	if exp := "Hmm.\n\nwinrate: 0.600 scoreLead: 2.5"; nodes[1].Comment != exp {
+		t.Errorf("got comment %q, but expected %q", nodes[1].Comment, exp)
+	}
+
+	// The comment can be read back by the analysis extractor.
+	extracted := movetree.NewNode()
+	extracted.Comment = nodes[0].Comment
+	if err := prop.ExtractAnalysis(extracted, &prop.ParseOptions{ExtractAnalysis: true}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(analysis(0.5, 1.5), extracted.Analysis); diff != "" {
+		t.Errorf("extracted analysis got diff (-want +got):\n%s", diff)
+	}
+
+	// After black's move, C3 was played, so only G7 is added, limited to two
+	// moves.
+	after := nodes[0]
+	if len(after.Children) != 2 {
+		t.Fatalf("got %d children after the first move, but expected the move played and G7", len(after.Children))
+	}
+	v := after.Children[1]
+	if exp := move.New(color.White, point.New(6, 6)); !v.Move.Equal(exp) {
+		t.Errorf("got variation %v, but expected %v", v.Move, exp)
+	}
+	if diff := cmp.Diff(analysis(0.3, -1.5), v.Analysis); diff != "" {
+		t.Errorf("analysis of the variation got diff (-want +got):\n%s", diff)
+	}
+	if len(v.Children) != 1 || !v.Children[0].Move.Equal(move.New(color.Black, point.New(2, 6))) || len(v.Children[0].Children) != 0 {
+		t.Errorf("got variation continuation %v, but expected only black C7", v.Children)
+	}
+}
+
+func TestAnnotate_Errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		sgf      string
+		analysis string
+	}{
+		{
+			desc:     "turn off the main line",
+			sgf:      "(;GM[1]SZ[9];B[ee])",
+			analysis: `{"id":"a","turnNumber":3,"rootInfo":{"winrate":0.5}}`,
+		},
+		{
+			desc:     "move off the board",
+			sgf:      "(;GM[1]SZ[9];B[ee])",
+			analysis: `{"id":"a","turnNumber":1,"rootInfo":{"winrate":0.5},"moveInfos":[{"move":"K10","pv":["K10"]}]}`,
+		},
+		{
+			desc:     "malformed move",
+			sgf:      "(;GM[1]SZ[9];B[ee])",
+			analysis: `{"id":"a","turnNumber":1,"rootInfo":{"winrate":0.5},"moveInfos":[{"move":"I3","pv":["I3"]}]}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g, err := sgf.Parse(tc.sgf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			al, err := ParseAnalysisList([]byte(tc.analysis))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := al.Annotate(g, &ReviewOptions{Variations: 1}); !errors.Is(err, ErrAnalysis) {
+				t.Errorf("got error %v, but expected %v", err, ErrAnalysis)
+			}
+		})
+	}
+}
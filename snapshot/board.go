@@ -9,6 +9,10 @@ import (
 // createBoard creates a Board snapshot from some board state
 func createBoard(b *board.Board, cbox *bbox.CropBox) (*Board, error) {
 	fb := b.FullBoardState()
+	height, width := len(fb), 0
+	if height > 0 {
+		width = len(fb[0])
+	}
 	intz := make([][]*Intersection, cbox.BBox.Width())
 	for r := cbox.BBox.Top(); r < cbox.BBox.Height(); r++ {
 		row := fb[r]
@@ -16,6 +20,7 @@ func createBoard(b *board.Board, cbox *bbox.CropBox) (*Board, error) {
 		for c := cbox.BBox.Left(); c < cbox.BBox.Width(); c++ {
 			col := row[c]
 			intz[r][c] = &Intersection{
+				Base:  symbol.BaseFromPosition(c, r, width, height),
 				Stone: symbol.StoneFromColor(col),
 			}
 		}
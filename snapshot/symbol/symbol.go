@@ -3,6 +3,7 @@ package symbol
 
 import (
 	"github.com/otrego/clamshell/go/color"
+	"github.com/otrego/clamshell/go/movetree"
 )
 
 // Symbol represents an intersection on the board.
@@ -20,6 +21,49 @@ func StoneFromColor(c color.Color) Symbol {
 	}
 }
 
+// BaseFromPosition returns the Base symbol for the intersection at column x
+// and row y of a board with the given number of columns and rows.
+func BaseFromPosition(x, y, width, height int) Symbol {
+	top, bottom := y == 0, y == height-1
+	left, right := x == 0, x == width-1
+	switch {
+	case top && left:
+		return TopLeft
+	case top && right:
+		return TopRight
+	case bottom && left:
+		return BottomLeft
+	case bottom && right:
+		return BottomRight
+	case top:
+		return TopEdge
+	case bottom:
+		return BottomEdge
+	case left:
+		return LeftEdge
+	case right:
+		return RightEdge
+	default:
+		return Center
+	}
+}
+
+// MarkFromType returns the appropriate Mark symbol from a mark type.
+func MarkFromType(m movetree.MarkType) Symbol {
+	switch m {
+	case movetree.MarkTriangle:
+		return Triangle
+	case movetree.MarkSquare:
+		return Square
+	case movetree.MarkCircle:
+		return Circle
+	case movetree.MarkX:
+		return Xmark
+	default:
+		return Empty
+	}
+}
+
 const (
 	// Empty is the default symbol.
 	Empty Symbol = 0
@@ -11,3 +11,25 @@ func TestUnicodeString(t *testing.T) {
 		t.Errorf("expected unknown.UnicodeString() == \"?\", but was %q", got)
 	}
 }
+
+func TestBaseFromPosition(t *testing.T) {
+	testCases := []struct {
+		x, y int
+		exp  Symbol
+	}{
+		{x: 0, y: 0, exp: TopLeft},
+		{x: 8, y: 0, exp: TopRight},
+		{x: 0, y: 12, exp: BottomLeft},
+		{x: 8, y: 12, exp: BottomRight},
+		{x: 4, y: 0, exp: TopEdge},
+		{x: 4, y: 12, exp: BottomEdge},
+		{x: 0, y: 6, exp: LeftEdge},
+		{x: 8, y: 6, exp: RightEdge},
+		{x: 4, y: 6, exp: Center},
+	}
+	for _, tc := range testCases {
+		if got := BaseFromPosition(tc.x, tc.y, 9, 13); got != tc.exp {
+			t.Errorf("BaseFromPosition(%d, %d, 9, 13)=%v, but expected %v", tc.x, tc.y, got, tc.exp)
+		}
+	}
+}